@@ -0,0 +1,97 @@
+package main
+
+import (
+	"armeria/internal/pkg/armeria"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+const usage = `Usage: armeria-admin [-config path] <command> [arguments]
+
+Maintains the data directory while the server is stopped. Running it against the data of a live server will
+lose changes, since the server overwrites the data when it saves.
+
+Commands:
+  create-character <name> <password>  create a character in the starting room
+  reset-password <name> <password>    set a new password for a character
+  validate                            check the data for dangling references
+  migrate                             migrate the data to the current schema version
+  export-area <name> [file]           write an area and its rooms as JSON to a file, or stdout
+`
+
+func main() {
+	configPath := flag.String("config", "./config/development.yml", "path to the config file")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Migrations must run before the data can be loaded.
+	if args[0] == "migrate" {
+		armeria.Init(*configPath, false)
+		armeria.Migrate()
+		return
+	}
+
+	armeria.InitOffline(*configPath)
+
+	switch args[0] {
+	case "create-character":
+		requireArgs(args, 3)
+		check(armeria.AdminCreateCharacter(args[1], args[2]))
+		armeria.SaveOffline()
+		fmt.Printf("Created character %s.\n", args[1])
+	case "reset-password":
+		requireArgs(args, 3)
+		check(armeria.AdminResetPassword(args[1], args[2]))
+		armeria.SaveOffline()
+		fmt.Printf("Reset the password for %s.\n", args[1])
+	case "validate":
+		problems := armeria.AdminValidate()
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		if len(problems) > 0 {
+			fmt.Printf("Found %d problem(s).\n", len(problems))
+			os.Exit(1)
+		}
+		fmt.Println("No problems found.")
+	case "export-area":
+		requireArgs(args, 2)
+		data, err := armeria.AdminExportArea(args[1])
+		check(err)
+		if len(args) > 2 {
+			check(ioutil.WriteFile(args[2], data, 0644))
+			return
+		}
+		fmt.Println(string(data))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// requireArgs exits with the usage text if the command doesn't have enough arguments.
+func requireArgs(args []string, n int) {
+	if len(args) < n {
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// check exits with the error, if there is one.
+func check(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "armeria-admin: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,4 @@
+-- Training Dummy Script
+function interact()
+  room_text("You strike the training dummy squarely! It wobbles on its post, then settles back into place.")
+end
\ No newline at end of file
@@ -0,0 +1,80 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The functions in this file back the armeria-admin tool. They operate on data loaded with InitOffline, and the
+// changes are only written to disk by SaveOffline.
+
+// AdminCreateCharacter creates a character in the first room of the world, as if they had finished character
+// creation.
+func AdminCreateCharacter(name, password string) error {
+	if !characterNameRegex.MatchString(name) {
+		return errors.New("names must be 3 to 20 letters long")
+	}
+	if !characterPasswordRegex.MatchString(password) {
+		return errors.New("passwords must be 6 to 64 characters long, without spaces")
+	}
+	if Armeria.characterManager.CharacterByName(name) != nil {
+		return errors.New("a character with that name already exists")
+	}
+
+	kit := Armeria.startingKits.For("")
+	start := kit.StartRoom()
+	if start == nil {
+		return errors.New("there is nowhere for new characters to start")
+	}
+
+	c := Armeria.characterManager.CreateCharacter(name, password)
+	c.ApplyStartingKit(kit)
+	if err := start.Here().Add(c.ID()); err != nil {
+		return err
+	}
+
+	CallGlobalFunc(c, "on_character_create")
+	return nil
+}
+
+// AdminResetPassword sets a new password for a character.
+func AdminResetPassword(name, password string) error {
+	c := Armeria.characterManager.CharacterByName(name)
+	if c == nil {
+		return errors.New("that character doesn't exist")
+	}
+	if !characterPasswordRegex.MatchString(password) {
+		return errors.New("passwords must be 6 to 64 characters long, without spaces")
+	}
+
+	c.SetPassword(password)
+	return nil
+}
+
+// AdminValidate checks the loaded data for dangling references, and returns a description of each problem.
+func AdminValidate() []string {
+	var problems []string
+	for _, p := range CheckLinks() {
+		problems = append(problems, fmt.Sprintf("%s: %s", p.Location, p.Problem))
+	}
+	return problems
+}
+
+// AdminExportArea returns an area and its rooms as indented JSON, in the same format as the world data file.
+func AdminExportArea(name string) ([]byte, error) {
+	a := Armeria.worldManager.AreaByName(name)
+	if a == nil {
+		return nil, errors.New("that area doesn't exist")
+	}
+
+	a.RLock()
+	defer a.RUnlock()
+
+	return json.MarshalIndent(a, "", "  ")
+}
+
+// SaveOffline writes the data loaded with InitOffline back to disk.
+func SaveOffline() {
+	Armeria.Save()
+}
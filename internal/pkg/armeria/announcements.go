@@ -0,0 +1,185 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// AnnouncementManager holds the message of the day and the announcements that are periodically broadcast to
+// every online character.
+type AnnouncementManager struct {
+	sync.RWMutex
+	dataFile            string
+	UnsafeMOTD          string          `json:"motd"`
+	UnsafeAnnouncements []*Announcement `json:"announcements"`
+	UnsafeNextID        int             `json:"nextId"`
+}
+
+// Announcement is a message that is broadcast to every online character on an interval.
+type Announcement struct {
+	ID       int           `json:"id"`
+	Message  string        `json:"message"`
+	Interval time.Duration `json:"interval"`
+	LastSent time.Time     `json:"lastSent"`
+}
+
+// NewAnnouncementManager creates a new AnnouncementManager.
+func NewAnnouncementManager() *AnnouncementManager {
+	m := &AnnouncementManager{
+		dataFile: fmt.Sprintf("%s/announcements.json", Armeria.dataPath),
+	}
+
+	m.LoadAnnouncements()
+
+	return m
+}
+
+// LoadAnnouncements loads the message of the day and announcements from disk into memory.
+func (m *AnnouncementManager) LoadAnnouncements() {
+	m.Lock()
+	defer m.Unlock()
+
+	announcementsFile, err := os.Open(m.dataFile)
+	defer announcementsFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(announcementsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("announcements loaded",
+		zap.Int("count", len(m.UnsafeAnnouncements)),
+	)
+}
+
+// SaveAnnouncements writes the in-memory message of the day and announcements to disk.
+func (m *AnnouncementManager) SaveAnnouncements() {
+	m.RLock()
+	defer m.RUnlock()
+
+	announcementsFile, err := os.Create(m.dataFile)
+	defer announcementsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := announcementsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = announcementsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// MOTD returns the message of the day.
+func (m *AnnouncementManager) MOTD() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeMOTD
+}
+
+// SetMOTD sets the message of the day.
+func (m *AnnouncementManager) SetMOTD(motd string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeMOTD = motd
+}
+
+// Announcements returns the scheduled announcements.
+func (m *AnnouncementManager) Announcements() []*Announcement {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeAnnouncements
+}
+
+// AddAnnouncement schedules a new announcement to be broadcast on an interval.
+func (m *AnnouncementManager) AddAnnouncement(message string, interval time.Duration) *Announcement {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeNextID++
+	a := &Announcement{
+		ID:       m.UnsafeNextID,
+		Message:  message,
+		Interval: interval,
+		LastSent: time.Now(),
+	}
+	m.UnsafeAnnouncements = append(m.UnsafeAnnouncements, a)
+
+	return a
+}
+
+// RemoveAnnouncement removes a scheduled announcement. It returns false if the announcement doesn't exist.
+func (m *AnnouncementManager) RemoveAnnouncement(id int) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, a := range m.UnsafeAnnouncements {
+		if a.ID == id {
+			m.UnsafeAnnouncements = append(m.UnsafeAnnouncements[:i], m.UnsafeAnnouncements[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// DueAnnouncements returns the announcements whose interval has elapsed, and marks them as sent.
+func (m *AnnouncementManager) DueAnnouncements() []*Announcement {
+	m.Lock()
+	defer m.Unlock()
+
+	var due []*Announcement
+	for _, a := range m.UnsafeAnnouncements {
+		if time.Since(a.LastSent) >= a.Interval {
+			a.LastSent = time.Now()
+			due = append(due, a)
+		}
+	}
+
+	return due
+}
+
+// Announce broadcasts a message to every online character.
+func Announce(message string) {
+	Broadcast(BroadcastStyleByName("info"), func(c *Character) bool { return true }, message)
+}
+
+// BroadcastAnnouncements broadcasts the scheduled announcements that are due.
+func BroadcastAnnouncements() {
+	for _, a := range Armeria.announcementManager.DueAnnouncements() {
+		Announce(a.Message)
+	}
+}
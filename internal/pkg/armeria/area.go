@@ -1,12 +1,12 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"encoding/json"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"sync"
-
-	"go.uber.org/zap"
 )
 
 // Area is a container for rooms.
@@ -51,6 +51,11 @@ func (a *Area) Name() string {
 	return a.UnsafeName
 }
 
+// PermissionScope returns the name used to scope permissions to the area (ie: CAN_BUILD:emberfall).
+func (a *Area) PermissionScope() string {
+	return strings.ToLower(strings.ReplaceAll(a.Name(), " ", "-"))
+}
+
 // Rooms returns all the rooms within the area.
 func (a *Area) Rooms() []*Room {
 	a.RLock()
@@ -74,36 +79,67 @@ func (a *Area) RoomAt(c *Coords) *Room {
 	return nil
 }
 
-// MinimapJSON returns the JSON used for minimap rendering on the client.
-func (a *Area) MinimapJSON() string {
+// AttributeInt returns a permanent attribute as an int.
+func (a *Area) AttributeInt(name string) int {
+	v := a.Attribute(name)
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+
+	return i
+}
+
+// MinimapJSON returns the JSON used for minimap rendering on the client, as seen by a specific Character. Only
+// the rooms the viewer has explored are included, unless they are a builder.
+func (a *Area) MinimapJSON(viewer *Character) string {
+	revealed := viewer.HasPermission("CAN_BUILD")
+	explored := viewer.ExploredRooms(a.Name())
+	regionLabels := a.Regions()
+	visible := func(r *Room) bool {
+		return revealed || r.Transient() || explored[explorationKey(r)]
+	}
+
+	// Wilderness areas only show the terrain around the viewer.
+	wilderness := a.Wilderness()
+	var cx, cy int
+	if wilderness {
+		if vr := viewer.Room(); vr != nil {
+			cx, cy = vr.Coords.X(), vr.Coords.Y()
+		}
+	}
+
 	a.RLock()
 	defer a.RUnlock()
 
-	var rooms []map[string]interface{}
-	for _, r := range a.UnsafeRooms {
-		var north, south, east, west, up, down string
-		if cr := r.ConnectedRoom(NorthDirection); cr != nil {
-			north = cr.LocationString()
+	connection := func(r *Room, direction string) string {
+		cr := r.ConnectedRoom(direction)
+		if cr == nil || !visible(cr) {
+			return ""
 		}
-		if cr := r.ConnectedRoom(SouthDirection); cr != nil {
-			south = cr.LocationString()
+		if cr.Transient() && (!a.wildernessMinimapVisible(cx, cy, cr.Coords.X(), cr.Coords.Y()) ||
+			!TerrainByName(cr.Attribute(AttributeTerrain)).Passable) {
+			return ""
 		}
-		if cr := r.ConnectedRoom(EastDirection); cr != nil {
-			east = cr.LocationString()
-		}
-		if cr := r.ConnectedRoom(WestDirection); cr != nil {
-			west = cr.LocationString()
-		}
-		if cr := r.ConnectedRoom(UpDirection); cr != nil {
-			up = cr.LocationString()
-		}
-		if cr := r.ConnectedRoom(DownDirection); cr != nil {
-			down = cr.LocationString()
+		return cr.LocationString()
+	}
+
+	rooms := make([]map[string]interface{}, 0)
+	for _, r := range a.UnsafeRooms {
+		if !visible(r) {
+			continue
 		}
+		north := connection(r, NorthDirection)
+		south := connection(r, SouthDirection)
+		east := connection(r, EastDirection)
+		west := connection(r, WestDirection)
+		up := connection(r, UpDirection)
+		down := connection(r, DownDirection)
 		rooms = append(rooms, map[string]interface{}{
-			"title": r.Attribute("title"),
-			"color": r.Attribute("color"),
-			"type":  r.Attribute("type"),
+			"title": r.AttributeFor(viewer, AttributeTitle),
+			"color": r.AttributeFor(viewer, AttributeColor),
+			"type":  r.AttributeFor(viewer, AttributeType),
+			"poi":   r.AttributeFor(viewer, AttributePOI),
 			"x":     r.Coords.X(),
 			"y":     r.Coords.Y(),
 			"z":     r.Coords.Z(),
@@ -116,9 +152,22 @@ func (a *Area) MinimapJSON() string {
 		})
 	}
 
+	if wilderness {
+		rooms = append(rooms, a.wildernessMinimapRooms(cx, cy)...)
+	}
+
+	// Region labels are revealed along with the room they're anchored to.
+	regions := make([]*MapRegion, 0)
+	for _, rg := range regionLabels {
+		if revealed || explored[fmt.Sprintf("%d,%d,%d", rg.X, rg.Y, rg.Z)] {
+			regions = append(regions, rg)
+		}
+	}
+
 	minimap := map[string]interface{}{
-		"name":  a.UnsafeName,
-		"rooms": rooms,
+		"name":    a.UnsafeName,
+		"rooms":   rooms,
+		"regions": regions,
 	}
 
 	mapJSON, err := json.Marshal(minimap)
@@ -151,22 +200,28 @@ func (a *Area) EditorData() *ObjectEditorData {
 }
 
 // SetAttribute sets a permanent attribute and only valid attributes can be set.
-func (a *Area) SetAttribute(name string, value string) {
-	a.Lock()
-	defer a.Unlock()
-
-	if !misc.Contains(AttributeList(ObjectTypeArea), name) {
-		Armeria.log.Fatal("attempted to set invalid attribute",
-			zap.String("attribute", name),
-			zap.String("value", value),
-		)
+func (a *Area) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeArea, name, value, a.Attribute); err != nil {
+		return err
 	}
 
+	old := a.Attribute(name)
+
+	a.Lock()
+
 	a.UnsafeAttributes[name] = value
+	a.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeArea, a, name, old, a.Attribute(name))
+	return nil
 }
 
 // Attribute returns a permanent attribute.
 func (a *Area) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeArea, a, name); ok {
+		return v
+	}
+
 	a.RLock()
 	defer a.RUnlock()
 
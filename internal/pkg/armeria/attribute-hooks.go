@@ -0,0 +1,183 @@
+package armeria
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AttributeObserver is called after a permanent attribute on an object has changed. The object is passed as-is,
+// and can be type-asserted based on the ObjectType the observer was registered for.
+type AttributeObserver func(o interface{}, attr, oldValue, newValue string)
+
+// ComputedAttribute derives the value of an attribute from an object instead of it being stored.
+type ComputedAttribute func(o interface{}) string
+
+// attributeKey uniquely identifies an attribute for a given ObjectType.
+type attributeKey struct {
+	objectType ObjectType
+	attr       string
+}
+
+var (
+	attributeHooksMutex sync.RWMutex
+	attributeObservers  = make(map[attributeKey][]AttributeObserver)
+	computedAttributes  = make(map[attributeKey]ComputedAttribute)
+)
+
+// ObserveAttribute registers an AttributeObserver that is called whenever the attribute changes on an object
+// of the given ObjectType.
+func ObserveAttribute(ot ObjectType, attr string, fn AttributeObserver) {
+	attributeHooksMutex.Lock()
+	defer attributeHooksMutex.Unlock()
+
+	k := attributeKey{objectType: ot, attr: attr}
+	attributeObservers[k] = append(attributeObservers[k], fn)
+}
+
+// NotifyAttributeObservers calls the observers registered for an attribute, if the value has changed.
+func NotifyAttributeObservers(ot ObjectType, o interface{}, attr, oldValue, newValue string) {
+	if oldValue == newValue {
+		return
+	}
+
+	attributeHooksMutex.RLock()
+	observers := attributeObservers[attributeKey{objectType: ot, attr: attr}]
+	attributeHooksMutex.RUnlock()
+
+	for _, fn := range observers {
+		fn(o, attr, oldValue, newValue)
+	}
+}
+
+// RegisterComputedAttribute registers an attribute for the given ObjectType whose value is derived when it is
+// read, rather than being stored. Computed attributes cannot be set.
+func RegisterComputedAttribute(ot ObjectType, attr string, fn ComputedAttribute) {
+	attributeHooksMutex.Lock()
+	defer attributeHooksMutex.Unlock()
+
+	computedAttributes[attributeKey{objectType: ot, attr: attr}] = fn
+}
+
+// ComputedAttributeValue returns the value of a computed attribute, and whether the attribute is computed.
+func ComputedAttributeValue(ot ObjectType, o interface{}, attr string) (string, bool) {
+	attributeHooksMutex.RLock()
+	fn, ok := computedAttributes[attributeKey{objectType: ot, attr: attr}]
+	attributeHooksMutex.RUnlock()
+
+	if !ok {
+		return "", false
+	}
+
+	return fn(o), true
+}
+
+// ComputedAttributeList returns the names of the computed attributes for a given ObjectType.
+func ComputedAttributeList(ot ObjectType) []string {
+	attributeHooksMutex.RLock()
+	defer attributeHooksMutex.RUnlock()
+
+	var attrs []string
+	for k := range computedAttributes {
+		if k.objectType == ot {
+			attrs = append(attrs, k.attr)
+		}
+	}
+
+	return attrs
+}
+
+// syncRoomObjectsFor re-syncs the room objects for every online character within a Room.
+func syncRoomObjectsFor(r *Room) {
+	if r == nil {
+		return
+	}
+
+	for _, c := range r.Here().Characters(true) {
+		c.Player().client.SyncRoomObjects()
+	}
+}
+
+// RegisterAttributeHooks registers the built-in attribute observers and computed attributes.
+func RegisterAttributeHooks() {
+	// Names, titles, and pictures appear in the room object list, so it must be refreshed.
+	refreshCharacterRoom := func(o interface{}, attr, oldValue, newValue string) {
+		c := o.(*Character)
+		if c.Online() {
+			syncRoomObjectsFor(c.Room())
+		}
+	}
+	ObserveAttribute(ObjectTypeCharacter, AttributeTitle, refreshCharacterRoom)
+	ObserveAttribute(ObjectTypeCharacter, AttributePicture, refreshCharacterRoom)
+
+	ObserveAttribute(ObjectTypeMobInstance, AttributeTitle, func(o interface{}, attr, oldValue, newValue string) {
+		syncRoomObjectsFor(o.(*MobInstance).Room())
+	})
+
+	refreshMobRooms := func(o interface{}, attr, oldValue, newValue string) {
+		for _, mi := range o.(*Mob).Instances() {
+			syncRoomObjectsFor(mi.Room())
+		}
+	}
+	ObserveAttribute(ObjectTypeMob, AttributeTitle, refreshMobRooms)
+	ObserveAttribute(ObjectTypeMob, AttributePicture, refreshMobRooms)
+
+	// Item pictures, rarities, and stats are shown in tooltips, which are cached on the client.
+	refreshItemTooltips := func(o interface{}, attr, oldValue, newValue string) {
+		for _, c := range Armeria.characterManager.OnlineCharacters() {
+			for _, ii := range o.(*Item).Instances() {
+				if c.Inventory().Contains(ii.ID()) || c.Room().Here().Contains(ii.ID()) {
+					c.Player().client.SetItemTooltipHTML(ii)
+				}
+			}
+		}
+	}
+	ObserveAttribute(ObjectTypeItem, AttributePicture, refreshItemTooltips)
+	ObserveAttribute(ObjectTypeItem, AttributeRarity, refreshItemTooltips)
+	ObserveAttribute(ObjectTypeItem, AttributeStats, refreshItemTooltips)
+
+	// Room titles, colors, and points of interest are shown on the minimap.
+	refreshMinimap := func(o interface{}, attr, oldValue, newValue string) {
+		r := o.(*Room)
+		for _, c := range r.ParentArea.Characters() {
+			c.Player().client.SyncMap()
+			if c.Room() == r {
+				c.Player().client.SyncRoomTitle()
+			}
+		}
+	}
+	ObserveAttribute(ObjectTypeRoom, AttributeTitle, refreshMinimap)
+	ObserveAttribute(ObjectTypeRoom, AttributeColor, refreshMinimap)
+	ObserveAttribute(ObjectTypeRoom, AttributeType, refreshMinimap)
+	ObserveAttribute(ObjectTypeRoom, AttributePOI, refreshMinimap)
+	ObserveAttribute(ObjectTypeArea, AttributeRegions, func(o interface{}, attr, oldValue, newValue string) {
+		for _, c := range o.(*Area).Characters() {
+			c.Player().client.SyncMap()
+		}
+	})
+
+	RegisterComputedAttribute(ObjectTypeCharacter, "online", func(o interface{}) string {
+		if o.(*Character).Online() {
+			return "true"
+		}
+		return "false"
+	})
+	RegisterComputedAttribute(ObjectTypeCharacter, "location", func(o interface{}) string {
+		c := o.(*Character)
+		if r := c.Room(); r != nil {
+			return r.LocationString()
+		}
+		return ""
+	})
+	RegisterComputedAttribute(ObjectTypeCharacter, AttributeMoney, func(o interface{}) string {
+		return fmt.Sprintf("%.2f", o.(*Character).Money())
+	})
+	RegisterComputedAttribute(ObjectTypeRoom, "location", func(o interface{}) string {
+		return o.(*Room).LocationString()
+	})
+	RegisterComputedAttribute(ObjectTypeMobInstance, "location", func(o interface{}) string {
+		if r := o.(*MobInstance).Room(); r != nil {
+			return r.LocationString()
+		}
+		return ""
+	})
+}
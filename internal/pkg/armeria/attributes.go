@@ -1,43 +1,100 @@
 package armeria
 
 import (
+	"armeria/internal/pkg/misc"
 	"armeria/internal/pkg/sfx"
 	"armeria/internal/pkg/validate"
+	"fmt"
 	"strings"
 )
 
 const (
-	AttributeChannels    string = "channels"
-	AttributeColor       string = "color"
-	AttributeDescription string = "description"
-	AttributeDown        string = "down"
-	AttributeEast        string = "east"
-	AttributeEquipSlot   string = "equipSlot"
-	AttributeFollowCrumb string = "followCrumb"
-	AttributeFollowSpeed string = "followSpeed"
-	AttributeGender      string = "gender"
-	AttributeHoldable    string = "holdable"
-	AttributeMoney       string = "money"
-	AttributeMusic       string = "music"
-	AttributeNorth       string = "north"
-	AttributeOwner       string = "owner"
-	AttributePermissions string = "permissions"
-	AttributePicture     string = "picture"
-	AttributeRarity      string = "rarity"
-	AttributeScript      string = "script"
-	AttributeSpawnLimit  string = "spawnLimit"
-	AttributeSpawnMob    string = "spawnMob"
-	AttributeSpawnSFX    string = "spawnSFX"
-	AttributeSouth       string = "south"
-	AttributeTitle       string = "title"
-	AttributeType        string = "type"
-	AttributeUp          string = "up"
-	AttributeVisible     string = "visible"
-	AttributeWest        string = "west"
+	AttributeChannels       string = "channels"
+	AttributeClass          string = "class"
+	AttributeAttackable     string = "attackable"
+	AttributeColor          string = "color"
+	AttributeConvoTimeout   string = "convoTimeout"
+	AttributeDescription    string = "description"
+	AttributeDetails        string = "details"
+	AttributeCorpse         string = "corpse"
+	AttributeCorpseDecay    string = "corpseDecay"
+	AttributeCraftRecipe    string = "craftRecipe"
+	AttributeCraftTime      string = "craftTime"
+	AttributeDamage         string = "damage"
+	AttributeDown           string = "down"
+	AttributeEast           string = "east"
+	AttributeEquipSlot      string = "equipSlot"
+	AttributeExperience     string = "experience"
+	AttributeFaction        string = "faction"
+	AttributeFollowCrumb    string = "followCrumb"
+	AttributeFollowSpeed    string = "followSpeed"
+	AttributeGatherLevel    string = "gatherLevel"
+	AttributeGatherRespawn  string = "gatherRespawn"
+	AttributeGatherSkill    string = "gatherSkill"
+	AttributeGatherYield    string = "gatherYield"
+	AttributeGender         string = "gender"
+	AttributeHarvestLevel   string = "harvestLevel"
+	AttributeHarvestTool    string = "harvestTool"
+	AttributeHarvestYield   string = "harvestYield"
+	AttributeHealth         string = "health"
+	AttributeHoldable       string = "holdable"
+	AttributeLanguage       string = "language"
+	AttributeLeash          string = "leash"
+	AttributeLedger         string = "ledger"
+	AttributeLoot           string = "loot"
+	AttributeLore           string = "lore"
+	AttributeMaxOccupancy   string = "maxOccupancy"
+	AttributeMoney          string = "money"
+	AttributeMusic          string = "music"
+	AttributeNorth          string = "north"
+	AttributeOwner          string = "owner"
+	AttributePalette        string = "palette"
+	AttributePermissions    string = "permissions"
+	AttributePOI            string = "poi"
+	AttributePicture        string = "picture"
+	AttributePronouns       string = "pronouns"
+	AttributePursuitRange   string = "pursuitRange"
+	AttributeQuestItem      string = "questItem"
+	AttributeRarity         string = "rarity"
+	AttributeRegions        string = "regions"
+	AttributeScript         string = "script"
+	AttributeSeason         string = "season"
+	AttributeSpawnLimit     string = "spawnLimit"
+	AttributeSpawnMob       string = "spawnMob"
+	AttributeSpawnScaling   string = "spawnScaling"
+	AttributeSpawnScaleRate string = "spawnScaleRate"
+	AttributeSpawnSFX       string = "spawnSFX"
+	AttributeSpawnTime      string = "spawnTime"
+	AttributeSpawns         string = "spawns"
+	AttributeSouth          string = "south"
+	AttributeSpecies        string = "species"
+	AttributeStats          string = "stats"
+	AttributeTameLevel      string = "tameLevel"
+	AttributeTameable       string = "tameable"
+	AttributeTerrain        string = "terrain"
+	AttributeTitle          string = "title"
+	AttributeTutorial       string = "tutorial"
+	AttributeTutorialReturn string = "tutorialReturn"
+	AttributeType           string = "type"
+	AttributeUp             string = "up"
+	AttributeVerbs          string = "verbs"
+	AttributeVehicleRoute   string = "vehicleRoute"
+	AttributeVehicleTerrain string = "vehicleTerrain"
+	AttributeVisible        string = "visible"
+	AttributeWaypoint       string = "waypoint"
+	AttributeWest           string = "west"
+	AttributeWilderness     string = "wilderness"
+	AttributeWildernessSize string = "wildernessSize"
 
-	TempAttributeEditorOpen string = "editorOpen"
-	TempAttributeGhost      string = "ghost"
-	TempAttributeReplyTo    string = "replyTo"
+	TempAttributeEditorOpen      string = "editorOpen"
+	TempAttributeEditorSelection string = "editorSelection"
+	TempAttributeGhost           string = "ghost"
+	TempAttributeInvisible       string = "invisible"
+	TempAttributeReplyTo         string = "replyTo"
+	TempAttributeTraveling       string = "traveling"
+	TempAttributeVehicle         string = "vehicle"
+	TempAttributeFollowing       string = "following"
+	TempAttributeWalking         string = "walking"
 )
 
 // AttributeCasing returns the correct casing for a given object type and attribute.
@@ -70,18 +127,34 @@ func AttributeList(ot ObjectType) []string {
 			AttributePermissions,
 			AttributeChannels,
 			AttributeGender,
-			AttributeMoney,
+			AttributePronouns,
+			AttributeSpecies,
+			AttributeDescription,
+			AttributeClass,
+			AttributePalette,
+			AttributeTutorial,
+			AttributeTutorialReturn,
+			AttributeLanguage,
 		}
 	case ObjectTypeArea:
 		return []string{
 			AttributeMusic,
+			AttributeRegions,
+			AttributeWilderness,
+			AttributeWildernessSize,
 		}
 	case ObjectTypeRoom:
 		return []string{
 			AttributeTitle,
 			AttributeDescription,
+			AttributeDetails,
 			AttributeColor,
 			AttributeType,
+			AttributeTerrain,
+			AttributeMaxOccupancy,
+			AttributeWaypoint,
+			AttributePOI,
+			AttributeSpawns,
 			AttributeNorth,
 			AttributeEast,
 			AttributeSouth,
@@ -95,21 +168,42 @@ func AttributeList(ot ObjectType) []string {
 			AttributeType,
 			AttributeEquipSlot,
 			AttributeRarity,
+			AttributeStats,
 			AttributeDescription,
 			AttributeOwner,
 			AttributeHoldable,
 			AttributeVisible,
 			AttributeSpawnMob,
 			AttributeSpawnLimit,
+			AttributeSpawnScaling,
+			AttributeSpawnScaleRate,
+			AttributeSpawnTime,
+			AttributeSeason,
+			AttributeGatherSkill,
+			AttributeGatherYield,
+			AttributeGatherLevel,
+			AttributeGatherRespawn,
+			AttributeCraftRecipe,
+			AttributeCraftTime,
+			AttributeVehicleTerrain,
+			AttributeVehicleRoute,
 			AttributeMoney,
+			AttributeQuestItem,
+			AttributeScript,
+			AttributeVerbs,
 		}
 	case ObjectTypeItemInstance:
 		return []string{
 			AttributeRarity,
+			AttributeStats,
 			AttributeDescription,
 			AttributeHoldable,
 			AttributeVisible,
 			AttributeSpawnLimit,
+			AttributeSpawnScaling,
+			AttributeSpawnScaleRate,
+			AttributeSpawnTime,
+			AttributeSeason,
 		}
 	case ObjectTypeMob:
 		return []string{
@@ -120,10 +214,31 @@ func AttributeList(ot ObjectType) []string {
 			AttributeSpawnSFX,
 			AttributeFollowCrumb,
 			AttributeFollowSpeed,
+			AttributeHealth,
+			AttributeDamage,
+			AttributeExperience,
+			AttributeAttackable,
+			AttributePursuitRange,
+			AttributeLeash,
+			AttributeFaction,
+			AttributeTameable,
+			AttributeTameLevel,
+			AttributeCorpse,
+			AttributeCorpseDecay,
+			AttributeHarvestYield,
+			AttributeHarvestLevel,
+			AttributeHarvestTool,
+			AttributeLoot,
+			AttributeLedger,
+			AttributeConvoTimeout,
+			AttributeLore,
 		}
 	case ObjectTypeMobInstance:
 		return []string{
 			AttributeTitle,
+			AttributeHealth,
+			AttributeDamage,
+			AttributeExperience,
 		}
 	}
 
@@ -141,6 +256,8 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 		return "enum:track-one|track-two"
 	case AttributeRarity:
 		return "enum:common|uncommon"
+	case AttributePOI:
+		return "enum:" + strings.Join(PointsOfInterest(), "|")
 	case AttributeGender:
 		switch ot {
 		case ObjectTypeCharacter:
@@ -148,6 +265,10 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 		case ObjectTypeMob:
 			return "enum:male|female|thing"
 		}
+	case AttributePronouns:
+		return "enum:" + strings.Join(PronounSetNames(), "|")
+	case AttributeClass:
+		return "enum:" + strings.Join(CharacterClasses(), "|")
 	case AttributeColor:
 		return "color"
 	case AttributeType:
@@ -155,18 +276,28 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 		case ObjectTypeItem:
 			return "enum:" + strings.Join(ItemTypes(), "|")
 		case ObjectTypeRoom:
-			return "enum:generic|track|bank|armor|sword|home|wand"
+			return "enum:generic|track|bank|armor|sword|home|wand|casino|dock"
 		default:
 			return "editable"
 		}
-	case AttributeHoldable:
+	case AttributeHoldable, AttributeTameable, AttributeAttackable:
 		return "enum:true|false"
 	case AttributeVisible:
 		return "enum:true|false"
 	case AttributeSpawnSFX:
 		return "enum:" + strings.Join(sfx.List(), "|")
+	case AttributeSpawnScaling:
+		return "enum:" + strings.Join(SpawnScalingModes(), "|")
 	case AttributeEquipSlot:
 		return "enum:" + strings.Join(ValidEquipmentSlotsAsString(), "|")
+	case AttributeGatherSkill:
+		return "enum:" + strings.Join(GatheringSkills(), "|")
+	case AttributeWilderness:
+		return "enum:true|false"
+	case AttributeTerrain:
+		return "enum:" + strings.Join(TerrainNames(), "|")
+	case AttributeLanguage:
+		return "enum:" + strings.Join(Armeria.languageManager.Languages(), "|")
 	}
 
 	return "editable"
@@ -175,10 +306,49 @@ func AttributeEditorType(ot ObjectType, attr string) string {
 // AttributeGroup returns the group the attribute should appear under within the object editor.
 func AttributeGroup(attr string) string {
 	switch attr {
-	case AttributeSpawnMob, AttributeSpawnLimit:
+	case AttributeSpawnMob, AttributeSpawnLimit, AttributeSpawnScaling, AttributeSpawnScaleRate, AttributeSpawnTime,
+		AttributeSeason, AttributeSpawns:
 		return "Mob Spawning"
+	case AttributeHealth, AttributeDamage, AttributeExperience, AttributeAttackable:
+		return "Difficulty"
+	case AttributeTameable, AttributeTameLevel:
+		return "Taming"
+	case AttributeCorpse, AttributeCorpseDecay, AttributeHarvestYield, AttributeHarvestLevel, AttributeHarvestTool:
+		return "Corpse"
+	case AttributeLoot:
+		return "Loot"
+	case AttributeLedger:
+		return "Vendor"
+	case AttributeConvoTimeout:
+		return "Conversations"
 	case AttributeMoney:
 		return "Bank Cards"
+	case AttributePronouns, AttributeSpecies:
+		return "Appearance"
+	case AttributePalette, AttributeLanguage:
+		return "Settings"
+	case AttributeTutorial, AttributeTutorialReturn:
+		return "Tutorial"
+	case AttributeLore:
+		return "Bestiary"
+	case AttributeQuestItem:
+		return "Quests"
+	case AttributeGatherSkill, AttributeGatherYield, AttributeGatherLevel, AttributeGatherRespawn:
+		return "Gathering"
+	case AttributeCraftRecipe, AttributeCraftTime:
+		return "Crafting"
+	case AttributeWilderness, AttributeWildernessSize, AttributeTerrain:
+		return "Wilderness"
+	case AttributeVehicleTerrain, AttributeVehicleRoute:
+		return "Vehicles"
+	case AttributeWaypoint, AttributePOI, AttributeRegions:
+		return "Minimap"
+	case AttributeMaxOccupancy:
+		return "Crowding"
+	case AttributeVerbs:
+		return "Interactions"
+	case AttributePursuitRange, AttributeLeash, AttributeFaction:
+		return "Pursuit"
 	}
 
 	return "General"
@@ -218,69 +388,362 @@ func AttributeDefault(ot ObjectType, attr string) string {
 		return "true"
 	case AttributeSpawnLimit:
 		return "0"
+	case AttributeSpawnScaling:
+		return SpawnScalingNone
+	case AttributeSpawnScaleRate:
+		return "25"
+	case AttributeTameable:
+		return "false"
+	case AttributeTameLevel:
+		return "0"
+	case AttributeHealth:
+		return "100"
+	case AttributeDamage:
+		return "10"
+	case AttributeExperience:
+		return "10"
 	case AttributeFollowSpeed:
 		return "12"
+	case AttributePursuitRange:
+		return "5"
+	case AttributeLeash:
+		return "60"
+	case AttributeGatherLevel, AttributeHarvestLevel:
+		return "0"
+	case AttributeCorpseDecay:
+		return "300"
+	case AttributeMaxOccupancy:
+		return "0"
+	case AttributeConvoTimeout:
+		return "60"
+	case AttributeGatherRespawn:
+		return "300"
+	case AttributeCraftTime:
+		return "10"
+	case AttributeWilderness:
+		return "false"
+	case AttributeWildernessSize:
+		return "50"
+	case AttributeLanguage:
+		return DefaultLanguage
 	}
 
 	return ""
 }
 
+// AttributeError is a structured error that describes why an attribute could not be set.
+type AttributeError struct {
+	ObjectType ObjectType
+	Attribute  string
+	Value      string
+	Reasons    []string
+}
+
+// Error returns the reasons the attribute could not be set as a one-line string.
+func (e *AttributeError) Error() string {
+	return strings.Join(e.Reasons, ", ")
+}
+
+// attributeValidationType returns the ObjectType whose validators apply to the given ObjectType. Instances are
+// validated the same way as their parents.
+func attributeValidationType(ot ObjectType) ObjectType {
+	switch ot {
+	case ObjectTypeItemInstance:
+		return ObjectTypeItem
+	case ObjectTypeMobInstance:
+		return ObjectTypeMob
+	}
+
+	return ot
+}
+
 // AttributeValidate returns the validation result of an attribute value for a given ObjectType. Case sensitive.
 func AttributeValidate(ot ObjectType, attr, val string) validate.ValidationResult {
 	var validatorString string
-	switch ot {
+	switch attributeValidationType(ot) {
 	case ObjectTypeMob:
 		switch attr {
 		case AttributeScript:
 			validatorString = "empty"
-			break
 		case AttributeGender:
 			validatorString = "in:thing,male,female"
-			break
 		case AttributeSpawnSFX:
 			validatorString = "in:" + strings.Join(sfx.List(), ",")
-			break
 		case AttributeFollowSpeed:
 			validatorString = "num|min:1|max:60"
-			break
+		case AttributePursuitRange:
+			validatorString = "num|min:0|max:50"
+		case AttributeLeash:
+			validatorString = "num|min:5|max:3600"
+		case AttributeHealth:
+			validatorString = "num|min:1|max:1000000"
+		case AttributeDamage:
+			validatorString = "num|min:0|max:100000"
+		case AttributeExperience:
+			validatorString = "num|min:0|max:100000"
+		case AttributeFaction:
+			validatorString = `regex:^[a-z0-9-]*$`
+		case AttributeTameable, AttributeAttackable:
+			validatorString = "bool"
+		case AttributeTameLevel:
+			validatorString = "num|min:0|max:100"
+		case AttributeCorpseDecay:
+			validatorString = "num|min:10|max:86400"
+		case AttributeHarvestLevel:
+			validatorString = "num|min:0|max:100"
+		case AttributeConvoTimeout:
+			validatorString = "num|min:0|max:3600"
 		}
 	case ObjectTypeCharacter:
 		switch attr {
 		case AttributeGender:
 			validatorString = "in:male,female"
-			break
-		case AttributeMoney:
-			validatorString = "num|min:0"
-			break
+		case AttributePronouns:
+			validatorString = "in:" + strings.Join(PronounSetNames(), ",")
+		case AttributeClass:
+			validatorString = "in:" + strings.Join(CharacterClasses(), ",")
+		case AttributeLanguage:
+			validatorString = "in:" + strings.Join(Armeria.languageManager.Languages(), ",")
 		}
 	case ObjectTypeItem:
 		switch attr {
 		case AttributeType:
 			validatorString = "in:" + strings.Join(ItemTypes(), ",")
-			break
 		case AttributeRarity:
 			validatorString = "in:common,uncommon"
-			break
 		case AttributeHoldable:
 			validatorString = "bool"
-			break
 		case AttributeVisible:
 			validatorString = "bool"
-			break
 		case AttributeSpawnLimit:
 			validatorString = "num|min:0|max:100"
-			break
+		case AttributeSpawnScaling:
+			validatorString = "in:" + strings.Join(SpawnScalingModes(), ",")
+		case AttributeSpawnScaleRate:
+			validatorString = "num|min:0|max:100"
 		case AttributeEquipSlot:
 			validatorString = "in:" + strings.Join(ValidEquipmentSlotsAsString(), ",")
-			break
+		case AttributeMoney:
+			validatorString = "decimal|min:0"
+		case AttributeGatherSkill:
+			validatorString = "in:" + strings.Join(GatheringSkills(), ",")
+		case AttributeGatherLevel:
+			validatorString = "num|min:0|max:100"
+		case AttributeGatherRespawn:
+			validatorString = "num|min:0|max:86400"
+		case AttributeCraftTime:
+			validatorString = "num|min:1|max:86400"
+		case AttributeQuestItem:
+			validatorString = `regex:^[a-z0-9-]*$`
+		case AttributeScript:
+			validatorString = "empty"
 		}
 	case ObjectTypeRoom:
 		switch attr {
 		case AttributeType:
-			validatorString = "in:generic,track,bank,armor,sword,home,wand"
-			break
+			validatorString = "in:generic,track,bank,armor,sword,home,wand,casino,dock"
+		case AttributeColor:
+			validatorString = `regex:^\d{1,3},\d{1,3},\d{1,3}$`
+		case AttributeTerrain:
+			validatorString = "in:" + strings.Join(TerrainNames(), ",")
+		case AttributePOI:
+			validatorString = "in:" + strings.Join(PointsOfInterest(), ",")
+		case AttributeMaxOccupancy:
+			validatorString = "num|min:0|max:1000"
+		}
+	case ObjectTypeArea:
+		switch attr {
+		case AttributeMusic:
+			validatorString = "in:track-one,track-two"
+		case AttributeWilderness:
+			validatorString = "bool"
+		case AttributeWildernessSize:
+			validatorString = "num|min:1|max:1000"
 		}
 	}
 
 	return validate.Check(val, validatorString)
 }
+
+// AttributeCrossValidate checks rules that depend on other attributes of the same object, using the attrs func
+// to read them. It returns a list of reasons the value is invalid, which is empty if the value is valid.
+func AttributeCrossValidate(ot ObjectType, attr, val string, attrs func(string) string) []string {
+	var reasons []string
+	switch attributeValidationType(ot) {
+	case ObjectTypeMob:
+		switch attr {
+		case AttributeCorpse:
+			if i := Armeria.itemManager.ItemByName(val); i == nil {
+				reasons = append(reasons, "item does not exist")
+			} else if i.Attribute(AttributeType) != ItemTypeCorpse {
+				reasons = append(reasons, "only corpse items can be left behind")
+			}
+		case AttributeHarvestYield:
+			table, err := ParseWeightedTable(val)
+			if err != nil {
+				reasons = append(reasons, err.Error())
+			}
+			for _, e := range table {
+				if Armeria.itemManager.ItemByName(e.Name) == nil {
+					reasons = append(reasons, fmt.Sprintf("item %q does not exist", e.Name))
+				}
+			}
+		case AttributeHarvestTool:
+			if Armeria.itemManager.ItemByName(val) == nil {
+				reasons = append(reasons, "item does not exist")
+			}
+		case AttributeLoot:
+			if Armeria.lootManager.TableByName(val) == nil {
+				reasons = append(reasons, "loot table does not exist")
+			}
+		case AttributeLedger:
+			if Armeria.ledgerManager.LedgerByName(val) == nil {
+				reasons = append(reasons, "ledger does not exist")
+			}
+		}
+	case ObjectTypeItem:
+		switch attr {
+		case AttributeSpawnMob:
+			if attrs(AttributeType) != ItemTypeMobSpawner {
+				reasons = append(reasons, "only mob spawners can spawn mobs")
+			}
+			if Armeria.mobManager.MobByName(val) == nil {
+				reasons = append(reasons, "mob does not exist")
+			}
+		case AttributeSpawnScaling, AttributeSpawnScaleRate:
+			if attrs(AttributeType) != ItemTypeMobSpawner {
+				reasons = append(reasons, "only mob spawners can scale the mobs they spawn")
+			}
+		case AttributeSpawnTime:
+			if attrs(AttributeType) != ItemTypeMobSpawner {
+				reasons = append(reasons, "only mob spawners can spawn at certain times of day")
+			}
+			reasons = append(reasons, validateSpawnTimes(val)...)
+		case AttributeEquipSlot:
+			if attrs(AttributeHoldable) == "false" {
+				reasons = append(reasons, "items that are not holdable cannot be equipped")
+			}
+		case AttributeStats:
+			if _, err := ParseItemStats(val); err != nil {
+				reasons = append(reasons, err.Error())
+			}
+		case AttributeVerbs:
+			if _, err := ParseVerbs(val); err != nil {
+				reasons = append(reasons, err.Error())
+			}
+		case AttributeMoney:
+			if attrs(AttributeType) != ItemTypeBankCard {
+				reasons = append(reasons, "only bank cards can hold money")
+			}
+		case AttributeGatherSkill, AttributeGatherLevel, AttributeGatherRespawn:
+			if attrs(AttributeType) != ItemTypeGatheringNode {
+				reasons = append(reasons, "only gathering nodes can be gathered from")
+			}
+		case AttributeGatherYield:
+			if attrs(AttributeType) != ItemTypeGatheringNode {
+				reasons = append(reasons, "only gathering nodes can be gathered from")
+			}
+			table, err := ParseWeightedTable(val)
+			if err != nil {
+				reasons = append(reasons, err.Error())
+			}
+			for _, e := range table {
+				if Armeria.itemManager.ItemByName(e.Name) == nil {
+					reasons = append(reasons, fmt.Sprintf("item %q does not exist", e.Name))
+				}
+			}
+		case AttributeCraftRecipe:
+			recipe, err := ParseRecipe(val)
+			if err != nil {
+				reasons = append(reasons, err.Error())
+			}
+			for _, i := range recipe {
+				if Armeria.itemManager.ItemByName(i.Name) == nil {
+					reasons = append(reasons, fmt.Sprintf("item %q does not exist", i.Name))
+				}
+			}
+		case AttributeVehicleTerrain:
+			if attrs(AttributeType) != ItemTypeVehicle {
+				reasons = append(reasons, "only vehicles can travel across terrain")
+			}
+			for _, t := range strings.Split(val, ",") {
+				if TerrainByName(strings.TrimSpace(t)) == nil {
+					reasons = append(reasons, fmt.Sprintf("%q is not a terrain", t))
+				}
+			}
+		case AttributeVehicleRoute:
+			if attrs(AttributeType) != ItemTypeVehicle {
+				reasons = append(reasons, "only vehicles can follow routes")
+			}
+			for _, d := range strings.Split(val, ",") {
+				if len(misc.NormalizeDirection(strings.TrimSpace(d))) == 0 {
+					reasons = append(reasons, fmt.Sprintf("%q is not a direction", d))
+				}
+			}
+		}
+	case ObjectTypeRoom:
+		switch attr {
+		case AttributeDetails:
+			if _, err := ParseRoomDetails(val); err != nil {
+				reasons = append(reasons, err.Error())
+			}
+		case AttributeSpawns:
+			spawns, err := ParseRoomSpawns(val)
+			if err != nil {
+				reasons = append(reasons, err.Error())
+			}
+			for _, s := range spawns {
+				if Armeria.mobManager.MobByName(s.Mob) == nil {
+					reasons = append(reasons, fmt.Sprintf("mob %q does not exist", s.Mob))
+				}
+			}
+		}
+	case ObjectTypeArea:
+		switch attr {
+		case AttributeRegions:
+			if _, err := ParseMapRegions(val); err != nil {
+				reasons = append(reasons, err.Error())
+			}
+		}
+	}
+
+	return reasons
+}
+
+// ValidateAttribute checks that an attribute can be set on the given ObjectType, and that the value passes the
+// attribute's validators. An empty value always passes, as it resets the attribute to its default. Cross-field
+// rules are skipped when attrs is nil. A *AttributeError is returned when the attribute cannot be set.
+func ValidateAttribute(ot ObjectType, attr, val string, attrs func(string) string) error {
+	if !misc.Contains(AttributeList(ot), attr) {
+		return &AttributeError{
+			ObjectType: ot,
+			Attribute:  attr,
+			Value:      val,
+			Reasons:    []string{"not a valid attribute"},
+		}
+	}
+
+	if len(val) == 0 {
+		return nil
+	}
+
+	var reasons []string
+	if valid := AttributeValidate(ot, attr, val); !valid.Result {
+		reasons = append(reasons, valid.OnlyErrors()...)
+	}
+
+	if attrs != nil {
+		reasons = append(reasons, AttributeCrossValidate(ot, attr, val, attrs)...)
+	}
+
+	if len(reasons) > 0 {
+		return &AttributeError{
+			ObjectType: ot,
+			Attribute:  attr,
+			Value:      val,
+			Reasons:    reasons,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// CharacterAutoSaveDelay is how long an auto-save waits before writing, so that a burst of significant events
+	// (ie: selling a stack of items) is written to disk once.
+	CharacterAutoSaveDelay = 2 * time.Second
+
+	AutoSaveReasonMoney    = "money"
+	AutoSaveReasonRareItem = "rare item"
+	AutoSaveReasonSkill    = "skill improved"
+	AutoSaveReasonLevel    = "level up"
+)
+
+// AutoSave saves the characters shortly after a significant change to a Character, such as money changing hands,
+// rather than waiting for the next PeriodicGameSave. This bounds what a player can lose if the server crashes.
+// Every character is stored within the same file, so changes within CharacterAutoSaveDelay of each other are
+// saved together. Nothing is auto-saved while the tickers aren't running, such as within the admin tools, which
+// save when they are done.
+func (m *CharacterManager) AutoSave(c *Character, reason string) {
+	if Armeria.tickManager == nil {
+		return
+	}
+
+	m.autoSaveMutex.Lock()
+	defer m.autoSaveMutex.Unlock()
+
+	if m.autoSaveReasons == nil {
+		m.autoSaveReasons = make(map[*Character][]string)
+	}
+	if !misc.Contains(m.autoSaveReasons[c], reason) {
+		m.autoSaveReasons[c] = append(m.autoSaveReasons[c], reason)
+	}
+
+	if m.autoSaveTimer == nil {
+		m.autoSaveTimer = time.AfterFunc(CharacterAutoSaveDelay, m.flushAutoSave)
+	}
+}
+
+// flushAutoSave saves the characters that were waiting to be auto-saved.
+func (m *CharacterManager) flushAutoSave() {
+	m.autoSaveMutex.Lock()
+	pending := m.autoSaveReasons
+	m.autoSaveReasons = nil
+	m.autoSaveTimer = nil
+	m.autoSaveMutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	m.SaveCharacters()
+
+	var saved []string
+	for c, reasons := range pending {
+		saved = append(saved, c.Name()+" ("+strings.Join(reasons, ", ")+")")
+	}
+	sort.Strings(saved)
+
+	Armeria.log.Info("characters auto-saved",
+		zap.Strings("characters", saved),
+	)
+}
+
+// autoSaveRareItem auto-saves a Character when an item that isn't common is added to their inventory.
+func autoSaveRareItem(oc *ObjectContainer, uuid string) {
+	c := oc.ParentCharacter()
+	if c == nil || c.Inventory() != oc {
+		return
+	}
+
+	o, rt := Armeria.registry.Get(uuid)
+	if rt != RegistryTypeItemInstance {
+		return
+	}
+
+	if o.(*ItemInstance).Attribute(AttributeRarity) != ItemRarityCommon {
+		Armeria.characterManager.AutoSave(c, AutoSaveReasonRareItem)
+	}
+}
@@ -0,0 +1,120 @@
+package armeria
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BestiaryEntry is what a Character knows about a mob they have encountered.
+type BestiaryEntry struct {
+	Encountered time.Time `json:"encountered"`
+	Kills       int       `json:"kills"`
+}
+
+// bestiaryEntry returns the Character's entry for a mob, creating it if the mob hasn't been encountered. The
+// Character must be locked.
+func (c *Character) bestiaryEntry(m *Mob) *BestiaryEntry {
+	if c.UnsafeBestiary == nil {
+		c.UnsafeBestiary = make(map[string]*BestiaryEntry)
+	}
+
+	e, ok := c.UnsafeBestiary[m.Name()]
+	if !ok {
+		e = &BestiaryEntry{Encountered: time.Now()}
+		c.UnsafeBestiary[m.Name()] = e
+	}
+
+	return e
+}
+
+// Bestiary returns the names of the mobs the Character has encountered, sorted alphabetically.
+func (c *Character) Bestiary() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	var names []string
+	for name := range c.UnsafeBestiary {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// BestiaryEntry returns what the Character knows about a mob, or nil if the mob hasn't been encountered.
+func (c *Character) BestiaryEntry(name string) *BestiaryEntry {
+	c.RLock()
+	defer c.RUnlock()
+
+	for n, e := range c.UnsafeBestiary {
+		if strings.ToLower(n) == strings.ToLower(name) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// EncounterMob adds a mob to the Character's bestiary. It returns false if the mob was already encountered.
+func (c *Character) EncounterMob(m *Mob) bool {
+	c.Lock()
+	_, known := c.UnsafeBestiary[m.Name()]
+	if !known {
+		c.bestiaryEntry(m)
+	}
+	c.Unlock()
+
+	if !known && c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"You encountered %s for the first time. Type %s to learn more.",
+				TextStyle(m.Name(), WithBold()),
+				TextStyle(fmt.Sprintf("/bestiary %s", m.Name()), WithBold()),
+			),
+			ColorCmdHelp,
+		)
+	}
+
+	return !known
+}
+
+// RecordKill adds a kill of a mob to the Character's bestiary, grants any titles earned by reaching the new kill
+// count, progresses the kill objectives of their quests, and returns the new kill count.
+func (c *Character) RecordKill(m *Mob) int {
+	c.Lock()
+	e := c.bestiaryEntry(m)
+	e.Kills++
+	kills := e.Kills
+	c.Unlock()
+
+	Armeria.log.Info("character killed mob",
+		zap.String("character", c.Name()),
+		zap.String("mob", m.Name()),
+		zap.Int("kills", kills),
+	)
+
+	for _, t := range Armeria.titleManager.Titles() {
+		if t.KillMob == "" || strings.ToLower(t.KillMob) != strings.ToLower(m.Name()) || kills < t.Kills {
+			continue
+		}
+		if c.GrantTitle(t) && c.Online() {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf(
+					"You earned the title %s for slaying %d %s!",
+					TextStyle(t.Name, WithBold()),
+					t.Kills,
+					m.Name(),
+				),
+				ColorSuccess,
+			)
+		}
+	}
+
+	c.advanceQuestObjectives(QuestObjectiveKill, m.Name())
+
+	return kills
+}
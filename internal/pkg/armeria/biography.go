@@ -0,0 +1,131 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// BiographyMaxLength is the most characters a biography can have, across all of its paragraphs.
+	BiographyMaxLength = 2000
+	// BiographyMaxParagraphs is the most paragraphs a biography can have.
+	BiographyMaxParagraphs = 10
+	// BiographyPermission is the permission staff need to hide biographies.
+	BiographyPermission = "CAN_CHAREDIT"
+)
+
+// sanitizeBiography cleans up a paragraph written by a player: control characters are removed and runs of
+// whitespace are collapsed to a single space.
+func sanitizeBiography(text string) string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Biography returns the paragraphs of the Character's biography.
+func (c *Character) Biography() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return append([]string{}, c.UnsafeBiography...)
+}
+
+// BiographyHidden returns the name of the staff member who hid the Character's biography, or an empty string if it
+// isn't hidden.
+func (c *Character) BiographyHidden() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeBiographyHidden
+}
+
+// VisibleBiography returns the paragraphs of the Character's biography that others can see, which is none when
+// staff have hidden it.
+func (c *Character) VisibleBiography() []string {
+	if len(c.BiographyHidden()) > 0 {
+		return nil
+	}
+	return c.Biography()
+}
+
+// AddBiographyParagraph adds a paragraph to the end of the Character's biography. The paragraph is sanitized and
+// run through the chat filter: blocked language is rejected, and flagged language is sent to staff for review.
+func (c *Character) AddBiographyParagraph(text string) error {
+	text = sanitizeBiography(text)
+	if len(text) == 0 {
+		return errors.New("the paragraph is empty")
+	}
+
+	result := Armeria.chatFilterManager.Filter(text)
+	if result.Blocked {
+		return errors.New("it contains language that isn't allowed here")
+	}
+
+	c.Lock()
+	length := len(text)
+	for _, p := range c.UnsafeBiography {
+		length += len(p)
+	}
+	if len(c.UnsafeBiography) >= BiographyMaxParagraphs {
+		c.Unlock()
+		return fmt.Errorf("biographies can have at most %d paragraphs", BiographyMaxParagraphs)
+	}
+	if length > BiographyMaxLength {
+		c.Unlock()
+		return fmt.Errorf("biographies can be at most %d characters long", BiographyMaxLength)
+	}
+	c.UnsafeBiography = append(c.UnsafeBiography, result.Text)
+	c.Unlock()
+
+	if len(result.Flagged) > 0 {
+		Armeria.chatFilterManager.Flag(c, "biography", text, result.Flagged)
+	}
+
+	return nil
+}
+
+// RemoveBiographyParagraph removes a paragraph from the Character's biography, by index.
+func (c *Character) RemoveBiographyParagraph(index int) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if index < 0 || index >= len(c.UnsafeBiography) {
+		return errors.New("there is no paragraph with that number")
+	}
+	c.UnsafeBiography = append(c.UnsafeBiography[:index], c.UnsafeBiography[index+1:]...)
+	return nil
+}
+
+// ClearBiography removes every paragraph from the Character's biography.
+func (c *Character) ClearBiography() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeBiography = nil
+}
+
+// SetBiographyHidden hides the Character's biography from others, or shows it again when staff is nil. Hidden
+// biographies stay hidden when they are edited, until staff show them again.
+func (c *Character) SetBiographyHidden(staff *Character) {
+	by := ""
+	if staff != nil {
+		by = staff.Name()
+	}
+
+	c.Lock()
+	c.UnsafeBiographyHidden = by
+	c.Unlock()
+
+	Armeria.log.Info("character biography moderated",
+		zap.String("character", c.Name()),
+		zap.String("hiddenBy", by),
+	)
+}
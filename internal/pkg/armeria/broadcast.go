@@ -0,0 +1,151 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// BroadcastStyle is a preset for how a broadcast message looks to the characters who receive it.
+type BroadcastStyle struct {
+	Name   string
+	Prefix string
+	Color  int
+	// Bold makes the whole message bold, rather than only the prefix.
+	Bold bool
+}
+
+// BroadcastFilter returns true if a character should receive a broadcast.
+type BroadcastFilter func(c *Character) bool
+
+var (
+	broadcastStyles = []*BroadcastStyle{
+		{Name: "info", Prefix: "[Announcement]", Color: ColorCmdHelp},
+		{Name: "event", Prefix: "[Event]", Color: ColorSuccess},
+		{Name: "warning", Prefix: "[Warning]", Color: ColorError},
+		{Name: "urgent", Prefix: "[URGENT]", Color: ColorError, Bold: true},
+	}
+)
+
+// BroadcastStyleNames returns the names of the broadcast style presets.
+func BroadcastStyleNames() []string {
+	var names []string
+	for _, s := range broadcastStyles {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// BroadcastStyleByName returns the broadcast style preset with a name, or nil if there isn't one.
+func BroadcastStyleByName(name string) *BroadcastStyle {
+	for _, s := range broadcastStyles {
+		if strings.ToLower(name) == s.Name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Format returns a broadcast message as it should be shown in the style.
+func (s *BroadcastStyle) Format(message string) string {
+	text := fmt.Sprintf("%s %s", TextStyle(s.Prefix, WithBold()), message)
+	if s.Bold {
+		text = TextStyle(text, WithBold())
+	}
+	return text
+}
+
+// ParseBroadcastFilter parses the characters a broadcast is meant for. The filter is either "all", or a
+// comma-separated list of terms that a character must all match: area=<name> for characters in an area (with
+// underscores in place of spaces), permission=<permission> for characters with a permission, and level<N or level>N
+// for characters below or above a level.
+func ParseBroadcastFilter(s string) (BroadcastFilter, error) {
+	if strings.ToLower(s) == "all" {
+		return func(c *Character) bool { return true }, nil
+	}
+
+	var filters []BroadcastFilter
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		if strings.HasPrefix(strings.ToLower(term), "level<") || strings.HasPrefix(strings.ToLower(term), "level>") {
+			level, err := strconv.Atoi(term[len("level<"):])
+			if err != nil {
+				return nil, fmt.Errorf("the level of %q must be a whole number", term)
+			}
+			if term[len("level")] == '<' {
+				filters = append(filters, func(c *Character) bool { return c.Level() < level })
+			} else {
+				filters = append(filters, func(c *Character) bool { return c.Level() > level })
+			}
+			continue
+		}
+
+		sections := strings.SplitN(term, "=", 2)
+		if len(sections) != 2 || len(sections[1]) == 0 {
+			return nil, fmt.Errorf("%q must be all, area=<name>, permission=<permission>, level<N or level>N", term)
+		}
+
+		value := sections[1]
+		switch strings.ToLower(sections[0]) {
+		case "area":
+			a := Armeria.worldManager.AreaByName(strings.ReplaceAll(value, "_", " "))
+			if a == nil {
+				return nil, fmt.Errorf("there is no area named %q", value)
+			}
+			filters = append(filters, func(c *Character) bool {
+				return c.Room() != nil && c.Room().ParentArea == a
+			})
+		case "permission":
+			perm := strings.ToUpper(value)
+			filters = append(filters, func(c *Character) bool {
+				return c.HasPermission(perm)
+			})
+		default:
+			return nil, fmt.Errorf("%q must be all, area=<name>, permission=<permission>, level<N or level>N", term)
+		}
+	}
+
+	return func(c *Character) bool {
+		for _, f := range filters {
+			if !f(c) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// Broadcast sends a message in a style to the online characters that match the filter. It returns the number of
+// characters who received it.
+func Broadcast(style *BroadcastStyle, filter BroadcastFilter, message string) int {
+	count := 0
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if filter(c) {
+			c.Player().client.ShowColorizedText(style.Format(message), style.Color)
+			count++
+		}
+	}
+	return count
+}
+
+// BroadcastFrom sends a broadcast on behalf of a staff member, and records who sent it.
+func BroadcastFrom(by *Character, style *BroadcastStyle, filter string, message string) (int, error) {
+	f, err := ParseBroadcastFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	count := Broadcast(style, f, message)
+
+	Armeria.log.Info("message broadcast",
+		zap.String("by", by.Name()),
+		zap.String("style", style.Name),
+		zap.String("filter", filter),
+		zap.Int("recipients", count),
+		zap.String("message", message),
+	)
+
+	return count, nil
+}
@@ -0,0 +1,252 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BulkEditTarget is a single object within a builder's bulk edit selection. Rooms, item instances, and mob
+// instances can be selected.
+type BulkEditTarget struct {
+	ObjectType ObjectType
+	Object     interface{}
+}
+
+// BulkEditChange is a preview of an attribute change to a single BulkEditTarget.
+type BulkEditChange struct {
+	Target   *BulkEditTarget
+	OldValue string
+	NewValue string
+	Error    error
+}
+
+// NewBulkEditTarget returns the BulkEditTarget for a uuid, or nil if the object cannot be bulk edited.
+func NewBulkEditTarget(uuid string) *BulkEditTarget {
+	o, rt := Armeria.registry.Get(uuid)
+	switch rt {
+	case RegistryTypeRoom:
+		return &BulkEditTarget{ObjectType: ObjectTypeRoom, Object: o}
+	case RegistryTypeItemInstance:
+		return &BulkEditTarget{ObjectType: ObjectTypeItemInstance, Object: o}
+	case RegistryTypeMobInstance:
+		return &BulkEditTarget{ObjectType: ObjectTypeMobInstance, Object: o}
+	}
+
+	return nil
+}
+
+// ID returns the uuid of the selected object.
+func (t *BulkEditTarget) ID() string {
+	switch o := t.Object.(type) {
+	case *Room:
+		return o.ID()
+	case *ItemInstance:
+		return o.ID()
+	case *MobInstance:
+		return o.ID()
+	}
+
+	return ""
+}
+
+// Name returns a human-readable name for the selected object.
+func (t *BulkEditTarget) Name() string {
+	switch o := t.Object.(type) {
+	case *Room:
+		return fmt.Sprintf("%s (%s)", o.Attribute(AttributeTitle), o.Coords.String())
+	case *ItemInstance:
+		return o.FormattedName()
+	case *MobInstance:
+		return o.FormattedName()
+	}
+
+	return ""
+}
+
+// Attribute returns the current value of an attribute on the selected object.
+func (t *BulkEditTarget) Attribute(name string) string {
+	switch o := t.Object.(type) {
+	case *Room:
+		return o.DraftAttribute(name)
+	case *ItemInstance:
+		return o.Attribute(name)
+	case *MobInstance:
+		return o.Attribute(name)
+	}
+
+	return ""
+}
+
+// Validate checks if an attribute can be set to the given value on the selected object.
+func (t *BulkEditTarget) Validate(name, value string) error {
+	return ValidateAttribute(t.ObjectType, name, value, t.Attribute)
+}
+
+// SetAttribute sets an attribute on the selected object.
+func (t *BulkEditTarget) SetAttribute(name, value string) error {
+	switch o := t.Object.(type) {
+	case *Room:
+		return o.SetDraftAttribute(name, value)
+	case *ItemInstance:
+		return o.SetAttribute(name, value)
+	case *MobInstance:
+		return o.SetAttribute(name, value)
+	}
+
+	return errors.New("object cannot be bulk edited")
+}
+
+// EditorData returns the object editor data for the selected object.
+func (t *BulkEditTarget) EditorData() *ObjectEditorData {
+	switch o := t.Object.(type) {
+	case *Room:
+		return o.EditorData()
+	case *ItemInstance:
+		return o.EditorData()
+	case *MobInstance:
+		return o.EditorData()
+	}
+
+	return nil
+}
+
+// BulkEditTargetsFromString parses a target string into a list of BulkEditTarget objects. Valid targets are
+// "." (the current room), "x,y,z" (a room in the current area), "x,y,z:x,y,z" (every room within the
+// bounds in the current area), or the uuid of a room, item instance, or mob instance.
+func BulkEditTargetsFromString(c *Character, target string) ([]*BulkEditTarget, error) {
+	if target == "." {
+		return []*BulkEditTarget{{ObjectType: ObjectTypeRoom, Object: c.Room()}}, nil
+	}
+
+	if t := NewBulkEditTarget(target); t != nil {
+		return []*BulkEditTarget{t}, nil
+	}
+
+	bounds := strings.Split(target, ":")
+	if len(bounds) > 2 {
+		return nil, errors.New("invalid room range")
+	}
+
+	var corners []*Coords
+	for _, b := range bounds {
+		co := NewCoordsFromString(b)
+		if co == nil {
+			return nil, errors.New("target is not a valid uuid or set of coordinates")
+		}
+		corners = append(corners, co)
+	}
+	if len(corners) == 1 {
+		corners = append(corners, corners[0])
+	}
+
+	minX, maxX := orderedInts(corners[0].X(), corners[1].X())
+	minY, maxY := orderedInts(corners[0].Y(), corners[1].Y())
+	minZ, maxZ := orderedInts(corners[0].Z(), corners[1].Z())
+
+	var targets []*BulkEditTarget
+	for _, r := range c.Room().ParentArea.Rooms() {
+		x, y, z := r.Coords.X(), r.Coords.Y(), r.Coords.Z()
+		if x >= minX && x <= maxX && y >= minY && y <= maxY && z >= minZ && z <= maxZ {
+			targets = append(targets, &BulkEditTarget{ObjectType: ObjectTypeRoom, Object: r})
+		}
+	}
+
+	if len(targets) == 0 {
+		return nil, errors.New("no rooms exist at those coordinates")
+	}
+
+	return targets, nil
+}
+
+// orderedInts returns the two ints from lowest to highest.
+func orderedInts(a, b int) (int, int) {
+	if a > b {
+		return b, a
+	}
+	return a, b
+}
+
+// BulkEditSelection returns the objects currently selected for bulk editing. Objects that no longer exist
+// are dropped from the selection.
+func (c *Character) BulkEditSelection() []*BulkEditTarget {
+	var targets []*BulkEditTarget
+	for _, id := range strings.Split(c.TempAttribute(TempAttributeEditorSelection), ",") {
+		if t := NewBulkEditTarget(id); t != nil {
+			targets = append(targets, t)
+		}
+	}
+
+	return targets
+}
+
+// SetBulkEditSelection replaces the objects currently selected for bulk editing.
+func (c *Character) SetBulkEditSelection(targets []*BulkEditTarget) {
+	var ids []string
+	for _, t := range targets {
+		if !misc.Contains(ids, t.ID()) {
+			ids = append(ids, t.ID())
+		}
+	}
+
+	c.SetTempAttribute(TempAttributeEditorSelection, strings.Join(ids, ","))
+}
+
+// PreviewBulkEdit returns the changes that would be made by a Character setting an attribute on every selected
+// object. Objects in areas that the Character cannot build in are skipped.
+func PreviewBulkEdit(c *Character, targets []*BulkEditTarget, name, value string) []*BulkEditChange {
+	var changes []*BulkEditChange
+	for _, t := range targets {
+		err := t.Validate(name, value)
+		if !c.HasPermissionIn("CAN_BUILD", Armeria.registry.GetArea(t.ID())) {
+			err = errors.New("no permission to build here")
+		}
+
+		changes = append(changes, &BulkEditChange{
+			Target:   t,
+			OldValue: t.Attribute(name),
+			NewValue: value,
+			Error:    err,
+		})
+	}
+
+	return changes
+}
+
+// bulkEditSummary returns a table describing a set of bulk edit changes.
+func bulkEditSummary(changes []*BulkEditChange) string {
+	rows := []string{TableRow(
+		TableCell{content: "Object", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "Current", header: true},
+		TableCell{content: "New", header: true},
+	)}
+
+	for _, ch := range changes {
+		nv := ch.NewValue
+		if ch.Error != nil {
+			nv = fmt.Sprintf("skipped: %s", ch.Error)
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: ch.Target.Name()},
+			TableCell{content: string(ch.Target.ObjectType)},
+			TableCell{content: ch.OldValue},
+			TableCell{content: nv},
+		))
+	}
+
+	return TextTable(rows...)
+}
+
+// bulkEditCount returns the number of changes that would apply without error.
+func bulkEditCount(changes []*BulkEditChange) int {
+	count := 0
+	for _, ch := range changes {
+		if ch.Error == nil {
+			count++
+		}
+	}
+
+	return count
+}
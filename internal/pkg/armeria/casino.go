@@ -0,0 +1,271 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MinimumBet is the smallest amount that can be bet on a minigame.
+	MinimumBet float64 = 1
+	// MaximumBet is the largest amount that can be bet on a minigame.
+	MaximumBet float64 = 1000
+	// WagerExpiry is how long a challenge between two characters can be accepted.
+	WagerExpiry = 2 * time.Minute
+	// RoomTypeCasino is the room type where games can be played against the house.
+	RoomTypeCasino = "casino"
+)
+
+// Minigame is a game of chance that characters can bet on.
+type Minigame struct {
+	Name string
+	Help string
+	// Play resolves a round between two sides, returning a description of what happened and the winning side
+	// (1 or 2, or 0 for a tie).
+	Play func(first, second string) (string, int)
+}
+
+// Wager is a bet that one Character has challenged another to.
+type Wager struct {
+	Game       *Minigame
+	Challenger *Character
+	Opponent   *Character
+	Bet        float64
+	Created    time.Time
+}
+
+// CasinoManager tracks the wagers waiting to be accepted.
+type CasinoManager struct {
+	sync.RWMutex
+	unsafeWagers map[*Character]*Wager
+}
+
+var (
+	cardRanks = []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "Jack", "Queen", "King", "Ace"}
+	cardSuits = []string{"Clubs", "Diamonds", "Hearts", "Spades"}
+
+	minigames = []*Minigame{
+		{
+			Name: "dice",
+			Help: "Both sides roll two dice, and the highest total wins.",
+			Play: func(first, second string) (string, int) {
+				a1, a2 := misc.RandomInt(6)+1, misc.RandomInt(6)+1
+				b1, b2 := misc.RandomInt(6)+1, misc.RandomInt(6)+1
+				desc := fmt.Sprintf(
+					"%s rolls %d and %d (%d). %s rolls %d and %d (%d).",
+					first, a1, a2, a1+a2, second, b1, b2, b1+b2,
+				)
+				return desc, compareScores(a1+a2, b1+b2)
+			},
+		},
+		{
+			Name: "highcard",
+			Help: "Both sides draw a card from a shuffled deck, and the highest rank wins.",
+			Play: func(first, second string) (string, int) {
+				deck := make([]int, len(cardRanks)*len(cardSuits))
+				for i := range deck {
+					deck[i] = i
+				}
+				misc.Shuffle(len(deck), func(i, j int) {
+					deck[i], deck[j] = deck[j], deck[i]
+				})
+				a, b := deck[0], deck[1]
+				desc := fmt.Sprintf(
+					"%s draws the %s. %s draws the %s.",
+					first, cardName(a), second, cardName(b),
+				)
+				return desc, compareScores(a%len(cardRanks), b%len(cardRanks))
+			},
+		},
+	}
+)
+
+// compareScores returns the side with the higher score, or 0 for a tie.
+func compareScores(first, second int) int {
+	if first > second {
+		return 1
+	} else if second > first {
+		return 2
+	}
+	return 0
+}
+
+// cardName returns the name of a card within a deck, by index.
+func cardName(card int) string {
+	return fmt.Sprintf("%s of %s", cardRanks[card%len(cardRanks)], cardSuits[card/len(cardRanks)])
+}
+
+// Minigames returns the games that can be played.
+func Minigames() []*Minigame {
+	return minigames
+}
+
+// MinigameByName returns the matching Minigame, by name.
+func MinigameByName(name string) *Minigame {
+	for _, g := range minigames {
+		if strings.ToLower(g.Name) == strings.ToLower(name) {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// MinigameNames returns the names of the games that can be played.
+func MinigameNames() []string {
+	var names []string
+	for _, g := range minigames {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+// validateBet returns an error if a bet is outside of the betting limits. The check is written so that NaN fails it.
+func validateBet(bet float64) error {
+	if !(bet >= MinimumBet && bet <= MaximumBet) {
+		return fmt.Errorf(
+			"bets must be between %s and %s",
+			misc.Money.FormatMoney(MinimumBet),
+			misc.Money.FormatMoney(MaximumBet),
+		)
+	}
+	return nil
+}
+
+// NewCasinoManager returns a new CasinoManager.
+func NewCasinoManager() *CasinoManager {
+	return &CasinoManager{
+		unsafeWagers: make(map[*Character]*Wager),
+	}
+}
+
+// PlayHouse plays a round of a Minigame between a Character and the house, which is represented by host (ie: the
+// name of a mob hosting the game). The house wins ties. It returns the amount paid out to the Character.
+func PlayHouse(c *Character, game *Minigame, bet float64, host string) (float64, error) {
+	if err := validateBet(bet); err != nil {
+		return 0, err
+	}
+
+	if err := c.DeductMoney(bet, MoneySourceCasino); err != nil {
+		return 0, errors.New("you can't afford that bet")
+	}
+
+	desc, winner := game.Play(c.Name(), host)
+
+	var payout float64
+	var outcome string
+	if winner == 1 {
+		payout = bet * 2
+		if err := c.AddMoney(payout, MoneySourceCasino); err != nil {
+			c.refundMoney(bet, MoneySourceCasino)
+			payout = 0
+			outcome = fmt.Sprintf("%s wins, but can't carry any more money, so the bet is returned.", c.Name())
+		} else {
+			outcome = fmt.Sprintf("%s wins %s!", c.Name(), misc.Money.FormatMoney(bet))
+		}
+	} else {
+		outcome = fmt.Sprintf("%s loses %s.", c.Name(), misc.Money.FormatMoney(bet))
+	}
+
+	Armeria.log.Info("house game played",
+		zap.String("character", c.Name()),
+		zap.String("game", game.Name),
+		zap.Float64("bet", bet),
+		zap.Float64("payout", payout),
+	)
+
+	if r := c.Room(); r != nil {
+		for _, char := range r.Here().Characters(true) {
+			char.Player().client.ShowText(fmt.Sprintf("%s %s", desc, TextStyle(outcome, WithBold())))
+		}
+	}
+	if c.Online() {
+		c.Player().client.SyncMoney()
+	}
+
+	return payout, nil
+}
+
+// Challenge offers a Wager to another Character, replacing any challenge they were already offered.
+func (m *CasinoManager) Challenge(w *Wager) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.unsafeWagers[w.Opponent] = w
+}
+
+// WagerFor removes and returns the challenge a Character was offered, or nil if there isn't one that can still be
+// accepted.
+func (m *CasinoManager) WagerFor(c *Character) *Wager {
+	m.Lock()
+	defer m.Unlock()
+
+	w := m.unsafeWagers[c]
+	delete(m.unsafeWagers, c)
+	if w == nil || time.Since(w.Created) > WagerExpiry {
+		return nil
+	}
+
+	return w
+}
+
+// Resolve plays a round of the Wager's game between both characters, paying the pot to the winner. Ties are
+// refunded. The pot only changes hands between the players, so it isn't recorded in the economy ledger.
+func (w *Wager) Resolve() error {
+	if w.Challenger.Room() != w.Opponent.Room() {
+		return errors.New("you both need to be in the same room")
+	}
+
+	if err := w.Challenger.HoldMoney(w.Bet); err != nil {
+		return fmt.Errorf("%s can no longer afford the bet", w.Challenger.Name())
+	}
+	if err := w.Opponent.HoldMoney(w.Bet); err != nil {
+		w.Challenger.returnHeldMoney(w.Bet)
+		return errors.New("you can't afford the bet")
+	}
+
+	desc, winner := w.Game.Play(w.Challenger.Name(), w.Opponent.Name())
+
+	var outcome string
+	players := []*Character{w.Challenger, w.Opponent}
+	if winner == 1 || winner == 2 {
+		c := players[winner-1]
+		if err := c.ReleaseMoney(w.Bet * 2); err != nil {
+			winner = 0
+			outcome = fmt.Sprintf("%s wins, but can't carry any more money, so both bets are returned.", c.Name())
+		} else {
+			outcome = fmt.Sprintf("%s wins %s!", c.Name(), misc.Money.FormatMoney(w.Bet))
+		}
+	} else {
+		outcome = "It's a tie, and both bets are returned."
+	}
+	if winner != 1 && winner != 2 {
+		w.Challenger.returnHeldMoney(w.Bet)
+		w.Opponent.returnHeldMoney(w.Bet)
+	}
+
+	Armeria.log.Info("wager resolved",
+		zap.String("challenger", w.Challenger.Name()),
+		zap.String("opponent", w.Opponent.Name()),
+		zap.String("game", w.Game.Name),
+		zap.Float64("bet", w.Bet),
+		zap.Int("winner", winner),
+	)
+
+	for _, char := range w.Challenger.Room().Here().Characters(true) {
+		char.Player().client.ShowText(fmt.Sprintf("%s %s", desc, TextStyle(outcome, WithBold())))
+	}
+	for _, c := range players {
+		if c.Online() {
+			c.Player().client.SyncMoney()
+		}
+	}
+
+	return nil
+}
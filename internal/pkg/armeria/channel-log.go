@@ -0,0 +1,156 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ChannelLogWindow is how long channel messages are kept for moderators to retract.
+	ChannelLogWindow = 30 * time.Minute
+	// ChannelLogLimit is the maximum number of channel messages kept at once.
+	ChannelLogLimit = 200
+)
+
+// ChannelMessage is a message a character sent to a channel.
+type ChannelMessage struct {
+	ID        string
+	Channel   *Channel
+	Character string
+	Text      string
+	Time      time.Time
+}
+
+// ChannelLog keeps the channel messages sent within the last ChannelLogWindow, so that moderators can retract
+// them.
+type ChannelLog struct {
+	sync.RWMutex
+	nextID   int
+	messages []*ChannelMessage
+}
+
+// NewChannelLog creates a new, empty ChannelLog.
+func NewChannelLog() *ChannelLog {
+	return &ChannelLog{}
+}
+
+// NextID returns a new id for a channel message. Within a cluster, ids are prefixed with the name of the node, so
+// that they are unique across every node.
+func (l *ChannelLog) NextID() string {
+	l.Lock()
+	l.nextID++
+	id := strconv.Itoa(l.nextID)
+	l.Unlock()
+
+	if Armeria.clusterManager != nil {
+		return fmt.Sprintf("%s-%s", Armeria.clusterManager.Node(), id)
+	}
+	return id
+}
+
+// Add records a channel message, and forgets the messages that fell outside of the window.
+func (l *ChannelLog) Add(msg *ChannelMessage) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.messages = append(l.messages, msg)
+
+	cutoff := time.Now().Add(-ChannelLogWindow)
+	for len(l.messages) > 0 && (len(l.messages) > ChannelLogLimit || l.messages[0].Time.Before(cutoff)) {
+		l.messages = l.messages[1:]
+	}
+}
+
+// Recent returns the messages sent to a channel that can still be retracted, oldest first.
+func (l *ChannelLog) Recent(ch *Channel) []ChannelMessage {
+	l.RLock()
+	defer l.RUnlock()
+
+	cutoff := time.Now().Add(-ChannelLogWindow)
+
+	var messages []ChannelMessage
+	for _, msg := range l.messages {
+		if msg.Channel == ch && msg.Time.After(cutoff) {
+			messages = append(messages, *msg)
+		}
+	}
+	return messages
+}
+
+// Remove forgets a channel message, and returns it. It returns nil if there was no such message, or it fell
+// outside of the window.
+func (l *ChannelLog) Remove(id string) *ChannelMessage {
+	l.Lock()
+	defer l.Unlock()
+
+	cutoff := time.Now().Add(-ChannelLogWindow)
+	for i, msg := range l.messages {
+		if msg.ID == id {
+			l.messages = append(l.messages[:i], l.messages[i+1:]...)
+			if msg.Time.Before(cutoff) {
+				return nil
+			}
+			return msg
+		}
+	}
+
+	return nil
+}
+
+// Retract removes a recent channel message from the displays and chat histories of everyone who received it. The
+// retraction is passed on to the rest of the cluster, where the message was delivered as well.
+func (l *ChannelLog) Retract(id string, by *Character) (*ChannelMessage, error) {
+	msg := l.Remove(id)
+	if msg == nil {
+		return nil, fmt.Errorf("there is no message with id %s from the last %s", id, TextDuration(ChannelLogWindow))
+	}
+
+	redactChannelMessage(msg.Channel, id)
+
+	if Armeria.clusterManager != nil {
+		Armeria.clusterManager.PublishRetraction(msg.Channel.key(), id)
+	}
+
+	Armeria.log.Info("channel message retracted",
+		zap.String("id", id),
+		zap.String("channel", msg.Channel.Name),
+		zap.String("character", msg.Character),
+		zap.String("text", msg.Text),
+		zap.String("by", by.Name()),
+	)
+
+	return msg, nil
+}
+
+// redactChannelMessage replaces a channel message with a notice that it was retracted, for the local characters
+// in the channel.
+func redactChannelMessage(ch *Channel, id string) {
+	notice := fmt.Sprintf("[%s] %s", TextStyle(ch.Name, WithBold()), TextStyle("This message was removed by a moderator.", WithItalics()))
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if c.InChannel(ch) {
+			c.RedactChatMessage(id, c.Colorize(notice, ColorCmdHelp))
+		}
+	}
+}
+
+// RedactChatMessage replaces a channel message in the Character's chat history and on their client.
+func (c *Character) RedactChatMessage(id, text string) {
+	marker := chatMessageMarker(id)
+
+	c.Lock()
+	for _, e := range c.unsafeChatHistory {
+		if strings.Contains(e.Text, marker) {
+			e.Text = text
+		}
+	}
+	c.Unlock()
+
+	if c.Online() {
+		c.Player().client.RedactChatMessage(id, text)
+	}
+}
@@ -3,6 +3,7 @@ package armeria
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Channel describes a particular talking channel.
@@ -67,14 +68,23 @@ func (c *Channel) HasPermission(char *Character) bool {
 }
 
 // Broadcast sends a message to all logged-in players that have joined the channel. You can pass
-// nil as the Character if this is coming from a system rather than a particular unsafeCharacter.
+// nil as the Character if this is coming from a system rather than a particular unsafeCharacter. Text from a
+// character is escaped and run through the chat filter, while text from the system can contain styling.
 func (c *Channel) Broadcast(from *Character, text string) {
+	var logged *ChannelMessage
 	var msgToOthers string
+	var unfilteredToOthers string
 	var msgToFrom string
 	var verbs []string
 
 	if from != nil {
+		filteredText, ok := from.FilterChat(c.Name, text)
+		if !ok {
+			return
+		}
+
 		normalizedText, textType := TextPunctuation(text)
+		filteredText, _ = TextPunctuation(filteredText)
 		switch textType {
 		case TextQuestion:
 			verbs = []string{"ask", "asks"}
@@ -86,34 +96,71 @@ func (c *Channel) Broadcast(from *Character, text string) {
 			verbs = []string{"say", "says"}
 			break
 		}
-		normalizedText = TextCapitalization(normalizedText)
-		msgToOthers = fmt.Sprintf("[%s] %s %s, \"%s\"", TextStyle(c.Name, WithBold()), from.FormattedNameWithTitle(), verbs[1], normalizedText)
-		msgToFrom = fmt.Sprintf(
-			"%s You %s, \"%s\"",
-			TextStyle(c.Name, WithChannelLabel(from.UserColor(c.Color))),
-			verbs[0],
-			normalizedText,
+		normalizedText = TextStylePlayer(TextCapitalization(normalizedText))
+		filteredText = TextStylePlayer(TextCapitalization(filteredText))
+
+		logged = &ChannelMessage{
+			ID:        Armeria.channelLog.NextID(),
+			Channel:   c,
+			Character: from.Name(),
+			Text:      text,
+			Time:      time.Now(),
+		}
+		Armeria.channelLog.Add(logged)
+
+		msgToOthers = TextStyle(
+			fmt.Sprintf("[%s] %s %s, \"%s\"", TextStyle(c.Name, WithBold()), from.FormattedNameWithTitle(), verbs[1], filteredText),
+			WithChatMessage(logged.ID),
+		)
+		unfilteredToOthers = TextStyle(
+			fmt.Sprintf("[%s] %s %s, \"%s\"", TextStyle(c.Name, WithBold()), from.FormattedNameWithTitle(), verbs[1], normalizedText),
+			WithChatMessage(logged.ID),
+		)
+		msgToFrom = TextStyle(
+			fmt.Sprintf(
+				"%s You %s, \"%s\"",
+				TextStyle(c.Name, WithChannelLabel(from.UserColor(c.Color))),
+				verbs[0],
+				from.ChatText(filteredText, normalizedText),
+			),
+			WithChatMessage(logged.ID),
 		)
 
 	} else {
 		msgToOthers = fmt.Sprintf("[%s] %s", TextStyle(c.Name, WithBold()), text)
+		unfilteredToOthers = msgToOthers
+	}
+
+	c.deliver(from, msgToOthers, unfilteredToOthers)
+
+	if from != nil {
+		from.Player().client.ShowChatText(c.Name, from.Colorize(msgToFrom, c.Color))
 	}
 
+	// System messages are sent by every node, so only messages from characters are bridged.
+	if from != nil && Armeria.clusterManager != nil {
+		Armeria.clusterManager.PublishChannelMessage(logged, msgToOthers, unfilteredToOthers)
+	}
+}
+
+// deliver shows a message to the logged-in characters that have joined the channel, except the sender. Characters
+// who turned off chat filtering are shown the unfiltered message instead.
+func (c *Channel) deliver(from *Character, msg, unfiltered string) {
 	for _, char := range Armeria.characterManager.OnlineCharacters() {
 		if char.InChannel(c) {
 			if from == nil || from.ID() != char.ID() {
-				char.Player().client.ShowColorizedText(
-					msgToOthers,
-					c.Color,
-				)
+				char.Player().client.ShowChatText(c.Name, char.Colorize(char.ChatText(msg, unfiltered), c.Color))
 			}
 		}
 	}
+}
 
-	if from != nil {
-		from.Player().client.ShowColorizedText(
-			msgToFrom,
-			c.Color,
-		)
+// key returns the key the Channel is stored under within the game state.
+func (c *Channel) key() string {
+	for k, ch := range Armeria.channels {
+		if ch == c {
+			return k
+		}
 	}
+	return ""
 }
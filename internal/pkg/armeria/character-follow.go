@@ -0,0 +1,101 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Leader returns the online Character that the Character is following, or nil if they aren't following anyone.
+func (c *Character) Leader() *Character {
+	id := c.TempAttribute(TempAttributeFollowing)
+	if len(id) == 0 {
+		return nil
+	}
+
+	leader := Armeria.characterManager.CharacterById(id)
+	if leader == nil || !leader.Online() {
+		return nil
+	}
+
+	return leader
+}
+
+// Followers returns the online characters in the same room that are following the Character.
+func (c *Character) Followers() []*Character {
+	var followers []*Character
+	for _, f := range c.Room().Here().Characters(true, c) {
+		if f.Leader() == c {
+			followers = append(followers, f)
+		}
+	}
+
+	return followers
+}
+
+// Follow makes the Character follow another Character, so they are carried along when the leader walks to another
+// room.
+func (c *Character) Follow(leader *Character) error {
+	if leader == c {
+		return errors.New("you can't follow yourself")
+	}
+	if leader.Room() != c.Room() {
+		return errors.New("you can only follow someone in the same room")
+	}
+	if leader.Setting(SettingNoFollow) == "true" {
+		return fmt.Errorf("%s doesn't want to be followed", leader.Name())
+	}
+	if c.Leader() == leader {
+		return fmt.Errorf("you're already following %s", leader.Name())
+	}
+
+	c.SetTempAttribute(TempAttributeFollowing, leader.ID())
+	return nil
+}
+
+// StopFollowing stops the Character from following anyone, letting the leader know when they are online. It
+// returns the Character that was being followed.
+func (c *Character) StopFollowing() *Character {
+	leader := c.Leader()
+	c.SetTempAttribute(TempAttributeFollowing, "")
+
+	if leader != nil {
+		leader.Player().client.ShowText(
+			TextStyle(fmt.Sprintf("%s is no longer following you.", c.FormattedName()), WithUserColor(leader, ColorMovement)),
+		)
+	}
+
+	return leader
+}
+
+// Lose sheds a follower, letting them know they've been left behind.
+func (c *Character) Lose(follower *Character) {
+	follower.SetTempAttribute(TempAttributeFollowing, "")
+	follower.Player().client.ShowText(
+		TextStyle(fmt.Sprintf("You're no longer following %s.", c.FormattedName()), WithUserColor(follower, ColorMovement)),
+	)
+}
+
+// carryFollowers moves the followers left behind in a room after their leader walks in a direction. Followers are
+// moved one at a time, so anyone following them is carried along as well.
+func carryFollowers(leader *Character, from *Room, direction string) {
+	to := leader.Room()
+	for _, f := range from.Here().Characters(true) {
+		if f.Leader() != leader {
+			continue
+		}
+
+		if allowed, _ := f.MoveAllowed(to); !allowed || len(f.TempAttribute(TempAttributeTraveling)) > 0 {
+			f.StopFollowing()
+			f.Player().client.ShowColorizedText(
+				fmt.Sprintf("You can't keep up with %s, and stop following them.", leader.FormattedName()),
+				ColorError,
+			)
+			continue
+		}
+
+		f.Player().client.ShowText(
+			TextStyle(fmt.Sprintf("You follow %s.", leader.FormattedName()), WithUserColor(f, ColorMovement)),
+		)
+		moveCharacter(&CommandContext{Player: f.Player(), Character: f}, to, direction)
+	}
+}
@@ -0,0 +1,114 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// WalkMaxSteps is the furthest (in rooms) a character can automatically walk.
+	WalkMaxSteps = 200
+	// WalkStepDelay is how long a character waits between rooms while automatically walking.
+	WalkStepDelay = time.Second
+)
+
+// Waypoint returns the Room marked as a waypoint with a particular name, preferring rooms in a particular Area. It
+// returns nil if there isn't a waypoint with that name.
+func (m *WorldManager) Waypoint(name string, near *Area) *Room {
+	var found *Room
+	for _, a := range m.Areas() {
+		for _, r := range a.Rooms() {
+			if strings.ToLower(r.Attribute(AttributeWaypoint)) != strings.ToLower(name) {
+				continue
+			}
+			if a == near {
+				return r
+			}
+			if found == nil {
+				found = r
+			}
+		}
+	}
+
+	return found
+}
+
+// Walking returns true if the Character is automatically walking somewhere.
+func (c *Character) Walking() bool {
+	return len(c.TempAttribute(TempAttributeWalking)) > 0
+}
+
+// StopWalking stops the Character from automatically walking. It returns true if they were walking.
+func (c *Character) StopWalking() bool {
+	if !c.Walking() {
+		return false
+	}
+
+	c.SetTempAttribute(TempAttributeWalking, "")
+	return true
+}
+
+// WalkTo sets the Character automatically walking to a Room, one room at a time. The walk is cancelled when the
+// Character moves some other way, such as walking themselves or being teleported. It returns the number of rooms
+// the walk will take.
+func (c *Character) WalkTo(to *Room) (int, error) {
+	if c.Room() == to {
+		return 0, errors.New("you're already there")
+	}
+	if c.Vehicle() != nil {
+		return 0, errors.New("you can't walk anywhere while you're aboard a vehicle")
+	}
+
+	path := PathBetween(c.Room(), to, WalkMaxSteps)
+	if len(path) == 0 {
+		return 0, errors.New("you can't find a way there")
+	}
+
+	walk := uuid.New().String()
+	c.SetTempAttribute(TempAttributeWalking, walk)
+	c.walkStep(walk, path)
+
+	return len(path), nil
+}
+
+// walkStep schedules the next step of an automatic walk. Each walk has its own id, so a step from an earlier walk
+// that was cancelled won't be taken.
+func (c *Character) walkStep(walk string, path []string) {
+	from := c.Room()
+	next := from.ConnectedRoom(path[0])
+	delay := WalkStepDelay
+	if next != nil {
+		delay += next.TravelTime()
+	}
+
+	time.AfterFunc(delay, func() {
+		if !c.Online() || c.TempAttribute(TempAttributeWalking) != walk || c.Room() != from {
+			return
+		}
+
+		// The wilderness room may have been unloaded while waiting, so find it again.
+		next := from.ConnectedRoom(path[0])
+		if allowed, reason := c.MoveAllowed(next); !allowed {
+			c.StopWalking()
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("Your way is blocked, and you stop walking. %s", reason),
+				ColorError,
+			)
+			return
+		}
+
+		moveCharacter(&CommandContext{Player: c.Player(), Character: c}, next, path[0])
+
+		if len(path) == 1 {
+			c.StopWalking()
+			c.Player().client.ShowColorizedText("You've arrived.", ColorSuccess)
+			return
+		}
+
+		c.walkStep(walk, path[1:])
+	})
+}
@@ -1,6 +1,7 @@
 package armeria
 
 import (
+	"armeria/internal/pkg/markup"
 	"armeria/internal/pkg/misc"
 	"armeria/internal/pkg/sfx"
 	"crypto/md5"
@@ -23,17 +24,40 @@ var _ ContainerObject = (*Character)(nil)
 // A Character is the player's logged in character.
 type Character struct {
 	sync.RWMutex
-	UUID                 string            `json:"uuid"`
-	UnsafeName           string            `json:"name"`
-	UnsafePassword       string            `json:"password"`
-	UnsafeAttributes     map[string]string `json:"attributes"`
-	UnsafeSettings       map[string]string `json:"settings"`
-	UnsafeInventory      *ObjectContainer  `json:"inventory"`
-	UnsafeEquipment      *ObjectContainer  `json:"equipment"`
-	UnsafeTempAttributes map[string]string `json:"-"`
-	UnsafeLastSeen       time.Time         `json:"lastSeen"`
-	UnsafeMobConvo       *Conversation     `json:"-"`
-	player               *Player
+	UUID                  string                     `json:"uuid"`
+	UnsafeName            string                     `json:"name"`
+	UnsafePassword        string                     `json:"password"`
+	UnsafeAttributes      map[string]string          `json:"attributes"`
+	UnsafeSettings        map[string]string          `json:"settings"`
+	UnsafeInventory       *ObjectContainer           `json:"inventory"`
+	UnsafeEquipment       *ObjectContainer           `json:"equipment"`
+	UnsafeTitles          []string                   `json:"titles"`
+	UnsafeBestiary        map[string]*BestiaryEntry  `json:"bestiary"`
+	UnsafeGatheringSkills map[string]int             `json:"gatheringSkills"`
+	UnsafeTaming          int                        `json:"taming,omitempty"`
+	UnsafeLevel           int                        `json:"level,omitempty"`
+	UnsafeExperience      int                        `json:"experience,omitempty"`
+	UnsafeMoney           int64                      `json:"money,omitempty"`
+	UnsafeBiography       []string                   `json:"biography,omitempty"`
+	UnsafeBiographyHidden string                     `json:"biographyHidden,omitempty"`
+	UnsafeReputation      map[string]int             `json:"reputation,omitempty"`
+	UnsafeQuests          []string                   `json:"quests,omitempty"`
+	UnsafeCompletedQuests []string                   `json:"completedQuests,omitempty"`
+	UnsafeQuestPickups    map[string][]string        `json:"questPickups,omitempty"`
+	UnsafeQuestDeliveries map[string]*QuestDelivery  `json:"questDeliveries,omitempty"`
+	UnsafeQuestProgress   map[string]*QuestInstance  `json:"questProgress,omitempty"`
+	UnsafeCraftQueue      []*CraftJob                `json:"craftQueue,omitempty"`
+	UnsafeWilderness      string                     `json:"wilderness,omitempty"`
+	UnsafeExplored        map[string]map[string]bool `json:"explored,omitempty"`
+	UnsafeTempAttributes  map[string]string          `json:"-"`
+	UnsafeLastSeen        time.Time                  `json:"lastSeen"`
+	UnsafeMobConvo        *Conversation              `json:"-"`
+	unsafeConvoOptions    *ConvoOptions
+	unsafeChatHistory     []*ChatHistoryEntry
+	unsafeOutput          []string
+	unsafeTeleportHistory []string
+	unsafeRecentCommands  []*RecentCommand
+	player                *Player
 }
 
 // PronounType is used to determine the correct pronoun (he/she etc.)
@@ -59,6 +83,7 @@ const (
 	PronounPossessiveAdjective
 	PronounPossessiveAbsolute
 	PronounObjective
+	PronounReflexive
 )
 
 // Init is called when the Character is created or loaded from disk.
@@ -98,11 +123,19 @@ func (c *Character) Name() string {
 	return c.UnsafeName
 }
 
+// SetName renames the Character.
+func (c *Character) SetName(name string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeName = name
+}
+
 // FormattedName returns the formatted Character name.
 func (c *Character) FormattedName() string {
 	c.RLock()
 	defer c.RUnlock()
-	return TextStyle(c.UnsafeName, WithBold())
+	return TextStylePlayer(c.UnsafeName, WithBold())
 }
 
 // FormattedNameWithTitle returns the formatted Character name including the unsafeCharacter's title (if set).
@@ -112,10 +145,10 @@ func (c *Character) FormattedNameWithTitle() string {
 
 	title := c.UnsafeAttributes["title"]
 	if title != "" {
-		return fmt.Sprintf("%s &lt;%s&gt;", TextStyle(c.UnsafeName, WithBold()), title)
+		return fmt.Sprintf("%s &lt;%s&gt;", TextStylePlayer(c.UnsafeName, WithBold()), TextStylePlayer(title))
 	}
 
-	return TextStyle(c.UnsafeName, WithBold())
+	return TextStylePlayer(c.UnsafeName, WithBold())
 }
 
 // CheckPassword returns a bool indicating whether the password is correct or not.
@@ -215,42 +248,17 @@ func (c *Character) Room() *Room {
 
 // UserColor will return the corresponding color according to the Character's color settings.
 func (c *Character) UserColor(color int) string {
-	switch color {
-	case ColorRoomTitle:
-		return "#6e94ff"
-	case ColorSay:
-		return "#ffeb3b"
-	case ColorMovement:
-		return "#00bcd4"
-	case ColorMovementAlt:
-		return "#00ffc6"
-	case ColorError:
-		return "#e91e63"
-	case ColorRoomDirs:
-		return "#4c9af3"
-	case ColorWhisper:
-		return "#b730f7"
-	case ColorSuccess:
-		return "#8ee22b"
-	case ColorCmdHelp:
-		return "#e9761e"
-	case ColorChannelGeneral:
-		return "#009688"
-	case ColorChannelCore:
-		return "#ff5722"
-	case ColorChannelBuilders:
-		return "#007cff"
-	case ColorMoney:
-		return "#fec205"
-	default:
-		return ""
+	if hex, ok := c.Palette()[color]; ok {
+		return hex
 	}
+
+	return PalettePresetByName("default").Colors[color]
 }
 
 // Colorize will color text according to the Character's color settings.
 // TODO Deprecate Character.Colorize() in favor of using TextStyles() with WithUserColor().
 func (c *Character) Colorize(text string, color int) string {
-	return fmt.Sprintf("<span style='color:%s'>%s</span>", c.UserColor(color), text)
+	return markup.HTML(markup.Color(c.UserColor(color), markup.Raw(text)))
 }
 
 // LastSeen returns the Time the Character last successfully logged into the game.
@@ -280,17 +288,33 @@ func (c *Character) LoggedIn() {
 		return
 	}
 
+	c.LoadChatHistory()
+
 	// Show server / character info
-	c.Player().client.ShowText(
-		fmt.Sprintf(
-			"The server has been running for %s.\n"+
-				"You last logged in at %s (server time). ",
-			TextStyle(time.Since(Armeria.startTime), WithBold()),
+	info := fmt.Sprintf("The server has been running for %s.", TextStyle(TextDuration(time.Since(Armeria.startTime)), WithBold()))
+	if !c.LastSeen().IsZero() {
+		info += fmt.Sprintf(
+			"\nYou last logged in %s, at %s (server time).",
+			TextRelativeTime(c.LastSeen()),
 			TextStyle(c.LastSeen().Format("Mon Jan 2 2006 15:04:05 MST"), WithBold()),
-		),
-	)
+		)
+	}
+	c.Player().client.ShowText(info)
+
+	// Show the message of the day
+	if motd := Armeria.announcementManager.MOTD(); len(motd) > 0 {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("%s %s", TextStyle("Message of the day:", WithBold()), motd),
+			ColorCmdHelp,
+		)
+	}
+
+	if Armeria.tickManager.Frozen() {
+		c.Player().client.ShowColorizedText(FrozenNotice, ColorCmdHelp)
+	}
 
 	// Update lastSeen
+	firstLogin := c.LastSeen().IsZero()
 	c.SetLastSeen(time.Now())
 
 	// Use command: /look
@@ -298,6 +322,9 @@ func (c *Character) LoggedIn() {
 
 	// Show message to others in the same room
 	for _, char := range room.Here().Characters(true, c) {
+		if !c.VisibleTo(char) {
+			continue
+		}
 		pc := char.Player()
 		pc.client.ShowText(
 			fmt.Sprintf("%s connected and appeared here with you.", c.Name()),
@@ -307,13 +334,37 @@ func (c *Character) LoggedIn() {
 	area.CharacterEntered(c, true)
 	room.CharacterEntered(c, true)
 
+	c.RemoveStaleQuestItems()
+	c.FailExpiredDeliveries()
+	c.SyncQuestTimers()
+
 	c.Player().client.SyncInventory()
+	c.Player().client.SyncCraftQueue()
 	c.Player().client.SyncPermissions()
 	c.Player().client.SyncPlayerInfo()
 	c.Player().client.SyncMoney()
 	c.Player().client.SyncCommands()
 	c.Player().client.SyncSettings()
 
+	// Let the character know staff responded to their petitions while they were away
+	if unread := Armeria.petitionManager.UnreadCount(c); unread > 0 {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"Staff responded to %s while you were away. Type %s to read the responses.",
+				TextStyle(fmt.Sprintf("%d of your petitions", unread), WithBold()),
+				TextStyle("/petitions", WithLinkCmd("/petitions")),
+			),
+			ColorCmdHelp,
+		)
+	}
+
+	// Send new characters through the tutorial
+	if firstLogin && len(c.Attribute(AttributeTutorial)) == 0 {
+		c.StartTutorial()
+	}
+
+	go CallGlobalFunc(c, "on_player_login")
+
 	Armeria.log.Info("character entered the game",
 		zap.String("character", c.Name()),
 	)
@@ -334,6 +385,9 @@ func (c *Character) LoggedOut() {
 
 	// Show message to others in the same room
 	for _, char := range room.Here().Characters(true, c) {
+		if !c.VisibleTo(char) {
+			continue
+		}
 		pc := char.Player()
 		pc.client.ShowText(
 			fmt.Sprintf("%s disconnected and is no longer here with you.", c.Name()),
@@ -343,15 +397,20 @@ func (c *Character) LoggedOut() {
 	area.CharacterLeft(c, true)
 	room.CharacterLeft(c, true)
 
-	// Clear temp attributes
+	// Clear temp attributes, except for invisibility, so that reconnecting doesn't give away invisible staff.
 	for key := range c.UnsafeTempAttributes {
-		delete(c.UnsafeTempAttributes, key)
+		if key != TempAttributeInvisible {
+			delete(c.UnsafeTempAttributes, key)
+		}
 	}
 
 	// Stop any on-going mob conversations
 	if c.MobConvo() != nil {
 		c.MobConvo().Cancel()
 	}
+	c.ClearConvoOptions()
+
+	c.SaveChatHistory()
 
 	Armeria.log.Info("character left the game",
 		zap.String("character", c.Name()),
@@ -366,7 +425,7 @@ func (c *Character) TempAttribute(name string) string {
 	return c.UnsafeTempAttributes[name]
 }
 
-// SetTempAttribute sets a temporary attribute, which is cleared on log out. Additionally, these
+// SetTempAttribute sets a temporary attribute, which is cleared on log out (apart from invisibility). These
 // attributes are not validated.
 func (c *Character) SetTempAttribute(name string, value string) {
 	c.Lock()
@@ -381,19 +440,27 @@ func (c *Character) SetTempAttribute(name string, value string) {
 
 // SetAttribute sets a permanent attribute and only valid attributes can be set.
 func (c *Character) SetAttribute(name string, value string) error {
-	c.Lock()
-	defer c.Unlock()
-
-	if !misc.Contains(AttributeList(ObjectTypeCharacter), name) {
-		return errors.New("attribute name is invalid")
+	if err := ValidateAttribute(ObjectTypeCharacter, name, value, c.Attribute); err != nil {
+		return err
 	}
 
+	old := c.Attribute(name)
+
+	c.Lock()
+
 	c.UnsafeAttributes[name] = value
+	c.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeCharacter, c, name, old, c.Attribute(name))
 	return nil
 }
 
 // Attribute returns a permanent attribute.
 func (c *Character) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeCharacter, c, name); ok {
+		return v
+	}
+
 	c.RLock()
 	defer c.RUnlock()
 
@@ -404,35 +471,6 @@ func (c *Character) Attribute(name string) string {
 	return c.UnsafeAttributes[name]
 }
 
-// Money returns the character's money as a float.
-func (c *Character) Money() float64 {
-	money := c.Attribute(AttributeMoney)
-	f, err := strconv.ParseFloat(money, 64)
-	if err != nil {
-		Armeria.log.Fatal("unable to convert money to float64",
-			zap.Error(err),
-		)
-	}
-	return f
-}
-
-// RemoveMoney attempts to remove money from the character and returns True if they can afford it.
-func (c *Character) RemoveMoney(amount float64) bool {
-	money := c.Money()
-	if amount > money {
-		return false
-	}
-
-	_ = c.SetAttribute(AttributeMoney, fmt.Sprintf("%.2f", money-amount))
-
-	return true
-}
-
-// AddMoney adds money to the character.
-func (c *Character) AddMoney(amount float64) {
-	_ = c.SetAttribute(AttributeMoney, fmt.Sprintf("%.2f", c.Money()+amount))
-}
-
 // SetSetting sets a Character setting and only valid settings can be set.
 func (c *Character) SetSetting(name string, value string) error {
 	c.Lock()
@@ -460,7 +498,11 @@ func (c *Character) Setting(name string) string {
 // MoveAllowed will check if moving to a particular location is valid/allowed.
 func (c *Character) MoveAllowed(r *Room) (bool, string) {
 	if r == nil {
-		return false, CommonInvalidDirection
+		return false, Tr(c, CommonInvalidDirection)
+	}
+
+	if !HostsArea(r.ParentArea) && Armeria.clusterManager.AreaNode(r.ParentArea) == nil {
+		return false, "That area is unavailable right now. Try again later."
 	}
 
 	if len(c.TempAttribute(TempAttributeGhost)) > 0 {
@@ -471,11 +513,30 @@ func (c *Character) MoveAllowed(r *Room) (bool, string) {
 		return false, "You cannot walk onto the train tracks!"
 	}
 
+	if c.Vehicle() != nil {
+		return false, "You'll need to disembark before you can walk anywhere."
+	}
+
+	if t := TerrainByName(r.Attribute(AttributeTerrain)); t != nil && !t.Passable {
+		return false, fmt.Sprintf("You can't cross the %s on foot.", strings.ToLower(t.Title))
+	}
+
+	// A max occupancy of zero means the room can hold any number of characters.
+	if max, _ := strconv.Atoi(r.Attribute(AttributeMaxOccupancy)); max > 0 && len(r.Here().Characters(true, c)) >= max {
+		return false, "It's too crowded in there. Try again once someone leaves."
+	}
+
 	return true, ""
 }
 
-// Move will move the Character to a new location (no move checks are performed).
+// Move will move the Character to a new location (no move checks are performed). Characters moving into an area
+// hosted by another node of the cluster are handed off to it.
 func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew string, sfx sfx.ClientSoundEffect) {
+	if !HostsArea(to.ParentArea) {
+		Armeria.clusterManager.HandOff(c, to, msgToChar, msgToOld)
+		return
+	}
+
 	oldRoom := c.Room()
 	if oldRoom == nil {
 		// If the character logged out in a room that no longer exists, allow movement to still work so they
@@ -493,6 +554,11 @@ func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew s
 	}
 
 	for _, char := range oldRoom.Here().Characters(true) {
+		if len(msgToOld) == 0 {
+			break
+		} else if !c.VisibleTo(char) {
+			continue
+		}
 		char.Player().client.ShowText(msgToOld)
 		if len(sfx) > 0 {
 			char.Player().client.PlaySFX(sfx)
@@ -500,6 +566,11 @@ func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew s
 	}
 
 	for _, char := range to.Here().Characters(true, c) {
+		if len(msgToNew) == 0 {
+			break
+		} else if !c.VisibleTo(char) {
+			continue
+		}
 		char.Player().client.ShowText(msgToNew)
 		if len(sfx) > 0 {
 			char.Player().client.PlaySFX(sfx)
@@ -521,10 +592,13 @@ func (c *Character) Move(to *Room, msgToChar string, msgToOld string, msgToNew s
 	oldRoom.CharacterEntered(c, false)
 	to.CharacterEntered(c, false)
 
+	followCharacter(c, oldRoom, to)
+
 	// Stop any on-going mob conversations.
 	if c.MobConvo() != nil {
 		c.MobConvo().Cancel()
 	}
+	c.ClearConvoOptions()
 
 	// If the object editor is open, move the editor to this room.
 	if c.TempAttribute(TempAttributeEditorOpen) == "true" {
@@ -574,15 +648,53 @@ func (c *Character) SettingsJSON() string {
 	return string(b)
 }
 
-// HasPermission returns true if the Character has a particular permission.
+// HasPermission returns true if the Character has a particular permission. A permission that is scoped to
+// an area (ie: CAN_BUILD:emberfall) also counts, so use HasPermissionIn when acting on a specific area.
 func (c *Character) HasPermission(p string) bool {
 	c.RLock()
 	defer c.RUnlock()
 
+	for _, perm := range strings.Split(c.UnsafeAttributes[AttributePermissions], " ") {
+		if perm == p || strings.HasPrefix(perm, p+":") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasGlobalPermission returns true if the Character has a particular permission that isn't scoped to an area.
+func (c *Character) HasGlobalPermission(p string) bool {
+	c.RLock()
+	defer c.RUnlock()
+
 	perms := strings.Split(c.UnsafeAttributes[AttributePermissions], " ")
 	return misc.Contains(perms, p)
 }
 
+// HasPermissionIn returns true if the Character has a particular permission, either globally or scoped to
+// the given Area.
+func (c *Character) HasPermissionIn(p string, a *Area) bool {
+	if c.HasGlobalPermission(p) {
+		return true
+	}
+
+	if a == nil {
+		return false
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+
+	for _, perm := range strings.Split(c.UnsafeAttributes[AttributePermissions], " ") {
+		if strings.HasPrefix(perm, p+":") && strings.EqualFold(perm[len(p)+1:], a.PermissionScope()) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Channels returns the Channel objects for the channels this unsafeCharacter is within.
 func (c *Character) Channels() []*Channel {
 	var channels []*Channel
@@ -662,30 +774,38 @@ func (c *Character) InventoryJSON() string {
 	return string(inventoryJSON)
 }
 
+// PronounSet returns the pronouns used to refer to the character. Explicitly chosen pronouns take precedence
+// over the character's gender.
+func (c *Character) PronounSet() *PronounSet {
+	return pronounSetFor(c.Attribute(AttributePronouns), c.Attribute(AttributeGender))
+}
+
 // Pronoun is used to determine the appropriate pronoun for the character.
 func (c *Character) Pronoun(pt PronounType) string {
-	gender := c.Attribute(AttributeGender)
-	if gender == "male" {
-		if pt == PronounSubjective {
-			return "he"
-		} else if pt == PronounPossessiveAbsolute {
-			return "his"
-		} else if pt == PronounPossessiveAdjective {
-			return "his"
-		} else if pt == PronounObjective {
-			return "him"
-		}
-	} else if gender == "female" {
-		if pt == PronounSubjective {
-			return "she"
-		} else if pt == PronounPossessiveAbsolute {
-			return "hers"
-		} else if pt == PronounPossessiveAdjective {
-			return "her"
-		} else if pt == PronounObjective {
-			return "her"
+	return c.PronounSet().Pronoun(pt)
+}
+
+// Appearance returns the description shown to others when they look at the character.
+func (c *Character) Appearance() string {
+	var lines []string
+
+	if sp := c.Attribute(AttributeSpecies); len(sp) > 0 {
+		article := "a"
+		if strings.ContainsAny(strings.ToLower(sp[0:1]), "aeiou") {
+			article = "an"
 		}
+		lines = append(lines, SubstitutePronouns(fmt.Sprintf("{They} {is|are} %s %s.", article, TextStylePlayer(sp)), c))
+	}
+
+	if desc := c.Attribute(AttributeDescription); len(desc) > 0 {
+		lines = append(lines, TextStylePlayer(desc))
+	} else {
+		lines = append(lines, SubstitutePronouns("There is nothing special about {them}.", c))
+	}
+
+	for _, p := range c.VisibleBiography() {
+		lines = append(lines, TextStylePlayer(p))
 	}
 
-	return ""
+	return strings.Join(lines, "\n")
 }
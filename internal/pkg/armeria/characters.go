@@ -17,6 +17,9 @@ type CharacterManager struct {
 	sync.RWMutex
 	dataFile         string
 	UnsafeCharacters []*Character `json:"characters"`
+	autoSaveMutex    sync.Mutex
+	autoSaveTimer    *time.Timer
+	autoSaveReasons  map[*Character][]string
 }
 
 func NewCharacterManager() *CharacterManager {
@@ -119,6 +122,26 @@ func (m *CharacterManager) CharacterById(uuid string) *Character {
 	return nil
 }
 
+// ReplaceCharacter swaps a Character for a newer copy of it, such as one arriving from another node in a cluster, or
+// adds it if it's new. The Character is initialized.
+func (m *CharacterManager) ReplaceCharacter(c *Character) {
+	m.Lock()
+	replaced := false
+	for i, existing := range m.UnsafeCharacters {
+		if existing.ID() == c.ID() {
+			m.UnsafeCharacters[i] = c
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		m.UnsafeCharacters = append(m.UnsafeCharacters, c)
+	}
+	m.Unlock()
+
+	c.Init()
+}
+
 // CreateCharacter creates a new Character, adds it to memory, initializes it and returns the Character.
 func (m *CharacterManager) CreateCharacter(name, password string) *Character {
 	m.Lock()
@@ -0,0 +1,354 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ChatFilterActionMask hides the matched word behind asterisks.
+	ChatFilterActionMask = "mask"
+	// ChatFilterActionFlag lets the message through, and queues it for staff to review.
+	ChatFilterActionFlag = "flag"
+	// ChatFilterActionBlock stops the message from being sent at all.
+	ChatFilterActionBlock = "block"
+)
+
+var (
+	// defaultChatFilterRules are the rules the chat filter starts out with.
+	defaultChatFilterRules = []*ChatFilterRule{
+		{Pattern: "fuck*", Action: ChatFilterActionMask},
+		{Pattern: "shit*", Action: ChatFilterActionMask},
+		{Pattern: "cunt*", Action: ChatFilterActionMask},
+		{Pattern: "bitch*", Action: ChatFilterActionMask},
+		{Pattern: "nazi*", Action: ChatFilterActionFlag},
+	}
+
+	chatFilterPatternRegex = regexp.MustCompile(`^[a-z]+\*?$`)
+)
+
+// ChatFilterManager holds the rules that filter what characters say to each other, and the messages the filter
+// flagged for staff to review.
+type ChatFilterManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeRules  []*ChatFilterRule `json:"rules"`
+	UnsafeFlags  []*ChatFilterFlag `json:"flags"`
+	UnsafeNextID int               `json:"nextId"`
+}
+
+// ChatFilterRule is a word the chat filter acts on. A pattern ending in an asterisk matches any word starting with
+// it (ie: "fuck*" also matches "fucking").
+type ChatFilterRule struct {
+	Pattern string `json:"pattern"`
+	Action  string `json:"action"`
+}
+
+// ChatFilterFlag is a message the chat filter flagged for staff to review.
+type ChatFilterFlag struct {
+	ID int `json:"id"`
+	// CharacterID is the uuid of the character who sent the message.
+	CharacterID string    `json:"character"`
+	Channel     string    `json:"channel"`
+	Text        string    `json:"text"`
+	Matched     []string  `json:"matched"`
+	Time        time.Time `json:"time"`
+	ReviewedBy  string    `json:"reviewedBy"`
+}
+
+// ChatFilterResult is the outcome of running a message through the chat filter.
+type ChatFilterResult struct {
+	// Text is the message with the masked words replaced by asterisks.
+	Text    string
+	Blocked bool
+	// Flagged holds the words that caused the message to be flagged for review.
+	Flagged []string
+}
+
+// NewChatFilterManager creates a new ChatFilterManager.
+func NewChatFilterManager() *ChatFilterManager {
+	m := &ChatFilterManager{
+		dataFile: fmt.Sprintf("%s/chat-filter.json", Armeria.dataPath),
+	}
+
+	m.LoadChatFilter()
+
+	return m
+}
+
+// LoadChatFilter loads the chat filter rules and flags from disk into memory.
+func (m *ChatFilterManager) LoadChatFilter() {
+	m.Lock()
+	defer m.Unlock()
+
+	filterFile, err := os.Open(m.dataFile)
+	defer filterFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(filterFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("chat filter loaded",
+		zap.Int("rules", len(m.UnsafeRules)),
+		zap.Int("flags", len(m.UnsafeFlags)),
+	)
+}
+
+// SaveChatFilter writes the in-memory chat filter rules and flags to disk.
+func (m *ChatFilterManager) SaveChatFilter() {
+	m.RLock()
+	defer m.RUnlock()
+
+	filterFile, err := os.Create(m.dataFile)
+	defer filterFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := filterFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = filterFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Rules returns a copy of the chat filter rules.
+func (m *ChatFilterManager) Rules() []ChatFilterRule {
+	m.RLock()
+	defer m.RUnlock()
+
+	rules := make([]ChatFilterRule, len(m.UnsafeRules))
+	for i, r := range m.UnsafeRules {
+		rules[i] = *r
+	}
+	return rules
+}
+
+// SetRule adds a word for the chat filter to act on, or changes the action of an existing one.
+func (m *ChatFilterManager) SetRule(pattern, action string) error {
+	pattern = strings.ToLower(pattern)
+	if !chatFilterPatternRegex.MatchString(pattern) {
+		return errors.New("the pattern must be a word of letters, optionally ending in *")
+	}
+	if action != ChatFilterActionMask && action != ChatFilterActionFlag && action != ChatFilterActionBlock {
+		return fmt.Errorf("the action must be %s, %s or %s", ChatFilterActionMask, ChatFilterActionFlag, ChatFilterActionBlock)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for _, r := range m.UnsafeRules {
+		if r.Pattern == pattern {
+			r.Action = action
+			return nil
+		}
+	}
+
+	m.UnsafeRules = append(m.UnsafeRules, &ChatFilterRule{Pattern: pattern, Action: action})
+	return nil
+}
+
+// RemoveRule removes a word from the chat filter. It returns false if there was no rule for it.
+func (m *ChatFilterManager) RemoveRule(pattern string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, r := range m.UnsafeRules {
+		if r.Pattern == strings.ToLower(pattern) {
+			m.UnsafeRules = append(m.UnsafeRules[:i], m.UnsafeRules[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the rule matches a normalized word.
+func (r *ChatFilterRule) Matches(word string) bool {
+	if strings.HasSuffix(r.Pattern, "*") {
+		return strings.HasPrefix(word, strings.TrimSuffix(r.Pattern, "*"))
+	}
+	return word == r.Pattern
+}
+
+// Filter runs a message through the chat filter. Words are matched whole and case insensitively, after undoing
+// common letter substitutions (ie: "sh1t"). When several rules match, blocking wins over flagging, and masking
+// still applies to a flagged message.
+func (m *ChatFilterManager) Filter(text string) *ChatFilterResult {
+	rules := m.Rules()
+	result := &ChatFilterResult{Text: text}
+	if len(rules) == 0 {
+		return result
+	}
+
+	original := []rune(text)
+	normalized := make([]rune, len(original))
+	for i, r := range original {
+		// Every substitution is a single character, so positions line up with the original text.
+		normalized[i] = []rune(leetReplacer.Replace(string(unicode.ToLower(r))))[0]
+	}
+
+	masked := make([]rune, len(original))
+	copy(masked, original)
+
+	for start := 0; start < len(normalized); {
+		if !unicode.IsLetter(normalized[start]) {
+			start++
+			continue
+		}
+
+		end := start
+		for end < len(normalized) && unicode.IsLetter(normalized[end]) {
+			end++
+		}
+
+		word := string(normalized[start:end])
+		for _, r := range rules {
+			if !r.Matches(word) {
+				continue
+			}
+
+			switch r.Action {
+			case ChatFilterActionMask:
+				for i := start; i < end; i++ {
+					masked[i] = '*'
+				}
+			case ChatFilterActionFlag:
+				result.Flagged = append(result.Flagged, string(original[start:end]))
+			case ChatFilterActionBlock:
+				result.Blocked = true
+			}
+		}
+
+		start = end
+	}
+
+	result.Text = string(masked)
+	return result
+}
+
+// Flag queues a message for staff to review, and notifies the online staff.
+func (m *ChatFilterManager) Flag(c *Character, channel, text string, matched []string) *ChatFilterFlag {
+	m.Lock()
+	m.UnsafeNextID++
+	f := &ChatFilterFlag{
+		ID:          m.UnsafeNextID,
+		CharacterID: c.ID(),
+		Channel:     channel,
+		Text:        text,
+		Matched:     matched,
+		Time:        time.Now(),
+	}
+	m.UnsafeFlags = append(m.UnsafeFlags, f)
+	m.Unlock()
+
+	Armeria.log.Info("chat message flagged",
+		zap.Int("id", f.ID),
+		zap.String("character", c.Name()),
+		zap.String("channel", channel),
+	)
+
+	NotifyStaff(fmt.Sprintf(
+		"%s was flagged by the chat filter (%s): %s",
+		c.FormattedName(),
+		TextStyle(fmt.Sprintf("#%d", f.ID), WithLinkCmd(fmt.Sprintf("/admin filter review %d", f.ID))),
+		TextStylePlayer(text),
+	))
+
+	return f
+}
+
+// Flags returns a copy of the flagged messages, optionally including the ones that were already reviewed.
+func (m *ChatFilterManager) Flags(includeReviewed bool) []ChatFilterFlag {
+	m.RLock()
+	defer m.RUnlock()
+
+	var flags []ChatFilterFlag
+	for _, f := range m.UnsafeFlags {
+		if includeReviewed || len(f.ReviewedBy) == 0 {
+			flags = append(flags, *f)
+		}
+	}
+	return flags
+}
+
+// Review marks a flagged message as reviewed by a staff member.
+func (m *ChatFilterManager) Review(id int, by *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, f := range m.UnsafeFlags {
+		if f.ID == id {
+			if len(f.ReviewedBy) > 0 {
+				return errors.New("that message was already reviewed")
+			}
+			f.ReviewedBy = by.ID()
+			return nil
+		}
+	}
+
+	return errors.New("there is no flagged message with that id")
+}
+
+// FilterChat runs a message the Character is sending on a channel through the chat filter. It returns the filtered
+// text, or false if the message was blocked, in which case the Character has already been told.
+func (c *Character) FilterChat(channel, text string) (string, bool) {
+	result := Armeria.chatFilterManager.Filter(text)
+	if result.Blocked {
+		c.Player().client.ShowColorizedText(
+			"Your message wasn't sent, because it contains language that isn't allowed here.",
+			ColorError,
+		)
+		return "", false
+	}
+
+	if len(result.Flagged) > 0 {
+		Armeria.chatFilterManager.Flag(c, channel, text, result.Flagged)
+	}
+
+	return result.Text, true
+}
+
+// ChatText returns the version of a message the Character should read: the filtered text, or the original text
+// if they turned off chat filtering.
+func (c *Character) ChatText(filtered, original string) string {
+	if c.Setting(SettingUnfilteredChat) == "true" {
+		return original
+	}
+	return filtered
+}
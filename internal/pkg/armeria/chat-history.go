@@ -0,0 +1,123 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ChatHistoryLimit is the maximum number of messages kept in each character's chat history.
+	ChatHistoryLimit = 200
+)
+
+// ChatHistoryEntry is a single message a Character received.
+type ChatHistoryEntry struct {
+	Time    time.Time `json:"time"`
+	Channel string    `json:"channel"`
+	Text    string    `json:"text"`
+}
+
+// chatHistoryFile returns the path to the file a Character's chat history is stored in.
+func (c *Character) chatHistoryFile() string {
+	return fmt.Sprintf("%s/chat-history/%s.json", Armeria.dataPath, c.ID())
+}
+
+// AddChatHistory records a message the Character received. The oldest messages are discarded once the history
+// reaches ChatHistoryLimit.
+func (c *Character) AddChatHistory(channel, text string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.unsafeChatHistory = append(c.unsafeChatHistory, &ChatHistoryEntry{
+		Time:    time.Now(),
+		Channel: strings.ToLower(channel),
+		Text:    text,
+	})
+
+	if len(c.unsafeChatHistory) > ChatHistoryLimit {
+		c.unsafeChatHistory = c.unsafeChatHistory[len(c.unsafeChatHistory)-ChatHistoryLimit:]
+	}
+}
+
+// ChatHistory returns up to the last n messages the Character received, optionally limited to a channel.
+func (c *Character) ChatHistory(n int, channel string) []*ChatHistoryEntry {
+	c.RLock()
+	defer c.RUnlock()
+
+	var entries []*ChatHistoryEntry
+	for i := len(c.unsafeChatHistory) - 1; i >= 0 && len(entries) < n; i-- {
+		e := c.unsafeChatHistory[i]
+		if len(channel) == 0 || e.Channel == strings.ToLower(channel) {
+			entries = append([]*ChatHistoryEntry{e}, entries...)
+		}
+	}
+
+	return entries
+}
+
+// LoadChatHistory reads the Character's chat history from disk, when chat history is stored on disk.
+func (c *Character) LoadChatHistory() {
+	if !Armeria.chatHistoryOnDisk {
+		return
+	}
+
+	b, err := ioutil.ReadFile(c.chatHistoryFile())
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		Armeria.log.Error("failed to read chat history",
+			zap.String("character", c.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	var entries []*ChatHistoryEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		Armeria.log.Error("failed to decode chat history",
+			zap.String("character", c.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	c.Lock()
+	c.unsafeChatHistory = entries
+	c.Unlock()
+}
+
+// SaveChatHistory writes the Character's chat history to disk, when chat history is stored on disk.
+func (c *Character) SaveChatHistory() {
+	if !Armeria.chatHistoryOnDisk {
+		return
+	}
+
+	c.RLock()
+	raw, err := json.Marshal(c.unsafeChatHistory)
+	c.RUnlock()
+	if err != nil {
+		Armeria.log.Error("failed to marshal chat history",
+			zap.String("character", c.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if err := os.MkdirAll(fmt.Sprintf("%s/chat-history", Armeria.dataPath), 0755); err != nil {
+		Armeria.log.Error("failed to create chat history directory", zap.Error(err))
+		return
+	}
+
+	if err := ioutil.WriteFile(c.chatHistoryFile(), raw, 0644); err != nil {
+		Armeria.log.Error("failed to write chat history",
+			zap.String("character", c.Name()),
+			zap.Error(err),
+		)
+	}
+}
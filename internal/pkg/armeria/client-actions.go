@@ -4,7 +4,9 @@ import (
 	"armeria/internal/pkg/sfx"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -24,6 +26,11 @@ type ObjectEditorData struct {
 	AccessKey  string                      `json:"accessKey"`
 	TextCoords string                      `json:"textCoords"`
 	IsChild    bool                        `json:"isChild"`
+	Selection  []string                    `json:"selection"`
+	Dirty      bool                        `json:"dirty"`
+	Notes      []*NoteEditorData           `json:"notes"`
+	Tabbed     bool                        `json:"tabbed"`
+	Version    int                         `json:"version"`
 }
 
 // ObjectEditorDataProperty is a struct that contains the json fields for each individual property within the
@@ -57,19 +64,44 @@ func (ca *ClientActions) ShowColorizedText(text string, color int) {
 	ca.ShowText(t)
 }
 
+// ShowChatText displays conversation text on the parent's main text window, and records it in the chat history
+// of the Character attached to the parent instance.
+func (ca *ClientActions) ShowChatText(channel string, text string) {
+	if c := ca.parent.Character(); c != nil {
+		c.AddChatHistory(channel, text)
+	}
+
+	ca.ShowText(text)
+}
+
 // ShowText displays text on the parent's main text window.
 func (ca *ClientActions) ShowText(text string) {
-	ca.parent.CallClientAction("showText", "\n"+text)
+	ca.ShowRawText("\n" + text)
 }
 
-// ShowRawText displays raw text on the parent's main text window.
+// ShowRawText displays raw text on the parent's main text window, and records it in the output buffer of the
+// Character attached to the parent instance.
 func (ca *ClientActions) ShowRawText(text string) {
+	if c := ca.parent.Character(); c != nil {
+		c.AddOutput(text)
+	}
+
+	ca.ShowUnbufferedText(text)
+}
+
+// ShowUnbufferedText displays raw text on the parent's main text window without recording it in the output
+// buffer (ie: output buffer search results).
+func (ca *ClientActions) ShowUnbufferedText(text string) {
+	if c := ca.parent.Character(); c != nil && c.Setting(SettingPlainText) == "true" {
+		text = TextPlain(text)
+	}
+
 	ca.parent.CallClientAction("showText", text)
 }
 
 // SyncMap displays the current area on the minimap.
 func (ca *ClientActions) SyncMap() {
-	minimap := ca.parent.Character().Room().ParentArea.MinimapJSON()
+	minimap := ca.parent.Character().Room().ParentArea.MinimapJSON(ca.parent.Character())
 	ca.parent.CallClientAction("setMapData", minimap)
 }
 
@@ -91,17 +123,33 @@ func (ca *ClientActions) SyncRoomTitle() {
 	if ca.parent.Character().HasPermission("CAN_BUILD") {
 		c := r.Coords
 		ca.parent.CallClientAction("setRoomTitle",
-			fmt.Sprintf("%s (%d,%d,%d)", r.Attribute("title"), c.X(), c.Y(), c.Z()),
+			fmt.Sprintf("%s (%d,%d,%d)", r.DraftAttribute(AttributeTitle), c.X(), c.Y(), c.Z()),
 		)
 	} else {
 		ca.parent.CallClientAction("setRoomTitle", r.Attribute(AttributeTitle))
 	}
 }
 
-// SyncInventory renders the inventory on the client.
+// SyncInventory renders the inventory on the client, along with the equipment (since items move between the two).
 func (ca *ClientActions) SyncInventory() {
 	inv := ca.parent.Character().InventoryJSON()
 	ca.parent.CallClientAction("setInventory", inv)
+	ca.SyncEquipment()
+}
+
+// SyncCraftQueue sends the Character's crafting queue, and the progress of the item being crafted, to the client.
+func (ca *ClientActions) SyncCraftQueue() {
+	ca.parent.CallClientAction("setCraftQueue", ca.parent.Character().CraftQueueJSON())
+}
+
+// SyncShop opens the shop window of a vendor on the client, or refreshes it if it's already open.
+func (ca *ClientActions) SyncShop(mi *MobInstance) {
+	ca.parent.CallClientAction("setShop", ca.parent.Character().ShopJSON(mi))
+}
+
+// SyncEquipment renders the equipment on the client.
+func (ca *ClientActions) SyncEquipment() {
+	ca.parent.CallClientAction("setEquipment", ca.parent.Character().EquipmentJSON())
 }
 
 // SyncPermissions sets the character permissions on the client (to allow/disallow certain client actions / UI tweaks).
@@ -121,7 +169,7 @@ func (ca *ClientActions) SendPong() {
 
 // SyncMoney sets the character's money on the client.
 func (ca *ClientActions) SyncMoney() {
-	ca.parent.CallClientAction("setMoney", ca.parent.Character().Attribute(AttributeMoney))
+	ca.parent.CallClientAction("setMoney", fmt.Sprintf("%.2f", ca.parent.Character().Money()))
 }
 
 // SyncPlayerInfo sets the character/player information on the client.
@@ -141,6 +189,18 @@ func (ca *ClientActions) ShowObjectEditor(editorData *ObjectEditorData) {
 	// add access key
 	c := ca.parent.Character()
 	editorData.AccessKey = c.Name() + "/" + c.PasswordHash()
+	// add the version being edited
+	editorData.Version = Armeria.editHistory.Version(editorDataKey(editorData))
+	// add bulk edit selection
+	editorData.Selection = []string{}
+	for _, t := range c.BulkEditSelection() {
+		editorData.Selection = append(editorData.Selection, t.ID())
+	}
+	// add staff notes
+	editorData.Notes = []*NoteEditorData{}
+	if c.HasPermission(NotePermission) {
+		editorData.Notes = Armeria.noteManager.EditorNotes(editorData)
+	}
 	j, err := json.Marshal(editorData)
 	if err != nil {
 		Armeria.log.Fatal("failed to marshal data for client action: ShowObjectEditor",
@@ -151,6 +211,65 @@ func (ca *ClientActions) ShowObjectEditor(editorData *ObjectEditorData) {
 	ca.parent.CallClientAction("setObjectEditorData", string(j))
 }
 
+// ShowScriptEditor opens the script editor on the client for a script that isn't attached to an object.
+func (ca *ClientActions) ShowScriptEditor(scriptType, name string) {
+	c := ca.parent.Character()
+	data := map[string]string{
+		"type":      scriptType,
+		"name":      name,
+		"accessKey": c.Name() + "/" + c.PasswordHash(),
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data for client action: ShowScriptEditor",
+			zap.Error(err),
+		)
+	}
+
+	ca.parent.CallClientAction("openScriptEditor", string(j))
+}
+
+// SetQuestTimer shows a countdown for a timed quest objective on the client. A zero duration removes the timer.
+func (ca *ClientActions) SetQuestTimer(quest, label string, remaining time.Duration) {
+	data := map[string]interface{}{
+		"quest":   quest,
+		"label":   label,
+		"seconds": int(remaining.Seconds()),
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data for client action: SetQuestTimer",
+			zap.Error(err),
+		)
+	}
+
+	ca.parent.CallClientAction("setQuestTimer", string(j))
+}
+
+// ExpireConvoOptions tells the client that a group of conversation options can no longer be selected.
+func (ca *ClientActions) ExpireConvoOptions(group int64) {
+	ca.parent.CallClientAction("expireConvoOptions", strconv.FormatInt(group, 10))
+}
+
+// RedactChatMessage replaces a channel message that was retracted by a moderator on the client.
+func (ca *ClientActions) RedactChatMessage(id, text string) {
+	data := map[string]interface{}{
+		"id":   id,
+		"text": "\n" + text,
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data for client action: RedactChatMessage",
+			zap.Error(err),
+		)
+	}
+
+	ca.parent.CallClientAction("redactChatMessage", string(j))
+}
+
 // Disconnect requests that the client disconnects from the server.
 func (ca *ClientActions) Disconnect() {
 	ca.parent.CallClientAction("disconnect", nil)
@@ -164,9 +283,27 @@ func (ca *ClientActions) ToggleAutologin() {
 	)
 }
 
-// SetItemTooltipHTML sets the item's tooltip HTML on the client and stores it in the client-side cache.
+// ClusterHandoff tells the client to reconnect to another node of the cluster, and log in there with the token.
+func (ca *ClientActions) ClusterHandoff(address string, token string) {
+	data := map[string]string{
+		"address": address,
+		"token":   token,
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data for client action: ClusterHandoff",
+			zap.Error(err),
+		)
+	}
+
+	ca.parent.CallClientAction("clusterHandoff", string(j))
+}
+
+// SetItemTooltipHTML sets the item's tooltip HTML, as seen by the player's character, on the client and stores it
+// in the client-side cache.
 func (ca *ClientActions) SetItemTooltipHTML(ii *ItemInstance) {
-	ca.parent.CallClientAction("setItemTooltipHTML", ii.TooltipContentJSON())
+	ca.parent.CallClientAction("setItemTooltipHTML", ii.TooltipContentJSON(ca.parent.Character()))
 }
 
 // SetItemTooltipHTMLRaw sets an item's tooltip HTML on the client to some arbitrary value.
@@ -187,6 +324,18 @@ func (ca *ClientActions) SetItemTooltipHTMLRaw(uuid, content string) {
 	ca.parent.CallClientAction("setItemTooltipHTML", string(ttJSON))
 }
 
+// SetCreationState sets the state of the character creation on the client.
+func (ca *ClientActions) SetCreationState(state *CreationState) {
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data for client action: SetCreationState",
+			zap.Error(err),
+		)
+	}
+
+	ca.parent.CallClientAction("setCreationState", string(stateJSON))
+}
+
 // PlaySFX plays a sound effect on the client.
 func (ca *ClientActions) PlaySFX(id sfx.ClientSoundEffect) {
 	data := map[string]interface{}{
@@ -0,0 +1,575 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// ClusterSubjectChannels is the message bus subject that global channel messages are bridged over.
+	ClusterSubjectChannels string = "armeria.channels"
+	// ClusterSubjectRetractions is the message bus subject that retracted channel messages are bridged over.
+	ClusterSubjectRetractions string = "armeria.channels.retractions"
+	// ClusterSubjectNodes is the message bus subject that nodes announce the areas they host over.
+	ClusterSubjectNodes string = "armeria.nodes"
+	// ClusterSubjectHandoffs is the message bus subject that characters are handed between nodes over.
+	ClusterSubjectHandoffs string = "armeria.handoffs"
+	// ClusterSubjectLogins is the message bus subject that one-time login tokens are sent to other nodes over.
+	ClusterSubjectLogins string = "armeria.logins"
+
+	// ClusterAnnounceInterval is how often each node announces the areas it hosts. A node that hasn't been heard
+	// from for two intervals is treated as down.
+	ClusterAnnounceInterval = 30 * time.Second
+	// ClusterHandoffTimeout is how long a client has to reconnect to another node before it's disconnected, and
+	// how long the login token it was given stays valid.
+	ClusterHandoffTimeout = 10 * time.Second
+)
+
+// MessageBus carries messages between the server processes of a cluster.
+type MessageBus interface {
+	// Publish sends data to every process subscribed to the subject, including this one.
+	Publish(subject string, data []byte) error
+	// Subscribe calls fn with the data of every message published to the subject.
+	Subscribe(subject string, fn func(data []byte)) error
+}
+
+// ClusterManager connects this server process to the other nodes of an experimental cluster. Global channels are
+// bridged between every node, and the world can be partitioned by giving each node the areas it hosts. Every node
+// loads the whole world, but only runs the areas it hosts: when a character heads into an area hosted elsewhere,
+// they are handed off to that node along with the items they carry, and their client reconnects to it.
+//
+// A character's data is only kept up to date on the node hosting the area they're in. Other systems, such as
+// contracts and combat, are still local to each node.
+type ClusterManager struct {
+	sync.RWMutex
+	node         string
+	address      string
+	areas        []string
+	bus          MessageBus
+	unsafeNodes  map[string]*ClusterNode
+	unsafeLogins map[string]*clusterLogin
+}
+
+// ClusterNode is another node of the cluster, as it last announced itself.
+type ClusterNode struct {
+	Name    string    `json:"node"`
+	Address string    `json:"address"`
+	Areas   []string  `json:"areas"`
+	Seen    time.Time `json:"-"`
+}
+
+// clusterHandoff is a character moving to the node that hosts the room they are heading to.
+type clusterHandoff struct {
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Area      string               `json:"area"`
+	Location  string               `json:"location"`
+	Character json.RawMessage      `json:"character"`
+	Items     []clusterHandoffItem `json:"items"`
+}
+
+// clusterLogin is a one-time token that a client sent to another node logs in there with. Nodes don't share
+// their copies of a character's password, so the node sending the client there hands out the token instead.
+type clusterLogin struct {
+	To        string    `json:"to"`
+	Character string    `json:"character"`
+	Token     string    `json:"token"`
+	Expires   time.Time `json:"-"`
+}
+
+// clusterHandoffItem is an item instance carried by a character being handed off.
+type clusterHandoffItem struct {
+	Item     string          `json:"item"`
+	Instance json.RawMessage `json:"instance"`
+}
+
+// clusterChannelMessage is a channel message bridged from another node.
+type clusterChannelMessage struct {
+	Node      string `json:"node"`
+	Channel   string `json:"channel"`
+	ID        string `json:"id"`
+	Character string `json:"character"`
+	Original  string `json:"original"`
+	Text      string `json:"text"`
+	// Unfiltered is the message as written, for characters who turned off chat filtering.
+	Unfiltered string `json:"unfiltered"`
+}
+
+// NewClusterManager creates a new ClusterManager that joins the cluster using the given MessageBus. The node
+// name defaults to the host name. The node hosts the named areas, or every area when there are none, and clients
+// reach it at address.
+func NewClusterManager(node string, areas []string, address string, bus MessageBus) *ClusterManager {
+	if len(node) == 0 {
+		node, _ = os.Hostname()
+	}
+
+	m := &ClusterManager{
+		node:         node,
+		address:      address,
+		bus:          bus,
+		unsafeNodes:  make(map[string]*ClusterNode),
+		unsafeLogins: make(map[string]*clusterLogin),
+	}
+
+	for _, name := range areas {
+		if Armeria.worldManager.AreaByName(name) == nil {
+			Armeria.log.Fatal("cluster area doesn't exist",
+				zap.String("area", name),
+			)
+		}
+		m.areas = append(m.areas, strings.ToLower(name))
+	}
+	if len(m.areas) > 0 && len(address) == 0 {
+		Armeria.log.Fatal("nodes hosting cluster areas need a cluster address")
+	}
+
+	if err := bus.Subscribe(ClusterSubjectChannels, m.receiveChannelMessage); err != nil {
+		Armeria.log.Fatal("failed to subscribe to cluster channels",
+			zap.Error(err),
+		)
+	}
+	if err := bus.Subscribe(ClusterSubjectRetractions, m.receiveRetraction); err != nil {
+		Armeria.log.Fatal("failed to subscribe to cluster retractions",
+			zap.Error(err),
+		)
+	}
+	if err := bus.Subscribe(ClusterSubjectNodes, m.receiveAnnouncement); err != nil {
+		Armeria.log.Fatal("failed to subscribe to cluster nodes",
+			zap.Error(err),
+		)
+	}
+	if err := bus.Subscribe(ClusterSubjectHandoffs, m.receiveHandoff); err != nil {
+		Armeria.log.Fatal("failed to subscribe to cluster handoffs",
+			zap.Error(err),
+		)
+	}
+
+	if err := bus.Subscribe(ClusterSubjectLogins, m.receiveLogin); err != nil {
+		Armeria.log.Fatal("failed to subscribe to cluster logins",
+			zap.Error(err),
+		)
+	}
+
+	go func() {
+		for {
+			m.announce()
+			time.Sleep(ClusterAnnounceInterval)
+		}
+	}()
+
+	Armeria.log.Info("joined cluster",
+		zap.String("node", node),
+		zap.Strings("areas", m.areas),
+	)
+
+	return m
+}
+
+// HostsArea returns true if this node runs the Area. Every area is run locally when clustering is disabled, or when
+// the node wasn't given any areas to host.
+func HostsArea(a *Area) bool {
+	if Armeria.clusterManager == nil || a == nil || len(Armeria.clusterManager.areas) == 0 {
+		return true
+	}
+	return misc.Contains(Armeria.clusterManager.areas, strings.ToLower(a.Name()))
+}
+
+// AreaNode returns the node that hosts an Area, or nil if no node that's up has announced that it hosts it.
+func (m *ClusterManager) AreaNode(a *Area) *ClusterNode {
+	m.RLock()
+	defer m.RUnlock()
+
+	name := strings.ToLower(a.Name())
+	for _, n := range m.unsafeNodes {
+		if time.Since(n.Seen) < 2*ClusterAnnounceInterval && misc.Contains(n.Areas, name) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Nodes returns the other nodes of the cluster, as they last announced themselves.
+func (m *ClusterManager) Nodes() []ClusterNode {
+	m.RLock()
+	defer m.RUnlock()
+
+	var nodes []ClusterNode
+	for _, n := range m.unsafeNodes {
+		nodes = append(nodes, *n)
+	}
+	return nodes
+}
+
+// announce tells the rest of the cluster which areas this node hosts, and where clients can reach it.
+func (m *ClusterManager) announce() {
+	data, _ := json.Marshal(&ClusterNode{Name: m.node, Address: m.address, Areas: m.areas})
+	if err := m.bus.Publish(ClusterSubjectNodes, data); err != nil {
+		Armeria.log.Error("failed to announce node to cluster",
+			zap.Error(err),
+		)
+	}
+}
+
+// receiveAnnouncement records the areas hosted by another node. Nodes that weren't known yet are answered with
+// this node's own announcement, so that they don't have to wait for the next one.
+func (m *ClusterManager) receiveAnnouncement(data []byte) {
+	n := &ClusterNode{}
+	if err := json.Unmarshal(data, n); err != nil || n.Name == m.node {
+		return
+	}
+	n.Seen = time.Now()
+
+	m.Lock()
+	_, known := m.unsafeNodes[n.Name]
+	m.unsafeNodes[n.Name] = n
+	m.Unlock()
+
+	if !known {
+		Armeria.log.Info("cluster node joined",
+			zap.String("node", n.Name),
+			zap.Strings("areas", n.Areas),
+		)
+		m.announce()
+	}
+}
+
+// HandOff sends a Character to the node hosting the room they are moving to, along with the items they carry. If
+// they're online, the messages are shown as if they had moved, and their client is told to reconnect to the other
+// node. This node keeps its copy of the Character in the new room, so that logging in here sends them back there.
+func (m *ClusterManager) HandOff(c *Character, to *Room, msgToChar string, msgToOld string) {
+	node := m.AreaNode(to.ParentArea)
+	if node == nil {
+		if c.Online() {
+			c.Player().client.ShowColorizedText("That area is unavailable right now. Try again later.", ColorError)
+		}
+		return
+	}
+
+	// Wilderness rooms are generated by each node, so the room is found by its location rather than its id.
+	handoff := &clusterHandoff{
+		From:     m.node,
+		To:       node.Name,
+		Area:     to.ParentArea.Name(),
+		Location: to.Coords.String(),
+	}
+	handoff.Character, _ = json.Marshal(c)
+	for _, oc := range []*ObjectContainer{c.Inventory(), c.Equipment()} {
+		for _, ii := range oc.Items() {
+			instance, _ := json.Marshal(ii)
+			handoff.Items = append(handoff.Items, clusterHandoffItem{Item: ii.Name(), Instance: instance})
+		}
+	}
+
+	data, _ := json.Marshal(handoff)
+	if err := m.bus.Publish(ClusterSubjectHandoffs, data); err != nil {
+		Armeria.log.Error("failed to hand off character",
+			zap.String("character", c.Name()),
+			zap.String("node", node.Name),
+			zap.Error(err),
+		)
+		if c.Online() {
+			c.Player().client.ShowColorizedText("That area is unavailable right now. Try again later.", ColorError)
+		}
+		return
+	}
+
+	oldRoom := c.Room()
+	if oldRoom != nil {
+		oldRoom.Here().Remove(c.ID())
+	}
+	_ = to.Here().Add(c.ID())
+
+	Armeria.log.Info("character handed off",
+		zap.String("character", c.Name()),
+		zap.String("node", node.Name),
+		zap.String("room", to.LocationString()),
+	)
+
+	if c.Online() && oldRoom != nil {
+		for _, char := range oldRoom.Here().Characters(true) {
+			if len(msgToOld) > 0 && c.VisibleTo(char) {
+				char.Player().client.ShowText(msgToOld)
+			}
+		}
+		c.Player().client.ShowText(msgToChar)
+
+		oldRoom.ParentArea.CharacterLeft(c, false)
+		oldRoom.CharacterLeft(c, false)
+		followCharacter(c, oldRoom, to)
+
+		Armeria.combatManager.Disengage(c.ID())
+		if c.MobConvo() != nil {
+			c.MobConvo().Cancel()
+		}
+		c.ClearConvoOptions()
+		c.SaveChatHistory()
+
+		// The character has left this node, so the player is detached from it before the client disconnects.
+		p := c.Player()
+		m.sendLogin(p, c, node)
+		c.SetPlayer(nil)
+		p.AttachCharacter(nil)
+		time.AfterFunc(ClusterHandoffTimeout, func() {
+			Armeria.playerManager.DisconnectPlayer(p)
+		})
+	}
+
+	Armeria.characterManager.SaveCharacters()
+	Armeria.worldManager.SaveWorld()
+}
+
+// Redirect tells a Player's client to log in to the node hosting the area their Character is in, because this
+// node's copy of the Character is out of date.
+func (m *ClusterManager) Redirect(p *Player, c *Character) {
+	node := m.AreaNode(c.Room().ParentArea)
+	if node == nil {
+		p.client.ShowColorizedText(
+			"This character is in an area that is unavailable right now. Try again later.",
+			ColorError,
+		)
+		return
+	}
+
+	Armeria.log.Info("character redirected to another node",
+		zap.String("character", c.Name()),
+		zap.String("node", node.Name),
+	)
+
+	m.sendLogin(p, c, node)
+}
+
+// sendLogin gives another node a one-time login token for a Character, and tells the Player's client to
+// reconnect to that node and log in with it.
+func (m *ClusterManager) sendLogin(p *Player, c *Character, node *ClusterNode) {
+	login := &clusterLogin{
+		To:        node.Name,
+		Character: strings.ToLower(c.Name()),
+		Token:     uuid.New().String(),
+	}
+
+	data, _ := json.Marshal(login)
+	if err := m.bus.Publish(ClusterSubjectLogins, data); err != nil {
+		Armeria.log.Error("failed to send login token to cluster node",
+			zap.String("character", c.Name()),
+			zap.String("node", node.Name),
+			zap.Error(err),
+		)
+	}
+
+	p.client.ClusterHandoff(node.Address, login.Character+":"+login.Token)
+}
+
+// receiveLogin keeps a login token sent to this node by another one, until it's used or expires.
+func (m *ClusterManager) receiveLogin(data []byte) {
+	login := &clusterLogin{}
+	if err := json.Unmarshal(data, login); err != nil || login.To != m.node {
+		return
+	}
+	login.Expires = time.Now().Add(ClusterHandoffTimeout)
+
+	m.Lock()
+	defer m.Unlock()
+
+	for name, l := range m.unsafeLogins {
+		if time.Now().After(l.Expires) {
+			delete(m.unsafeLogins, name)
+		}
+	}
+	m.unsafeLogins[login.Character] = login
+}
+
+// ClaimClusterLogin returns true if the token is a login token for the Character that was sent to this node by
+// another node of the cluster. Each token can only be claimed once.
+func ClaimClusterLogin(c *Character, token string) bool {
+	m := Armeria.clusterManager
+	if m == nil {
+		return false
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	name := strings.ToLower(c.Name())
+	login, ok := m.unsafeLogins[name]
+	if !ok || login.Token != token || time.Now().After(login.Expires) {
+		return false
+	}
+	delete(m.unsafeLogins, name)
+	return true
+}
+
+// receiveHandoff takes in a Character handed off to this node, replacing this node's out of date copy of them
+// and the items they carry, and places them in the room they were heading to.
+func (m *ClusterManager) receiveHandoff(data []byte) {
+	var handoff clusterHandoff
+	if err := json.Unmarshal(data, &handoff); err != nil || handoff.To != m.node {
+		return
+	}
+
+	var to *Room
+	if a, loc := Armeria.worldManager.AreaByName(handoff.Area), NewCoordsFromString(handoff.Location); a != nil && loc != nil {
+		if to = a.RoomAt(loc); to == nil {
+			to = Armeria.wildernessManager.RoomAt(a, loc)
+		}
+	}
+	if to == nil {
+		Armeria.log.Error("character handed off to a room that doesn't exist",
+			zap.String("area", handoff.Area),
+			zap.String("location", handoff.Location),
+		)
+		return
+	}
+
+	c := &Character{}
+	if err := json.Unmarshal(handoff.Character, c); err != nil {
+		Armeria.log.Error("failed to decode handed off character",
+			zap.Error(err),
+		)
+		return
+	}
+
+	old := Armeria.characterManager.CharacterById(c.ID())
+	if old != nil {
+		if old.Online() {
+			Armeria.log.Error("character handed off while already playing on this node",
+				zap.String("character", old.Name()),
+				zap.String("from", handoff.From),
+			)
+			return
+		}
+		for _, oc := range []*ObjectContainer{old.Inventory(), old.Equipment()} {
+			for _, ii := range oc.Items() {
+				oc.Remove(ii.ID())
+				ii.Parent.DeleteInstance(ii)
+			}
+		}
+		if oc := Armeria.registry.GetObjectContainer(old.ID()); oc != nil {
+			oc.Remove(old.ID())
+		}
+	}
+
+	for _, hi := range handoff.Items {
+		item := Armeria.itemManager.ItemByName(hi.Item)
+		ii := &ItemInstance{}
+		if item == nil || json.Unmarshal(hi.Instance, ii) != nil {
+			Armeria.log.Error("failed to take in an item carried by a handed off character",
+				zap.String("character", c.Name()),
+				zap.String("item", hi.Item),
+			)
+			continue
+		}
+		if o, rt := Armeria.registry.Get(ii.ID()); rt == RegistryTypeItemInstance {
+			existing := o.(*ItemInstance)
+			if oc := Armeria.registry.GetObjectContainer(existing.ID()); oc != nil {
+				oc.Remove(existing.ID())
+			}
+			existing.Parent.DeleteInstance(existing)
+		}
+		item.AddInstance(ii)
+	}
+
+	Armeria.characterManager.ReplaceCharacter(c)
+	if err := to.Here().Add(c.ID()); err != nil {
+		Armeria.log.Error("failed to place handed off character",
+			zap.String("character", c.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	Armeria.log.Info("character arrived from another node",
+		zap.String("character", c.Name()),
+		zap.String("node", handoff.From),
+		zap.String("room", to.LocationString()),
+	)
+}
+
+// Node returns the name of this node within the cluster.
+func (m *ClusterManager) Node() string {
+	return m.node
+}
+
+// PublishChannelMessage sends a channel message, as seen by the other members of the channel, to the rest of
+// the cluster, along with the message before it went through the chat filter.
+func (m *ClusterManager) PublishChannelMessage(msg *ChannelMessage, text, unfiltered string) {
+	data, _ := json.Marshal(&clusterChannelMessage{
+		Node:       m.node,
+		Channel:    msg.Channel.key(),
+		ID:         msg.ID,
+		Character:  msg.Character,
+		Original:   msg.Text,
+		Text:       text,
+		Unfiltered: unfiltered,
+	})
+	if err := m.bus.Publish(ClusterSubjectChannels, data); err != nil {
+		Armeria.log.Error("failed to publish channel message to cluster",
+			zap.String("channel", msg.Channel.key()),
+			zap.Error(err),
+		)
+	}
+}
+
+// receiveChannelMessage shows a channel message from another node to the local characters in the channel.
+func (m *ClusterManager) receiveChannelMessage(data []byte) {
+	var msg clusterChannelMessage
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Node == m.node {
+		return
+	}
+
+	ch := Armeria.channels[msg.Channel]
+	if ch == nil {
+		return
+	}
+
+	// Nodes that haven't been upgraded only send the filtered message.
+	if len(msg.Unfiltered) == 0 {
+		msg.Unfiltered = msg.Text
+	}
+
+	// Moderators on any node can retract the message.
+	if len(msg.ID) > 0 {
+		Armeria.channelLog.Add(&ChannelMessage{
+			ID:        msg.ID,
+			Channel:   ch,
+			Character: msg.Character,
+			Text:      msg.Original,
+			Time:      time.Now(),
+		})
+	}
+
+	ch.deliver(nil, msg.Text, msg.Unfiltered)
+}
+
+// PublishRetraction tells the rest of the cluster that a moderator retracted a channel message.
+func (m *ClusterManager) PublishRetraction(channel, id string) {
+	data, _ := json.Marshal(&clusterChannelMessage{Node: m.node, Channel: channel, ID: id})
+	if err := m.bus.Publish(ClusterSubjectRetractions, data); err != nil {
+		Armeria.log.Error("failed to publish retraction to cluster",
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+	}
+}
+
+// receiveRetraction removes a channel message retracted on another node from the local characters' displays.
+func (m *ClusterManager) receiveRetraction(data []byte) {
+	var msg clusterChannelMessage
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Node == m.node {
+		return
+	}
+
+	ch := Armeria.channels[msg.Channel]
+	if ch == nil {
+		return
+	}
+
+	Armeria.channelLog.Remove(msg.ID)
+	redactChannelMessage(ch, msg.ID)
+}
@@ -0,0 +1,408 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// CombatRoundInterval is how often the attacks of everyone in combat are resolved.
+	CombatRoundInterval = 3 * time.Second
+	// CharacterBaseHealth is a character's health before the health stats of their equipment.
+	CharacterBaseHealth = 100
+	// CharacterBaseDamage is the damage a character deals before the damage stats of their equipment.
+	CharacterBaseDamage = 5
+	// CombatRecoveryPercent is how much of their health a wounded combatant recovers each round out of combat.
+	CombatRecoveryPercent = 10
+)
+
+// Combatant is a Character or MobInstance that can take part in combat.
+type Combatant interface {
+	ID() string
+	FormattedName() string
+	Room() *Room
+}
+
+// engagement is a Combatant attacking another each combat round.
+type engagement struct {
+	attacker Combatant
+	target   Combatant
+}
+
+// wound is the damage a Combatant has taken and not yet recovered from.
+type wound struct {
+	combatant Combatant
+	damage    int
+}
+
+// CombatManager tracks who is fighting whom, along with the wounds of everyone who has been hurt. Each combatant
+// attacks a single target, and the attacks are resolved together every CombatRoundInterval by CombatRounds.
+// Combat isn't kept across restarts.
+type CombatManager struct {
+	sync.RWMutex
+	engagements map[string]*engagement
+	wounds      map[string]*wound
+}
+
+// NewCombatManager returns a new CombatManager with no one in combat.
+func NewCombatManager() *CombatManager {
+	return &CombatManager{
+		engagements: make(map[string]*engagement),
+		wounds:      make(map[string]*wound),
+	}
+}
+
+// Engage makes a Combatant attack a target each round. A target that isn't already fighting fights back. Characters
+// stop following their leader when combat starts, so they aren't carried away from the fight.
+func (m *CombatManager) Engage(attacker, target Combatant) {
+	m.Lock()
+	m.engagements[attacker.ID()] = &engagement{attacker: attacker, target: target}
+	if _, fighting := m.engagements[target.ID()]; !fighting {
+		m.engagements[target.ID()] = &engagement{attacker: target, target: attacker}
+	}
+	m.Unlock()
+
+	for _, cb := range []Combatant{attacker, target} {
+		if c, ok := cb.(*Character); ok && c.Leader() != nil {
+			c.StopFollowing()
+		}
+	}
+
+	Armeria.log.Info("combat engaged",
+		zap.String("attacker", attacker.ID()),
+		zap.String("target", target.ID()),
+	)
+}
+
+// Disengage stops a Combatant from fighting, along with everyone fighting them.
+func (m *CombatManager) Disengage(id string) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.disengage(id)
+}
+
+// disengage stops a Combatant from fighting, along with everyone fighting them. The caller must hold the lock.
+func (m *CombatManager) disengage(id string) {
+	delete(m.engagements, id)
+	for aid, e := range m.engagements {
+		if e.target.ID() == id {
+			delete(m.engagements, aid)
+		}
+	}
+}
+
+// Target returns who a Combatant is attacking, or nil if they aren't in combat.
+func (m *CombatManager) Target(id string) Combatant {
+	m.RLock()
+	defer m.RUnlock()
+
+	if e, ok := m.engagements[id]; ok {
+		return e.target
+	}
+	return nil
+}
+
+// InCombat returns true if a Combatant is attacking someone, or being attacked.
+func (m *CombatManager) InCombat(id string) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.inCombat(id)
+}
+
+// inCombat returns true if a Combatant is attacking someone, or being attacked. The caller must hold the lock.
+func (m *CombatManager) inCombat(id string) bool {
+	if _, ok := m.engagements[id]; ok {
+		return true
+	}
+	for _, e := range m.engagements {
+		if e.target.ID() == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Health returns the current health of a Combatant.
+func (m *CombatManager) Health(cb Combatant) int {
+	m.RLock()
+	defer m.RUnlock()
+
+	if w, ok := m.wounds[cb.ID()]; ok {
+		return CombatMaxHealth(cb) - w.damage
+	}
+	return CombatMaxHealth(cb)
+}
+
+// hurt deals damage to a Combatant, and returns their remaining health, which is never below zero.
+func (m *CombatManager) hurt(cb Combatant, damage int) int {
+	m.Lock()
+	defer m.Unlock()
+
+	w, ok := m.wounds[cb.ID()]
+	if !ok {
+		w = &wound{combatant: cb}
+		m.wounds[cb.ID()] = w
+	}
+	w.damage += damage
+
+	health := CombatMaxHealth(cb) - w.damage
+	if health < 0 {
+		return 0
+	}
+	return health
+}
+
+// heal removes the wounds of a Combatant, restoring them to full health.
+func (m *CombatManager) heal(id string) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.wounds, id)
+}
+
+// recover lets each wounded Combatant that isn't in combat recover some of their health, and forgets the wounds
+// of mobs that are no longer in the game. Characters who log out keep their wounds until they're back, so logging
+// out isn't a way to heal.
+func (m *CombatManager) recover() {
+	m.Lock()
+	defer m.Unlock()
+
+	for id, w := range m.wounds {
+		if !combatantPresent(w.combatant) {
+			if c, ok := w.combatant.(*Character); !ok || Armeria.characterManager.CharacterById(c.ID()) == nil {
+				delete(m.wounds, id)
+			}
+			continue
+		}
+		if m.inCombat(id) {
+			continue
+		}
+
+		amount := CombatMaxHealth(w.combatant) * CombatRecoveryPercent / 100
+		if amount < 1 {
+			amount = 1
+		}
+		w.damage -= amount
+		if w.damage <= 0 {
+			delete(m.wounds, id)
+		}
+	}
+}
+
+// engagementList returns the current engagements, as pairs of attacker and target.
+func (m *CombatManager) engagementList() []*engagement {
+	m.RLock()
+	defer m.RUnlock()
+
+	var list []*engagement
+	for _, e := range m.engagements {
+		list = append(list, e)
+	}
+	return list
+}
+
+// combatantPresent returns true if a Combatant is still in the game world.
+func combatantPresent(cb Combatant) bool {
+	switch o := cb.(type) {
+	case *Character:
+		return o.Online()
+	case *MobInstance:
+		_, rt := Armeria.registry.Get(o.ID())
+		return rt == RegistryTypeMobInstance && o.Room() != nil
+	}
+	return false
+}
+
+// equipmentStat returns the total of a stat across the items a Character has equipped.
+func equipmentStat(c *Character, name string) int {
+	total := 0
+	for _, ii := range c.Equipment().Items() {
+		total += ii.Stats().Value(name)
+	}
+	return total
+}
+
+// CombatMaxHealth returns the health of a Combatant when they are unhurt. A character's health is increased by the
+// health stats of their equipment, and a mob's is its health attribute.
+func CombatMaxHealth(cb Combatant) int {
+	switch o := cb.(type) {
+	case *Character:
+		return CharacterBaseHealth + equipmentStat(o, "health")
+	case *MobInstance:
+		return o.AttributeInt(AttributeHealth)
+	}
+	return 0
+}
+
+// CombatDamage returns the damage a Combatant deals before it is randomized and reduced by armor. A character's
+// damage is increased by the damage stats of their equipment, and a mob's is its damage attribute.
+func CombatDamage(cb Combatant) int {
+	switch o := cb.(type) {
+	case *Character:
+		return CharacterBaseDamage + equipmentStat(o, "damage")
+	case *MobInstance:
+		return o.AttributeInt(AttributeDamage)
+	}
+	return 0
+}
+
+// CombatArmor returns how much damage is blocked from each attack against a Combatant, from the armor stats of a
+// character's equipment. Mobs don't wear armor.
+func CombatArmor(cb Combatant) int {
+	if c, ok := cb.(*Character); ok {
+		return equipmentStat(c, "armor")
+	}
+	return 0
+}
+
+// rollDamage returns the damage dealt by one attack: the attacker's damage varies by a quarter either way, and is
+// reduced by the target's armor. An attack that lands always deals at least one damage.
+func rollDamage(attacker, target Combatant) int {
+	base := CombatDamage(attacker)
+	if base <= 0 {
+		return 0
+	}
+
+	damage := base*(75+misc.RandomInt(51))/100 - CombatArmor(target)
+	if damage < 1 {
+		damage = 1
+	}
+	return damage
+}
+
+// resolveAttack resolves a single attack from one Combatant against another, and shows everyone in the room what
+// happened.
+func (m *CombatManager) resolveAttack(attacker, target Combatant) {
+	damage := rollDamage(attacker, target)
+	health := m.hurt(target, damage)
+	room := target.Room()
+
+	if c, ok := attacker.(*Character); ok {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"You hit %s for %d damage. [%d/%d]",
+				target.FormattedName(),
+				damage,
+				health,
+				CombatMaxHealth(target),
+			),
+			ColorSuccess,
+		)
+	}
+
+	if c, ok := target.(*Character); ok {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"%s hits you for %d damage. [%d/%d]",
+				attacker.FormattedName(),
+				damage,
+				health,
+				CombatMaxHealth(target),
+			),
+			ColorError,
+		)
+	}
+
+	for _, c := range room.Here().Characters(true) {
+		if c.ID() == attacker.ID() || c.ID() == target.ID() {
+			continue
+		}
+		c.Player().client.ShowText(fmt.Sprintf("%s hits %s.", attacker.FormattedName(), target.FormattedName()))
+	}
+
+	if health <= 0 {
+		m.defeat(target, attacker)
+	}
+}
+
+// defeat ends the fights of a Combatant who has run out of health. Mobs die, crediting the character who defeated
+// them. Characters are spared, and recover their health, while the mobs that were chasing them head home.
+func (m *CombatManager) defeat(loser, winner Combatant) {
+	m.Disengage(loser.ID())
+	m.heal(loser.ID())
+
+	Armeria.log.Info("combatant defeated",
+		zap.String("loser", loser.ID()),
+		zap.String("winner", winner.ID()),
+	)
+
+	switch o := loser.(type) {
+	case *MobInstance:
+		killer, _ := winner.(*Character)
+		o.Die(killer)
+	case *Character:
+		o.Player().client.ShowColorizedText(
+			fmt.Sprintf("You were defeated by %s, and stagger away to recover.", winner.FormattedName()),
+			ColorError,
+		)
+		for _, c := range o.Room().Here().Characters(true, o) {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s was defeated by %s.", o.FormattedName(), winner.FormattedName()),
+			)
+		}
+		for _, mi := range o.Room().Here().Mobs() {
+			if mi.Pursuing() == o {
+				mi.GiveUpPursuit()
+			}
+		}
+	}
+}
+
+// joinPursuits makes the mobs that are pursuing a character attack them, once they are in the same room.
+func (m *CombatManager) joinPursuits() {
+	for _, mob := range Armeria.mobManager.Mobs() {
+		for _, mi := range mob.Instances() {
+			c := mi.Pursuing()
+			if c == nil || c.Room() != mi.Room() || m.Target(mi.ID()) != nil {
+				continue
+			}
+			m.Engage(mi, c)
+			announceMobAttack(mi, c)
+		}
+	}
+}
+
+// announceMobAttack warns everyone in the room that a mob has attacked a Character.
+func announceMobAttack(mi *MobInstance, c *Character) {
+	for _, char := range c.Room().Here().Characters(true) {
+		char.Player().client.ShowColorizedText(
+			fmt.Sprintf("%s attacks %s!", mi.FormattedName(), c.FormattedName()),
+			ColorError,
+		)
+	}
+}
+
+// Round resolves one round of combat. Fights end when either side leaves the game, or they are no longer in the
+// same room.
+func (m *CombatManager) Round() {
+	m.joinPursuits()
+
+	for _, e := range m.engagementList() {
+		// An earlier attack this round may have ended the fight.
+		if m.Target(e.attacker.ID()) != e.target {
+			continue
+		}
+
+		if !combatantPresent(e.attacker) || !combatantPresent(e.target) || e.attacker.Room() != e.target.Room() {
+			m.Lock()
+			delete(m.engagements, e.attacker.ID())
+			m.Unlock()
+			continue
+		}
+
+		m.resolveAttack(e.attacker, e.target)
+	}
+
+	m.recover()
+}
+
+// CombatRounds runs CombatManager.Round as a ticker.
+func CombatRounds() {
+	Armeria.combatManager.Round()
+}
@@ -4,11 +4,17 @@ import (
 	"armeria/internal/pkg/misc"
 	"armeria/internal/pkg/sfx"
 	"armeria/internal/pkg/validate"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"code.cloudfoundry.org/bytefmt"
 	"github.com/muesli/reflow/wordwrap"
 	"go.uber.org/zap"
 
@@ -33,7 +39,7 @@ func handleLoginCommand(ctx *CommandContext) {
 			return
 		}
 
-		if c.PasswordHash() != sections[1] {
+		if c.PasswordHash() != sections[1] && !ClaimClusterLogin(c, sections[1]) {
 			ctx.Player.client.ShowColorizedText("Invalid token for that character.", ColorError)
 			return
 		}
@@ -56,21 +62,27 @@ func handleLoginCommand(ctx *CommandContext) {
 		return
 	}
 
+	c.RestoreWildernessRoom()
 	if c.Room() == nil {
 		ctx.Player.client.ShowColorizedText("This character logged out of a room which no longer exists.", ColorError)
 		return
 	}
 
-	ctx.Player.AttachCharacter(c)
-	c.SetPlayer(ctx.Player)
-
-	ctx.Player.client.ShowColorizedText(fmt.Sprintf("You've entered Armeria as %s!", c.FormattedName()), ColorSuccess)
+	// The character is being played on the node hosting their area, which has the up to date copy of them.
+	if !HostsArea(c.Room().ParentArea) {
+		Armeria.clusterManager.Redirect(ctx.Player, c)
+		return
+	}
 
-	c.LoggedIn()
+	Armeria.loginQueue.Admit(ctx.Player, c)
 }
 
 func handleCreateCommand(ctx *CommandContext) {
-	ctx.Player.client.ShowText("You cannot manually create new characters yet. Stay tuned.")
+	ctx.Player.client.ShowColorizedText(
+		"Let's create your character! Type your answer to each question and press enter.",
+		ColorSuccess,
+	)
+	Armeria.creationManager.Start(ctx.Player)
 }
 
 func handleLookCommand(ctx *CommandContext) {
@@ -90,17 +102,33 @@ func handleLookCommand(ctx *CommandContext) {
 			searchInv = false
 		}
 
+		// Room details are only matched by their full name, so they take priority over partial object names.
 		result := oc.GetByAny(at)
+		if result.Type == RegistryTypeUnknown && (searchInv || r.DetailFor(ctx.Character, at) == nil) {
+			result = oc.GetLoose(at)
+			if ctx.ShowAmbiguousTarget(result) {
+				return
+			}
+		}
+		if result.Type == RegistryTypeItemInstance && !searchInv && !result.Object.(*ItemInstance).PhasedVisibleTo(ctx.Character) {
+			result = &ObjectContainerResult{Type: RegistryTypeUnknown}
+		} else if result.Type == RegistryTypeCharacter && !result.Object.(*Character).VisibleTo(ctx.Character) {
+			result = &ObjectContainerResult{Type: RegistryTypeUnknown}
+		}
 		if result.Type == RegistryTypeUnknown {
+			if d := r.DetailFor(ctx.Character, at); d != nil && !searchInv {
+				lookAtDetail(ctx, at, d)
+				return
+			}
 			ctx.Player.client.ShowColorizedText("You don't see anything by that name.", ColorError)
 			return
 		}
 
 		var lookResult string
 		if result.Type == RegistryTypeItemInstance {
-			lookResult = result.Object.Attribute(AttributeDescription)
+			lookResult = TextTemplate(result.Object.Attribute(AttributeDescription), ctx.Character)
 		} else if result.Type == RegistryTypeCharacter {
-			lookResult = fmt.Sprintf("There is nothing special about %s.", result.Object.(*Character).Pronoun(PronounObjective))
+			lookResult = result.Object.(*Character).Appearance()
 		}
 
 		if len(lookResult) == 0 {
@@ -173,8 +201,8 @@ func handleLookCommand(ctx *CommandContext) {
 	}
 
 	ctx.Player.client.ShowText(
-		TextStyle(r.Attribute(AttributeTitle), WithBold(), WithSize(14), WithUserColor(ctx.Character, ColorRoomTitle)) + "\n" +
-			wordwrap.String(r.Attribute(AttributeDescription), wrapDescAt) +
+		TextStyle(r.AttributeFor(ctx.Character, AttributeTitle), WithBold(), WithSize(14), WithUserColor(ctx.Character, ColorRoomTitle)) + "\n" +
+			wordwrap.String(TextTemplate(r.AttributeFor(ctx.Character, AttributeDescription), ctx.Character), wrapDescAt) +
 			TextStyle(validDirString, WithUserColor(ctx.Character, ColorRoomDirs)),
 	)
 
@@ -187,6 +215,39 @@ func handleLookCommand(ctx *CommandContext) {
 	}
 }
 
+// lookAtDetail shows a room detail to the character looking at it, and lets any mobs in the room react.
+func lookAtDetail(ctx *CommandContext, keyword string, d *RoomDetail) {
+	r := ctx.Character.Room()
+	keyword = strings.ToLower(keyword)
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"You take a look at the %s.\n%s",
+			TextStyle(keyword, WithBold()),
+			TextTemplate(d.Description, ctx.Character),
+		),
+	)
+
+	if !ctx.PlayerInitiated {
+		return
+	}
+
+	for _, c := range r.Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s is taking a look at the %s.", ctx.Character.FormattedName(), TextStyle(keyword, WithBold())),
+		)
+	}
+
+	for _, mi := range r.Here().Mobs() {
+		go CallMobFunc(
+			ctx.Character,
+			mi,
+			"character_looked",
+			lua.LString(keyword),
+		)
+	}
+}
+
 func handleGlanceCommand(ctx *CommandContext) {
 	r := ctx.Character.Room()
 
@@ -194,8 +255,10 @@ func handleGlanceCommand(ctx *CommandContext) {
 	for _, o := range r.Here().All() {
 		obj := o.(ContainerObject)
 
-		if obj.Type() == ContainerObjectTypeCharacter && obj.(*Character).Player() == nil {
-			continue
+		if obj.Type() == ContainerObjectTypeCharacter {
+			if obj.(*Character).Player() == nil || !obj.(*Character).VisibleTo(ctx.Character) {
+				continue
+			}
 		}
 
 		if obj.ID() != ctx.Character.ID() {
@@ -243,7 +306,7 @@ func handleGlanceCommand(ctx *CommandContext) {
 	}
 
 	ctx.Player.client.ShowText(
-		ctx.Character.Colorize(r.Attribute("title"), ColorRoomTitle) +
+		ctx.Character.Colorize(r.AttributeFor(ctx.Character, AttributeTitle), ColorRoomTitle) +
 			ctx.Character.Colorize(validDirString, ColorRoomDirs) +
 			withYou,
 	)
@@ -271,7 +334,22 @@ func handleSayCommand(ctx *CommandContext) {
 		}
 	}
 
+	// Replying with the number of a conversation option selects it.
+	if _, err := strconv.Atoi(ctx.Args["text"]); err == nil {
+		mi := ctx.Character.ConvoOptionsMob()
+		if option := ctx.Character.ConvoOption(mi, ctx.Args["text"]); mi != nil && option != nil {
+			Armeria.commandManager.ProcessCommand(ctx.Player, fmt.Sprintf("select \"%s\" \"%s\"", mi.ID(), option.ID), false)
+			return
+		}
+	}
+
+	filteredText, ok := ctx.Character.FilterChat("say", ctx.Args["text"])
+	if !ok {
+		return
+	}
+
 	normalizedText, textType := TextPunctuation(ctx.Args["text"])
+	filteredText, _ = TextPunctuation(filteredText)
 
 	var verbs []string
 	switch textType {
@@ -283,17 +361,28 @@ func handleSayCommand(ctx *CommandContext) {
 		verbs = []string{"say", "says"}
 	}
 
-	normalizedText = TextCapitalization(normalizedText)
+	normalizedText = TextStylePlayer(TextCapitalization(normalizedText))
+	filteredText = TextStylePlayer(TextCapitalization(filteredText))
 
-	ctx.Player.client.ShowText(
-		ctx.Player.Character().Colorize(fmt.Sprintf("You %s, \"%s\"", verbs[0], normalizedText), ColorSay),
+	ctx.Player.client.ShowChatText(
+		"say",
+		ctx.Player.Character().Colorize(
+			fmt.Sprintf("You %s, \"%s\"", verbs[0], ctx.Character.ChatText(filteredText, normalizedText)),
+			ColorSay,
+		),
 	)
 
 	room := ctx.Character.Room()
 	for _, c := range room.Here().Characters(true, ctx.Character) {
-		c.Player().client.ShowText(
+		c.Player().client.ShowChatText(
+			"say",
 			c.Player().Character().Colorize(
-				fmt.Sprintf("%s %s, \"%s\"", ctx.Character.FormattedName(), verbs[1], normalizedText),
+				fmt.Sprintf(
+					"%s %s, \"%s\"",
+					ctx.Character.FormattedName(),
+					verbs[1],
+					c.ChatText(filteredText, normalizedText),
+				),
 				ColorSay,
 			),
 		)
@@ -318,6 +407,15 @@ func handleMoveCommand(ctx *CommandContext) {
 		return
 	}
 
+	if len(ctx.Character.TempAttribute(TempAttributeTraveling)) > 0 {
+		ctx.Player.client.ShowColorizedText("You're already on your way somewhere.", ColorError)
+		return
+	}
+
+	if ctx.PlayerInitiated && ctx.Character.StopWalking() {
+		ctx.Player.client.ShowColorizedText("You stop walking.", ColorMovement)
+	}
+
 	newRoom := ctx.Character.Room().ConnectedRoom(normDir)
 	if newRoom == nil {
 		currentRoomAttr := ctx.Character.Room().Attribute(normDir)
@@ -325,10 +423,10 @@ func handleMoveCommand(ctx *CommandContext) {
 			if len(currentRoomAttr) > 1 {
 				ctx.Player.client.ShowColorizedText(currentRoomAttr[1:], ColorError)
 			} else {
-				ctx.Player.client.ShowColorizedText(CommonInvalidDirection, ColorError)
+				ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonInvalidDirection), ColorError)
 			}
 		} else {
-			ctx.Player.client.ShowColorizedText(CommonInvalidDirection, ColorError)
+			ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonInvalidDirection), ColorError)
 		}
 		return
 	}
@@ -339,10 +437,41 @@ func handleMoveCommand(ctx *CommandContext) {
 		return
 	}
 
-	oldAreaUUID := ctx.Character.Room().ParentArea.ID()
+	// Rough terrain takes a while to travel through.
+	if travel := newRoom.TravelTime(); travel > 0 && len(ctx.Character.TempAttribute(TempAttributeGhost)) == 0 {
+		oldRoom := ctx.Character.Room()
+		ctx.Character.SetTempAttribute(TempAttributeTraveling, normDir)
+		ctx.Player.client.ShowText(
+			TextStyle(
+				fmt.Sprintf("You set off %s through the %s...", normDir, strings.ToLower(newRoom.Attribute(AttributeTitle))),
+				WithUserColor(ctx.Character, ColorMovement),
+			),
+		)
+		c := ctx.Character
+		time.AfterFunc(travel, func() {
+			c.SetTempAttribute(TempAttributeTraveling, "")
+			if !c.Online() || c.Room() != oldRoom {
+				return
+			}
+			// The wilderness room may have been unloaded while traveling, so find it again. The character may also
+			// have reconnected, so they're shown around through the player they're on now.
+			if newRoom = oldRoom.ConnectedRoom(normDir); newRoom != nil {
+				moveCharacter(&CommandContext{Player: c.Player(), Character: c}, newRoom, normDir)
+			}
+		})
+		return
+	}
+
+	moveCharacter(ctx, newRoom, normDir)
+}
+
+// moveCharacter moves the Character in a direction to a new Room, and shows them around.
+func moveCharacter(ctx *CommandContext, newRoom *Room, normDir string) {
+	oldRoom := ctx.Character.Room()
+	oldAreaUUID := oldRoom.ParentArea.ID()
 	ctx.Character.Move(
 		newRoom,
-		TextStyle(fmt.Sprintf("You walk %s.", misc.MoveToStringFromDir("to the", normDir)), WithUserColor(ctx.Character, ColorMovement)),
+		TextStyle(Tr(ctx.Character, "move.walk", misc.MoveToStringFromDir("to the", normDir)), WithUserColor(ctx.Character, ColorMovement)),
 		TextStyle(fmt.Sprintf("%s walks %s.", ctx.Character.FormattedName(), misc.MoveToStringFromDir("to the", normDir)), WithUserColor(ctx.Character, ColorMovement)),
 		TextStyle(fmt.Sprintf("%s walked in from %s.", ctx.Character.FormattedName(), misc.MoveFromStringFromDir("the", misc.OppositeDirection(normDir))), WithUserColor(ctx.Character, ColorMovement)),
 		"",
@@ -350,7 +479,7 @@ func handleMoveCommand(ctx *CommandContext) {
 
 	if newRoom.ParentArea.ID() != oldAreaUUID {
 		ctx.Player.client.ShowColorizedText(
-			fmt.Sprintf("You've just entered %s.", TextStyle(newRoom.ParentArea.Name(), WithBold())),
+			Tr(ctx.Character, "move.enteredArea", TextStyle(newRoom.ParentArea.Name(), WithBold())),
 			ColorMovementAlt,
 		)
 	}
@@ -360,9 +489,15 @@ func handleMoveCommand(ctx *CommandContext) {
 	} else {
 		Armeria.commandManager.ProcessCommand(ctx.Player, "look", false)
 	}
+
+	carryFollowers(ctx.Character, oldRoom, normDir)
 }
 
 func handleRoomEditCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	t := ctx.Args["target"]
 	a := ctx.Character.Room().ParentArea
 	tr := ctx.Character.Room()
@@ -370,6 +505,10 @@ func handleRoomEditCommand(ctx *CommandContext) {
 	args := strings.Split(t, ",")
 
 	if args[0] == "" {
+		if tr.Transient() {
+			ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTransientRoom), ColorError)
+			return
+		}
 		ctx.Player.client.ShowObjectEditor(tr.EditorData())
 		return
 	}
@@ -397,6 +536,10 @@ func handleRoomEditCommand(ctx *CommandContext) {
 }
 
 func handleRoomSetCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	attr := AttributeCasing(ctx.Args["property"])
 	if !misc.Contains(AttributeList(ObjectTypeRoom), attr) {
 		ctx.Player.client.ShowColorizedText("That's not a valid room attribute.", ColorError)
@@ -426,22 +569,54 @@ func handleRoomSetCommand(ctx *CommandContext) {
 		tr = ctx.Character.Room().ParentArea.RoomAt(NewCoords(x, y, z, 0))
 	}
 
-	if tr != nil {
-		tr.SetAttribute(attr, ctx.Args["value"])
-	} else {
+	if tr == nil {
 		ctx.Player.client.ShowColorizedText("The specified room does not exist.", ColorError)
 		return
+	} else if tr.Transient() {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTransientRoom), ColorError)
+		return
+	}
+
+	if !ctx.CheckEditVersion(tr.EditorData, attr) {
+		return
 	}
 
+	if ctx.DryRun() {
+		ctx.Simulation.Modify(
+			ObjectTypeRoom,
+			fmt.Sprintf("%s (%s)", tr.DraftAttribute(AttributeTitle), tr.Coords.String()),
+			attr,
+			tr.DraftAttribute(attr),
+			ctx.Args["value"],
+			ValidateAttribute(ObjectTypeRoom, attr, ctx.Args["value"], tr.DraftAttribute),
+		)
+		return
+	}
+
+	old := tr.DraftAttribute(attr)
+	if err := tr.SetDraftAttribute(attr, ctx.Args["value"]); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("room", tr.ID()), attr, old, tr.DraftAttribute(attr))
+
 	ctx.Player.client.SyncMap()
+	ctx.Player.client.SyncRoomTitle()
 
 	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
-		c.Player().client.ShowText(
-			fmt.Sprintf("%s modified the room.", ctx.Character.FormattedName()),
-		)
+		if c.HasPermission("CAN_BUILD") {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s modified the room.", ctx.Character.FormattedName()),
+			)
+		}
 	}
 	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You modified the %s property of the room (%s).", TextStyle(attr, WithBold()), ta),
+		fmt.Sprintf(
+			"You modified the %s property of the room (%s). Use %s to make your changes visible to players.",
+			TextStyle(attr, WithBold()),
+			ta,
+			TextStyle("/publish", WithLinkCmd("/publish")),
+		),
 		ColorSuccess,
 	)
 
@@ -452,6 +627,10 @@ func handleRoomSetCommand(ctx *CommandContext) {
 }
 
 func handleRoomMoveCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	dir := strings.ToLower(ctx.Args["direction"])
 
 	if dir == "up" || dir == "down" {
@@ -486,8 +665,8 @@ func handleRoomMoveCommand(ctx *CommandContext) {
 	// Link the rooms (if applicable).
 	oppositeRm := rm.ConnectedRoom(oppositeDir)
 	if oppositeRm != nil {
-		oppositeRm.SetAttribute(dir, rm.Coords.String())
-		rm.SetAttribute(oppositeDir, oppositeRm.Coords.String())
+		_ = oppositeRm.SetAttribute(dir, rm.Coords.String())
+		_ = rm.SetAttribute(oppositeDir, oppositeRm.Coords.String())
 	}
 
 	// Sync the minimap for anyone in the area.
@@ -502,6 +681,10 @@ func handleRoomMoveCommand(ctx *CommandContext) {
 }
 
 func handleRoomCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	d := ctx.Args["direction"]
 
 	o := misc.DirectionOffsets(d)
@@ -521,10 +704,14 @@ func handleRoomCreateCommand(ctx *CommandContext) {
 		return
 	}
 
+	if !ctx.UseQuota(QuotaRooms) {
+		return
+	}
+
 	rm := Armeria.worldManager.CreateRoom(ctx.Character.Room().ParentArea, c)
 
 	// Match room colors.
-	rm.SetAttribute(AttributeColor, ctx.Character.Room().Attribute(AttributeColor))
+	_ = rm.SetAttribute(AttributeColor, ctx.Character.Room().Attribute(AttributeColor))
 
 	for _, c := range ctx.Character.Room().ParentArea.Characters() {
 		c.Player().client.SyncMap()
@@ -533,7 +720,82 @@ func handleRoomCreateCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowText("A new room has been created.")
 }
 
+func handleRoomCloneCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
+	d := ctx.Args["direction"]
+
+	o := misc.DirectionOffsets(d)
+	if o == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid direction to clone a room in.", ColorError)
+		return
+	}
+
+	src := ctx.Character.Room()
+	co := src.Coords
+	c := NewCoords(co.X()+o["x"], co.Y()+o["y"], co.Z()+o["z"], 0)
+	if src.ParentArea.RoomAt(c) != nil {
+		ctx.Player.client.ShowColorizedText("There's already a room in that direction.", ColorError)
+		return
+	}
+
+	if !ctx.UseQuota(QuotaRooms) {
+		return
+	}
+
+	rm := Armeria.worldManager.CreateRoom(src.ParentArea, c)
+
+	// Copy everything except for explicit exits, which wouldn't lead anywhere sensible from the new room.
+	src.RLock()
+	attrs := make(map[string]string)
+	for k, v := range src.UnsafeAttributes {
+		if misc.DirectionOffsets(k) == nil {
+			attrs[k] = v
+		}
+	}
+	src.RUnlock()
+
+	for k, v := range attrs {
+		_ = rm.SetAttribute(k, v)
+	}
+
+	spawners := 0
+	for _, ii := range src.Here().Items() {
+		if ii.Attribute(AttributeType) != ItemTypeMobSpawner {
+			continue
+		}
+
+		clone := ii.Parent.CreateInstance()
+		ii.RLock()
+		for k, v := range ii.UnsafeAttributes {
+			clone.UnsafeAttributes[k] = v
+		}
+		ii.RUnlock()
+
+		if err := rm.Here().Add(clone.ID()); err != nil {
+			ii.Parent.DeleteInstance(clone)
+			continue
+		}
+		spawners++
+	}
+
+	for _, c := range src.ParentArea.Characters() {
+		c.Player().client.SyncMap()
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("A copy of this room has been created to the %s, with %d mob spawner(s).", d, spawners),
+		ColorSuccess,
+	)
+}
+
 func handleRoomDestroyCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	d := ctx.Args["direction"]
 
 	o := misc.DirectionOffsets(d)
@@ -559,6 +821,11 @@ func handleRoomDestroyCommand(ctx *CommandContext) {
 		return
 	}
 
+	if ctx.DryRun() {
+		ctx.Simulation.Remove(ObjectTypeRoom, fmt.Sprintf("%s (%s)", r.Attribute(AttributeTitle), r.Coords.String()))
+		return
+	}
+
 	r.ParentArea.RemoveRoom(r)
 
 	for _, c := range ctx.Character.Room().ParentArea.Characters() {
@@ -599,22 +866,38 @@ func handleWhisperCommand(ctx *CommandContext) {
 		return
 	}
 
+	filteredText, ok := ctx.Character.FilterChat("whisper", m)
+	if !ok {
+		return
+	}
+
 	c.SetTempAttribute(TempAttributeReplyTo, ctx.Character.Name())
 
-	normalizedText, _ := TextPunctuation(m)
+	normalizedText, _ := TextPunctuation(TextStylePlayer(m))
+	filteredText, _ = TextPunctuation(TextStylePlayer(filteredText))
 
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You whisper to %s, \"%s\"", c.FormattedNameWithTitle(), normalizedText),
-		ColorWhisper,
+	ctx.Player.client.ShowChatText(
+		"whisper",
+		ctx.Character.Colorize(
+			fmt.Sprintf(
+				"You whisper to %s, \"%s\"",
+				c.FormattedNameWithTitle(),
+				ctx.Character.ChatText(filteredText, normalizedText),
+			),
+			ColorWhisper,
+		),
 	)
 
-	c.Player().client.ShowColorizedText(
-		fmt.Sprintf("%s whispers to you from %s, \"%s\"",
-			ctx.Character.FormattedNameWithTitle(),
-			c.Room().ParentArea.Name(),
-			normalizedText,
+	c.Player().client.ShowChatText(
+		"whisper",
+		c.Colorize(
+			fmt.Sprintf("%s whispers to you from %s, \"%s\"",
+				ctx.Character.FormattedNameWithTitle(),
+				c.Room().ParentArea.Name(),
+				c.ChatText(filteredText, normalizedText),
+			),
+			ColorWhisper,
 		),
-		ColorWhisper,
 	)
 }
 
@@ -631,32 +914,47 @@ func handleReplyCommand(ctx *CommandContext) {
 }
 
 func handleWhoCommand(ctx *CommandContext) {
-	chars := Armeria.characterManager.OnlineCharacters()
+	var chars []*Character
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if c.VisibleTo(ctx.Character) {
+			chars = append(chars, c)
+		}
+	}
 
-	rows := []string{TableRow(
-		TableCell{content: "Character", header: true},
-		TableCell{content: "Organization", header: true},
-		TableCell{content: "Location", header: true},
-	)}
+	// Staff also see how laggy each connection is.
+	staff := ctx.Character.HasPermission(LatencyPermission)
+
+	headers := []TableCell{
+		{content: "Character", header: true},
+		{content: "Organization", header: true},
+		{content: "Location", header: true},
+	}
+	if staff {
+		headers = append(headers, TableCell{content: "Latency", header: true})
+	}
+	header := TableRow(headers...)
 
+	var rows []string
 	for _, c := range chars {
-		rows = append(rows, TableRow(
-			TableCell{content: fmt.Sprintf("[%d] %s", 0, c.FormattedNameWithTitle())},
-			TableCell{content: fmt.Sprintf(
+		cells := []TableCell{
+			{content: fmt.Sprintf("[%d] %s", 0, c.FormattedNameWithTitle())},
+			{content: fmt.Sprintf(
 				"%s of %s",
 				TextStyle("CEO", WithBold()),
 				"Armeria Industries, Inc.",
 			)},
-			TableCell{content: c.Room().ParentArea.Name()},
-		))
+			{content: c.Room().ParentArea.Name()},
+		}
+		if staff {
+			cells = append(cells, TableCell{content: c.Player().LatencyString()})
+		}
+		rows = append(rows, TableRow(cells...))
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
 	ctx.Player.client.ShowText(
-		fmt.Sprintf("There are %s characters online.",
-			TextStyle(strconv.Itoa(len(chars)), WithBold()),
-		),
+		TrN(ctx.Character, "who.online", len(chars), TextStyle(strconv.Itoa(len(chars)), WithBold())),
 	)
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
 }
 
 func handleCharacterEditCommand(ctx *CommandContext) {
@@ -710,6 +1008,16 @@ func handleCharacterCreateCommand(ctx *CommandContext) {
 		return
 	}
 
+	if ctx.Args["override"] != "override" {
+		if err := Armeria.nameManager.CheckName(charName, nil); err != nil {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The name was rejected: %s. Add \"override\" to create the character anyway.", err),
+				ColorError,
+			)
+			return
+		}
+	}
+
 	Armeria.characterManager.CreateCharacter(charName, charPass)
 
 	ctx.Player.client.ShowColorizedText("The character has been created!", ColorSuccess)
@@ -731,15 +1039,16 @@ func handleCharacterSetCommand(ctx *CommandContext) {
 		return
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeCharacter, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	if !ctx.CheckEditVersion(c.EditorData, attr) {
+		return
 	}
 
-	_ = c.SetAttribute(attr, val)
+	old := c.Attribute(attr)
+	if err := c.SetAttribute(attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("character", c.ID()), attr, old, c.Attribute(attr))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You modified the %s property of the character %s.", TextStyle(attr, WithBold()), c.FormattedName()),
@@ -759,13 +1068,62 @@ func handleCharacterSetCommand(ctx *CommandContext) {
 	}
 }
 
+func handleCharacterMoneyCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	amount := ctx.Args["amount"]
+	sign := amount[0:1]
+	value, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimLeft(amount, "+-"), "$"), 64)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("The amount must be a number.", ColorError)
+		return
+	}
+
+	switch sign {
+	case "+":
+		err = c.AddMoney(value, MoneySourceAdjustments)
+	case "-":
+		err = c.DeductMoney(value, MoneySourceAdjustments)
+	default:
+		err = c.SetMoney(value, MoneySourceAdjustments)
+	}
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The money could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s now has %s.",
+			c.FormattedName(),
+			ctx.Character.Colorize(misc.Money.FormatMoney(c.Money()), ColorMoney),
+		),
+		ColorSuccess,
+	)
+
+	if c.Online() {
+		c.Player().client.SyncMoney()
+		if c.Name() != ctx.Character.Name() {
+			c.Player().client.ShowText(
+				fmt.Sprintf("Your money was modified by %s.", ctx.Character.FormattedName()),
+			)
+		}
+	}
+}
+
 func handleMobListCommand(ctx *CommandContext) {
 	f := ctx.Args["filter"]
 
-	rows := []string{TableRow(
+	header := TableRow(
 		TableCell{content: "Mob", header: true},
 		TableCell{content: "Instances", header: true},
-	)}
+	)
+
+	var rows []string
 
 	for _, m := range Armeria.mobManager.Mobs() {
 		if len(f) == 0 || strings.Contains(strings.ToLower(m.Name()), strings.ToLower(f)) {
@@ -783,7 +1141,7 @@ func handleMobListCommand(ctx *CommandContext) {
 		}
 	}
 
-	if len(f) > 0 && len(rows) == 1 {
+	if len(f) > 0 && len(rows) == 0 {
 		ctx.Player.client.ShowColorizedText(
 			fmt.Sprintf("There are no mobs matching \"%s\".", f),
 			ColorError,
@@ -791,10 +1149,14 @@ func handleMobListCommand(ctx *CommandContext) {
 		return
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
 }
 
 func handleMobCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	n := ctx.Args["name"]
 
 	if Armeria.mobManager.MobByName(n) != nil {
@@ -802,6 +1164,10 @@ func handleMobCreateCommand(ctx *CommandContext) {
 		return
 	}
 
+	if !ctx.UseQuota(QuotaMobs) {
+		return
+	}
+
 	m := Armeria.mobManager.CreateMob(n)
 	Armeria.mobManager.AddMob(m)
 
@@ -811,35 +1177,82 @@ func handleMobCreateCommand(ctx *CommandContext) {
 	)
 }
 
-func handleMobDeleteCommand(ctx *CommandContext) {
-	n := ctx.Args["name"]
+func handleMobCloneCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
 
-	mob := Armeria.mobManager.MobByName(n)
-	if mob == nil {
+	src := Armeria.mobManager.MobByName(ctx.Args["mob"])
+	if src == nil {
 		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
 		return
 	}
 
-	if len(mob.Instances()) > 0 {
-		ctx.Player.client.ShowColorizedText("That mob has one or more instances in the game world and cannot be deleted.", ColorError)
+	n := ctx.Args["name"]
+	if Armeria.mobManager.MobByName(n) != nil {
+		ctx.Player.client.ShowColorizedText("A mob already exists with that name.", ColorError)
 		return
 	}
 
-	Armeria.mobManager.RemoveMob(mob)
+	if !ctx.UseQuota(QuotaMobs) {
+		return
+	}
 
-	ctx.Player.client.ShowColorizedText("The mob has been removed from the game.", ColorSuccess)
-}
+	m := Armeria.mobManager.CloneMob(src, n)
+	Armeria.mobManager.AddMob(m)
 
-func handleMobEditCommand(ctx *CommandContext) {
-	mname := ctx.Args["mob"]
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"A mob named %s has been created as a copy of %s.",
+			TextStyle(n, WithBold()),
+			TextStyle(src.Name(), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
 
-	m := Armeria.mobManager.MobByName(mname)
-	if m == nil {
-		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
+func handleMobDeleteCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
 		return
 	}
 
-	ctx.Player.client.ShowObjectEditor(m.EditorData())
+	n := ctx.Args["name"]
+
+	mob := Armeria.mobManager.MobByName(n)
+	if mob == nil {
+		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
+		return
+	}
+
+	if len(mob.Instances()) > 0 {
+		ctx.Player.client.ShowColorizedText("That mob has one or more instances in the game world and cannot be deleted.", ColorError)
+		return
+	}
+
+	if ctx.DryRun() {
+		ctx.Simulation.Remove(ObjectTypeMob, mob.Name())
+		return
+	}
+
+	Armeria.mobManager.RemoveMob(mob)
+
+	ctx.Player.client.ShowColorizedText("The mob has been removed from the game.", ColorSuccess)
+}
+
+func handleMobEditCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	mname := ctx.Args["mob"]
+
+	m := Armeria.mobManager.MobByName(mname)
+	if m == nil {
+		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowObjectEditor(m.EditorData())
 }
 
 func handleMobInstanceEditCommand(ctx *CommandContext) {
@@ -853,11 +1266,18 @@ func handleMobInstanceEditCommand(ctx *CommandContext) {
 	}
 
 	mi := o.(*MobInstance)
+	if !ctx.CanBuildIn(Armeria.registry.GetArea(mi.ID())) {
+		return
+	}
 
 	ctx.Player.client.ShowObjectEditor(mi.EditorData())
 }
 
 func handleMobSetCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	mob := ctx.Args["mob"]
 	attr := AttributeCasing(ctx.Args["property"])
 	val := ctx.Args["value"]
@@ -873,15 +1293,28 @@ func handleMobSetCommand(ctx *CommandContext) {
 		return
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeMob, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	if !ctx.CheckEditVersion(m.EditorData, attr) {
+		return
+	}
+
+	if ctx.DryRun() {
+		ctx.Simulation.Modify(
+			ObjectTypeMob,
+			m.Name(),
+			attr,
+			m.Attribute(attr),
+			val,
+			ValidateAttribute(ObjectTypeMob, attr, val, m.Attribute),
+		)
+		return
 	}
 
-	m.SetAttribute(attr, val)
+	old := m.Attribute(attr)
+	if err := m.SetAttribute(attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("mob", m.Name()), attr, old, m.Attribute(attr))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You modified the %s property of the mob %s.",
@@ -908,6 +1341,9 @@ func handleMobInstanceSetCommand(ctx *CommandContext) {
 	}
 
 	mi := o.(*MobInstance)
+	if !ctx.CanBuildIn(Armeria.registry.GetArea(mi.ID())) {
+		return
+	}
 	attr := AttributeCasing(ctx.Args["property"])
 	val := ctx.Args["value"]
 
@@ -919,15 +1355,16 @@ func handleMobInstanceSetCommand(ctx *CommandContext) {
 		return
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeMob, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	if !ctx.CheckEditVersion(mi.EditorData, attr) {
+		return
 	}
 
-	_ = mi.SetAttribute(attr, val)
+	old := mi.Attribute(attr)
+	if err := mi.SetAttribute(attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("specific-mob", mi.ID()), attr, old, mi.Attribute(attr))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You modified the %s property of the mob instace %s (%s).",
@@ -945,12 +1382,20 @@ func handleMobInstanceSetCommand(ctx *CommandContext) {
 }
 
 func handleMobSpawnCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	m := Armeria.mobManager.MobByName(ctx.Args["mob"])
 	if m == nil {
 		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
 		return
 	}
 
+	if !ctx.UseQuota(QuotaMobs) {
+		return
+	}
+
 	mi := m.CreateInstance()
 	_ = ctx.Character.Room().Here().Add(mi.ID())
 
@@ -972,6 +1417,8 @@ func handleMobInstancesCommand(ctx *CommandContext) {
 	rows := []string{TableRow(
 		TableCell{content: "Mob", header: true},
 		TableCell{content: "UUID", header: true},
+		TableCell{content: "Health", header: true},
+		TableCell{content: "Damage", header: true},
 		TableCell{content: "Location", header: true},
 	)}
 
@@ -979,6 +1426,8 @@ func handleMobInstancesCommand(ctx *CommandContext) {
 		rows = append(rows, TableRow(
 			TableCell{content: mi.FormattedName()},
 			TableCell{content: mi.ID()},
+			TableCell{content: mi.Attribute(AttributeHealth)},
+			TableCell{content: mi.Attribute(AttributeDamage)},
 			TableCell{
 				content: TextStyle(
 					fmt.Sprintf("%s (%s)", mi.Room().LocationString(), mi.Room().Attribute("title")),
@@ -991,7 +1440,68 @@ func handleMobInstancesCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
+func handleMobStatsCommand(ctx *CommandContext) {
+	m := Armeria.mobManager.MobByName(ctx.Args["mob"])
+	if m == nil {
+		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
+		return
+	}
+
+	stats := m.ScriptStats()
+	if len(stats) == 0 {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("The script for %s hasn't run since it was loaded.", TextStyle(m.Name(), WithBold())),
+		)
+		return
+	}
+
+	funcs := make([]string, 0, len(stats))
+	for f := range stats {
+		funcs = append(funcs, f)
+	}
+	sort.Slice(funcs, func(i, j int) bool {
+		return stats[funcs[i]].Time > stats[funcs[j]].Time
+	})
+
+	rows := []string{TableRow(
+		TableCell{content: "Function", header: true},
+		TableCell{content: "Calls", header: true},
+		TableCell{content: "Errors", header: true},
+		TableCell{content: "Total Time", header: true},
+		TableCell{content: "Average", header: true},
+	)}
+
+	var errs []string
+	for _, f := range funcs {
+		s := stats[f]
+		rows = append(rows, TableRow(
+			TableCell{content: f + "()"},
+			TableCell{content: TextNumber(s.Calls)},
+			TableCell{content: TextNumber(s.Errors)},
+			TableCell{content: s.Time.Round(time.Microsecond).String()},
+			TableCell{content: s.Average().Round(time.Microsecond).String()},
+		))
+		if len(s.LastError) > 0 {
+			errs = append(errs, fmt.Sprintf("%s %s", TextStyle(f+"():", WithBold()), s.LastError))
+		}
+	}
+
+	sections := []string{
+		fmt.Sprintf("Script performance for %s, excluding time spent sleeping:", TextStyle(m.Name(), WithBold())),
+		TextTable(rows...),
+	}
+	if len(errs) > 0 {
+		sections = append(sections, fmt.Sprintf("[b]Most recent errors:[/b]\n%s", strings.Join(errs, "\n")))
+	}
+
+	ctx.Player.client.ShowText(strings.Join(sections, "\n\n"))
+}
+
 func handleWipeCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	filter := ctx.Args["filter"]
 	matches := 0
 
@@ -1003,6 +1513,18 @@ func handleWipeCommand(ctx *CommandContext) {
 			continue
 		}
 
+		if ctx.DryRun() {
+			switch obj.Type() {
+			case ContainerObjectTypeMob:
+				ctx.Simulation.Remove(ObjectTypeMobInstance, obj.FormattedName())
+				matches = matches + 1
+			case ContainerObjectTypeItem:
+				ctx.Simulation.Remove(ObjectTypeItemInstance, obj.FormattedName())
+				matches = matches + 1
+			}
+			continue
+		}
+
 		switch obj.Type() {
 		case ContainerObjectTypeMob:
 			m := Armeria.mobManager.MobByName(obj.Name())
@@ -1024,6 +1546,8 @@ func handleWipeCommand(ctx *CommandContext) {
 	if len(filter) > 0 && matches == 0 {
 		ctx.Player.client.ShowColorizedText("The filter did not match anything in the room.", ColorError)
 		return
+	} else if ctx.DryRun() {
+		return
 	}
 
 	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
@@ -1038,6 +1562,10 @@ func handleWipeCommand(ctx *CommandContext) {
 }
 
 func handleItemCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	n := ctx.Args["name"]
 
 	if Armeria.itemManager.ItemByName(n) != nil {
@@ -1045,6 +1573,10 @@ func handleItemCreateCommand(ctx *CommandContext) {
 		return
 	}
 
+	if !ctx.UseQuota(QuotaItems) {
+		return
+	}
+
 	i := Armeria.itemManager.CreateItem(n)
 	Armeria.itemManager.AddItem(i)
 
@@ -1054,7 +1586,45 @@ func handleItemCreateCommand(ctx *CommandContext) {
 	)
 }
 
+func handleItemCloneCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	src := Armeria.itemManager.ItemByName(ctx.Args["item"])
+	if src == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist.", ColorError)
+		return
+	}
+
+	n := ctx.Args["name"]
+	if Armeria.itemManager.ItemByName(n) != nil {
+		ctx.Player.client.ShowColorizedText("An item already exists with that name.", ColorError)
+		return
+	}
+
+	if !ctx.UseQuota(QuotaItems) {
+		return
+	}
+
+	i := Armeria.itemManager.CloneItem(src, n)
+	Armeria.itemManager.AddItem(i)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"An item named %s has been created as a copy of %s.",
+			TextStyle(n, WithBold()),
+			TextStyle(src.Name(), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
 func handleItemDeleteCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	n := ctx.Args["name"]
 
 	item := Armeria.itemManager.ItemByName(n)
@@ -1068,6 +1638,11 @@ func handleItemDeleteCommand(ctx *CommandContext) {
 		return
 	}
 
+	if ctx.DryRun() {
+		ctx.Simulation.Remove(ObjectTypeItem, item.Name())
+		return
+	}
+
 	Armeria.itemManager.RemoveItem(item)
 
 	ctx.Player.client.ShowColorizedText("The item has been removed from the game.", ColorSuccess)
@@ -1076,11 +1651,13 @@ func handleItemDeleteCommand(ctx *CommandContext) {
 func handleItemListCommand(ctx *CommandContext) {
 	f := ctx.Args["filter"]
 
-	rows := []string{TableRow(
+	header := TableRow(
 		TableCell{content: "Item", header: true},
 		TableCell{content: "Instances", header: true},
 		TableCell{content: "Type", header: true},
-	)}
+	)
+
+	var rows []string
 
 	for _, i := range Armeria.itemManager.Items() {
 		if len(f) == 0 || strings.Contains(strings.ToLower(i.Name()), strings.ToLower(f)) {
@@ -1104,7 +1681,7 @@ func handleItemListCommand(ctx *CommandContext) {
 		}
 	}
 
-	if len(f) > 0 && len(rows) == 1 {
+	if len(f) > 0 && len(rows) == 0 {
 		ctx.Player.client.ShowColorizedText(
 			fmt.Sprintf("There are no items matching \"%s\".", f),
 			ColorError,
@@ -1112,16 +1689,24 @@ func handleItemListCommand(ctx *CommandContext) {
 		return
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
 }
 
 func handleItemSpawnCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	i := Armeria.itemManager.ItemByName(ctx.Args["item"])
 	if i == nil {
 		ctx.Player.client.ShowColorizedText("That item doesn't exist.", ColorError)
 		return
 	}
 
+	if !ctx.UseQuota(QuotaItems) {
+		return
+	}
+
 	ii := i.CreateInstance()
 	_ = ctx.Character.Room().Here().Add(ii.ID())
 
@@ -1134,6 +1719,10 @@ func handleItemSpawnCommand(ctx *CommandContext) {
 }
 
 func handleItemEditCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	i := Armeria.itemManager.ItemByName(ctx.Args["item"])
 	if i == nil {
 		ctx.Player.client.ShowColorizedText("That item doesn't exist.", ColorError)
@@ -1154,11 +1743,18 @@ func handleItemInstanceEditCommand(ctx *CommandContext) {
 	}
 
 	ii := o.(*ItemInstance)
+	if !ctx.CanBuildIn(Armeria.registry.GetArea(ii.ID())) {
+		return
+	}
 
 	ctx.Player.client.ShowObjectEditor(ii.EditorData())
 }
 
 func handleItemSetCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	item := ctx.Args["item"]
 	attr := AttributeCasing(ctx.Args["property"])
 	val := ctx.Args["value"]
@@ -1174,15 +1770,28 @@ func handleItemSetCommand(ctx *CommandContext) {
 		return
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeItem, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	if !ctx.CheckEditVersion(i.EditorData, attr) {
+		return
+	}
+
+	if ctx.DryRun() {
+		ctx.Simulation.Modify(
+			ObjectTypeItem,
+			i.Name(),
+			attr,
+			i.Attribute(attr),
+			val,
+			ValidateAttribute(ObjectTypeItem, attr, val, i.Attribute),
+		)
+		return
 	}
 
-	i.SetAttribute(attr, val)
+	old := i.Attribute(attr)
+	if err := i.SetAttribute(attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("item", i.Name()), attr, old, i.Attribute(attr))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You modified the %s property of the item %s.",
@@ -1213,6 +1822,9 @@ func handleItemInstanceSetCommand(ctx *CommandContext) {
 	}
 
 	ii := o.(*ItemInstance)
+	if !ctx.CanBuildIn(Armeria.registry.GetArea(ii.ID())) {
+		return
+	}
 	attr := AttributeCasing(ctx.Args["property"])
 	val := ctx.Args["value"]
 
@@ -1224,15 +1836,16 @@ func handleItemInstanceSetCommand(ctx *CommandContext) {
 		return
 	}
 
-	if len(val) > 0 {
-		valid := AttributeValidate(ObjectTypeItem, attr, val)
-		if !valid.Result {
-			ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", valid), ColorError)
-			return
-		}
+	if !ctx.CheckEditVersion(ii.EditorData, attr) {
+		return
 	}
 
-	_ = ii.SetAttribute(attr, val)
+	old := ii.Attribute(attr)
+	if err := ii.SetAttribute(attr, val); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The attribute value could not be validated: %s.", err), ColorError)
+		return
+	}
+	ctx.RecordEdit(editKey("specific-item", ii.ID()), attr, old, ii.Attribute(attr))
 
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You modified the %s property of the item instace %s (%s).",
@@ -1307,12 +1920,39 @@ func handleItemInstancesCommand(ctx *CommandContext) {
 					content: fmt.Sprintf("Mob: %s (%s)", ii.MobInstance().FormattedName(), ii.MobInstance().ID()),
 				},
 			))
+		} else if ctr.ParentType() == ContainerParentTypeQuarantine {
+			rows = append(rows, TableRow(
+				TableCell{content: ii.FormattedName()},
+				TableCell{content: ii.ID()},
+				TableCell{
+					content: TextStyle("Quarantined", WithLinkCmd("/admin quarantine list")),
+				},
+			))
 		}
 	}
 
 	ctx.Player.client.ShowText(TextTable(rows...))
 }
 
+func handleInvisibleCommand(ctx *CommandContext) {
+	if ctx.Character.Invisible() {
+		ctx.Character.SetTempAttribute(TempAttributeInvisible, "")
+		ctx.Player.client.ShowColorizedText("You are now visible to everyone.", ColorSuccess)
+	} else {
+		ctx.Character.SetTempAttribute(TempAttributeInvisible, "1")
+		ctx.Player.client.ShowColorizedText("You are now invisible to players.", ColorSuccess)
+	}
+
+	Armeria.log.Info("character toggled invisibility",
+		zap.String("character", ctx.Character.Name()),
+		zap.Bool("invisible", ctx.Character.Invisible()),
+	)
+
+	for _, c := range ctx.Character.Room().Here().Characters(true) {
+		c.Player().client.SyncRoomObjects()
+	}
+}
+
 func handleGhostCommand(ctx *CommandContext) {
 	if len(ctx.Character.TempAttribute(TempAttributeGhost)) > 0 {
 		ctx.Character.SetTempAttribute(TempAttributeGhost, "")
@@ -1324,6 +1964,10 @@ func handleGhostCommand(ctx *CommandContext) {
 }
 
 func handleAreaCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	n := ctx.Args["name"]
 
 	if Armeria.worldManager.AreaByName(n) != nil {
@@ -1380,6 +2024,10 @@ func handleAreaEditCommand(ctx *CommandContext) {
 		}
 	}
 
+	if !ctx.CanBuildIn(a) {
+		return
+	}
+
 	ctx.Player.client.ShowObjectEditor(a.EditorData())
 }
 
@@ -1390,12 +2038,19 @@ func handlePasswordCommand(ctx *CommandContext) {
 }
 
 func handleTeleportCommand(ctx *CommandContext) {
-	t := ctx.Args["destination"]
+	t := strings.TrimSpace(ctx.Args["destination"])
 
 	charToMove := ctx.Character
 	var destination *Room
 	var moveMsg string
-	if t[0:2] == "@@" {
+	if strings.ToLower(t) == "back" {
+		destination = ctx.Character.PopTeleportHistory()
+		if destination == nil {
+			ctx.Player.client.ShowColorizedText("You haven't teleported anywhere to go back from.", ColorError)
+			return
+		}
+		moveMsg = "You teleported back to where you were."
+	} else if strings.HasPrefix(t, "@@") {
 		cn := t[2:]
 		c := Armeria.characterManager.CharacterByName(cn)
 		if c == nil {
@@ -1406,38 +2061,43 @@ func handleTeleportCommand(ctx *CommandContext) {
 		destination = ctx.Character.Room()
 		moveMsg = fmt.Sprintf("You were teleported far away by %s!", ctx.Character.FormattedName())
 		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You summoned %s here.", c.FormattedName()), ColorMovement)
-	} else if t[0:1] == "@" {
-		cn := t[1:]
-		c := Armeria.characterManager.CharacterByName(cn)
-		if c == nil {
-			ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
-			return
-		} else if !c.Online() {
+	} else if c := teleportCharacter(t); c != nil {
+		if !c.Online() {
 			ctx.Player.client.ShowColorizedText("That character is not online.", ColorError)
 			return
 		}
 
 		destination = c.Room()
 		moveMsg = fmt.Sprintf("You teleported to %s.", c.FormattedName())
+	} else if strings.HasPrefix(t, "@") {
+		ctx.Player.client.ShowColorizedText("There is no character by that name.", ColorError)
+		return
 	} else {
-		loc := strings.Split(t, ",")
+		// Coordinates can be written as "area,x,y,z" or "area x,y,z", and default to 0,0,0.
+		var loc []string
+		if i := strings.LastIndex(t, " "); i > -1 && len(strings.Split(t[i+1:], ",")) == 3 {
+			loc = append([]string{t[:i]}, strings.Split(t[i+1:], ",")...)
+		} else {
+			loc = strings.Split(t, ",")
+		}
+
 		if len(loc) == 1 {
 			loc = append(loc, "0", "0", "0")
 		} else if len(loc) != 4 {
-			ctx.Player.client.ShowColorizedText("Incorrect format for teleport. Use [area],[x],[y],[z].", ColorError)
+			ctx.Player.client.ShowColorizedText("Incorrect format for teleport. Use [area] [x],[y],[z].", ColorError)
 			return
 		}
 
-		a := Armeria.worldManager.AreaByName(loc[0])
+		a := Armeria.worldManager.AreaByName(strings.TrimSpace(loc[0]))
 		if a == nil {
-			ctx.Player.client.ShowColorizedText("That is not a valid area.", ColorError)
+			ctx.Player.client.ShowColorizedText("That is not a valid area or character.", ColorError)
 			return
 		}
 
 		var x, y, z int
-		x, xerr := strconv.Atoi(loc[1])
-		y, yerr := strconv.Atoi(loc[2])
-		z, zerr := strconv.Atoi(loc[3])
+		x, xerr := strconv.Atoi(strings.TrimSpace(loc[1]))
+		y, yerr := strconv.Atoi(strings.TrimSpace(loc[2]))
+		z, zerr := strconv.Atoi(strings.TrimSpace(loc[3]))
 		if xerr != nil || yerr != nil || zerr != nil {
 			ctx.Player.client.ShowColorizedText("The x, y, and z coordinate must be a valid number.", ColorError)
 			return
@@ -1452,6 +2112,24 @@ func handleTeleportCommand(ctx *CommandContext) {
 		return
 	}
 
+	origin := charToMove.Room()
+	if origin == destination {
+		ctx.Player.client.ShowColorizedText("You're already there.", ColorError)
+		return
+	}
+
+	Armeria.log.Info("character teleported",
+		zap.String("by", ctx.Character.Name()),
+		zap.String("character", charToMove.Name()),
+		zap.String("from", origin.LocationString()),
+		zap.String("to", destination.LocationString()),
+	)
+
+	// Going back doesn't add to the history, so that /tp back can be repeated to retrace each jump.
+	if charToMove == ctx.Character && strings.ToLower(t) != "back" {
+		ctx.Character.PushTeleportHistory(origin)
+	}
+
 	charToMove.Move(
 		destination,
 		TextStyle(moveMsg, WithUserColor(charToMove, ColorMovement)),
@@ -1465,6 +2143,17 @@ func handleTeleportCommand(ctx *CommandContext) {
 	}
 }
 
+// teleportCharacter returns the character a teleport destination refers to, written as "@name" or just the name.
+// Areas take priority over characters that happen to share their name.
+func teleportCharacter(destination string) *Character {
+	if strings.HasPrefix(destination, "@") {
+		return Armeria.characterManager.CharacterByName(destination[1:])
+	} else if Armeria.worldManager.AreaByName(destination) != nil {
+		return nil
+	}
+	return Armeria.characterManager.CharacterByName(destination)
+}
+
 func handleCommandsCommand(ctx *CommandContext) {
 	var valid []*Command
 	var largest int
@@ -1478,11 +2167,12 @@ func handleCommandsCommand(ctx *CommandContext) {
 		}
 	}
 
-	rows := []string{TableRow(
+	header := TableRow(
 		TableCell{content: "Command", header: true},
 		TableCell{content: "Description", header: true},
-	)}
+	)
 
+	var rows []string
 	for _, cmd := range valid {
 		rows = append(rows, TableRow(
 			TableCell{content: TextStyle("/"+cmd.Name, WithBold()), styling: "padding:0px 2px"},
@@ -1490,7 +2180,13 @@ func handleCommandsCommand(ctx *CommandContext) {
 		))
 	}
 
-	ctx.Player.client.ShowColorizedText(TextTable(rows...), ColorCmdHelp)
+	pages := TablePages(header, rows, PagerPageSize)
+	if ctx.Character != nil {
+		for i := range pages {
+			pages[i] = ctx.Character.Colorize(pages[i], ColorCmdHelp)
+		}
+	}
+	ctx.Player.ShowPages(pages)
 }
 
 func handleClipboardCopyCommand(ctx *CommandContext) {
@@ -1536,6 +2232,10 @@ func handleClipboardCopyCommand(ctx *CommandContext) {
 }
 
 func handleClipboardPasteCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
 	n := ctx.Args["name"]
 
 	cbt := ctx.Character.TempAttribute("clipboard_type")
@@ -1559,7 +2259,7 @@ func handleClipboardPasteCommand(ctx *CommandContext) {
 		// paste room attributes
 		for _, attr := range cba {
 			attrValue := ctx.Character.TempAttribute("clipboard_attribute_" + attr)
-			r.SetAttribute(attr, attrValue)
+			_ = r.SetDraftAttribute(attr, attrValue)
 		}
 		ctx.Player.client.ShowColorizedText("Room attributes on the clipboard have been applied.", ColorSuccess)
 		ctx.Player.client.SyncMap()
@@ -1584,11 +2284,37 @@ func handleClipboardClearCommand(ctx *CommandContext) {
 
 func handleGetCommand(ctx *CommandContext) {
 	searchString := ctx.Args["item"]
-
 	roomObjects := ctx.Character.Room().Here()
+
+	// Picking up a group of items (ie: all.coin) skips over anything that can't be picked up.
+	if _, all, _ := ParseTargetSelector(searchString); all {
+		picked := 0
+		for _, result := range roomObjects.GetAllLoose(searchString) {
+			if result.Type != RegistryTypeItemInstance {
+				continue
+			}
+			item := result.Object.(*ItemInstance)
+			if !canPickUp(ctx.Character, item) {
+				continue
+			}
+			if !pickUpItem(ctx, roomObjects, item) {
+				break
+			}
+			picked++
+		}
+
+		if picked == 0 {
+			ctx.Player.client.ShowColorizedText("There's nothing here like that to pick up.", ColorError)
+		}
+		return
+	}
+
 	result := roomObjects.GetLoose(searchString)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	} else if result.Type != RegistryTypeItemInstance {
 		ctx.Player.client.ShowColorizedText("You cannot pick that up.", ColorError)
@@ -1596,24 +2322,59 @@ func handleGetCommand(ctx *CommandContext) {
 	}
 
 	item := result.Object.(*ItemInstance)
-
-	if item.Attribute(AttributeHoldable) == "false" {
+	if item.IsQuestItem() && !item.PhasedVisibleTo(ctx.Character) {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+		return
+	} else if !canPickUp(ctx.Character, item) {
 		ctx.Player.client.ShowColorizedText("You are not able to pick that up.", ColorError)
 		return
 	}
 
-	err := ctx.Character.Inventory().Add(item.ID())
-	if err == ErrContainerNoRoom {
-		ctx.Player.client.ShowColorizedText("You have no room in your inventory.", ColorError)
-		return
-	} else if err == ErrContainerDuplicate {
-		ctx.Player.client.ShowColorizedText("You already have that item instance in your inventory.", ColorError)
-		return
+	pickUpItem(ctx, roomObjects, item)
+}
+
+// canPickUp returns true if the Character is able to pick up an item lying in a room.
+func canPickUp(c *Character, item *ItemInstance) bool {
+	if item.Attribute(AttributeHoldable) == "false" || item.Attribute(AttributeType) == ItemTypeVehicle {
+		return false
 	}
-	roomObjects.Remove(item.ID())
 
-	ctx.Player.client.SyncRoomObjects()
-	ctx.Player.client.SyncInventory()
+	return !item.IsQuestItem() || item.PhasedVisibleTo(c)
+}
+
+// pickUpItem moves an item from the room into the character's inventory. It returns false if the item couldn't be
+// picked up, after telling the player why.
+func pickUpItem(ctx *CommandContext, roomObjects *ObjectContainer, item *ItemInstance) bool {
+	// Quest items in rooms are phased, so everyone on the quest picks up their own copy.
+	if item.IsQuestItem() {
+		copied, err := ctx.Character.TakePhasedItem(item)
+		if err != nil {
+			ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't pick that up: %s.", err), ColorError)
+			return false
+		}
+
+		ctx.Player.client.SyncRoomObjects()
+		ctx.Player.client.SyncInventory()
+		ctx.Player.client.PlaySFX(sfx.PickupItem)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You picked up a %s.", copied.FormattedName()),
+			ColorSuccess,
+		)
+		return true
+	}
+
+	err := ctx.Character.Inventory().Add(item.ID())
+	if err == ErrContainerNoRoom {
+		ctx.Player.client.ShowColorizedText("You have no room in your inventory.", ColorError)
+		return false
+	} else if err == ErrContainerDuplicate {
+		ctx.Player.client.ShowColorizedText("You already have that item instance in your inventory.", ColorError)
+		return false
+	}
+	roomObjects.Remove(item.ID())
+
+	ctx.Player.client.SyncRoomObjects()
+	ctx.Player.client.SyncInventory()
 	ctx.Player.client.PlaySFX(sfx.PickupItem)
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You picked up a %s.", item.FormattedName()),
@@ -1626,19 +2387,51 @@ func handleGetCommand(ctx *CommandContext) {
 			fmt.Sprintf("%s picked up a %s.", ctx.Character.FormattedName(), item.FormattedName()),
 		)
 	}
+
+	return true
 }
 
 func handleDropCommand(ctx *CommandContext) {
 	searchString := ctx.Args["item"]
 
+	// Dropping a group of items (ie: all.coin) leaves quest items behind.
+	if _, all, _ := ParseTargetSelector(searchString); all {
+		dropped := 0
+		for _, result := range ctx.Character.Inventory().GetAllLoose(searchString) {
+			item := result.Object.(*ItemInstance)
+			if item.IsQuestItem() {
+				continue
+			}
+			dropItem(ctx, item)
+			dropped++
+		}
+
+		if dropped == 0 {
+			ctx.Player.client.ShowColorizedText("You aren't carrying anything like that to drop.", ColorError)
+		}
+		return
+	}
+
 	result := ctx.Character.Inventory().GetLoose(searchString)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonItemNotFoundOnCharacter), ColorError)
 		return
 	}
 
 	item := result.Object.(*ItemInstance)
+	if item.IsQuestItem() {
+		ctx.Player.client.ShowColorizedText("You can't drop a quest item.", ColorError)
+		return
+	}
+
+	dropItem(ctx, item)
+}
 
+// dropItem moves an item from the character's inventory into the room.
+func dropItem(ctx *CommandContext, item *ItemInstance) {
 	ctx.Character.Inventory().Remove(item.ID())
 	_ = ctx.Character.Room().Here().Add(item.ID())
 
@@ -1792,6 +2585,62 @@ func handleChannelSayCommand(ctx *CommandContext) {
 	ch.Broadcast(ctx.Character, sayText)
 }
 
+func handleChannelRecentCommand(ctx *CommandContext) {
+	ch := ChannelByName(ctx.Args["channel"])
+	if ch == nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid channel name.", ColorError)
+		return
+	}
+
+	messages := Armeria.channelLog.Recent(ch)
+	if len(messages) == 0 {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("There have been no messages on the %s channel in the last %s.",
+				TextStyle(ch.Name, WithBold()),
+				TextDuration(ChannelLogWindow),
+			),
+		)
+		return
+	}
+
+	header := TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Sent", header: true},
+		TableCell{content: "Message", header: true},
+		TableCell{content: "", header: true},
+	)
+
+	var rows []string
+	for _, msg := range messages {
+		rows = append(rows, TableRow(
+			TableCell{content: msg.ID},
+			TableCell{content: msg.Character},
+			TableCell{content: msg.Time.Format("15:04")},
+			TableCell{content: TextStylePlayer(msg.Text)},
+			TableCell{content: TextStyle("retract", WithLinkCmd(fmt.Sprintf("/channel retract %s", msg.ID)))},
+		))
+	}
+
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
+}
+
+func handleChannelRetractCommand(ctx *CommandContext) {
+	msg, err := Armeria.channelLog.Retract(ctx.Args["id"], ctx.Character)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You retracted the message %s sent to the %s channel.",
+			TextStyle(msg.Character, WithBold()),
+			TextStyle(msg.Channel.Name, WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
 func handleChannelShorthandSayCommand(ctx *CommandContext) {
 	Armeria.commandManager.ProcessCommand(
 		ctx.Player,
@@ -1827,7 +2676,16 @@ func handleSettingsCommand(ctx *CommandContext) {
 				TableCell{content: SettingDefault(s), styling: "padding:0px 2px;color:#666"},
 			))
 		}
-		ctx.Player.client.ShowText(TextTable(rows...))
+		ctx.Player.client.ShowText(
+			fmt.Sprintf(
+				"%s\nUse %s to customize your color palette.",
+				TextTable(rows...),
+				TextStyle("/settings colors", WithLinkCmd("/settings colors")),
+			),
+		)
+		return
+	} else if setting == "colors" {
+		handleSettingsColors(ctx, strings.Fields(value))
 		return
 	} else if !misc.Contains(ValidSettings(), setting) {
 		ctx.Player.client.ShowColorizedText(
@@ -1890,6 +2748,76 @@ func handleSettingsCommand(ctx *CommandContext) {
 
 }
 
+// handleSettingsColors views or changes the Character's color palette. Valid arguments are a color name and a
+// hex color, "preset" and a preset name, or "reset".
+func handleSettingsColors(ctx *CommandContext, args []string) {
+	if len(args) == 0 {
+		rows := []string{TableRow(
+			TableCell{content: "Color", header: true},
+			TableCell{content: "Current", header: true},
+			TableCell{content: "Default", header: true},
+		)}
+		for _, n := range PaletteColorNames() {
+			color, _ := PaletteColorByName(n)
+			rows = append(rows, TableRow(
+				TableCell{content: TextStyle(n, WithUserColor(ctx.Character, color))},
+				TableCell{content: ctx.Character.UserColor(color), styling: "padding:0px 2px"},
+				TableCell{content: PalettePresetByName("default").Colors[color], styling: "padding:0px 2px;color:#666"},
+			))
+		}
+
+		var presets []string
+		for _, p := range palettePresets {
+			presets = append(presets, fmt.Sprintf(
+				"%s - %s",
+				TextStyle(p.Name, WithLinkCmd(fmt.Sprintf("/settings colors preset %s", p.Name))),
+				p.Description,
+			))
+		}
+
+		ctx.Player.client.ShowText(
+			fmt.Sprintf(
+				"%s\nUse /settings colors [color] [#hex] to change a color, or apply a preset:\n%s",
+				TextTable(rows...),
+				strings.Join(presets, "\n"),
+			),
+		)
+		return
+	}
+
+	palette := ctx.Character.Palette()
+	switch strings.ToLower(args[0]) {
+	case "reset":
+		palette = map[int]string{}
+	case "preset":
+		if len(args) != 2 || PalettePresetByName(args[1]) == nil {
+			ctx.Player.client.ShowColorizedText("That's not a valid color preset.", ColorError)
+			return
+		}
+		palette = map[int]string{}
+		for color, hex := range PalettePresetByName(args[1]).Colors {
+			palette[color] = hex
+		}
+	default:
+		color, ok := PaletteColorByName(args[0])
+		if !ok {
+			ctx.Player.client.ShowColorizedText("That's not a valid color name.", ColorError)
+			return
+		} else if len(args) != 2 {
+			ctx.Player.client.ShowColorizedText("You must specify a hex color, such as #ff0000.", ColorError)
+			return
+		}
+		palette[color] = args[1]
+	}
+
+	if err := ctx.Character.SetPalette(palette); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Your colors could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText("Your color palette has been updated.", ColorSuccess)
+}
+
 func handleBugCommand(ctx *CommandContext) {
 	bug := ctx.Args["bug"]
 
@@ -1930,15 +2858,26 @@ func handleGiveCommand(ctx *CommandContext) {
 	item := ctx.Args["item"]
 
 	ctr := ctx.Character.Room().Here()
-	targetResult := ctr.GetByAny(target)
+	targetResult := ctr.GetLoose(target)
+	if ctx.ShowAmbiguousTarget(targetResult) {
+		return
+	}
 	if targetResult.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	}
 
-	itemResult := ctx.Character.Inventory().GetByAny(item)
+	if strings.HasPrefix(item, "$") {
+		giveMoney(ctx, targetResult, item)
+		return
+	}
+
+	itemResult := ctx.Character.Inventory().GetLoose(item)
+	if ctx.ShowAmbiguousTarget(itemResult) {
+		return
+	}
 	if itemResult.Type != RegistryTypeItemInstance {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonItemNotFoundOnCharacter), ColorError)
 		return
 	}
 
@@ -1971,6 +2910,9 @@ func handleGiveCommand(ctx *CommandContext) {
 	} else if targetResult.Object.ID() == ctx.Character.ID() {
 		ctx.Player.client.ShowColorizedText("You cannot give things to yourself.", ColorError)
 		return
+	} else if targetResult.Type == RegistryTypeCharacter && itemResult.Object.(*ItemInstance).IsQuestItem() {
+		ctx.Player.client.ShowColorizedText("Quest items can't be traded to other characters.", ColorError)
+		return
 	}
 
 	// set the target object container
@@ -2036,6 +2978,12 @@ func handleGiveCommand(ctx *CommandContext) {
 		)
 		targetResult.Object.(*Character).Player().client.SyncInventory()
 	} else if targetResult.Type == RegistryTypeMobInstance {
+		if quest := ctx.Character.CompleteDelivery(ii, targetResult.Object.(*MobInstance)); len(quest) > 0 {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("You made the delivery for the %s quest in time.", TextStyle(quest, WithBold())),
+				ColorSuccess,
+			)
+		}
 		go CallMobFunc(
 			ctx.Character,
 			targetResult.Object.(*MobInstance),
@@ -2060,20 +3008,219 @@ func handleGiveCommand(ctx *CommandContext) {
 	}
 }
 
+// giveMoney handles giving an amount of money to another character with /give.
+func giveMoney(ctx *CommandContext, targetResult *ObjectContainerResult, amount string) {
+	if targetResult.Type != RegistryTypeCharacter {
+		ctx.Player.client.ShowColorizedText("You can only give money to other characters.", ColorError)
+		return
+	}
+
+	money, err := ParseMoney(amount)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't give that: %s.", err), ColorError)
+		return
+	}
+
+	target := targetResult.Object.(*Character)
+	if !target.Online() {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+		return
+	}
+	if err := ctx.Character.TransferMoney(target, money); err == ErrInsufficientFunds {
+		ctx.Player.client.ShowColorizedText("You don't have that much money.", ColorError)
+		return
+	} else if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't give that: %s.", err), ColorError)
+		return
+	}
+
+	Armeria.log.Info("money given",
+		zap.String("from", ctx.Character.Name()),
+		zap.String("to", target.Name()),
+		zap.Float64("amount", money),
+	)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You gave %s %s.",
+			target.FormattedName(),
+			ctx.Character.Colorize(misc.Money.FormatMoney(money), ColorMoney),
+		),
+		ColorSuccess,
+	)
+	ctx.Player.client.SyncMoney()
+
+	target.Player().client.ShowText(
+		fmt.Sprintf(
+			"%s gave you %s.",
+			ctx.Character.FormattedName(),
+			target.Colorize(misc.Money.FormatMoney(money), ColorMoney),
+		),
+	)
+	target.Player().client.SyncMoney()
+
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character, target) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s gave %s some money.", ctx.Character.FormattedName(), target.FormattedName()),
+		)
+	}
+}
+
 func handleEmoteCommand(ctx *CommandContext) {
-	emotion := ctx.Args["emote"]
+	emotion := TextStylePlayer(ctx.Args["emote"])
 
 	if emotion[len(emotion)-1:] == "." {
 		emotion = emotion[:len(emotion)-1]
 	}
 
 	for _, c := range ctx.Character.Room().Here().Characters(true) {
-		c.Player().client.ShowText(
-			fmt.Sprintf("%s %s.", ctx.Character.FormattedName(), emotion),
+		c.Player().client.ShowChatText(
+			"emote",
+			fmt.Sprintf("%s %s.", ctx.Character.FormattedName(), SubstitutePronouns(emotion, ctx.Character)),
+		)
+	}
+}
+
+func handleAppearanceCommand(ctx *CommandContext) {
+	props := []string{AttributePronouns, AttributeSpecies, AttributeDescription}
+	attr := AttributeCasing(ctx.Args["property"])
+
+	if len(attr) == 0 {
+		rows := []string{TableRow(
+			TableCell{content: "Property", header: true},
+			TableCell{content: "Value", header: true},
+		)}
+		for _, p := range props {
+			rows = append(rows, TableRow(
+				TableCell{content: p},
+				TableCell{content: TextStylePlayer(ctx.Character.Attribute(p))},
+			))
+		}
+
+		ctx.Player.client.ShowText(
+			fmt.Sprintf(
+				"Your appearance:\n%s\nPronouns can be one of: %s.",
+				TextTable(rows...),
+				strings.Join(PronounSetNames(), ", "),
+			),
+		)
+		return
+	}
+
+	if !misc.Contains(props, attr) {
+		ctx.Player.client.ShowColorizedText("That's not part of your appearance.", ColorError)
+		return
+	}
+
+	if err := ctx.Character.SetAttribute(attr, ctx.Args["value"]); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Your appearance could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You changed the %s of your appearance.", TextStyle(attr, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleBiographyShowCommand(ctx *CommandContext) {
+	bio := ctx.Character.Biography()
+	if len(bio) == 0 {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("You haven't written a biography. Use %s to start one.", TextStyle("/biography add", WithBold())),
+		)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "Paragraph", header: true},
+	)}
+	for i, p := range bio {
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(i + 1)},
+			TableCell{content: TextStylePlayer(p)},
+		))
+	}
+
+	text := TextTable(rows...)
+	if by := ctx.Character.BiographyHidden(); len(by) > 0 {
+		text = fmt.Sprintf(
+			"%s\n%s",
+			text,
+			TextStyle(fmt.Sprintf("Your biography was hidden from others by %s.", by), WithItalics()),
+		)
+	}
+
+	ctx.Player.client.ShowText(text)
+}
+
+func handleBiographyAddCommand(ctx *CommandContext) {
+	if err := ctx.Character.AddBiographyParagraph(ctx.Args["text"]); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Your biography wasn't changed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText("You added a paragraph to your biography.", ColorSuccess)
+}
+
+func handleBiographyRemoveCommand(ctx *CommandContext) {
+	index, err := strconv.Atoi(ctx.Args["paragraph"])
+	if err == nil {
+		err = ctx.Character.RemoveBiographyParagraph(index - 1)
+	}
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("There is no paragraph with that number.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText("You removed the paragraph from your biography.", ColorSuccess)
+}
+
+func handleBiographyClearCommand(ctx *CommandContext) {
+	ctx.Character.ClearBiography()
+	ctx.Player.client.ShowColorizedText("You cleared your biography.", ColorSuccess)
+}
+
+func handleBiographyHideCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	c.SetBiographyHidden(ctx.Character)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The biography of %s is now hidden from others.", c.FormattedName()),
+		ColorSuccess,
+	)
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			"Staff hid your biography from others, because of its content. Edit it, then petition staff to show it again.",
+			ColorError,
 		)
 	}
 }
 
+func handleBiographyUnhideCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	c.SetBiographyHidden(nil)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The biography of %s is visible to others again.", c.FormattedName()),
+		ColorSuccess,
+	)
+	if c.Online() {
+		c.Player().client.ShowColorizedText("Staff made your biography visible to others again.", ColorSuccess)
+	}
+}
+
 func handleLedgerListCommand(ctx *CommandContext) {
 	rows := []string{TableRow(
 		TableCell{content: "Ledger", header: true},
@@ -2091,6 +3238,10 @@ func handleLedgerListCommand(ctx *CommandContext) {
 }
 
 func handleLedgerCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	name := ctx.Args["name"]
 
 	if strings.Contains(name, " ") {
@@ -2111,6 +3262,10 @@ func handleLedgerCreateCommand(ctx *CommandContext) {
 }
 
 func handleLedgerRenameCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	ledgerName := ctx.Args["ledger_name"]
 	newName := ctx.Args["new_name"]
 
@@ -2131,6 +3286,10 @@ func handleLedgerRenameCommand(ctx *CommandContext) {
 }
 
 func handleLedgerAddCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	ledgerName := ctx.Args["ledger_name"]
 	itemName := ctx.Args["item_name"]
 
@@ -2161,6 +3320,10 @@ func handleLedgerAddCommand(ctx *CommandContext) {
 }
 
 func handleLedgerRemoveCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	ledgerName := ctx.Args["ledger_name"]
 	itemName := ctx.Args["item_name"]
 
@@ -2190,24 +3353,54 @@ func handleLedgerShowCommand(ctx *CommandContext) {
 		return
 	}
 
-	rows := []string{TableRow(
-		TableCell{content: "Item", header: true},
-		TableCell{content: "Buy", header: true},
-		TableCell{content: "Sell", header: true},
-	)}
+	pricing := ledger.Pricing()
+	header := []TableCell{
+		{content: "Item", header: true},
+		{content: "Buy", header: true},
+		{content: "Sell", header: true},
+		{content: "Requires", header: true},
+		{content: "Stock", header: true},
+	}
+	if pricing != nil {
+		header = append(header, TableCell{content: "Supply", header: true})
+	}
+	rows := []string{TableRow(header...)}
 
 	for _, entry := range ledger.Entries() {
-		rows = append(rows, TableRow(
-			TableCell{content: entry.ItemName},
-			TableCell{content: misc.Money.FormatMoney(entry.BuyPrice)},
-			TableCell{content: misc.Money.FormatMoney(entry.SellPrice)},
-		))
+		buy := misc.Money.FormatMoney(entry.BuyPrice)
+		sell := misc.Money.FormatMoney(entry.SellPrice)
+		if pricing != nil {
+			buy = fmt.Sprintf("%s (now %s)", buy, misc.Money.FormatMoney(ledger.BuyPrice(entry)))
+			sell = fmt.Sprintf("%s (now %s)", sell, misc.Money.FormatMoney(ledger.SellPrice(entry)))
+		}
+		stock := ""
+		if entry.Stock > 0 {
+			stock = fmt.Sprintf("%d (restocks in %s)", entry.Stock, TextDuration(time.Duration(entry.Restock)*time.Second))
+		}
+		row := []TableCell{
+			{content: entry.ItemName},
+			{content: buy},
+			{content: sell},
+			{content: entry.Requires},
+			{content: stock},
+		}
+		if pricing != nil {
+			row = append(row, TableCell{content: fmt.Sprintf("%.1f", ledger.Supply(entry))})
+		}
+		rows = append(rows, TableRow(row...))
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
-}
-
-func handleLedgerSearchCommand(ctx *CommandContext) {
+	if pricing != nil {
+		ctx.Player.client.ShowText(
+			fmt.Sprintf("Prices drift with supply (%s).\n%s", pricing, TextTable(rows...)),
+		)
+		return
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleLedgerSearchCommand(ctx *CommandContext) {
 	itemName := ctx.Args["item_name"]
 
 	matches := make(map[string][]string)
@@ -2243,6 +3436,10 @@ func handleLedgerSearchCommand(ctx *CommandContext) {
 }
 
 func handleLedgerSetCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
 	buyOrSell := strings.ToLower(ctx.Args["buy_or_sell"])
 	ledgerName := ctx.Args["ledger_name"]
 	itemName := ctx.Args["item_name"]
@@ -2280,14 +3477,146 @@ func handleLedgerSetCommand(ctx *CommandContext) {
 	ctx.Player.client.ShowColorizedText("The price has been set on the ledger.", ColorSuccess)
 }
 
+func handleLedgerRequireCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	ledgerName := ctx.Args["ledger_name"]
+	itemName := ctx.Args["item_name"]
+	requires := ctx.Args["requirements"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	entry := ledger.Contains(itemName)
+	if entry == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+		return
+	}
+
+	if _, err := ParseRequirements(requires); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Those requirements are invalid: %s.", err), ColorError)
+		return
+	}
+
+	entry.Requires = requires
+
+	if len(requires) == 0 {
+		ctx.Player.client.ShowColorizedText("Anyone can now buy that item from the ledger.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The requirements have been set on the ledger.", ColorSuccess)
+	}
+}
+
+func handleLedgerStockCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	ledgerName := ctx.Args["ledger_name"]
+	itemName := ctx.Args["item_name"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	entry := ledger.Contains(itemName)
+	if entry == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist on that ledger.", ColorError)
+		return
+	}
+
+	stock, err := strconv.Atoi(ctx.Args["stock"])
+	if err != nil || stock < 0 {
+		ctx.Player.client.ShowColorizedText("The stock must be a positive number.", ColorError)
+		return
+	}
+
+	restock := 300
+	if len(ctx.Args["restock"]) > 0 {
+		restock, err = strconv.Atoi(ctx.Args["restock"])
+		if err != nil || restock < 1 || restock > VendorMaxRestock {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The restock time must be from 1 to %d seconds.", VendorMaxRestock),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	ledger.Lock()
+	entry.Stock = stock
+	entry.Restock = restock
+	if stock == 0 {
+		entry.Restock = 0
+	}
+	ledger.Unlock()
+
+	if stock == 0 {
+		ctx.Player.client.ShowColorizedText("Vendors will never run out of that item.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"Vendors will hold %d of that item, each restocking %s after it's bought.",
+				stock,
+				TextDuration(time.Duration(restock)*time.Second),
+			),
+			ColorSuccess,
+		)
+	}
+}
+
+func handleLedgerPricingCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	ledgerName := ctx.Args["ledger_name"]
+	rules := ctx.Args["rules"]
+
+	ledger := Armeria.ledgerManager.LedgerByName(ledgerName)
+	if ledger == nil {
+		ctx.Player.client.ShowColorizedText("A ledger by that name doesn't exist.", ColorError)
+		return
+	}
+
+	if len(rules) == 0 {
+		ledger.SetPricing(nil)
+		ctx.Player.client.ShowColorizedText("The prices on the ledger are now fixed.", ColorSuccess)
+		return
+	}
+
+	pricing, err := ParseLedgerPricing(rules)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Those pricing rules are invalid: %s.", err), ColorError)
+		return
+	}
+
+	ledger.SetPricing(pricing)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The prices on the ledger now drift with supply (%s).", pricing),
+		ColorSuccess,
+	)
+}
+
 func handleBuyCommand(ctx *CommandContext) {
 	mobName := ctx.Args["npc"]
 	itemName := ctx.Args["item"]
 
 	// Ensure mob is present in the room
-	result := ctx.Character.Room().Here().GetByName(mobName)
+	result := ctx.Character.Room().Here().GetLoose(mobName)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	}
 	mobInstance := result.Object.(*MobInstance)
@@ -2295,10 +3624,12 @@ func handleBuyCommand(ctx *CommandContext) {
 	// Ensure mob is aware of a ledger that contains the item
 	var item *ItemInstance
 	var itemLedger *LedgerEntry
-	for _, ledger := range mobInstance.ItemLedgers() {
+	var itemLedgerOwner *Ledger
+	for _, ledger := range mobInstance.VendorLedgers() {
 		ledgerEntry := ledger.Contains(itemName)
 		if ledgerEntry != nil {
 			itemLedger = ledgerEntry
+			itemLedgerOwner = ledger
 			mobInstance.Inventory().PopulateFromLedger(ledger)
 			if result := mobInstance.Inventory().GetByName(ledgerEntry.ItemName); result.Type == RegistryTypeItemInstance {
 				item = result.Object.(*ItemInstance)
@@ -2306,19 +3637,31 @@ func handleBuyCommand(ctx *CommandContext) {
 			}
 		}
 	}
-	if item == nil || itemLedger == nil || itemLedger.BuyPrice == 0 {
+	if item == nil || itemLedger == nil || itemLedger.BuyPrice == 0 || !ctx.Character.MeetsRequirements(itemLedger.Requires) {
 		ctx.Player.client.ShowColorizedText(fmt.Sprintf("%s does not have that to sell.", mobInstance.Name()), ColorError)
 		return
 	}
 
 	// Ensure character has room in their inventory
 	if ctx.Character.Inventory().Count() >= ctx.Character.Inventory().MaxSize() {
-		ctx.Player.client.ShowColorizedText(CommonInventoryFilled, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonInventoryFilled), ColorError)
+		return
+	}
+
+	// Reserve the item, ensuring the vendor hasn't sold out
+	reservation, ok := Armeria.vendorManager.TryTake(mobInstance, itemLedger)
+	if !ok {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s has sold out of that, and will have more later.", mobInstance.Name()),
+			ColorError,
+		)
 		return
 	}
 
 	// Remove money from character
-	if !ctx.Character.RemoveMoney(itemLedger.BuyPrice) {
+	price := itemLedgerOwner.BuyPrice(itemLedger)
+	if err := ctx.Character.DeductMoney(price, MoneySourceVendors); err != nil {
+		Armeria.vendorManager.Release(mobInstance, itemLedger, reservation)
 		ctx.Player.client.ShowColorizedText("You can't afford that.", ColorError)
 		return
 	}
@@ -2328,20 +3671,23 @@ func handleBuyCommand(ctx *CommandContext) {
 	if err := ctx.Character.Inventory().Add(item.ID()); err != nil {
 		// Something went wrong, let's destroy the item instance and return the money
 		item.Parent.DeleteInstance(item)
-		ctx.Character.AddMoney(itemLedger.BuyPrice)
+		ctx.Character.refundMoney(price, MoneySourceVendors)
+		Armeria.vendorManager.Release(mobInstance, itemLedger, reservation)
 		ctx.Player.client.ShowColorizedText("Something went wrong with the transaction.", ColorError)
 		return
 	}
+	itemLedgerOwner.RecordTrade(itemLedger, -1)
 
 	ctx.Player.client.SyncMoney()
 	ctx.Player.client.SyncInventory()
+	ctx.Player.client.SyncShop(mobInstance)
 	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf(
 			"You bought a %s from %s for %s.",
 			item.FormattedName(),
 			mobInstance.FormattedName(),
-			ctx.Character.Colorize(misc.Money.FormatMoney(itemLedger.BuyPrice), ColorMoney),
+			ctx.Character.Colorize(misc.Money.FormatMoney(price), ColorMoney),
 		),
 		ColorSuccess,
 	)
@@ -2355,6 +3701,14 @@ func handleBuyCommand(ctx *CommandContext) {
 			),
 		)
 	}
+
+	go CallMobFunc(
+		ctx.Character,
+		mobInstance,
+		"on_buy",
+		lua.LString(item.Name()),
+		lua.LNumber(price),
+	)
 }
 
 func handleSellCommand(ctx *CommandContext) {
@@ -2362,29 +3716,42 @@ func handleSellCommand(ctx *CommandContext) {
 	itemName := ctx.Args["item"]
 
 	// Ensure mob is present in the room
-	result := ctx.Character.Room().Here().GetByName(mobName)
+	result := ctx.Character.Room().Here().GetLoose(mobName)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	}
 	mobInstance := result.Object.(*MobInstance)
 
 	// Ensure item exists in the character's inventory
+	itemResult := ctx.Character.Inventory().GetLoose(itemName)
+	if ctx.ShowAmbiguousTarget(itemResult) {
+		return
+	}
 	var item *ItemInstance
-	if result := ctx.Character.Inventory().GetByAny(itemName); result.Type == RegistryTypeItemInstance {
-		item = result.Object.(*ItemInstance)
+	if itemResult.Type == RegistryTypeItemInstance {
+		item = itemResult.Object.(*ItemInstance)
 	}
 	if item == nil {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonItemNotFoundOnCharacter), ColorError)
+		return
+	}
+	if item.IsQuestItem() {
+		ctx.Player.client.ShowColorizedText("Quest items can't be sold.", ColorError)
 		return
 	}
 
 	// Ensure mob is aware of a ledger that contains the item
 	var itemLedger *LedgerEntry
-	for _, ledger := range mobInstance.ItemLedgers() {
+	var itemLedgerOwner *Ledger
+	for _, ledger := range mobInstance.VendorLedgers() {
 		ledgerEntry := ledger.Contains(item.Name())
 		if ledgerEntry != nil {
 			itemLedger = ledgerEntry
+			itemLedgerOwner = ledger
 			break
 		}
 	}
@@ -2394,7 +3761,12 @@ func handleSellCommand(ctx *CommandContext) {
 	}
 
 	// Add money to the character
-	ctx.Character.AddMoney(itemLedger.SellPrice)
+	price := itemLedgerOwner.SellPrice(itemLedger)
+	if err := ctx.Character.AddMoney(price, MoneySourceVendors); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't sell that: %s.", err), ColorError)
+		return
+	}
+	itemLedgerOwner.RecordTrade(itemLedger, 1)
 
 	// Destroy the item
 	ctx.Character.Inventory().Remove(item.ID())
@@ -2402,13 +3774,14 @@ func handleSellCommand(ctx *CommandContext) {
 
 	ctx.Player.client.SyncMoney()
 	ctx.Player.client.SyncInventory()
+	ctx.Player.client.SyncShop(mobInstance)
 	ctx.Player.client.PlaySFX(sfx.SellBuyItem)
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf(
 			"You sold a %s to %s for %s.",
 			item.FormattedName(),
 			mobInstance.FormattedName(),
-			ctx.Character.Colorize(misc.Money.FormatMoney(itemLedger.SellPrice), ColorMoney),
+			ctx.Character.Colorize(misc.Money.FormatMoney(price), ColorMoney),
 		),
 		ColorSuccess,
 	)
@@ -2422,6 +3795,89 @@ func handleSellCommand(ctx *CommandContext) {
 			),
 		)
 	}
+
+	go CallMobFunc(
+		ctx.Character,
+		mobInstance,
+		"on_sell",
+		lua.LString(item.Name()),
+		lua.LNumber(price),
+	)
+}
+
+func handleListCommand(ctx *CommandContext) {
+	var vendor *MobInstance
+	if name := ctx.Args["npc"]; len(name) > 0 {
+		result := ctx.Character.Room().Here().GetLoose(name)
+		if ctx.ShowAmbiguousTarget(result) {
+			return
+		}
+		if result.Type != RegistryTypeMobInstance {
+			ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+			return
+		}
+		vendor = result.Object.(*MobInstance)
+	} else {
+		for _, mi := range ctx.Character.Room().Here().Mobs() {
+			if mi.Vendor() {
+				vendor = mi
+				break
+			}
+		}
+	}
+	if vendor == nil || !vendor.Vendor() {
+		ctx.Player.client.ShowColorizedText("There's nobody here with anything to sell.", ColorError)
+		return
+	}
+
+	shop := vendor.ShopFor(ctx.Character)
+
+	buyTable := []string{TableRow(
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Description", header: true},
+		TableCell{content: "Stock", header: true},
+		TableCell{content: "Buy", header: true},
+	)}
+	for _, si := range shop.Buy {
+		stock := "plenty"
+		if si.Stock >= 0 {
+			stock = strconv.Itoa(si.Stock)
+		}
+		buyTable = append(buyTable, TableRow(
+			TableCell{content: si.Name},
+			TableCell{content: si.Description},
+			TableCell{content: stock},
+			TableCell{content: TextStyle(
+				fmt.Sprintf("Buy %s <%s>", si.Name, si.Price),
+				WithLinkCmd(fmt.Sprintf("/buy \"%s\" \"%s\"", vendor.Name(), si.Name)),
+			)},
+		))
+	}
+
+	text := fmt.Sprintf("%s has nothing for sale right now.", vendor.FormattedName())
+	if len(buyTable) > 1 {
+		text = fmt.Sprintf("%s has for sale:\n%s", vendor.FormattedName(), TextTable(buyTable...))
+	}
+
+	sellTable := []string{TableRow(
+		TableCell{content: "Inventory", header: true},
+		TableCell{content: "Sell", header: true},
+	)}
+	for _, si := range shop.Sell {
+		sellTable = append(sellTable, TableRow(
+			TableCell{content: si.Name},
+			TableCell{content: TextStyle(
+				fmt.Sprintf("Sell %s <%s>", si.Name, si.Price),
+				WithLinkCmd(fmt.Sprintf("/sell \"%s\" \"%s\"", vendor.Name(), si.Name)),
+			)},
+		))
+	}
+	if len(sellTable) > 1 {
+		text = fmt.Sprintf("%s\n%s will buy:\n%s", text, vendor.FormattedName(), TextTable(sellTable...))
+	}
+
+	ctx.Player.client.ShowText(text)
+	ctx.Player.client.SyncShop(vendor)
 }
 
 func handleDestroyCommand(ctx *CommandContext) {
@@ -2429,17 +3885,35 @@ func handleDestroyCommand(ctx *CommandContext) {
 
 	if result := ctx.Character.Inventory().GetByAny(searchString); result.Type == RegistryTypeItemInstance {
 		item := result.Object.(*ItemInstance)
+		if ctx.DryRun() {
+			ctx.Simulation.Remove(ObjectTypeItemInstance, item.FormattedName())
+			return
+		}
 		ctx.Character.Inventory().Remove(item.ID())
 		item.Delete()
 		ctx.Player.client.ShowColorizedText("The item has been destroyed!", ColorSuccess)
 		ctx.Player.client.SyncInventory()
 		return
 	} else if result := ctx.Character.Room().Here().GetByAny(searchString); result.Type == RegistryTypeItemInstance {
+		if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+			return
+		}
 		item := result.Object.(*ItemInstance)
+		if ctx.DryRun() {
+			ctx.Simulation.Remove(ObjectTypeItemInstance, item.FormattedName())
+			return
+		}
 		ctx.Character.Room().Here().Remove(item.ID())
 		item.Delete()
 	} else if result := ctx.Character.Room().Here().GetByAny(searchString); result.Type == RegistryTypeMobInstance {
+		if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+			return
+		}
 		mob := result.Object.(*MobInstance)
+		if ctx.DryRun() {
+			ctx.Simulation.Remove(ObjectTypeMobInstance, mob.FormattedName())
+			return
+		}
 		ctx.Character.Room().Here().Remove(mob.ID())
 		mob.Delete()
 	} else {
@@ -2472,25 +3946,43 @@ func handleTickersCommand(ctx *CommandContext) {
 		))
 	}
 
-	ctx.Player.client.ShowText(TextTable(rows...))
+	text := TextTable(rows...)
+	if status := Armeria.tickManager.FrozenStatus(); len(status) > 0 {
+		text = status + " Only the tickers that keep the server healthy are running.\n" + text
+	}
+
+	ctx.Player.client.ShowText(text)
 }
 
 func handleSelectCommand(ctx *CommandContext) {
 	mob := ctx.Args["mob"]
 	optionId := ctx.Args["option_id"]
 
-	result := ctx.Character.Room().Here().GetByAny(mob)
+	result := ctx.Character.Room().Here().GetLoose(mob)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	}
 	mobInst := result.Object.(*MobInstance)
 
-	if len(mobInst.ConvoText(optionId)) == 0 {
+	option := ctx.Character.ConvoOption(mobInst, optionId)
+	if option == nil {
+		ctx.Player.client.ShowColorizedText("That is not a valid selection.", ColorError)
+		return
+	}
+	optionId = option.ID
+
+	if len(mobInst.ConvoText(optionId)) == 0 || !ctx.Character.MeetsRequirements(mobInst.ConvoRequires(optionId)) {
 		ctx.Player.client.ShowColorizedText("That is not a valid selection.", ColorError)
 		return
 	}
 
+	// Only one answer can be given to the options on offer.
+	ctx.Character.ClearConvoOptions()
+
 	Armeria.commandManager.ProcessCommand(ctx.Player, fmt.Sprintf("say %s", mobInst.ConvoText(optionId)), false)
 
 	go CallMobFunc(
@@ -2504,13 +3996,18 @@ func handleSelectCommand(ctx *CommandContext) {
 func handleInteractCommand(ctx *CommandContext) {
 	mob := ctx.Args["mob"]
 
-	result := ctx.Character.Room().Here().GetByAny(mob)
+	result := ctx.Character.Room().Here().GetLoose(mob)
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
 	if result.Type != RegistryTypeMobInstance {
-		ctx.Player.client.ShowColorizedText(CommonTargetNotFoundHere, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
 		return
 	}
 	mobInst := result.Object.(*MobInstance)
 
+	ctx.Character.advanceQuestObjectives(QuestObjectiveTalk, mobInst.Name())
+
 	go CallMobFunc(
 		ctx.Character,
 		mobInst,
@@ -2518,40 +4015,47 @@ func handleInteractCommand(ctx *CommandContext) {
 	)
 }
 
-func handleEquipCommand(ctx *CommandContext) {
-	itemName := ctx.Args["item"]
-
-	// If no item is specified, display the character's equipment.
-	if len(itemName) == 0 {
-		eq := ctx.Character.Equipment()
+func handleEquipmentCommand(ctx *CommandContext) {
+	eq := ctx.Character.Equipment()
 
-		rows := []string{TableRow(
-			TableCell{content: "Slot", header: true},
-			TableCell{content: "Item(s)", header: true},
-		)}
+	rows := []string{TableRow(
+		TableCell{content: "Slot", header: true},
+		TableCell{content: "Item(s)", header: true},
+	)}
 
-		for _, slot := range ValidEquipmentSlots() {
-			items := eq.AtSlotName(slot)
-			itemNames := "&lt;nothing&gt;"
-			if len(items) > 0 {
-				itemNames = ""
-				for _, itemResult := range items {
-					itemNames = fmt.Sprintf("%s%s\n", itemNames, itemResult.Object.FormattedName())
-				}
+	for _, slot := range ValidEquipmentSlots() {
+		items := eq.AtSlotName(slot)
+		itemNames := "&lt;nothing&gt;"
+		if len(items) > 0 {
+			itemNames = ""
+			for _, itemResult := range items {
+				itemNames = fmt.Sprintf("%s%s\n", itemNames, itemResult.Object.FormattedName())
 			}
-			rows = append(rows, TableRow(
-				TableCell{content: EquipSlotFormalName(slot)},
-				TableCell{content: itemNames},
-			))
 		}
+		rows = append(rows, TableRow(
+			TableCell{content: EquipSlotFormalName(slot)},
+			TableCell{content: itemNames},
+		))
+	}
 
-		ctx.Player.client.ShowText(TextTable(rows...))
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleEquipCommand(ctx *CommandContext) {
+	itemName := ctx.Args["item"]
+
+	// If no item is specified, display the character's equipment.
+	if len(itemName) == 0 {
+		handleEquipmentCommand(ctx)
 		return
 	}
 
 	res := ctx.Character.Inventory().GetLoose(itemName)
+	if ctx.ShowAmbiguousTarget(res) {
+		return
+	}
 	if res.Type == RegistryTypeUnknown {
-		ctx.Player.client.ShowColorizedText(CommonItemNotFoundOnCharacter, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonItemNotFoundOnCharacter), ColorError)
 		return
 	}
 
@@ -2562,28 +4066,55 @@ func handleEquipCommand(ctx *CommandContext) {
 		return
 	}
 
+	// Wearing an item in a slot that only fits one swaps it with the item already there.
+	var swapped *ItemInstance
 	atSlot := ctx.Character.Equipment().AtSlotName(EquipmentSlot(equipSlot))
 	maxAtSlot := EquipSlotMax(EquipmentSlot(equipSlot))
 	if len(atSlot) >= maxAtSlot {
-		ctx.Player.client.ShowColorizedText("You have an item of that type already equipped.", ColorError)
-		return
+		if maxAtSlot > 1 {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf(
+					"You already have %d items equipped to your %s.",
+					maxAtSlot,
+					strings.ToLower(EquipSlotFormalName(EquipmentSlot(equipSlot))),
+				),
+				ColorError,
+			)
+			return
+		}
+		swapped = atSlot[0].Object.(*ItemInstance)
 	}
 
 	ctx.Character.Inventory().Remove(item.ID())
+	if swapped != nil {
+		ctx.Character.Equipment().Remove(swapped.ID())
+		_ = ctx.Character.Inventory().Add(swapped.ID())
+	}
 	_ = ctx.Character.Equipment().Add(item.ID())
 	ctx.Character.Equipment().SetSlotName(item.ID(), EquipmentSlot(equipSlot))
 
 	ctx.Player.client.SyncInventory()
-	ctx.Player.client.ShowColorizedText(
-		fmt.Sprintf("You equipped a %s to yourself.", item.FormattedName()),
-		ColorSuccess,
-	)
+	ctx.Character.RefreshComparisons(equipSlot)
+	if swapped != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You swapped your %s for a %s.", swapped.FormattedName(), item.FormattedName()),
+			ColorSuccess,
+		)
+	} else {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You equipped a %s to yourself.", item.FormattedName()),
+			ColorSuccess,
+		)
+	}
 }
 
 func handleRemoveCommand(ctx *CommandContext) {
 	itemName := ctx.Args["item"]
 
 	res := ctx.Character.Equipment().GetLoose(itemName)
+	if ctx.ShowAmbiguousTarget(res) {
+		return
+	}
 	if res.Type == RegistryTypeUnknown {
 		ctx.Player.client.ShowColorizedText("You don't have an item equipped by that name.", ColorError)
 		return
@@ -2591,14 +4122,3177 @@ func handleRemoveCommand(ctx *CommandContext) {
 
 	item := res.Object.(*ItemInstance)
 	if err := ctx.Character.Inventory().Add(item.ID()); err != nil {
-		ctx.Player.client.ShowColorizedText(CommonInventoryFilled, ColorError)
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonInventoryFilled), ColorError)
 		return
 	}
 	ctx.Character.Equipment().Remove(item.ID())
 
 	ctx.Player.client.SyncInventory()
+	ctx.Character.RefreshComparisons(item.Attribute(AttributeEquipSlot))
 	ctx.Player.client.ShowColorizedText(
 		fmt.Sprintf("You removed a %s from yourself.", item.FormattedName()),
 		ColorSuccess,
 	)
 }
+
+func handleAdminPromoteCommand(ctx *CommandContext) {
+	area := ctx.Args["area"]
+	force := strings.ToLower(ctx.Args["force"]) == "force"
+
+	res, err := Armeria.promotionManager.PromoteArea(area, force)
+	if err != nil {
+		if ce, ok := err.(*PromotionConflictError); ok {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf(
+					"The following objects were changed on the live world since they were last promoted:\n%s\n"+
+						"Use [b]/admin promote %s force[/b] to overwrite them.",
+					strings.Join(ce.Objects, "\n"),
+					area,
+				),
+				ColorError,
+			)
+			return
+		}
+
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The area could not be promoted: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"The area [b]%s[/b] has been promoted: %d room(s) created, %d updated, and %d removed; "+
+				"%d item(s), %d mob(s), and %d script(s) promoted; %d object(s) placed in new rooms.",
+			area,
+			res.RoomsCreated,
+			res.RoomsUpdated,
+			res.RoomsRemoved,
+			res.Items,
+			res.Mobs,
+			res.Scripts,
+			res.Objects,
+		),
+		ColorSuccess,
+	)
+}
+
+func handleBulkAddCommand(ctx *CommandContext) {
+	targets, err := BulkEditTargetsFromString(ctx.Character, ctx.Args["target"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The selection could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	for _, t := range targets {
+		if !ctx.CanBuildIn(Armeria.registry.GetArea(t.ID())) {
+			return
+		}
+	}
+
+	ctx.Character.SetBulkEditSelection(append(ctx.Character.BulkEditSelection(), targets...))
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You added %d object(s) to your selection, which now has %d object(s).",
+			len(targets),
+			len(ctx.Character.BulkEditSelection()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleBulkRemoveCommand(ctx *CommandContext) {
+	targets, err := BulkEditTargetsFromString(ctx.Character, ctx.Args["target"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The selection could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	var remaining []*BulkEditTarget
+	for _, t := range ctx.Character.BulkEditSelection() {
+		keep := true
+		for _, rt := range targets {
+			if rt.ID() == t.ID() {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			remaining = append(remaining, t)
+		}
+	}
+
+	ctx.Character.SetBulkEditSelection(remaining)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Your selection now has %d object(s).", len(remaining)),
+		ColorSuccess,
+	)
+}
+
+func handleBulkClearCommand(ctx *CommandContext) {
+	ctx.Character.SetBulkEditSelection(nil)
+	ctx.Player.client.ShowColorizedText("Your selection has been cleared.", ColorSuccess)
+}
+
+func handleBulkListCommand(ctx *CommandContext) {
+	targets := ctx.Character.BulkEditSelection()
+	if len(targets) == 0 {
+		ctx.Player.client.ShowText("You don't have anything selected.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Object", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "UUID", header: true},
+	)}
+
+	for _, t := range targets {
+		rows = append(rows, TableRow(
+			TableCell{content: t.Name()},
+			TableCell{content: string(t.ObjectType)},
+			TableCell{content: t.ID()},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleBulkPreviewCommand(ctx *CommandContext) {
+	targets := ctx.Character.BulkEditSelection()
+	if len(targets) == 0 {
+		ctx.Player.client.ShowColorizedText("You don't have anything selected.", ColorError)
+		return
+	}
+
+	attr := AttributeCasing(ctx.Args["property"])
+	changes := PreviewBulkEdit(ctx.Character, targets, attr, ctx.Args["value"])
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Setting %s would modify %d of %d selected object(s):\n%s",
+			TextStyle(attr, WithBold()),
+			bulkEditCount(changes),
+			len(changes),
+			bulkEditSummary(changes),
+		),
+	)
+}
+
+func handleBulkSetCommand(ctx *CommandContext) {
+	targets := ctx.Character.BulkEditSelection()
+	if len(targets) == 0 {
+		ctx.Player.client.ShowColorizedText("You don't have anything selected.", ColorError)
+		return
+	}
+
+	attr := AttributeCasing(ctx.Args["property"])
+	val := ctx.Args["value"]
+	changes := PreviewBulkEdit(ctx.Character, targets, attr, val)
+
+	if ctx.DryRun() {
+		for _, ch := range changes {
+			ctx.Simulation.Modify(ch.Target.ObjectType, ch.Target.Name(), attr, ch.OldValue, ch.NewValue, ch.Error)
+		}
+		return
+	}
+
+	if bulkEditCount(changes) == 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("None of the selected objects can be modified:\n%s", bulkEditSummary(changes)),
+			ColorError,
+		)
+		return
+	}
+
+	for _, ch := range changes {
+		if ch.Error == nil {
+			ch.Error = ch.Target.SetAttribute(attr, val)
+		}
+		if ch.Error == nil {
+			ctx.RecordEdit(editorDataKey(ch.Target.EditorData()), attr, ch.OldValue, ch.Target.Attribute(attr))
+		}
+	}
+
+	ctx.Player.client.SyncMap()
+	ctx.Player.client.SyncRoomObjects()
+	ctx.Player.client.SyncInventory()
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You modified the %s property of %d selected object(s):\n%s",
+			TextStyle(attr, WithBold()),
+			bulkEditCount(changes),
+			bulkEditSummary(changes),
+		),
+		ColorSuccess,
+	)
+
+	editorOpen := ctx.Character.TempAttribute(TempAttributeEditorOpen)
+	if editorOpen == "true" {
+		ctx.Player.client.ShowObjectEditor(targets[0].EditorData())
+	}
+}
+
+// dirtyRoomsInScope returns the rooms with unpublished changes for a publish or discard scope.
+func dirtyRoomsInScope(ctx *CommandContext, scope string) ([]*Room, bool) {
+	var rooms []*Room
+	switch strings.ToLower(scope) {
+	case "", "here", ".":
+		if ctx.Character.Room().Dirty() {
+			rooms = append(rooms, ctx.Character.Room())
+		}
+	case "area":
+		for _, r := range ctx.Character.Room().ParentArea.Rooms() {
+			if r.Dirty() {
+				rooms = append(rooms, r)
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	return rooms, true
+}
+
+func handlePublishCommand(ctx *CommandContext) {
+	scope := strings.ToLower(ctx.Args["scope"])
+	listOnly := scope == "list"
+	if listOnly {
+		scope = "area"
+	}
+
+	if !listOnly && !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
+	rooms, ok := dirtyRoomsInScope(ctx, scope)
+	if !ok {
+		ctx.Player.client.ShowColorizedText("That's not a valid scope. Use \"here\", \"area\", or \"list\".", ColorError)
+		return
+	} else if len(rooms) == 0 {
+		ctx.Player.client.ShowText("There are no unpublished changes.")
+		return
+	}
+
+	if listOnly {
+		rows := []string{TableRow(
+			TableCell{content: "Room", header: true},
+			TableCell{content: "Coords", header: true},
+			TableCell{content: "Unpublished", header: true},
+		)}
+		for _, r := range rooms {
+			rows = append(rows, TableRow(
+				TableCell{content: r.DraftAttribute(AttributeTitle)},
+				TableCell{content: r.Coords.String()},
+				TableCell{content: strings.Join(r.DraftAttributeNames(), ", ")},
+			))
+		}
+		ctx.Player.client.ShowText(TextTable(rows...))
+		return
+	}
+
+	for _, r := range rooms {
+		if err := r.Publish(); err != nil {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The room at %s could not be published: %s.", r.Coords.String(), err),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You published the changes to %d room(s).", len(rooms)),
+		ColorSuccess,
+	)
+}
+
+func handleDiscardCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(ctx.Character.Room().ParentArea) {
+		return
+	}
+
+	rooms, ok := dirtyRoomsInScope(ctx, ctx.Args["scope"])
+	if !ok {
+		ctx.Player.client.ShowColorizedText("That's not a valid scope. Use \"here\" or \"area\".", ColorError)
+		return
+	} else if len(rooms) == 0 {
+		ctx.Player.client.ShowText("There are no unpublished changes.")
+		return
+	}
+
+	for _, r := range rooms {
+		r.Discard()
+	}
+
+	ctx.Player.client.SyncMap()
+	ctx.Player.client.SyncRoomTitle()
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You discarded the unpublished changes to %d room(s).", len(rooms)),
+		ColorSuccess,
+	)
+
+	editorOpen := ctx.Character.TempAttribute(TempAttributeEditorOpen)
+	if editorOpen == "true" {
+		ctx.Player.client.ShowObjectEditor(ctx.Character.Room().EditorData())
+	}
+}
+
+func handleAdminEconomyCommand(ctx *CommandContext) {
+	days := EconomyReportDays
+	if len(ctx.Args["days"]) > 0 {
+		var err error
+		days, err = strconv.Atoi(ctx.Args["days"])
+		if err != nil || days < 1 || days > EconomyHistoryDays {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The number of days must be between 1 and %d.", EconomyHistoryDays),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	ctx.Player.client.ShowText(economySummary(days))
+}
+
+func handleAdminWorldStatsCommand(ctx *CommandContext) {
+	ws := CollectWorldStats()
+
+	rows := []string{TableRow(
+		TableCell{content: "Content", header: true},
+		TableCell{content: "Count", header: true},
+	)}
+	for _, stat := range []struct {
+		name  string
+		count int
+	}{
+		{"Areas", ws.Areas},
+		{"Rooms", ws.Rooms},
+		{"Items", ws.Items},
+		{"Item instances", ws.ItemInstances},
+		{"Mobs", ws.Mobs},
+		{"Mob instances", ws.MobInstances},
+		{"Orphaned objects", len(ws.OrphanedObjects)},
+		{"Rooms lacking descriptions", len(ws.RoomsMissingDesc)},
+		{"Mobs lacking scripts", len(ws.MobsMissingScript)},
+	} {
+		rows = append(rows, TableRow(
+			TableCell{content: stat.name},
+			TableCell{content: strconv.Itoa(stat.count)},
+		))
+	}
+
+	sections := []string{TextTable(rows...)}
+
+	if len(ws.OrphanedObjects) > 0 {
+		sections = append(sections, fmt.Sprintf(
+			"[b]Orphaned objects:[/b]\n%s",
+			strings.Join(ws.OrphanedObjects, "\n"),
+		))
+	}
+
+	if len(ws.RoomsMissingDesc) > 0 {
+		var rms []string
+		for _, r := range ws.RoomsMissingDesc {
+			rms = append(rms, TextStyle(
+				fmt.Sprintf("%s (%s)", r.Attribute(AttributeTitle), r.LocationString()),
+				WithLinkCmd(fmt.Sprintf("/tp %s", r.LocationString())),
+			))
+		}
+		sections = append(sections, fmt.Sprintf("[b]Rooms lacking descriptions:[/b]\n%s", strings.Join(rms, "\n")))
+	}
+
+	if len(ws.MobsMissingScript) > 0 {
+		var mobs []string
+		for _, m := range ws.MobsMissingScript {
+			mobs = append(mobs, TextStyle(m.Name(), WithLinkCmd(fmt.Sprintf("/mob edit %s", m.Name()))))
+		}
+		sections = append(sections, fmt.Sprintf("[b]Mobs lacking scripts:[/b]\n%s", strings.Join(mobs, "\n")))
+	}
+
+	sections = append(sections, fmt.Sprintf("[b]Creation quotas:[/b]\n%s", quotaSummary()))
+
+	ctx.Player.client.ShowText(strings.Join(sections, "\n\n"))
+}
+
+func handleAdminValidateCommand(ctx *CommandContext) {
+	problems := CheckLinks()
+	if len(problems) == 0 {
+		ctx.Player.client.ShowColorizedText("No dangling references were found.", ColorSuccess)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Location", header: true},
+		TableCell{content: "Problem", header: true},
+		TableCell{content: "Suggested Fix", header: true},
+	)}
+	for _, p := range problems {
+		rows = append(rows, TableRow(
+			TableCell{content: p.Location},
+			TableCell{content: p.Problem},
+			TableCell{content: p.Fix},
+		))
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%d dangling reference(s) were found:\n%s", len(problems), TextTable(rows...)),
+		ColorError,
+	)
+}
+
+func handleTitleListCommand(ctx *CommandContext) {
+	titles := ctx.Character.Titles()
+	if len(titles) == 0 {
+		ctx.Player.client.ShowText("You haven't earned any titles yet.")
+		return
+	}
+
+	current := ctx.Character.Attribute(AttributeTitle)
+	rows := []string{TableRow(
+		TableCell{content: "Title", header: true},
+		TableCell{content: "Description", header: true},
+	)}
+	for _, name := range titles {
+		var desc string
+		if t := Armeria.titleManager.TitleByName(name); t != nil {
+			desc = t.Description
+		}
+
+		content := TextStyle(name, WithLinkCmd(fmt.Sprintf("/title set %s", name)))
+		if strings.ToLower(name) == strings.ToLower(current) {
+			content = TextStyle(name, WithBold())
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: content},
+			TableCell{content: desc},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleTitleSetCommand(ctx *CommandContext) {
+	t := Armeria.titleManager.TitleByName(ctx.Args["title"])
+	if t == nil || !ctx.Character.HasTitle(t.Name) {
+		ctx.Player.client.ShowColorizedText("You haven't earned that title.", ColorError)
+		return
+	}
+
+	if err := ctx.Character.SetAttribute(AttributeTitle, t.Name); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("Your title could not be set: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You are now known as %s, %s.", ctx.Character.FormattedName(), TextStyle(t.Name, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleTitleClearCommand(ctx *CommandContext) {
+	_ = ctx.Character.SetAttribute(AttributeTitle, "")
+	ctx.Player.client.ShowColorizedText("You are no longer displaying a title.", ColorSuccess)
+}
+
+func handleTitleCatalogCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Title", header: true},
+		TableCell{content: "Description", header: true},
+	)}
+	for _, t := range Armeria.titleManager.Titles() {
+		desc := t.Description
+		if t.Kills > 0 {
+			desc = strings.TrimSpace(fmt.Sprintf("%s (slay %d %s)", desc, t.Kills, t.KillMob))
+		}
+		if len(t.ExploreArea) > 0 {
+			desc = strings.TrimSpace(fmt.Sprintf("%s (explore all of %s)", desc, t.ExploreArea))
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: t.Name},
+			TableCell{content: desc},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleTitleCreateCommand(ctx *CommandContext) {
+	n := ctx.Args["title"]
+	if Armeria.titleManager.TitleByName(n) != nil {
+		ctx.Player.client.ShowColorizedText("That title is already in the catalog.", ColorError)
+		return
+	}
+
+	Armeria.titleManager.AddTitle(&Title{Name: n, Description: ctx.Args["description"]})
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The title %s has been added to the catalog.", TextStyle(n, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleTitleGrantCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	t := Armeria.titleManager.TitleByName(ctx.Args["title"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("That title isn't in the catalog.", ColorError)
+		return
+	}
+
+	if !c.GrantTitle(t) {
+		ctx.Player.client.ShowColorizedText("That character has already earned that title.", ColorError)
+		return
+	}
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"You have earned the title %s! Use %s to display it.",
+				TextStyle(t.Name, WithBold()),
+				TextStyle("/title list", WithLinkCmd("/title list")),
+			),
+			ColorSuccess,
+		)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You granted the title %s to %s.", TextStyle(t.Name, WithBold()), c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleTitleRevokeCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	if !c.RevokeTitle(ctx.Args["title"]) {
+		ctx.Player.client.ShowColorizedText("That character hasn't earned that title.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You revoked the title %s from %s.", TextStyle(ctx.Args["title"], WithBold()), c.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleAdminMOTDCommand(ctx *CommandContext) {
+	msg := ctx.Args["message"]
+
+	if len(msg) == 0 {
+		motd := Armeria.announcementManager.MOTD()
+		if len(motd) == 0 {
+			ctx.Player.client.ShowText("There is no message of the day.")
+		} else {
+			ctx.Player.client.ShowText(fmt.Sprintf("The message of the day is:\n%s", motd))
+		}
+		return
+	}
+
+	if strings.ToLower(msg) == "clear" {
+		msg = ""
+	}
+
+	Armeria.announcementManager.SetMOTD(msg)
+	ctx.Player.client.ShowColorizedText("The message of the day has been updated.", ColorSuccess)
+}
+
+func handleAdminAnnounceNowCommand(ctx *CommandContext) {
+	Announce(ctx.Args["message"])
+}
+
+func handleAdminAnnounceAddCommand(ctx *CommandContext) {
+	interval, err := time.ParseDuration(ctx.Args["interval"])
+	if err != nil || interval < time.Minute {
+		ctx.Player.client.ShowColorizedText("The interval must be a duration of at least 1m (ie: 30m, 2h).", ColorError)
+		return
+	}
+
+	a := Armeria.announcementManager.AddAnnouncement(ctx.Args["message"], interval)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Announcement #%d will be broadcast every %s.", a.ID, interval),
+		ColorSuccess,
+	)
+}
+
+func handleAdminAnnounceListCommand(ctx *CommandContext) {
+	announcements := Armeria.announcementManager.Announcements()
+	if len(announcements) == 0 {
+		ctx.Player.client.ShowText("There are no scheduled announcements.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Interval", header: true},
+		TableCell{content: "Message", header: true},
+	)}
+	for _, a := range announcements {
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(a.ID)},
+			TableCell{content: a.Interval.String()},
+			TableCell{content: a.Message},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleAdminAnnounceRemoveCommand(ctx *CommandContext) {
+	id, err := strconv.Atoi(ctx.Args["id"])
+	if err != nil || !Armeria.announcementManager.RemoveAnnouncement(id) {
+		ctx.Player.client.ShowColorizedText("That announcement doesn't exist.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("Announcement #%d has been removed.", id), ColorSuccess)
+}
+
+func handleAdminBroadcastCommand(ctx *CommandContext) {
+	style := BroadcastStyleByName(ctx.Args["style"])
+	if style == nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The style must be one of: %s.", strings.Join(BroadcastStyleNames(), ", ")),
+			ColorError,
+		)
+		return
+	}
+
+	count, err := BroadcastFrom(ctx.Character, style, ctx.Args["filter"], ctx.Args["message"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The broadcast reached %d online character(s).", count),
+		ColorSuccess,
+	)
+}
+
+func handleAdminForceCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	err := Armeria.commandManager.ForceCommand(ctx.Character, c, ctx.Args["command"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s ran %s.",
+			c.FormattedName(),
+			TextStylePlayer("/"+strings.TrimPrefix(ctx.Args["command"], "/"), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleAdminInspectCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowObjectEditor(c.InspectData())
+}
+
+func handleAdminFreezeCommand(ctx *CommandContext) {
+	if err := Armeria.tickManager.Freeze(ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	Announce(FrozenNotice)
+	NotifyStaff(fmt.Sprintf("%s froze the world. Use %s to resume it.", ctx.Character.FormattedName(), TextStyle("/admin unfreeze", WithBold())))
+}
+
+func handleAdminUnfreezeCommand(ctx *CommandContext) {
+	if err := Armeria.tickManager.Unfreeze(ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	Announce("The world has thawed, and everything is moving again.")
+}
+
+func handleAdminReplayRecordCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	rec, err := Armeria.sessionRecorder.Start(c)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"Recording %s's session as %s. Use %s when you have what you need.",
+			c.FormattedName(),
+			TextStyle(rec.ID, WithBold()),
+			TextStyle("/admin replay stop "+c.Name(), WithBold()),
+		),
+		ColorSuccess,
+	)
+	if c.Online() && c != ctx.Character {
+		c.Player().client.ShowColorizedText("Staff are recording your session to help track down a bug.", ColorCmdHelp)
+	}
+}
+
+func handleAdminReplayStopCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	rec, err := Armeria.sessionRecorder.Stop(c)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"Stopped recording %s's session. Use %s to replay it.",
+			c.FormattedName(),
+			TextStyle("/admin replay view "+rec.ID, WithLinkCmd("/admin replay view "+rec.ID)),
+		),
+		ColorSuccess,
+	)
+	if c.Online() && c != ctx.Character {
+		c.Player().client.ShowColorizedText("Staff stopped recording your session.", ColorCmdHelp)
+	}
+}
+
+func handleAdminReplayListCommand(ctx *CommandContext) {
+	recordings := SessionRecordings()
+	if len(recordings) == 0 {
+		ctx.Player.client.ShowText("There are no session recordings.")
+		return
+	}
+
+	header := TableRow(
+		TableCell{content: "Recording", header: true},
+		TableCell{content: "Size", header: true},
+		TableCell{content: "Last Message", header: true},
+	)
+
+	var rows []string
+	for _, r := range recordings {
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(r.ID, WithLinkCmd("/admin replay view "+r.ID))},
+			TableCell{content: bytefmt.ByteSize(uint64(r.Size))},
+			TableCell{content: TextRelativeTime(r.Modified)},
+		))
+	}
+
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
+}
+
+func handleAdminReplayViewCommand(ctx *CommandContext) {
+	entries, err := ReadSessionRecording(ctx.Args["id"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	} else if len(entries) == 0 {
+		ctx.Player.client.ShowText("That recording is empty.")
+		return
+	}
+
+	header := TableRow(
+		TableCell{content: "Time", header: true},
+		TableCell{content: "", header: true},
+		TableCell{content: "Message", header: true},
+	)
+
+	var rows []string
+	for _, e := range entries {
+		// Text is shown exactly as the player saw it, while commands and other client actions are shown raw.
+		var message string
+		switch {
+		case e.Direction == SessionRecordingInbound && e.Type == "command":
+			message = TextStylePlayer(e.Data, WithBold())
+		case e.Direction == SessionRecordingOutbound && e.Type == "showText":
+			message = e.Data
+		default:
+			data := e.Data
+			if len(data) > 120 {
+				data = data[:120] + "..."
+			}
+			message = fmt.Sprintf("[%s] %s", e.Type, TextStylePlayer(data))
+		}
+
+		direction := "&lt;"
+		if e.Direction == SessionRecordingInbound {
+			direction = "&gt;"
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: "+" + TextDuration(e.Time.Sub(entries[0].Time))},
+			TableCell{content: direction},
+			TableCell{content: message},
+		))
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Recording %s started at %s, and holds %d messages.",
+			TextStyle(ctx.Args["id"], WithBold()),
+			TextStyle(entries[0].Time.Format("Mon Jan 2 2006 15:04:05 MST"), WithBold()),
+			len(entries),
+		),
+	)
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
+}
+
+func handleAdminQuarantineListCommand(ctx *CommandContext) {
+	items := Armeria.quarantineManager.Items()
+	if len(items) == 0 {
+		ctx.Player.client.ShowText("There are no quarantined items.")
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"These items were found in more than one place, or in no place at all, and have been taken out of the "+
+				"game. Use %s to return one to play, or %s to remove it for good.\n%s",
+			TextStyle("/admin quarantine release &lt;uuid&gt;", WithBold()),
+			TextStyle("/admin quarantine destroy &lt;uuid&gt;", WithBold()),
+			quarantineSummary(items),
+		),
+	)
+}
+
+func handleAdminQuarantineCheckCommand(ctx *CommandContext) {
+	ctx.Player.client.ShowText("Checking for duplicated or lost items...")
+
+	if n := CheckItemIntegrity(); n == 0 {
+		ctx.Player.client.ShowColorizedText("No duplicated or lost items were found.", ColorSuccess)
+	}
+}
+
+func handleAdminQuarantineReleaseCommand(ctx *CommandContext) {
+	uuid := ctx.Args["uuid"]
+	if err := Armeria.quarantineManager.Release(uuid, ctx.Character.Inventory()); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	o, _ := Armeria.registry.Get(uuid)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s was released from quarantine into your inventory.", o.(*ItemInstance).FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleAdminQuarantineDestroyCommand(ctx *CommandContext) {
+	if err := Armeria.quarantineManager.Destroy(ctx.Args["uuid"]); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText("The quarantined item was removed from the game.", ColorSuccess)
+}
+
+func handleTutorialCommand(ctx *CommandContext) {
+	switch strings.ToLower(ctx.Args["action"]) {
+	case "":
+		if ctx.Character.TutorialStep() < 0 {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf(
+					"You aren't taking the tutorial. Use %s to take it again.",
+					TextStyle("/tutorial restart", WithBold()),
+				),
+				ColorCmdHelp,
+			)
+			return
+		}
+		ctx.Character.ShowTutorialStep()
+	case "skip":
+		if ctx.Character.TutorialStep() < 0 {
+			ctx.Player.client.ShowColorizedText("You aren't taking the tutorial.", ColorError)
+			return
+		}
+		ctx.Player.client.ShowColorizedText("You skipped the tutorial.", ColorSuccess)
+		ctx.Character.EndTutorial(TutorialSkipped)
+	case "restart":
+		if !ctx.Character.StartTutorial() {
+			ctx.Player.client.ShowColorizedText("The tutorial isn't available right now.", ColorError)
+		}
+	default:
+		ctx.Player.client.ShowColorizedText("That's not a valid action. Use skip or restart.", ColorError)
+	}
+}
+
+func handleCharacterRenameCommand(ctx *CommandContext) {
+	c := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if c == nil {
+		ctx.Player.client.ShowColorizedText("That character doesn't exist.", ColorError)
+		return
+	}
+
+	newName, err := formatCharacterName(ctx.Args["new_name"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The name was rejected: %s.", err), ColorError)
+		return
+	}
+
+	if other := Armeria.characterManager.CharacterByName(newName); other != nil && other != c {
+		ctx.Player.client.ShowColorizedText("A character with that name already exists.", ColorError)
+		return
+	}
+
+	if ctx.Args["override"] != "override" {
+		if err := Armeria.nameManager.CheckName(newName, c); err != nil {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The name was rejected: %s. Add \"override\" to rename the character anyway.", err),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	oldName := c.Name()
+	c.SetName(newName)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s has been renamed to %s.", TextStyle(oldName, WithBold()), c.FormattedName()),
+		ColorSuccess,
+	)
+
+	if c.Online() && c != ctx.Character {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("You have been renamed to %s.", c.FormattedName()),
+			ColorSuccess,
+		)
+		c.Player().client.SyncPlayerInfo()
+	}
+}
+
+func handleAdminNamesListCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Rule", header: true},
+		TableCell{content: "Value", header: true},
+	)}
+	for _, w := range Armeria.nameManager.Blocked() {
+		rows = append(rows, TableRow(
+			TableCell{content: "blocked word"},
+			TableCell{content: w},
+		))
+	}
+	for _, p := range Armeria.nameManager.Reserved() {
+		rows = append(rows, TableRow(
+			TableCell{content: "reserved pattern"},
+			TableCell{content: p},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleAdminNamesBlockCommand(ctx *CommandContext) {
+	if !Armeria.nameManager.Block(ctx.Args["word"]) {
+		ctx.Player.client.ShowColorizedText("That word is already blocked.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Character names can no longer contain %s.", TextStyle(ctx.Args["word"], WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminNamesUnblockCommand(ctx *CommandContext) {
+	if !Armeria.nameManager.Unblock(ctx.Args["word"]) {
+		ctx.Player.client.ShowColorizedText("That word isn't blocked.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Character names can contain %s again.", TextStyle(ctx.Args["word"], WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminNamesReserveCommand(ctx *CommandContext) {
+	if err := Armeria.nameManager.Reserve(ctx.Args["pattern"]); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The pattern could not be reserved: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Names matching %s are now reserved.", TextStyle(ctx.Args["pattern"], WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminNamesUnreserveCommand(ctx *CommandContext) {
+	if !Armeria.nameManager.Unreserve(ctx.Args["pattern"]) {
+		ctx.Player.client.ShowColorizedText("That pattern isn't reserved.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Names matching %s are no longer reserved.", TextStyle(ctx.Args["pattern"], WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminNamesCheckCommand(ctx *CommandContext) {
+	if err := Armeria.nameManager.CheckName(ctx.Args["name"], nil); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The name was rejected: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText("That name can be used.", ColorSuccess)
+}
+
+func handleAdminFilterListCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Pattern", header: true},
+		TableCell{content: "Action", header: true},
+	)}
+	for _, r := range Armeria.chatFilterManager.Rules() {
+		rows = append(rows, TableRow(
+			TableCell{content: r.Pattern},
+			TableCell{content: r.Action},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleAdminFilterSetCommand(ctx *CommandContext) {
+	pattern := strings.ToLower(ctx.Args["pattern"])
+	action := strings.ToLower(ctx.Args["action"])
+	if err := Armeria.chatFilterManager.SetRule(pattern, action); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The chat filter could not be changed: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The chat filter will now %s %s.", action, TextStyle(pattern, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminFilterRemoveCommand(ctx *CommandContext) {
+	if !Armeria.chatFilterManager.RemoveRule(ctx.Args["pattern"]) {
+		ctx.Player.client.ShowColorizedText("That word isn't in the chat filter.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The chat filter no longer acts on %s.", TextStyle(ctx.Args["pattern"], WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleAdminFilterFlagsCommand(ctx *CommandContext) {
+	all := strings.ToLower(ctx.Args["all"]) == "all"
+	flags := Armeria.chatFilterManager.Flags(all)
+	if len(flags) == 0 {
+		ctx.Player.client.ShowText("There are no flagged messages waiting for review.")
+		return
+	}
+
+	header := TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Character", header: true},
+		TableCell{content: "Channel", header: true},
+		TableCell{content: "Sent", header: true},
+		TableCell{content: "Message", header: true},
+		TableCell{content: "Reviewed By", header: true},
+	)
+
+	var rows []string
+	for _, f := range flags {
+		name := "(deleted)"
+		if c := Armeria.characterManager.CharacterById(f.CharacterID); c != nil {
+			name = c.FormattedName()
+		}
+		reviewer := TextStyle("review", WithLinkCmd(fmt.Sprintf("/admin filter review %d", f.ID)))
+		if c := Armeria.characterManager.CharacterById(f.ReviewedBy); c != nil {
+			reviewer = c.FormattedName()
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(f.ID)},
+			TableCell{content: name},
+			TableCell{content: f.Channel},
+			TableCell{content: f.Time.Format("2006-01-02 15:04")},
+			TableCell{content: TextStylePlayer(f.Text)},
+			TableCell{content: reviewer},
+		))
+	}
+
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
+}
+
+func handleAdminFilterReviewCommand(ctx *CommandContext) {
+	id, err := strconv.Atoi(ctx.Args["id"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid flagged message id.", ColorError)
+		return
+	}
+
+	if err := Armeria.chatFilterManager.Review(id, ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Flagged message %s has been marked as reviewed.", TextStyle(fmt.Sprintf("#%d", id), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleHistoryCommand(ctx *CommandContext) {
+	count := 20
+	channel := ctx.Args["channel"]
+
+	if len(ctx.Args["count"]) > 0 {
+		n, err := strconv.Atoi(ctx.Args["count"])
+		if err != nil {
+			// Allow the count to be omitted, ie: /history whisper
+			channel = ctx.Args["count"]
+		} else if n < 1 || n > ChatHistoryLimit {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The count must be between 1 and %d.", ChatHistoryLimit),
+				ColorError,
+			)
+			return
+		} else {
+			count = n
+		}
+	}
+
+	entries := ctx.Character.ChatHistory(count, channel)
+	if len(entries) == 0 {
+		ctx.Player.client.ShowText("There are no messages in your history.")
+		return
+	}
+
+	lines := []string{TextStyle(fmt.Sprintf("Your last %d message(s):", len(entries)), WithBold())}
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("[%s] %s", e.Time.Format("15:04"), e.Text))
+	}
+
+	ctx.Player.client.ShowText(strings.Join(lines, "\n"))
+}
+
+func handleGrepCommand(ctx *CommandContext) {
+	re, err := regexp.Compile("(?i)" + ctx.Args["pattern"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid regular expression.", ColorError)
+		return
+	}
+
+	lines := 20
+	if len(ctx.Args["lines"]) > 0 {
+		lines, err = strconv.Atoi(ctx.Args["lines"])
+		if err != nil || lines < 1 || lines > OutputBufferLimit {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("The number of lines must be between 1 and %d.", OutputBufferLimit),
+				ColorError,
+			)
+			return
+		}
+	}
+
+	matches := ctx.Character.SearchOutput(re, lines)
+	if len(matches) == 0 {
+		ctx.Player.client.ShowUnbufferedText("\nNothing you've seen recently matches that pattern.")
+		return
+	}
+
+	results := []string{TextStyle(fmt.Sprintf("%d matching line(s):", len(matches)), WithBold())}
+	for _, m := range matches {
+		results = append(results, re.ReplaceAllStringFunc(m, func(match string) string {
+			return TextStyle(match, WithBold(), WithUserColor(ctx.Character, ColorSuccess))
+		}))
+	}
+
+	ctx.Player.client.ShowUnbufferedText("\n" + strings.Join(results, "\n"))
+}
+
+func handleAdminMultiboxCommand(ctx *CommandContext) {
+	byIP := make(map[string][]string)
+	var ips []string
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		ip := c.Player().IP()
+		if _, ok := byIP[ip]; !ok {
+			ips = append(ips, ip)
+		}
+		byIP[ip] = append(byIP[ip], c.FormattedName())
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Address", header: true},
+		TableCell{content: "Characters", header: true},
+	)}
+	for _, ip := range ips {
+		if len(byIP[ip]) < 2 {
+			continue
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: ip},
+			TableCell{content: strings.Join(byIP[ip], ", ")},
+		))
+	}
+
+	if len(rows) == 1 {
+		ctx.Player.client.ShowText("No address has more than one character logged in.")
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf("The multibox policy is [b]%s[/b].\n%s", Armeria.multiboxPolicy, TextTable(rows...)),
+	)
+}
+
+func handleBestiaryCommand(ctx *CommandContext) {
+	name := ctx.Args["mob"]
+
+	if len(name) == 0 {
+		known := ctx.Character.Bestiary()
+		if len(known) == 0 {
+			ctx.Player.client.ShowText("You haven't encountered any creatures yet.")
+			return
+		}
+
+		rows := []string{TableRow(
+			TableCell{content: "Creature", header: true},
+			TableCell{content: "Kills", header: true},
+			TableCell{content: "First Encountered", header: true},
+		)}
+		for _, n := range known {
+			e := ctx.Character.BestiaryEntry(n)
+			rows = append(rows, TableRow(
+				TableCell{content: TextStyle(n, WithLinkCmd(fmt.Sprintf("/bestiary %s", n)))},
+				TableCell{content: TextNumber(e.Kills)},
+				TableCell{content: e.Encountered.Format("Jan 2, 2006")},
+			))
+		}
+
+		ctx.Player.client.ShowText(TextTable(rows...))
+		return
+	}
+
+	e := ctx.Character.BestiaryEntry(name)
+	m := Armeria.mobManager.MobByName(name)
+	if e == nil || m == nil {
+		ctx.Player.client.ShowColorizedText("You haven't encountered a creature by that name.", ColorError)
+		return
+	}
+
+	lore := m.Attribute(AttributeLore)
+	if len(lore) == 0 {
+		lore = "Little is known about this creature."
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"%s\n%s\nYou have slain %s.",
+			TextStyle(m.Name(), WithBold()),
+			lore,
+			TextStyle(TextNumber(e.Kills), WithBold()),
+		),
+	)
+}
+
+func handleTitleKillRewardCommand(ctx *CommandContext) {
+	t := Armeria.titleManager.TitleByName(ctx.Args["title"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("That title isn't in the catalog.", ColorError)
+		return
+	}
+
+	kills, err := strconv.Atoi(ctx.Args["kills"])
+	if err != nil || kills < 0 {
+		ctx.Player.client.ShowColorizedText("The number of kills must be a number of 0 or more.", ColorError)
+		return
+	}
+
+	if kills == 0 {
+		Armeria.titleManager.SetKillReward(t, "", 0)
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The title %s is no longer earned by slaying mobs.", TextStyle(t.Name, WithBold())),
+			ColorSuccess,
+		)
+		return
+	}
+
+	m := Armeria.mobManager.MobByName(ctx.Args["mob"])
+	if m == nil {
+		ctx.Player.client.ShowColorizedText("That mob doesn't exist.", ColorError)
+		return
+	}
+
+	Armeria.titleManager.SetKillReward(t, m.Name(), kills)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"The title %s is now earned by slaying %d %s.",
+			TextStyle(t.Name, WithBold()),
+			kills,
+			TextStyle(m.Name(), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleAdminSeasonsCommand(ctx *CommandContext) {
+	seasons := Armeria.seasonManager.Seasons()
+	if len(seasons) == 0 {
+		ctx.Player.client.ShowText("There are no seasonal events in the config.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Season", header: true},
+		TableCell{content: "Dates", header: true},
+		TableCell{content: "Rooms", header: true},
+		TableCell{content: "Active", header: true},
+	)}
+	for _, s := range seasons {
+		active := "no"
+		if Armeria.seasonManager.Active(s.Name) {
+			active = TextStyle("yes", WithBold())
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: s.Name},
+			TableCell{content: fmt.Sprintf("%s to %s", s.Start, s.End)},
+			TableCell{content: strconv.Itoa(len(s.Rooms))},
+			TableCell{content: active},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+// parseBet parses a bet, returning an error if it is invalid or outside of the betting limits.
+func parseBet(s string) (float64, error) {
+	bet, err := strconv.ParseFloat(strings.TrimPrefix(s, "$"), 64)
+	if err != nil {
+		return 0, errors.New("bets must be a number")
+	}
+	return bet, validateBet(bet)
+}
+
+func handleGambleGamesCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Game", header: true},
+		TableCell{content: "Rules", header: true},
+	)}
+	for _, g := range Minigames() {
+		rows = append(rows, TableRow(
+			TableCell{content: g.Name},
+			TableCell{content: g.Help},
+		))
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"%s\nBets range from %s to %s, and winners double their bet. The house wins ties.",
+			TextTable(rows...),
+			misc.Money.FormatMoney(MinimumBet),
+			misc.Money.FormatMoney(MaximumBet),
+		),
+	)
+}
+
+func handleGamblePlayCommand(ctx *CommandContext) {
+	if ctx.Character.Room().Attribute(AttributeType) != RoomTypeCasino {
+		ctx.Player.client.ShowColorizedText("You can only play against the house in a casino.", ColorError)
+		return
+	}
+
+	game := MinigameByName(ctx.Args["game"])
+	if game == nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("That game doesn't exist. Choose one of: %s.", strings.Join(MinigameNames(), ", ")),
+			ColorError,
+		)
+		return
+	}
+
+	bet, err := parseBet(ctx.Args["bet"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't play: %s.", err), ColorError)
+		return
+	}
+
+	if _, err := PlayHouse(ctx.Character, game, bet, "The house"); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't play: %s.", err), ColorError)
+	}
+}
+
+func handleGambleChallengeCommand(ctx *CommandContext) {
+	game := MinigameByName(ctx.Args["game"])
+	if game == nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("That game doesn't exist. Choose one of: %s.", strings.Join(MinigameNames(), ", ")),
+			ColorError,
+		)
+		return
+	}
+
+	bet, err := parseBet(ctx.Args["bet"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't challenge them: %s.", err), ColorError)
+		return
+	}
+
+	opponent := Armeria.characterManager.CharacterByName(ctx.Args["character"])
+	if opponent == nil || !opponent.Online() || opponent.Room() != ctx.Character.Room() || opponent == ctx.Character {
+		ctx.Player.client.ShowColorizedText("There's nobody here by that name to challenge.", ColorError)
+		return
+	}
+
+	if ctx.Character.Money() < bet {
+		ctx.Player.client.ShowColorizedText("You can't afford that bet.", ColorError)
+		return
+	}
+
+	Armeria.casinoManager.Challenge(&Wager{
+		Game:       game,
+		Challenger: ctx.Character,
+		Opponent:   opponent,
+		Bet:        bet,
+		Created:    time.Now(),
+	})
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"You challenged %s to a game of %s for %s.",
+			opponent.FormattedName(),
+			TextStyle(game.Name, WithBold()),
+			misc.Money.FormatMoney(bet),
+		),
+	)
+	opponent.Player().client.ShowText(
+		fmt.Sprintf(
+			"%s challenged you to a game of %s for %s. Type %s or %s.",
+			ctx.Character.FormattedName(),
+			TextStyle(game.Name, WithBold()),
+			misc.Money.FormatMoney(bet),
+			TextStyle("/gamble accept", WithLinkCmd("/gamble accept")),
+			TextStyle("/gamble decline", WithLinkCmd("/gamble decline")),
+		),
+	)
+}
+
+func handleGambleAcceptCommand(ctx *CommandContext) {
+	w := Armeria.casinoManager.WagerFor(ctx.Character)
+	if w == nil {
+		ctx.Player.client.ShowColorizedText("You haven't been challenged to a game.", ColorError)
+		return
+	}
+
+	if err := w.Resolve(); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The game is off: %s.", err), ColorError)
+		if w.Challenger.Online() {
+			w.Challenger.Player().client.ShowColorizedText(
+				fmt.Sprintf("Your game with %s is off: %s.", ctx.Character.FormattedName(), err),
+				ColorError,
+			)
+		}
+	}
+}
+
+func handleGambleDeclineCommand(ctx *CommandContext) {
+	w := Armeria.casinoManager.WagerFor(ctx.Character)
+	if w == nil {
+		ctx.Player.client.ShowColorizedText("You haven't been challenged to a game.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowText(fmt.Sprintf("You declined the challenge from %s.", w.Challenger.FormattedName()))
+	if w.Challenger.Online() {
+		w.Challenger.Player().client.ShowText(
+			fmt.Sprintf("%s declined your challenge.", ctx.Character.FormattedName()),
+		)
+	}
+}
+
+func handleLotteryInfoCommand(ctx *CommandContext) {
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"The lottery pot is %s, and the next drawing is in %s. You have %s for this drawing, at %s each.",
+			TextStyle(misc.Money.FormatMoney(Armeria.lotteryManager.Pot()*(1-LotteryHouseCut)), WithBold()),
+			TextDuration(time.Until(Armeria.lotteryManager.NextDraw())),
+			TextStyle(TextNumber(Armeria.lotteryManager.Tickets(ctx.Character))+" tickets", WithBold()),
+			misc.Money.FormatMoney(LotteryTicketPrice),
+		),
+	)
+}
+
+func handleLotteryBuyCommand(ctx *CommandContext) {
+	count := 1
+	if len(ctx.Args["tickets"]) > 0 {
+		var err error
+		count, err = strconv.Atoi(ctx.Args["tickets"])
+		if err != nil || count < 1 {
+			ctx.Player.client.ShowColorizedText("You must buy at least one ticket.", ColorError)
+			return
+		}
+	}
+
+	if !Armeria.lotteryManager.BuyTickets(ctx.Character, count) {
+		ctx.Player.client.ShowColorizedText("You can't afford that many tickets.", ColorError)
+		return
+	}
+
+	ctx.Player.client.SyncMoney()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You bought %s lottery tickets, and now have %s for the next drawing. Good luck!",
+			TextNumber(count),
+			TextNumber(Armeria.lotteryManager.Tickets(ctx.Character)),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleLotteryDrawCommand(ctx *CommandContext) {
+	winner, winnings := Armeria.lotteryManager.Draw()
+	if winner == nil {
+		ctx.Player.client.ShowColorizedText("Nobody bought tickets for this drawing.", ColorError)
+		return
+	}
+
+	AnnounceLotteryWinner(winner, winnings)
+}
+
+func handleGatherCommand(ctx *CommandContext) {
+	var nodes []*ItemInstance
+	for _, ii := range ctx.Character.Room().Here().Items() {
+		t := ii.Attribute(AttributeType)
+		if t != ItemTypeGatheringNode && t != ItemTypeCorpse {
+			continue
+		}
+		if len(ctx.Args["node"]) == 0 || strings.ToLower(ii.Name()) == strings.ToLower(ctx.Args["node"]) {
+			nodes = append(nodes, ii)
+		}
+	}
+
+	if len(nodes) == 0 {
+		ctx.Player.client.ShowColorizedText("There's nothing here to gather from.", ColorError)
+		return
+	}
+
+	node := nodes[0]
+	var ii *ItemInstance
+	var err error
+	if node.Attribute(AttributeType) == ItemTypeCorpse {
+		ii, err = ctx.Character.Harvest(node)
+	} else {
+		ii, err = ctx.Character.Gather(node)
+	}
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You can't gather from %s: %s.", node.FormattedName(), err),
+			ColorError,
+		)
+		return
+	}
+
+	if ii == nil {
+		ctx.Player.client.ShowText(fmt.Sprintf("You try your luck at %s, but come away empty-handed.", node.FormattedName()))
+		return
+	}
+
+	ctx.Player.client.SyncInventory()
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You gathered %s from %s.", ii.FormattedName(), node.FormattedName()),
+		ColorSuccess,
+	)
+
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s gathered %s from %s.", ctx.Character.FormattedName(), ii.FormattedName(), node.FormattedName()),
+		)
+	}
+}
+
+// contractTable renders a list of contracts as a table.
+func contractTable(contracts []*Contract) string {
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Payment", header: true},
+		TableCell{content: "Poster", header: true},
+		TableCell{content: "Status", header: true},
+	)}
+
+	Armeria.contractManager.RLock()
+	defer Armeria.contractManager.RUnlock()
+
+	for _, ct := range contracts {
+		poster := "(deleted)"
+		if c := ct.Poster(); c != nil {
+			poster = c.Name()
+		}
+		status := ct.Status
+		if ct.Status == ContractStatusOpen {
+			status = fmt.Sprintf("open for %s", TextDuration(time.Until(ct.Expires)))
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(strconv.Itoa(ct.ID), WithLinkCmd(fmt.Sprintf("/contract show %d", ct.ID)))},
+			TableCell{content: ct.Item},
+			TableCell{content: misc.Money.FormatMoney(ct.Payment)},
+			TableCell{content: poster},
+			TableCell{content: status},
+		))
+	}
+
+	return TextTable(rows...)
+}
+
+// contractFromArgs returns the Contract identified by the "id" argument, showing an error if it doesn't exist.
+func contractFromArgs(ctx *CommandContext) *Contract {
+	id, err := strconv.Atoi(strings.TrimPrefix(ctx.Args["id"], "#"))
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("Contracts are identified by number.", ColorError)
+		return nil
+	}
+
+	ct := Armeria.contractManager.Contract(id)
+	if ct == nil {
+		ctx.Player.client.ShowColorizedText("That contract doesn't exist.", ColorError)
+		return nil
+	}
+
+	return ct
+}
+
+// requireContractBoard shows an error and returns false if there is no contract board in the character's room.
+func requireContractBoard(ctx *CommandContext) bool {
+	if !ctx.Character.ContractBoardHere() {
+		ctx.Player.client.ShowColorizedText("You need to be at a contract board to do that.", ColorError)
+		return false
+	}
+	return true
+}
+
+func handleContractListCommand(ctx *CommandContext) {
+	contracts := Armeria.contractManager.Contracts(ContractStatusOpen)
+	if len(contracts) == 0 {
+		ctx.Player.client.ShowText("There are no open contracts.")
+		return
+	}
+
+	ctx.Player.client.ShowText(contractTable(contracts))
+}
+
+func handleContractMineCommand(ctx *CommandContext) {
+	contracts := Armeria.contractManager.ContractsInvolving(ctx.Character)
+	if len(contracts) == 0 {
+		ctx.Player.client.ShowText("You don't have any contracts in progress.")
+		return
+	}
+
+	ctx.Player.client.ShowText(contractTable(contracts))
+}
+
+func handleContractShowCommand(ctx *CommandContext) {
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+
+	crafter := "nobody yet"
+	if c := ct.Crafter(); c != nil {
+		crafter = c.Name()
+	}
+
+	lines := []string{contractTable([]*Contract{ct})}
+
+	Armeria.contractManager.RLock()
+	lines = append(lines, fmt.Sprintf("Posted %s, fulfilled by %s.", ct.Created.Format("Jan 2 15:04"), crafter))
+	if ct.Status == ContractStatusDelivered && ct.Delivered != nil {
+		lines = append(lines, fmt.Sprintf(
+			"The payment is released in %s, unless the delivery is disputed.",
+			TextDuration(time.Until(ct.Delivered.Add(ContractReleaseDelay))),
+		))
+	}
+	if len(ct.Dispute) > 0 {
+		lines = append(lines, fmt.Sprintf("Disputed: %s", TextStylePlayer(ct.Dispute)))
+	}
+	Armeria.contractManager.RUnlock()
+
+	ctx.Player.client.ShowText(strings.Join(lines, "\n"))
+}
+
+func handleContractPostCommand(ctx *CommandContext) {
+	if !requireContractBoard(ctx) {
+		return
+	}
+
+	payment, err := strconv.ParseFloat(strings.TrimPrefix(ctx.Args["payment"], "$"), 64)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("The payment must be a number.", ColorError)
+		return
+	}
+	days, err := strconv.Atoi(ctx.Args["days"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("The number of days must be a whole number.", ColorError)
+		return
+	}
+	item := Armeria.itemManager.ItemByName(ctx.Args["item"])
+	if item == nil {
+		ctx.Player.client.ShowColorizedText("That item doesn't exist.", ColorError)
+		return
+	}
+
+	ct, err := Armeria.contractManager.Post(ctx.Character, item, payment, days)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't post that contract: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You posted contract #%d for a %s. The board holds your %s until it's delivered.",
+			ct.ID,
+			TextStyle(item.Name(), WithBold()),
+			ctx.Character.Colorize(misc.Money.FormatMoney(payment), ColorMoney),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleContractCancelCommand(ctx *CommandContext) {
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+	if ct.PosterID != ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can only cancel your own contracts.", ColorError)
+		return
+	}
+
+	if err := Armeria.contractManager.Cancel(ct); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't cancel that: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("You cancelled contract #%d.", ct.ID), ColorSuccess)
+}
+
+func handleContractFulfillCommand(ctx *CommandContext) {
+	if !requireContractBoard(ctx) {
+		return
+	}
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+
+	if err := Armeria.contractManager.Deliver(ct, ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't fulfill that: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You delivered a %s for contract #%d. You'll be paid once it's collected, or in %s.",
+			TextStyle(ct.Item, WithBold()),
+			ct.ID,
+			TextDuration(ContractReleaseDelay),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleContractCollectCommand(ctx *CommandContext) {
+	if !requireContractBoard(ctx) {
+		return
+	}
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+	if ct.PosterID != ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can only collect from your own contracts.", ColorError)
+		return
+	}
+
+	if err := Armeria.contractManager.Collect(ct, ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't collect that: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You collected the %s from contract #%d.", TextStyle(ct.Item, WithBold()), ct.ID),
+		ColorSuccess,
+	)
+}
+
+func handleContractDisputeCommand(ctx *CommandContext) {
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+	if ct.PosterID != ctx.Character.ID() {
+		ctx.Player.client.ShowColorizedText("You can only dispute your own contracts.", ColorError)
+		return
+	}
+
+	if err := Armeria.contractManager.Dispute(ct, ctx.Args["reason"]); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't dispute that: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You disputed contract #%d. The payment is held until staff settle it.", ct.ID),
+		ColorSuccess,
+	)
+}
+
+func handleContractDisputesCommand(ctx *CommandContext) {
+	contracts := Armeria.contractManager.Contracts(ContractStatusDisputed)
+	if len(contracts) == 0 {
+		ctx.Player.client.ShowText("There are no disputed contracts.")
+		return
+	}
+
+	ctx.Player.client.ShowText(contractTable(contracts))
+}
+
+func handleContractResolveCommand(ctx *CommandContext) {
+	ct := contractFromArgs(ctx)
+	if ct == nil {
+		return
+	}
+
+	favor := strings.ToLower(ctx.Args["favor"])
+	if favor != "poster" && favor != "crafter" {
+		ctx.Player.client.ShowColorizedText("Contracts are settled in favor of the poster or the crafter.", ColorError)
+		return
+	}
+
+	if err := Armeria.contractManager.Resolve(ct, favor == "crafter"); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't resolve that: %s.", err), ColorError)
+		return
+	}
+
+	Armeria.log.Info("contract dispute resolved",
+		zap.Int("id", ct.ID),
+		zap.String("by", ctx.Character.Name()),
+		zap.String("favor", favor),
+	)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You settled contract #%d in favor of the %s.", ct.ID, favor),
+		ColorSuccess,
+	)
+}
+
+func handleScoreCommand(ctx *CommandContext) {
+	c := ctx.Character
+
+	experience := strconv.Itoa(c.Experience())
+	if c.Level() < MaxLevel {
+		experience = fmt.Sprintf("%d / %d", c.Experience(), ExperienceForLevel(c.Level()+1))
+	}
+
+	rows := []string{
+		TableRow(
+			TableCell{content: "Level", header: true},
+			TableCell{content: fmt.Sprintf("%d / %d", c.Level(), MaxLevel)},
+		),
+		TableRow(
+			TableCell{content: "Experience", header: true},
+			TableCell{content: experience},
+		),
+		TableRow(
+			TableCell{content: "Health", header: true},
+			TableCell{content: fmt.Sprintf("%d / %d", Armeria.combatManager.Health(c), CombatMaxHealth(c))},
+		),
+		TableRow(
+			TableCell{content: "Damage", header: true},
+			TableCell{content: strconv.Itoa(CombatDamage(c))},
+		),
+		TableRow(
+			TableCell{content: "Armor", header: true},
+			TableCell{content: strconv.Itoa(CombatArmor(c))},
+		),
+	}
+	for _, s := range GatheringSkills() {
+		rows = append(rows, TableRow(
+			TableCell{content: strings.Title(s), header: true},
+			TableCell{content: fmt.Sprintf("%d / %d", c.GatheringSkill(s), MaxGatheringSkill)},
+		))
+	}
+	rows = append(rows, TableRow(
+		TableCell{content: "Taming", header: true},
+		TableCell{content: fmt.Sprintf("%d / %d", c.TamingSkill(), MaxTamingSkill)},
+	), TableRow(
+		TableCell{content: "Money", header: true},
+		TableCell{content: c.Colorize(misc.Money.FormatMoney(c.Money()), ColorMoney)},
+	))
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf("%s\n%s", c.FormattedNameWithTitle(), TextTable(rows...)),
+	)
+}
+
+func handleSkillsCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Skill", header: true},
+		TableCell{content: "Level", header: true},
+	)}
+	for _, s := range GatheringSkills() {
+		rows = append(rows, TableRow(
+			TableCell{content: strings.Title(s)},
+			TableCell{content: fmt.Sprintf("%d / %d", ctx.Character.GatheringSkill(s), MaxGatheringSkill)},
+		))
+	}
+	rows = append(rows, TableRow(
+		TableCell{content: "Taming"},
+		TableCell{content: fmt.Sprintf("%d / %d", ctx.Character.TamingSkill(), MaxTamingSkill)},
+	))
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+// showPetition shows a Petition and its responses to a Player.
+func showPetition(p *Player, pt *Petition) {
+	Armeria.petitionManager.RLock()
+	lines := []string{
+		fmt.Sprintf(
+			"%s %s",
+			TextStyle(fmt.Sprintf("Petition #%d", pt.ID), WithBold()),
+			fmt.Sprintf("(%s, sent %s)", pt.Status, pt.Created.Format("Jan 2 15:04")),
+		),
+		TextStylePlayer(pt.Text),
+	}
+	if len(pt.ClaimedBy) > 0 {
+		lines = append(lines, fmt.Sprintf("Handled by %s.", TextStyle(pt.ClaimedBy, WithBold())))
+	}
+	for _, r := range pt.Responses {
+		lines = append(lines, fmt.Sprintf(
+			"%s %s: %s",
+			r.Time.Format("Jan 2 15:04"),
+			TextStyle(r.Author, WithBold()),
+			TextStylePlayer(r.Text),
+		))
+	}
+	Armeria.petitionManager.RUnlock()
+
+	p.client.ShowText(strings.Join(lines, "\n"))
+}
+
+// petitionTable returns a table summarizing petitions.
+func petitionTable(petitions []*Petition) string {
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "From", header: true},
+		TableCell{content: "Status", header: true},
+		TableCell{content: "Petition", header: true},
+	)}
+
+	Armeria.petitionManager.RLock()
+	defer Armeria.petitionManager.RUnlock()
+
+	for _, pt := range petitions {
+		from := "(deleted)"
+		if c := pt.Character(); c != nil {
+			from = c.Name()
+		}
+		status := pt.Status
+		if len(pt.ClaimedBy) > 0 {
+			status = fmt.Sprintf("%s by %s", status, pt.ClaimedBy)
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(strconv.Itoa(pt.ID), WithLinkCmd(fmt.Sprintf("/ticket view %d", pt.ID)))},
+			TableCell{content: from},
+			TableCell{content: status},
+			TableCell{content: TextStylePlayer(pt.Text)},
+		))
+	}
+
+	return TextTable(rows...)
+}
+
+// petitionFromArgs returns the Petition matching the id argument, showing an error if it doesn't exist.
+func petitionFromArgs(ctx *CommandContext) *Petition {
+	id, err := strconv.Atoi(strings.TrimPrefix(ctx.Args["id"], "#"))
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("Petitions are identified by number.", ColorError)
+		return nil
+	}
+
+	pt := Armeria.petitionManager.Petition(id)
+	if pt == nil {
+		ctx.Player.client.ShowColorizedText("That petition doesn't exist.", ColorError)
+		return nil
+	}
+
+	return pt
+}
+
+func handlePetitionCommand(ctx *CommandContext) {
+	pt := Armeria.petitionManager.Create(ctx.Character, ctx.Args["text"])
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"Your petition (#%d) was sent to staff. You'll be notified when they respond, or type %s to check on it.",
+			pt.ID,
+			TextStyle("/petitions", WithLinkCmd("/petitions")),
+		),
+		ColorSuccess,
+	)
+}
+
+func handlePetitionsCommand(ctx *CommandContext) {
+	if len(ctx.Args["id"]) > 0 {
+		pt := petitionFromArgs(ctx)
+		if pt == nil {
+			return
+		}
+		if pt.CharacterID != ctx.Character.ID() {
+			ctx.Player.client.ShowColorizedText("That petition doesn't exist.", ColorError)
+			return
+		}
+		showPetition(ctx.Player, pt)
+		Armeria.petitionManager.MarkRead(pt)
+		return
+	}
+
+	petitions := Armeria.petitionManager.PetitionsBy(ctx.Character)
+	if len(petitions) == 0 {
+		ctx.Player.client.ShowText("You haven't sent any petitions.")
+		return
+	}
+
+	for _, pt := range petitions {
+		showPetition(ctx.Player, pt)
+		Armeria.petitionManager.MarkRead(pt)
+	}
+}
+
+func handleTicketListCommand(ctx *CommandContext) {
+	petitions := Armeria.petitionManager.Petitions(len(ctx.Args["all"]) > 0)
+	if len(petitions) == 0 {
+		ctx.Player.client.ShowText("There are no open petitions.")
+		return
+	}
+
+	ctx.Player.client.ShowText(petitionTable(petitions))
+}
+
+func handleTicketViewCommand(ctx *CommandContext) {
+	if pt := petitionFromArgs(ctx); pt != nil {
+		showPetition(ctx.Player, pt)
+	}
+}
+
+func handleTicketClaimCommand(ctx *CommandContext) {
+	pt := petitionFromArgs(ctx)
+	if pt == nil {
+		return
+	}
+
+	Armeria.petitionManager.Claim(pt, ctx.Character)
+	NotifyStaff(fmt.Sprintf("%s claimed petition #%d.", ctx.Character.FormattedName(), pt.ID))
+}
+
+func handleTicketRespondCommand(ctx *CommandContext) {
+	pt := petitionFromArgs(ctx)
+	if pt == nil {
+		return
+	}
+
+	Armeria.petitionManager.Respond(pt, ctx.Character, ctx.Args["text"])
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("You responded to petition #%d.", pt.ID), ColorSuccess)
+}
+
+func handleTicketCloseCommand(ctx *CommandContext) {
+	pt := petitionFromArgs(ctx)
+	if pt == nil {
+		return
+	}
+
+	if len(ctx.Args["text"]) > 0 {
+		Armeria.petitionManager.Respond(pt, ctx.Character, ctx.Args["text"])
+	}
+	Armeria.petitionManager.Close(pt, ctx.Character)
+	NotifyStaff(fmt.Sprintf("%s closed petition #%d.", ctx.Character.FormattedName(), pt.ID))
+}
+
+func handleNoteAddCommand(ctx *CommandContext) {
+	targetType, target, text := ParseNoteTarget(ctx.Character, ctx.Args["text"])
+	if len(text) == 0 {
+		ctx.Player.client.ShowColorizedText("The note can't be empty.", ColorError)
+		return
+	}
+
+	n := Armeria.noteManager.Add(ctx.Character, targetType, target, text)
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You added note %s to the %s.", TextStyle(fmt.Sprintf("#%d", n.ID), WithBold()), n.TargetName()),
+		ColorSuccess,
+	)
+}
+
+func handleNoteListCommand(ctx *CommandContext) {
+	filter := ctx.Args["filter"]
+
+	var notes []Note
+	switch strings.ToLower(filter) {
+	case "":
+		notes = Armeria.noteManager.Notes(func(n *Note) bool { return !n.IsResolved() })
+	case "all":
+		notes = Armeria.noteManager.Notes(func(n *Note) bool { return true })
+	default:
+		targetType, target, _ := ParseNoteTarget(ctx.Character, filter+":")
+		if len(targetType) == 0 {
+			ctx.Player.client.ShowColorizedText("There is no mob or item by that name.", ColorError)
+			return
+		}
+		notes = Armeria.noteManager.OpenNotesFor(targetType, target)
+	}
+
+	if len(notes) == 0 {
+		ctx.Player.client.ShowText("There are no open notes.")
+		return
+	}
+
+	header := TableRow(
+		TableCell{content: "ID", header: true},
+		TableCell{content: "Attached To", header: true},
+		TableCell{content: "Author", header: true},
+		TableCell{content: "Written", header: true},
+		TableCell{content: "Note", header: true},
+		TableCell{content: "", header: true},
+	)
+
+	var rows []string
+	for _, n := range notes {
+		status := TextStyle("resolve", WithLinkCmd(fmt.Sprintf("/note resolve %d", n.ID)))
+		if n.IsResolved() {
+			status = "resolved"
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(n.ID)},
+			TableCell{content: n.TargetName()},
+			TableCell{content: n.AuthorName()},
+			TableCell{content: n.Created.Format("2006-01-02")},
+			TableCell{content: TextStylePlayer(n.Text)},
+			TableCell{content: status},
+		))
+	}
+
+	ctx.Player.ShowPages(TablePages(header, rows, PagerPageSize))
+}
+
+func handleNoteResolveCommand(ctx *CommandContext) {
+	id, err := strconv.Atoi(ctx.Args["id"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("That's not a valid note id.", ColorError)
+		return
+	}
+
+	if err := Armeria.noteManager.Resolve(id, ctx.Character); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Note %s has been resolved.", TextStyle(fmt.Sprintf("#%d", id), WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleBoardCommand(ctx *CommandContext) {
+	result := ctx.Character.Room().Here().GetLoose(ctx.Args["vehicle"])
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
+	if result.Type != RegistryTypeItemInstance {
+		ctx.Player.client.ShowColorizedText("You don't see a vehicle by that name.", ColorError)
+		return
+	}
+
+	v := result.Object.(*ItemInstance)
+	if err := ctx.Character.Board(v); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowText(fmt.Sprintf("You climb aboard the %s.", v.FormattedName()))
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s climbs aboard the %s.", ctx.Character.FormattedName(), v.FormattedName()),
+		)
+	}
+}
+
+func handleDisembarkCommand(ctx *CommandContext) {
+	v := ctx.Character.Vehicle()
+	if err := ctx.Character.Disembark(); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowText(fmt.Sprintf("You climb off of the %s.", v.FormattedName()))
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s climbs off of the %s.", ctx.Character.FormattedName(), v.FormattedName()),
+		)
+	}
+}
+
+func handleSteerCommand(ctx *CommandContext) {
+	v := ctx.Character.Vehicle()
+	if v == nil {
+		ctx.Player.client.ShowColorizedText("You aren't aboard a vehicle.", ColorError)
+		return
+	}
+
+	if len(VehicleRoute(v)) > 0 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The %s follows a set route, and can't be steered.", v.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	direction := misc.NormalizeDirection(ctx.Args["direction"])
+	if len(direction) == 0 {
+		ctx.Player.client.ShowColorizedText("That's not a valid direction to steer in.", ColorError)
+		return
+	}
+
+	if err := DriveVehicle(v, direction); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+	}
+}
+
+func handleFollowCommand(ctx *CommandContext) {
+	name := ctx.Args["character"]
+
+	if len(name) == 0 {
+		leader := ctx.Character.StopFollowing()
+		if leader == nil {
+			ctx.Player.client.ShowColorizedText("You aren't following anyone.", ColorError)
+			return
+		}
+
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You're no longer following %s.", leader.FormattedName()),
+			ColorSuccess,
+		)
+		return
+	}
+
+	leader := Armeria.characterManager.CharacterByName(name)
+	if leader == nil || !leader.Online() || leader.Room() != ctx.Character.Room() {
+		ctx.Player.client.ShowColorizedText("There's nobody here by that name.", ColorError)
+		return
+	}
+
+	previous := ctx.Character.Leader()
+	if err := ctx.Character.Follow(leader); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	if previous != nil {
+		previous.Player().client.ShowText(
+			TextStyle(
+				fmt.Sprintf("%s is no longer following you.", ctx.Character.FormattedName()),
+				WithUserColor(previous, ColorMovement),
+			),
+		)
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You're now following %s. Use %s to stop.",
+			leader.FormattedName(),
+			TextStyle("/follow", WithLinkCmd("/follow")),
+		),
+		ColorSuccess,
+	)
+	leader.Player().client.ShowText(
+		TextStyle(fmt.Sprintf("%s is now following you.", ctx.Character.FormattedName()), WithUserColor(leader, ColorMovement)),
+	)
+}
+
+func handleLoseCommand(ctx *CommandContext) {
+	name := ctx.Args["character"]
+
+	followers := ctx.Character.Followers()
+	if len(followers) == 0 {
+		ctx.Player.client.ShowColorizedText("Nobody is following you.", ColorError)
+		return
+	}
+
+	var lost []string
+	for _, f := range followers {
+		if len(name) == 0 || strings.ToLower(f.Name()) == strings.ToLower(name) {
+			ctx.Character.Lose(f)
+			lost = append(lost, f.FormattedName())
+		}
+	}
+
+	if len(lost) == 0 {
+		ctx.Player.client.ShowColorizedText("That character isn't following you.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You've lost %s.", strings.Join(lost, ", ")),
+		ColorSuccess,
+	)
+}
+
+func handleWalkToCommand(ctx *CommandContext) {
+	d := ctx.Args["destination"]
+
+	if len(d) == 0 {
+		if !ctx.Character.StopWalking() {
+			ctx.Player.client.ShowColorizedText("You aren't walking anywhere.", ColorError)
+			return
+		}
+		ctx.Player.client.ShowColorizedText("You stop walking.", ColorSuccess)
+		return
+	}
+
+	var destination *Room
+	switch strings.Count(d, ",") {
+	case 2:
+		if c := NewCoordsFromString(d); c != nil {
+			destination = ctx.Character.Room().ParentArea.RoomAt(c)
+		}
+	case 3:
+		destination = Armeria.worldManager.RoomByLocation(d)
+	default:
+		destination = Armeria.worldManager.Waypoint(d, ctx.Character.Room().ParentArea)
+	}
+
+	if destination == nil {
+		ctx.Player.client.ShowColorizedText("There's no room or waypoint by that name.", ColorError)
+		return
+	}
+
+	steps, err := ctx.Character.WalkTo(destination)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You set off for %s, %d rooms away. Use %s to stop.",
+			TextStyle(destination.Attribute(AttributeTitle), WithBold()),
+			steps,
+			TextStyle("/walkto", WithLinkCmd("/walkto")),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleMoreCommand(ctx *CommandContext) {
+	if !ctx.Player.ShowNextPage() {
+		ctx.Player.client.ShowColorizedText("There's nothing more to show.", ColorError)
+	}
+}
+
+func handleLanguageCommand(ctx *CommandContext) {
+	lang := strings.ToLower(ctx.Args["language"])
+
+	if len(lang) == 0 {
+		ctx.Player.client.ShowText(
+			Tr(
+				ctx.Character,
+				"language.current",
+				TextStyle(ctx.Character.Language(), WithBold()),
+				strings.Join(Armeria.languageManager.Languages(), ", "),
+			),
+		)
+		return
+	}
+
+	if !validLanguage(lang) {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, "language.invalid"), ColorError)
+		return
+	} else if lang == ctx.Character.Language() {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, "language.unchanged"), ColorError)
+		return
+	}
+
+	_ = ctx.Character.SetAttribute(AttributeLanguage, lang)
+
+	ctx.Player.client.ShowColorizedText(
+		Tr(ctx.Character, "language.changed", TextStyle(lang, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleExploredCommand(ctx *CommandContext) {
+	rows := []string{TableRow(
+		TableCell{content: "Area", header: true},
+		TableCell{content: "Rooms Explored", header: true},
+		TableCell{content: "Progress", header: true},
+	)}
+
+	for _, a := range Armeria.worldManager.Areas() {
+		explored, total := ctx.Character.ExplorationProgress(a)
+		if explored == 0 || total == 0 {
+			continue
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: a.Name()},
+			TableCell{content: fmt.Sprintf("%s of %s", TextNumber(explored), TextNumber(total))},
+			TableCell{content: fmt.Sprintf("%d%%", explored*100/total)},
+		))
+	}
+
+	if len(rows) == 1 {
+		ctx.Player.client.ShowText("You haven't explored anywhere yet.")
+		return
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleTitleExploreRewardCommand(ctx *CommandContext) {
+	t := Armeria.titleManager.TitleByName(ctx.Args["title"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("That title isn't in the catalog.", ColorError)
+		return
+	}
+
+	if len(ctx.Args["area"]) == 0 {
+		Armeria.titleManager.SetExploreReward(t, "")
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("The title %s is no longer earned by exploring.", TextStyle(t.Name, WithBold())),
+			ColorSuccess,
+		)
+		return
+	}
+
+	a := Armeria.worldManager.AreaByName(ctx.Args["area"])
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("That area doesn't exist.", ColorError)
+		return
+	}
+
+	Armeria.titleManager.SetExploreReward(t, a.Name())
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"The title %s is now earned by exploring all of %s.",
+			TextStyle(t.Name, WithBold()),
+			TextStyle(a.Name(), WithBold()),
+		),
+		ColorSuccess,
+	)
+}
+
+func handleProfileCommand(ctx *CommandContext) {
+	if ctx.Character.Setting(SettingPublicProfile) != "true" {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"Your profile is private. Use %s to share it on the web.",
+				TextStyle("/settings public_profile true", WithBold()),
+			),
+			ColorCmdHelp,
+		)
+		return
+	}
+
+	link := "/c/" + url.PathEscape(ctx.Character.Name())
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"Your profile is shared at %s (or %s for community tools).",
+			TextStyle(link, WithBold()),
+			TextStyle(link+".json", WithBold()),
+		),
+	)
+}
+
+func handleAdminScriptCommand(ctx *CommandContext) {
+	ctx.Player.client.ShowScriptEditor(GlobalScriptName, GlobalScriptName)
+	ctx.Player.client.ShowColorizedText("The global script has been opened in the script editor.", ColorSuccess)
+}
+
+func handleAttackCommand(ctx *CommandContext) {
+	result := ctx.Character.Room().Here().GetLoose(ctx.Args["target"])
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
+
+	var target Combatant
+	switch result.Type {
+	case RegistryTypeMobInstance:
+		mi := result.Object.(*MobInstance)
+		if mi.Owner() == ctx.Character {
+			ctx.Player.client.ShowColorizedText("You can't attack your own companion.", ColorError)
+			return
+		} else if !mi.Attackable() {
+			ctx.Player.client.ShowColorizedText(
+				fmt.Sprintf("%s can't be attacked.", mi.FormattedName()),
+				ColorError,
+			)
+			return
+		}
+		target = mi
+	case RegistryTypeCharacter:
+		c := result.Object.(*Character)
+		if c == ctx.Character {
+			ctx.Player.client.ShowColorizedText("You can't attack yourself.", ColorError)
+			return
+		} else if !c.Online() {
+			ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+			return
+		}
+		target = c
+	default:
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+		return
+	}
+
+	if Armeria.combatManager.Target(ctx.Character.ID()) == target {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You're already attacking %s.", target.FormattedName()),
+			ColorError,
+		)
+		return
+	}
+
+	Armeria.combatManager.Engage(ctx.Character, target)
+
+	ctx.Player.client.ShowColorizedText(fmt.Sprintf("You attack %s!", target.FormattedName()), ColorSuccess)
+	for _, c := range ctx.Character.Room().Here().Characters(true, ctx.Character) {
+		if c == target {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("%s attacks you!", ctx.Character.FormattedName()),
+				ColorError,
+			)
+			continue
+		}
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s attacks %s!", ctx.Character.FormattedName(), target.FormattedName()),
+		)
+	}
+
+	if mi, ok := target.(*MobInstance); ok {
+		mi.CallForHelp(ctx.Character)
+		go CallMobFunc(ctx.Character, mi, "attacked")
+	}
+}
+
+func handleTameCommand(ctx *CommandContext) {
+	result := ctx.Character.Room().Here().GetLoose(ctx.Args["mob"])
+	if ctx.ShowAmbiguousTarget(result) {
+		return
+	}
+	if result.Type != RegistryTypeMobInstance {
+		ctx.Player.client.ShowColorizedText(Tr(ctx.Character, CommonTargetNotFoundHere), ColorError)
+		return
+	}
+	mi := result.Object.(*MobInstance)
+
+	skill := ctx.Character.TamingSkill()
+	tamed, err := ctx.Character.Tame(mi)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't tame it: %s.", err), ColorError)
+		return
+	}
+
+	others := ctx.Character.Room().Here().Characters(true, ctx.Character)
+	if !tamed {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("%s resists your attempt to tame it, and turns on you!", mi.FormattedName()),
+			ColorError,
+		)
+		for _, c := range others {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s tried to tame %s, and failed.", ctx.Character.FormattedName(), mi.FormattedName()),
+			)
+		}
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("%s is now your companion, and will follow you wherever you go.", mi.FormattedName()),
+		ColorSuccess,
+	)
+	if ctx.Character.TamingSkill() > skill {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("Your taming skill increased to %d.", ctx.Character.TamingSkill()),
+			ColorSuccess,
+		)
+	}
+	for _, c := range others {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s tamed %s.", ctx.Character.FormattedName(), mi.FormattedName()),
+		)
+	}
+}
+
+func handleReleaseCommand(ctx *CommandContext) {
+	mi := ctx.Character.Companion()
+	if mi == nil {
+		ctx.Player.client.ShowColorizedText("You don't have a companion.", ColorError)
+		return
+	}
+
+	mi.Release()
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You released %s back into the wild.", mi.FormattedName()),
+		ColorSuccess,
+	)
+}
+
+func handleCraftRecipesCommand(ctx *CommandContext) {
+	items := CraftableItems()
+	if len(items) == 0 {
+		ctx.Player.client.ShowText("Nothing can be crafted yet.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Ingredients", header: true},
+		TableCell{content: "Time", header: true},
+		TableCell{content: "", header: true},
+	)}
+	for _, i := range items {
+		recipe, _ := ParseRecipe(i.Attribute(AttributeCraftRecipe))
+		seconds, _ := strconv.Atoi(i.Attribute(AttributeCraftTime))
+		rows = append(rows, TableRow(
+			TableCell{content: i.Name()},
+			TableCell{content: recipe.String()},
+			TableCell{content: TextDuration(time.Duration(seconds) * time.Second)},
+			TableCell{content: TextStyle("craft", WithLinkCmd(fmt.Sprintf("/craft make %s", i.Name())))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleCraftMakeCommand(ctx *CommandContext) {
+	item := Armeria.itemManager.ItemByName(ctx.Args["item"])
+	if item == nil || len(item.Attribute(AttributeCraftRecipe)) == 0 {
+		ctx.Player.client.ShowColorizedText("There is no recipe for that.", ColorError)
+		return
+	}
+
+	if err := ctx.Character.QueueCraft(item); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't craft that: %s.", err), ColorError)
+		return
+	}
+
+	queue := ctx.Character.CraftQueue()
+	if len(queue) == 1 {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You start crafting a %s.", TextStyle(item.Name(), WithBold())),
+			ColorSuccess,
+		)
+	} else {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf(
+				"You add a %s to your crafting queue, at position %d.",
+				TextStyle(item.Name(), WithBold()),
+				len(queue),
+			),
+			ColorSuccess,
+		)
+	}
+}
+
+func handleCraftQueueCommand(ctx *CommandContext) {
+	queue := ctx.Character.CraftQueue()
+	if len(queue) == 0 {
+		ctx.Player.client.ShowText("You aren't crafting anything.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "Item", header: true},
+		TableCell{content: "Remaining", header: true},
+		TableCell{content: "", header: true},
+	)}
+	for i, job := range queue {
+		remaining := TextDuration(time.Duration(job.Remaining) * time.Second)
+		if i == 0 && job.Remaining == 0 {
+			remaining = "ready (make room in your inventory)"
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(i + 1)},
+			TableCell{content: job.Item},
+			TableCell{content: remaining},
+			TableCell{content: TextStyle("cancel", WithLinkCmd(fmt.Sprintf("/craft cancel %d", i+1)))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleCraftCancelCommand(ctx *CommandContext) {
+	position, err := strconv.Atoi(ctx.Args["position"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText("The position must be a number.", ColorError)
+		return
+	}
+
+	job, err := ctx.Character.CancelCraft(position - 1)
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("You can't cancel that: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You stop crafting the %s, and get its ingredients back.", TextStyle(job.Item, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleQuestLogCommand(ctx *CommandContext) {
+	quests := ctx.Character.Quests()
+	if len(quests) == 0 {
+		ctx.Player.client.ShowText("You aren't on any quests.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Quest", header: true},
+		TableCell{content: "Objectives", header: true},
+		TableCell{content: "Delivery", header: true},
+		TableCell{content: "", header: true},
+	)}
+	for _, q := range quests {
+		var objectives []string
+		if quest := Armeria.questManager.QuestByName(q); quest != nil {
+			if len(quest.Description) > 0 {
+				objectives = append(objectives, quest.Description)
+			}
+			questObjectives, progress := ctx.Character.QuestProgress(quest)
+			for i, o := range questObjectives {
+				objectives = append(objectives, fmt.Sprintf("%s (%d/%d)", o, progress[i], o.Count))
+			}
+		}
+
+		var delivery string
+		if d := ctx.Character.Delivery(q); d != nil {
+			if d.Delivered {
+				delivery = fmt.Sprintf("Delivered %s to %s", d.Item, d.Mob)
+			} else {
+				delivery = fmt.Sprintf("Deliver %s to %s (%s left)", d.Item, d.Mob, TextDuration(d.Remaining()))
+			}
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(q, WithBold())},
+			TableCell{content: strings.Join(objectives, "\n")},
+			TableCell{content: delivery},
+			TableCell{content: TextStyle("abandon", WithLinkCmd(fmt.Sprintf("/quest abandon %s", q)))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleQuestAbandonCommand(ctx *CommandContext) {
+	quest := strings.ToLower(ctx.Args["quest"])
+	if !ctx.Character.AbandonQuest(quest) {
+		ctx.Player.client.ShowColorizedText("You aren't on that quest.", ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("You abandoned the %s quest.", TextStyle(quest, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleQuestCatalogCommand(ctx *CommandContext) {
+	quests := Armeria.questManager.Quests()
+	if len(quests) == 0 {
+		ctx.Player.client.ShowText("No quests have been defined.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Quest", header: true},
+		TableCell{content: "Description", header: true},
+		TableCell{content: "Objectives", header: true},
+		TableCell{content: "Reward", header: true},
+	)}
+	for _, q := range quests {
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(q.Name, WithLinkCmd(fmt.Sprintf("/quest show %s", q.Name)))},
+			TableCell{content: q.Description},
+			TableCell{content: strconv.Itoa(len(q.objectives()))},
+			TableCell{content: q.Reward.String()},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleQuestCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	n := strings.ToLower(ctx.Args["quest"])
+	if !ValidQuestName(n) {
+		ctx.Player.client.ShowColorizedText(
+			"Quest names can only use lowercase letters, numbers and dashes.",
+			ColorError,
+		)
+		return
+	}
+	if Armeria.questManager.QuestByName(n) != nil {
+		ctx.Player.client.ShowColorizedText("A quest by that name already exists.", ColorError)
+		return
+	}
+
+	Armeria.questManager.AddQuest(&Quest{Name: n, Description: ctx.Args["description"]})
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The %s quest has been created.", TextStyle(n, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleQuestShowCommand(ctx *CommandContext) {
+	q := Armeria.questManager.QuestByName(ctx.Args["quest"])
+	if q == nil {
+		ctx.Player.client.ShowColorizedText("A quest by that name doesn't exist.", ColorError)
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "#", header: true},
+		TableCell{content: "Kind", header: true},
+		TableCell{content: "Target", header: true},
+		TableCell{content: "Count", header: true},
+	)}
+	for i, o := range q.objectives() {
+		rows = append(rows, TableRow(
+			TableCell{content: strconv.Itoa(i + 1)},
+			TableCell{content: o.Kind},
+			TableCell{content: o.Target},
+			TableCell{content: strconv.Itoa(o.Count)},
+		))
+	}
+
+	reward := q.Reward.String()
+	if len(reward) == 0 {
+		reward = "nothing"
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"[b]%s[/b]: %s\n%s\nReward: %s",
+			q.Name,
+			q.Description,
+			TextTable(rows...),
+			reward,
+		),
+	)
+}
+
+func handleQuestObjectiveCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	q := Armeria.questManager.QuestByName(ctx.Args["quest"])
+	if q == nil {
+		ctx.Player.client.ShowColorizedText("A quest by that name doesn't exist.", ColorError)
+		return
+	}
+
+	count, err := strconv.Atoi(ctx.Args["count"])
+	if err != nil || count < 0 {
+		ctx.Player.client.ShowColorizedText("The count must be a positive number.", ColorError)
+		return
+	}
+
+	kind := strings.ToLower(ctx.Args["kind"])
+	if err := Armeria.questManager.SetObjective(q, kind, ctx.Args["target"], count); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The objective couldn't be set: %s.", err), ColorError)
+		return
+	}
+
+	if count == 0 {
+		ctx.Player.client.ShowColorizedText("The objective has been removed from the quest.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The objective has been set on the quest.", ColorSuccess)
+	}
+}
+
+func handleQuestRewardCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	q := Armeria.questManager.QuestByName(ctx.Args["quest"])
+	if q == nil {
+		ctx.Player.client.ShowColorizedText("A quest by that name doesn't exist.", ColorError)
+		return
+	}
+
+	kind := strings.ToLower(ctx.Args["kind"])
+	value := ctx.Args["value"]
+	if err := Armeria.questManager.SetReward(q, kind, value); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The reward couldn't be set: %s.", err), ColorError)
+		return
+	}
+
+	if len(value) == 0 {
+		ctx.Player.client.ShowColorizedText("The reward has been removed from the quest.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The reward has been set on the quest.", ColorSuccess)
+	}
+}
+
+func handleLootListCommand(ctx *CommandContext) {
+	tables := Armeria.lootManager.Tables()
+	if len(tables) == 0 {
+		ctx.Player.client.ShowText("No loot tables have been created.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Loot Table", header: true},
+		TableCell{content: "Rolls", header: true},
+		TableCell{content: "Entries", header: true},
+	)}
+	for _, t := range tables {
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(t.Name, WithLinkCmd(fmt.Sprintf("/loot show %s", t.Name)))},
+			TableCell{content: strconv.Itoa(t.Rolls)},
+			TableCell{content: strconv.Itoa(len(t.entries()))},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
+
+func handleLootCreateCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	n := strings.ToLower(ctx.Args["table"])
+	if !ValidLootTableName(n) {
+		ctx.Player.client.ShowColorizedText(
+			"Loot table names can only use lowercase letters, numbers and dashes.",
+			ColorError,
+		)
+		return
+	}
+	if Armeria.lootManager.TableByName(n) != nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name already exists.", ColorError)
+		return
+	}
+
+	Armeria.lootManager.AddTable(n)
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The %s loot table has been created.", TextStyle(n, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleLootShowCommand(ctx *CommandContext) {
+	t := Armeria.lootManager.TableByName(ctx.Args["table"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name doesn't exist.", ColorError)
+		return
+	}
+
+	entries := t.entries()
+	total := 0
+	for _, e := range entries {
+		total += e.Weight
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Kind", header: true},
+		TableCell{content: "Name", header: true},
+		TableCell{content: "Weight", header: true},
+		TableCell{content: "Chance", header: true},
+		TableCell{content: "Quantity", header: true},
+	)}
+	for _, e := range entries {
+		rows = append(rows, TableRow(
+			TableCell{content: e.Kind},
+			TableCell{content: e.Name},
+			TableCell{content: strconv.Itoa(e.Weight)},
+			TableCell{content: fmt.Sprintf("%d%%", e.Weight*100/total)},
+			TableCell{content: e.Quantity()},
+		))
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf(
+			"[b]%s[/b]: rolled %d time(s)\n%s",
+			t.Name,
+			t.Rolls,
+			TextTable(rows...),
+		),
+	)
+}
+
+func handleLootEntryCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	t := Armeria.lootManager.TableByName(ctx.Args["table"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name doesn't exist.", ColorError)
+		return
+	}
+
+	weight, err := strconv.Atoi(ctx.Args["weight"])
+	if err != nil || weight < 0 {
+		ctx.Player.client.ShowColorizedText("The weight must be a positive number.", ColorError)
+		return
+	}
+
+	min, max, err := ParseLootQuantity(ctx.Args["quantity"])
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The entry couldn't be set: %s.", err), ColorError)
+		return
+	}
+
+	kind := strings.ToLower(ctx.Args["kind"])
+	if err := Armeria.lootManager.SetEntry(t, kind, ctx.Args["name"], weight, min, max); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The entry couldn't be set: %s.", err), ColorError)
+		return
+	}
+
+	if weight == 0 {
+		ctx.Player.client.ShowColorizedText("The entry has been removed from the loot table.", ColorSuccess)
+	} else {
+		ctx.Player.client.ShowColorizedText("The entry has been set on the loot table.", ColorSuccess)
+	}
+}
+
+func handleLootRollsCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	t := Armeria.lootManager.TableByName(ctx.Args["table"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name doesn't exist.", ColorError)
+		return
+	}
+
+	rolls, err := strconv.Atoi(ctx.Args["rolls"])
+	if err == nil {
+		err = Armeria.lootManager.SetRolls(t, rolls)
+	} else {
+		err = errors.New("the rolls must be a number")
+	}
+	if err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The rolls couldn't be set: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The %s loot table will be rolled %d time(s).", TextStyle(t.Name, WithBold()), rolls),
+		ColorSuccess,
+	)
+}
+
+func handleLootRollCommand(ctx *CommandContext) {
+	t := Armeria.lootManager.TableByName(ctx.Args["table"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name doesn't exist.", ColorError)
+		return
+	}
+
+	loot := t.Roll()
+	if len(loot) == 0 {
+		ctx.Player.client.ShowText(fmt.Sprintf("The %s loot table dropped nothing.", TextStyle(t.Name, WithBold())))
+		return
+	}
+
+	ctx.Player.client.ShowText(
+		fmt.Sprintf("The %s loot table dropped %s.", TextStyle(t.Name, WithBold()), strings.Join(loot, ", ")),
+	)
+}
+
+func handleLootDeleteCommand(ctx *CommandContext) {
+	if !ctx.CanBuildIn(nil) {
+		return
+	}
+
+	t := Armeria.lootManager.TableByName(ctx.Args["table"])
+	if t == nil {
+		ctx.Player.client.ShowColorizedText("A loot table by that name doesn't exist.", ColorError)
+		return
+	}
+
+	if err := Armeria.lootManager.RemoveTable(t); err != nil {
+		ctx.Player.client.ShowColorizedText(fmt.Sprintf("The loot table couldn't be deleted: %s.", err), ColorError)
+		return
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("The %s loot table has been deleted.", TextStyle(t.Name, WithBold())),
+		ColorSuccess,
+	)
+}
+
+func handleReputationCommand(ctx *CommandContext) {
+	factions := ctx.Character.Factions()
+	if len(factions) == 0 {
+		ctx.Player.client.ShowText("You haven't made a name for yourself with any factions yet.")
+		return
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Faction", header: true},
+		TableCell{content: "Reputation", header: true},
+	)}
+	for _, f := range factions {
+		rows = append(rows, TableRow(
+			TableCell{content: strings.Title(f)},
+			TableCell{content: fmt.Sprintf("%d / %d", ctx.Character.Reputation(f), MaxReputation)},
+		))
+	}
+
+	ctx.Player.client.ShowText(TextTable(rows...))
+}
@@ -110,8 +110,9 @@ func RegisterGameCommands() {
 			Handler: handleInteractCommand,
 		},
 		{
-			Name: "destroy",
-			Help: "Destroys an item or mob in the room or your inventory.",
+			Name:     "destroy",
+			Help:     "Destroys an item or mob in the room or your inventory.",
+			NoPrefix: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter:  true,
 				RequirePermission: "CAN_BUILD",
@@ -123,6 +124,7 @@ func RegisterGameCommands() {
 					Help:             "The name, or uuid, of the item or mob to destroy.",
 				},
 			},
+			DryRun:  true,
 			Handler: handleDestroyCommand,
 		},
 		{
@@ -194,6 +196,7 @@ func RegisterGameCommands() {
 							Optional:         true,
 						},
 					},
+					DryRun:  true,
 					Handler: handleRoomSetCommand,
 				},
 				{
@@ -217,13 +220,25 @@ func RegisterGameCommands() {
 					Handler: handleRoomCreateCommand,
 				},
 				{
-					Name: "destroy",
-					Help: "Destroy a room in the specified direction.",
+					Name: "clone",
+					Help: "Create a copy of the current room, including its mob spawners, in the specified direction.",
 					Arguments: []*CommandArgument{
 						{
 							Name: "direction",
 						},
 					},
+					Handler: handleRoomCloneCommand,
+				},
+				{
+					Name:     "destroy",
+					Help:     "Destroy a room in the specified direction.",
+					NoPrefix: true,
+					Arguments: []*CommandArgument{
+						{
+							Name: "direction",
+						},
+					},
+					DryRun:  true,
 					Handler: handleRoomDestroyCommand,
 				},
 			},
@@ -265,6 +280,19 @@ func RegisterGameCommands() {
 					},
 					Handler: handleCharacterSetCommand,
 				},
+				{
+					Name: "money",
+					Help: "Set the money of the specified character, or adjust it by a signed amount (ie: +5 or -5).",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name: "amount",
+						},
+					},
+					Handler: handleCharacterMoneyCommand,
+				},
 				{
 					Name: "edit",
 					Help: "Open the editor panel for the specified character.",
@@ -281,67 +309,948 @@ func RegisterGameCommands() {
 					Help: "Creates a new character.",
 					Arguments: []*CommandArgument{
 						{
-							Name: "character",
-							Help: "The name of the character to create.",
-						},
-						{
-							Name: "password",
-							Help: "The password of the character.",
+							Name: "character",
+							Help: "The name of the character to create.",
+						},
+						{
+							Name: "password",
+							Help: "The password of the character.",
+						},
+						{
+							Name:     "override",
+							Help:     "Use \"override\" to skip the name checks.",
+							Optional: true,
+						},
+					},
+					Handler: handleCharacterCreateCommand,
+				},
+				{
+					Name: "rename",
+					Help: "Renames a character.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+							Help: "The name of the character to rename.",
+						},
+						{
+							Name: "new_name",
+							Help: "The new name of the character.",
+						},
+						{
+							Name:     "override",
+							Help:     "Use \"override\" to skip the name checks.",
+							Optional: true,
+						},
+					},
+					Handler: handleCharacterRenameCommand,
+				},
+			},
+		},
+		{
+			Name: "save",
+			Help: "Write the in-memory game data to disk.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Handler: handleSaveCommand,
+		},
+		{
+			Name: "refresh",
+			Help: "Re-render the data on the client.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleRefreshCommand,
+		},
+		{
+			Name:     "whisper",
+			AltNames: []string{"w"},
+			Help:     "Send a private message to an online character.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "target",
+				},
+				{
+					Name:             "message",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleWhisperCommand,
+		},
+		{
+			Name:     "reply",
+			AltNames: []string{"r"},
+			Help:     "Reply to the last whisper you received.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "message",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleReplyCommand,
+		},
+		{
+			Name: "history",
+			Help: "Re-read conversation you received recently.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "count",
+					Help:     "The number of messages to show (default: 20).",
+					Optional: true,
+				},
+				{
+					Name:     "channel",
+					Help:     "Only show messages from a channel (ie: say, whisper, emote, or general).",
+					Optional: true,
+				},
+			},
+			Handler: handleHistoryCommand,
+		},
+		{
+			Name: "petition",
+			Help: "Ask staff for help. Staff will be notified and respond as soon as they can.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "text",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handlePetitionCommand,
+		},
+		{
+			Name: "petitions",
+			Help: "View the status of your petitions, and the responses from staff.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "id",
+					Optional: true,
+				},
+			},
+			Handler: handlePetitionsCommand,
+		},
+		{
+			Name: "note",
+			Help: "Leave notes and TODOs for other staff.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: NotePermission,
+			},
+			Subcommands: []*Command{
+				{
+					Name: "add",
+					Help: "Add a note to the backlog, or attach it to something by starting with its name.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "text",
+							Help:             "The note, optionally starting with \"here:\" or the name of a mob or item (ie: Brenda: needs a shop).",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleNoteAddCommand,
+				},
+				{
+					Name: "list",
+					Help: "List the open notes.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "filter",
+							Help:             "Either \"all\" to include resolved notes, \"here\", or the name of a mob or item.",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleNoteListCommand,
+				},
+				{
+					Name: "resolve",
+					Help: "Mark a note as done.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleNoteResolveCommand,
+				},
+			},
+		},
+		{
+			Name: "ticket",
+			Help: "Handle the petitions sent by players.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: PetitionPermission,
+			},
+			Subcommands: []*Command{
+				{
+					Name: "list",
+					Help: "List the open petitions.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "all",
+							Help:     "Include closed petitions.",
+							Optional: true,
+						},
+					},
+					Handler: handleTicketListCommand,
+				},
+				{
+					Name: "view",
+					Help: "View a petition and its responses.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleTicketViewCommand,
+				},
+				{
+					Name: "claim",
+					Help: "Take responsibility for a petition.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleTicketClaimCommand,
+				},
+				{
+					Name: "respond",
+					Help: "Respond to a petition.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleTicketRespondCommand,
+				},
+				{
+					Name: "close",
+					Help: "Close a petition, optionally with a final response.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleTicketCloseCommand,
+				},
+			},
+		},
+		{
+			Name:     "board",
+			AltNames: []string{"embark"},
+			Help:     "Climb aboard a vehicle, such as a boat or a cart.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "vehicle",
+				},
+			},
+			Handler: handleBoardCommand,
+		},
+		{
+			Name: "disembark",
+			Help: "Climb off of the vehicle you're aboard.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleDisembarkCommand,
+		},
+		{
+			Name:     "steer",
+			AltNames: []string{"sail", "drive"},
+			Help:     "Steer the vehicle you're aboard in a direction.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name: "direction",
+				},
+			},
+			Handler: handleSteerCommand,
+		},
+		{
+			Name: "language",
+			Help: "View or change the language you read the game in.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "language",
+					Optional: true,
+				},
+			},
+			Handler: handleLanguageCommand,
+		},
+		{
+			Name:    "more",
+			Help:    "Show the next page of long output.",
+			Handler: handleMoreCommand,
+		},
+		{
+			Name:     "walkto",
+			AltNames: []string{"travel"},
+			Help:     "Walk to a room or waypoint automatically, or stop walking.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "destination",
+					Optional:         true,
+					IncludeRemaining: true,
+					Help:             "A waypoint, [x],[y],[z] in this area, or [area],[x],[y],[z].",
+				},
+			},
+			Handler: handleWalkToCommand,
+		},
+		{
+			Name: "follow",
+			Help: "Follow another character as they travel, or stop following.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "character",
+					Optional: true,
+				},
+			},
+			Handler: handleFollowCommand,
+		},
+		{
+			Name: "lose",
+			Help: "Stop one or all of your followers from following you.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "character",
+					Optional: true,
+				},
+			},
+			Handler: handleLoseCommand,
+		},
+		{
+			Name:     "gather",
+			AltNames: []string{"fish", "mine", "harvest"},
+			Help:     "Gather from a fishing spot, ore vein, or herb patch, or harvest a corpse in the room.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "node",
+					IncludeRemaining: true,
+					Optional:         true,
+				},
+			},
+			Handler: handleGatherCommand,
+		},
+		{
+			Name: "craft",
+			Help: "Craft items from their ingredients, which takes time.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "recipes",
+					Help:    "List the items that can be crafted, and what they need.",
+					Handler: handleCraftRecipesCommand,
+				},
+				{
+					Name: "make",
+					Help: "Use up the ingredients for an item, and add it to your crafting queue.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "item",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleCraftMakeCommand,
+				},
+				{
+					Name:    "queue",
+					Help:    "List the items in your crafting queue.",
+					Handler: handleCraftQueueCommand,
+				},
+				{
+					Name: "cancel",
+					Help: "Remove an item from your crafting queue, and get its ingredients back.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "position",
+							Help: "The item's position in your crafting queue.",
+						},
+					},
+					Handler: handleCraftCancelCommand,
+				},
+			},
+		},
+		{
+			Name: "contract",
+			Help: "Request items from crafters, paying up front, or fulfill their requests.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List the contracts waiting for a crafter.",
+					Handler: handleContractListCommand,
+				},
+				{
+					Name:    "mine",
+					Help:    "List the contracts you posted or delivered.",
+					Handler: handleContractMineCommand,
+				},
+				{
+					Name: "show",
+					Help: "Show the details of a contract.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleContractShowCommand,
+				},
+				{
+					Name: "post",
+					Help: "Request an item at a contract board. The payment is held by the board until it's delivered.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "payment",
+						},
+						{
+							Name: "days",
+							Help: "How many days the contract stays open for, up to 14.",
+						},
+						{
+							Name:             "item",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleContractPostCommand,
+				},
+				{
+					Name: "cancel",
+					Help: "Withdraw a contract nobody has fulfilled yet, and get the payment back.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleContractCancelCommand,
+				},
+				{
+					Name: "fulfill",
+					Help: "Deliver the item for a contract at a contract board. You are paid once the poster collects it, or after a day.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleContractFulfillCommand,
+				},
+				{
+					Name: "collect",
+					Help: "Collect the item delivered for your contract at a contract board.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+					},
+					Handler: handleContractCollectCommand,
+				},
+				{
+					Name: "dispute",
+					Help: "Ask staff to look into a delivery before the crafter is paid.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+						{
+							Name:             "reason",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleContractDisputeCommand,
+				},
+				{
+					Name: "disputes",
+					Help: "List the disputed contracts.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: PetitionPermission,
+					},
+					Handler: handleContractDisputesCommand,
+				},
+				{
+					Name: "resolve",
+					Help: "Settle a disputed contract in favor of the poster (refunding them) or the crafter (paying them).",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: PetitionPermission,
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "id",
+						},
+						{
+							Name: "favor",
+							Help: "Either \"poster\" or \"crafter\".",
+						},
+					},
+					Handler: handleContractResolveCommand,
+				},
+			},
+		},
+		{
+			Name: "score",
+			Help: "View your level, experience, combat stats and skills.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleScoreCommand,
+		},
+		{
+			Name: "skills",
+			Help: "View your gathering and taming skills.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleSkillsCommand,
+		},
+		{
+			Name: "quest",
+			Help: "Manage the quests you are on.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "log",
+					Help:    "List the quests you are on.",
+					Handler: handleQuestLogCommand,
+				},
+				{
+					Name: "abandon",
+					Help: "Give up on a quest, and lose the quest items that came with it.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "quest",
+						},
+					},
+					Handler: handleQuestAbandonCommand,
+				},
+				{
+					Name: "catalog",
+					Help: "List every quest that has been defined.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_BUILD",
+					},
+					Handler: handleQuestCatalogCommand,
+				},
+				{
+					Name: "create",
+					Help: "Define a new quest, which scripts can then start with q_start.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_BUILD",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "quest",
+							Help: "The name of the quest, using lowercase letters, numbers and dashes (ie: lost-ring).",
+						},
+						{
+							Name:             "description",
+							Help:             "What the quest is about, shown in the quest log.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleQuestCreateCommand,
+				},
+				{
+					Name: "show",
+					Help: "Show the objectives and rewards of a quest.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_BUILD",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "quest",
+						},
+					},
+					Handler: handleQuestShowCommand,
+				},
+				{
+					Name: "objective",
+					Help: "Add an objective to a quest, or change how many times it must be done.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_BUILD",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "quest",
+						},
+						{
+							Name: "kind",
+							Help: "One of: kill, fetch, talk or script.",
+						},
+						{
+							Name: "count",
+							Help: "The number of times it must be done, or 0 to remove the objective.",
+						},
+						{
+							Name:             "target",
+							Help:             "The mob or item involved, or a description for script objectives.",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleQuestObjectiveCommand,
+				},
+				{
+					Name: "reward",
+					Help: "Set what is given out when a quest is completed.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_BUILD",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "quest",
+						},
+						{
+							Name: "kind",
+							Help: "One of: money, title or item.",
+						},
+						{
+							Name:             "value",
+							Help:             "The amount of money, or name of the title or item. Leave empty to remove the reward.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleQuestRewardCommand,
+				},
+			},
+		},
+		{
+			Name: "loot",
+			Help: "Manage the loot tables that mobs and scripts drop loot from.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List every loot table.",
+					Handler: handleLootListCommand,
+				},
+				{
+					Name: "create",
+					Help: "Create a new, empty loot table.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+							Help: "The name of the loot table, using lowercase letters, numbers and dashes (ie: goblin-drops).",
+						},
+					},
+					Handler: handleLootCreateCommand,
+				},
+				{
+					Name: "show",
+					Help: "Show the entries of a loot table.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+						},
+					},
+					Handler: handleLootShowCommand,
+				},
+				{
+					Name: "entry",
+					Help: "Add an entry to a loot table, or change its weight and quantity.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+						},
+						{
+							Name: "kind",
+							Help: "One of: item, table or nothing.",
+						},
+						{
+							Name: "weight",
+							Help: "How likely the entry is to be picked, compared to the others, or 0 to remove the entry.",
+						},
+						{
+							Name: "quantity",
+							Help: "How many of the item drop, or times the table is rolled, as a number or range (ie: 1-3).",
+						},
+						{
+							Name:             "name",
+							Help:             "The name of the item or loot table.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleLootEntryCommand,
+				},
+				{
+					Name: "rolls",
+					Help: "Set how many times a loot table is rolled each time it drops loot.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+						},
+						{
+							Name: "rolls",
+						},
+					},
+					Handler: handleLootRollsCommand,
+				},
+				{
+					Name: "roll",
+					Help: "Try out a loot table, without creating any items.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+						},
+					},
+					Handler: handleLootRollCommand,
+				},
+				{
+					Name:     "delete",
+					Help:     "Delete a loot table that isn't used by any mobs or other loot tables.",
+					NoPrefix: true,
+					Arguments: []*CommandArgument{
+						{
+							Name: "table",
+						},
+					},
+					Handler: handleLootDeleteCommand,
+				},
+			},
+		},
+		{
+			Name: "reputation",
+			Help: "View your standing with each faction.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleReputationCommand,
+		},
+		{
+			Name: "attack",
+			Help: "Attack a mob or character in the room. The fight goes on until one of you is defeated or leaves the room.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "target",
+					Help:             "The name (or uuid) of the mob or character.",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleAttackCommand,
+		},
+		{
+			Name: "tame",
+			Help: "Try to tame a wild mob as your companion.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "mob",
+					Help:             "The name (or uuid) of the mob.",
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleTameCommand,
+		},
+		{
+			Name: "release",
+			Help: "Release your companion back into the wild.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleReleaseCommand,
+		},
+		{
+			Name: "gamble",
+			Help: "Bet on games of chance against the house or other players.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "games",
+					Help:    "List the games that can be played.",
+					Handler: handleGambleGamesCommand,
+				},
+				{
+					Name: "play",
+					Help: "Play a game against the house. You must be in a casino.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "game",
+						},
+						{
+							Name: "bet",
+						},
+					},
+					Handler: handleGamblePlayCommand,
+				},
+				{
+					Name: "challenge",
+					Help: "Challenge another character in the room to a game.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name: "game",
+						},
+						{
+							Name: "bet",
+						},
+					},
+					Handler: handleGambleChallengeCommand,
+				},
+				{
+					Name:    "accept",
+					Help:    "Accept the challenge you were offered.",
+					Handler: handleGambleAcceptCommand,
+				},
+				{
+					Name:    "decline",
+					Help:    "Decline the challenge you were offered.",
+					Handler: handleGambleDeclineCommand,
+				},
+			},
+		},
+		{
+			Name: "lottery",
+			Help: "Buy tickets for the daily lottery drawing.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "info",
+					Help:    "View the pot and when the next drawing is.",
+					Handler: handleLotteryInfoCommand,
+				},
+				{
+					Name: "buy",
+					Help: "Buy lottery tickets.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "tickets",
+							Help:     "The number of tickets to buy (default: 1).",
+							Optional: true,
 						},
 					},
-					Handler: handleCharacterCreateCommand,
+					Handler: handleLotteryBuyCommand,
+				},
+				{
+					Name: "draw",
+					Help: "Draw the lottery now.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_SYSOP",
+					},
+					Handler: handleLotteryDrawCommand,
 				},
 			},
 		},
 		{
-			Name: "save",
-			Help: "Write the in-memory game data to disk.",
+			Name: "bestiary",
+			Help: "List the creatures you've encountered, or learn more about one of them.",
 			Permissions: &CommandPermissions{
-				RequireCharacter:  true,
-				RequirePermission: "CAN_SYSOP",
+				RequireCharacter: true,
 			},
-			Handler: handleSaveCommand,
+			Arguments: []*CommandArgument{
+				{
+					Name:             "mob",
+					IncludeRemaining: true,
+					Optional:         true,
+				},
+			},
+			Handler: handleBestiaryCommand,
 		},
 		{
-			Name: "refresh",
-			Help: "Re-render the data on the client.",
+			Name: "explored",
+			Help: "See how much of each area you've explored.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
-			Handler: handleRefreshCommand,
+			Handler: handleExploredCommand,
 		},
 		{
-			Name:     "whisper",
-			AltNames: []string{"w"},
-			Help:     "Send a private message to an online character.",
+			Name: "profile",
+			Help: "Get a link to your public character profile.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
-			Arguments: []*CommandArgument{
-				{
-					Name: "target",
-				},
-				{
-					Name:             "message",
-					IncludeRemaining: true,
-				},
-			},
-			Handler: handleWhisperCommand,
+			Handler: handleProfileCommand,
 		},
 		{
-			Name:     "reply",
-			AltNames: []string{"r"},
-			Help:     "Reply to the last whisper you received.",
+			Name: "grep",
+			Help: "Search the text you've seen recently.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
 			Arguments: []*CommandArgument{
 				{
-					Name:             "message",
-					IncludeRemaining: true,
+					Name: "pattern",
+					Help: "A case-insensitive regular expression to search for. Use quotes to include spaces.",
+				},
+				{
+					Name:     "lines",
+					Help:     "The maximum number of matching lines to show (default: 20).",
+					Optional: true,
 				},
 			},
-			Handler: handleReplyCommand,
+			Handler: handleGrepCommand,
 		},
 		{
 			Name: "who",
@@ -381,6 +1290,20 @@ func RegisterGameCommands() {
 					},
 					Handler: handleMobCreateCommand,
 				},
+				{
+					Name: "clone",
+					Help: "Create a copy of a mob, including its script, under a new name.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "mob",
+						},
+						{
+							Name:             "name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleMobCloneCommand,
+				},
 				{
 					Name: "edit",
 					Help: "Edit a mob within the object editor.",
@@ -408,6 +1331,7 @@ func RegisterGameCommands() {
 							Optional:         true,
 						},
 					},
+					DryRun:  true,
 					Handler: handleMobSetCommand,
 				},
 				{
@@ -432,6 +1356,17 @@ func RegisterGameCommands() {
 					},
 					Handler: handleMobInstancesCommand,
 				},
+				{
+					Name: "stats",
+					Help: "View the performance of a mob's script.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "mob",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleMobStatsCommand,
+				},
 				{
 					Name: "iedit",
 					Help: "Edit a specific mob instance within the object editor.",
@@ -461,14 +1396,16 @@ func RegisterGameCommands() {
 					Handler: handleMobInstanceSetCommand,
 				},
 				{
-					Name: "delete",
-					Help: "Delete a mob that has no remaining instances.",
+					Name:     "delete",
+					Help:     "Delete a mob that has no remaining instances.",
+					NoPrefix: true,
 					Arguments: []*CommandArgument{
 						{
 							Name:             "name",
 							IncludeRemaining: true,
 						},
 					},
+					DryRun:  true,
 					Handler: handleMobDeleteCommand,
 				},
 			},
@@ -547,6 +1484,20 @@ func RegisterGameCommands() {
 					},
 					Handler: handleItemCreateCommand,
 				},
+				{
+					Name: "clone",
+					Help: "Create a copy of an item under a new name.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "item",
+						},
+						{
+							Name:             "name",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleItemCloneCommand,
+				},
 				{
 					Name: "spawn",
 					Help: "Spawn an item in your current room.",
@@ -585,6 +1536,7 @@ func RegisterGameCommands() {
 							Optional:         true,
 						},
 					},
+					DryRun:  true,
 					Handler: handleItemSetCommand,
 				},
 				{
@@ -627,21 +1579,24 @@ func RegisterGameCommands() {
 					Handler: handleItemInstancesCommand,
 				},
 				{
-					Name: "delete",
-					Help: "Delete an item that has no remaining instances.",
+					Name:     "delete",
+					Help:     "Delete an item that has no remaining instances.",
+					NoPrefix: true,
 					Arguments: []*CommandArgument{
 						{
 							Name:             "name",
 							IncludeRemaining: true,
 						},
 					},
+					DryRun:  true,
 					Handler: handleItemDeleteCommand,
 				},
 			},
 		},
 		{
-			Name: "wipe",
-			Help: "Wipe everything, or a specific thing, in your current room.",
+			Name:     "wipe",
+			Help:     "Wipe everything, or a specific thing, in your current room.",
+			NoPrefix: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter:  true,
 				RequirePermission: "CAN_BUILD",
@@ -653,6 +1608,7 @@ func RegisterGameCommands() {
 					Optional:         true,
 				},
 			},
+			DryRun:  true,
 			Handler: handleWipeCommand,
 		},
 		{
@@ -665,8 +1621,19 @@ func RegisterGameCommands() {
 			Handler: handleGhostCommand,
 		},
 		{
-			Name: "password",
-			Help: "Set a new password for your character.",
+			Name:     "invisible",
+			AltNames: []string{"invis"},
+			Help:     "Hide from players in rooms, /who and connection messages.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: InvisiblePermission,
+			},
+			Handler: handleInvisibleCommand,
+		},
+		{
+			Name:    "password",
+			Help:    "Set a new password for your character.",
+			NoForce: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -678,6 +1645,253 @@ func RegisterGameCommands() {
 			},
 			Handler: handlePasswordCommand,
 		},
+		{
+			Name: "appearance",
+			Help: "View or change your pronouns, species, and description.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "property",
+					Help:     "The part of your appearance to change: pronouns, species, or description.",
+					Optional: true,
+				},
+				{
+					Name:             "value",
+					Help:             "The new value. Leave empty to clear it.",
+					Optional:         true,
+					IncludeRemaining: true,
+				},
+			},
+			Handler: handleAppearanceCommand,
+		},
+		{
+			Name: "biography",
+			Help: "Write the story of your character, shown to others when they look at you.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "show",
+					Help:    "Show your biography.",
+					Handler: handleBiographyShowCommand,
+				},
+				{
+					Name: "add",
+					Help: "Add a paragraph to the end of your biography.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleBiographyAddCommand,
+				},
+				{
+					Name: "remove",
+					Help: "Remove a paragraph from your biography.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "paragraph",
+							Help: "The number of the paragraph, as shown by /biography show.",
+						},
+					},
+					Handler: handleBiographyRemoveCommand,
+				},
+				{
+					Name:     "clear",
+					Help:     "Remove your whole biography.",
+					NoPrefix: true,
+					Handler:  handleBiographyClearCommand,
+				},
+				{
+					Name: "hide",
+					Help: "Hide a character's biography from others, when it is inappropriate.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: BiographyPermission,
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleBiographyHideCommand,
+				},
+				{
+					Name: "unhide",
+					Help: "Show a hidden biography to others again.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: BiographyPermission,
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleBiographyUnhideCommand,
+				},
+			},
+		},
+		{
+			Name: "tutorial",
+			Help: "Show your current tutorial lesson, skip the tutorial, or take it again.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "action",
+					Help:     "Use skip to leave the tutorial, or restart to take it again.",
+					Optional: true,
+				},
+			},
+			Handler: handleTutorialCommand,
+		},
+		{
+			Name: "title",
+			Help: "Manage the title displayed alongside your name.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Subcommands: []*Command{
+				{
+					Name:    "list",
+					Help:    "List the titles you have earned.",
+					Handler: handleTitleListCommand,
+				},
+				{
+					Name: "set",
+					Help: "Display a title you have earned.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "title",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleTitleSetCommand,
+				},
+				{
+					Name:     "clear",
+					Help:     "Stop displaying a title.",
+					NoPrefix: true,
+					Handler:  handleTitleClearCommand,
+				},
+				{
+					Name: "catalog",
+					Help: "List every title that can be earned.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_CHAREDIT",
+					},
+					Handler: handleTitleCatalogCommand,
+				},
+				{
+					Name: "create",
+					Help: "Add a title to the catalog.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_SYSOP",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "title",
+							Help: "The name of the title. Use quotes for titles with spaces.",
+						},
+						{
+							Name:             "description",
+							Help:             "How the title is earned.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleTitleCreateCommand,
+				},
+				{
+					Name: "killreward",
+					Help: "Grant a title automatically once a character has slain a mob enough times.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_SYSOP",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "title",
+							Help: "The name of the title. Use quotes for titles with spaces.",
+						},
+						{
+							Name: "kills",
+							Help: "The number of kills required, or 0 to remove the reward.",
+						},
+						{
+							Name:             "mob",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleTitleKillRewardCommand,
+				},
+				{
+					Name: "explorereward",
+					Help: "Grant a title automatically once a character has explored every room in an area.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_SYSOP",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "title",
+							Help: "The name of the title. Use quotes for titles with spaces.",
+						},
+						{
+							Name:     "area",
+							Help:     "The area to explore. Leave empty to remove the reward.",
+							Optional: true,
+						},
+					},
+					Handler: handleTitleExploreRewardCommand,
+				},
+				{
+					Name: "grant",
+					Help: "Grant a title from the catalog to a character.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_CHAREDIT",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name:             "title",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleTitleGrantCommand,
+				},
+				{
+					Name: "revoke",
+					Help: "Revoke a title from a character.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: "CAN_CHAREDIT",
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name:             "title",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleTitleRevokeCommand,
+				},
+			},
+		},
 		{
 			Name:     "teleport",
 			AltNames: []string{"tp"},
@@ -689,6 +1903,7 @@ func RegisterGameCommands() {
 			Arguments: []*CommandArgument{
 				{
 					Name:             "destination",
+					Help:             "A character, an area and coordinates (ie: Wobgi Jungle 3,1,0), @@name to summon someone, or back to return from your last jump.",
 					IncludeRemaining: true,
 				},
 			},
@@ -731,9 +1946,10 @@ func RegisterGameCommands() {
 					Handler: handleClipboardPasteCommand,
 				},
 				{
-					Name:    "clear",
-					Help:    "Clear the clipboard.",
-					Handler: handleClipboardClearCommand,
+					Name:     "clear",
+					Help:     "Clear the clipboard.",
+					NoPrefix: true,
+					Handler:  handleClipboardClearCommand,
 				},
 			},
 		},
@@ -747,6 +1963,7 @@ func RegisterGameCommands() {
 				{
 					Name:             "item",
 					IncludeRemaining: true,
+					Help:             "The item, a specific one of several (ie: 2.torch), or a group (ie: all.coin or all).",
 				},
 			},
 			Handler: handleGetCommand,
@@ -761,6 +1978,7 @@ func RegisterGameCommands() {
 				{
 					Name:             "item",
 					IncludeRemaining: true,
+					Help:             "The item, a specific one of several (ie: 2.torch), or a group (ie: all.coin or all).",
 				},
 			},
 			Handler: handleDropCommand,
@@ -782,8 +2000,9 @@ func RegisterGameCommands() {
 			Handler: handleSwapCommand,
 		},
 		{
-			Name: "autologin",
-			Help: "Toggle auto-login for your character.",
+			Name:    "autologin",
+			Help:    "Toggle auto-login for your character.",
+			NoForce: true,
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -819,21 +2038,50 @@ func RegisterGameCommands() {
 							Name: "channel",
 						},
 					},
-					Handler: handleChannelLeaveCommand,
+					Handler: handleChannelLeaveCommand,
+				},
+				{
+					Name: "say",
+					Help: "Say someting to a channel.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+						{
+							Name:             "text",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleChannelSayCommand,
+				},
+				{
+					Name: "recent",
+					Help: "List the recent messages on a channel that can be retracted.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: PetitionPermission,
+					},
+					Arguments: []*CommandArgument{
+						{
+							Name: "channel",
+						},
+					},
+					Handler: handleChannelRecentCommand,
 				},
 				{
-					Name: "say",
-					Help: "Say someting to a channel.",
+					Name: "retract",
+					Help: "Remove a recent channel message from everyone's display.",
+					Permissions: &CommandPermissions{
+						RequireCharacter:  true,
+						RequirePermission: PetitionPermission,
+					},
 					Arguments: []*CommandArgument{
 						{
-							Name: "channel",
-						},
-						{
-							Name:             "text",
-							IncludeRemaining: true,
+							Name: "id",
+							Help: "The id of the message, from /channel recent.",
 						},
 					},
-					Handler: handleChannelSayCommand,
+					Handler: handleChannelRetractCommand,
 				},
 			},
 		},
@@ -847,11 +2095,13 @@ func RegisterGameCommands() {
 			Arguments: []*CommandArgument{
 				{
 					Name:     "name",
+					Help:     "The setting to change, or \"colors\" to customize your color palette.",
 					Optional: true,
 				},
 				{
-					Name:     "value",
-					Optional: true,
+					Name:             "value",
+					Optional:         true,
+					IncludeRemaining: true,
 				},
 			},
 			Handler: handleSettingsCommand,
@@ -874,7 +2124,7 @@ func RegisterGameCommands() {
 		},
 		{
 			Name: "give",
-			Help: "Give an item to someone or something.",
+			Help: "Give an item or money to someone or something.",
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -884,6 +2134,7 @@ func RegisterGameCommands() {
 				},
 				{
 					Name:             "item",
+					Help:             "An item, or an amount of money starting with $ (ie: $5).",
 					IncludeRemaining: true,
 				},
 			},
@@ -898,6 +2149,7 @@ func RegisterGameCommands() {
 			Arguments: []*CommandArgument{
 				{
 					Name:             "emote",
+					Help:             "Use {their}, {them}, {they}, and {theirs} to refer to yourself with your pronouns.",
 					IncludeRemaining: true,
 				},
 			},
@@ -1018,6 +2270,68 @@ func RegisterGameCommands() {
 					},
 					Handler: handleLedgerSetCommand,
 				},
+				{
+					Name: "require",
+					Help: "Limit who can buy an item on a ledger, by reputation, completed quests or attributes.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+							Help: "The name of the ledger.",
+						},
+						{
+							Name: "item_name",
+							Help: "The name of the item.",
+						},
+						{
+							Name:             "requirements",
+							Help:             "Comma-separated requirements (ie: reputation:wobgi:100,quest:lost-ring), or nothing to clear them.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleLedgerRequireCommand,
+				},
+				{
+					Name: "stock",
+					Help: "Limit how many of an item on a ledger each vendor holds, and how long each one takes to restock.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+							Help: "The name of the ledger.",
+						},
+						{
+							Name: "item_name",
+							Help: "The name of the item.",
+						},
+						{
+							Name: "stock",
+							Help: "How many each vendor holds, or 0 to never run out.",
+						},
+						{
+							Name:     "restock",
+							Help:     "How many seconds each one bought takes to restock (default: 300).",
+							Optional: true,
+						},
+					},
+					Handler: handleLedgerStockCommand,
+				},
+				{
+					Name: "pricing",
+					Help: "Make the prices on a ledger drift with how much of each item has recently been sold and bought.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "ledger_name",
+							Help: "The name of the ledger.",
+						},
+						{
+							Name:             "rules",
+							Help:             "Comma-separated pricing rules (ie: sensitivity:2,halflife:24,min:50,max:200), or nothing to fix the prices.",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleLedgerPricingCommand,
+				},
 			},
 		},
 		{
@@ -1058,6 +2372,22 @@ func RegisterGameCommands() {
 			},
 			Handler: handleSellCommand,
 		},
+		{
+			Name: "list",
+			Help: "List what an NPC has for sale, and what they will buy from you.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:             "npc",
+					Help:             "The name of the NPC (default: the first one in the room with something to sell).",
+					IncludeRemaining: true,
+					Optional:         true,
+				},
+			},
+			Handler: handleListCommand,
+		},
 		{
 			Name: "tickers",
 			Help: "Displays the status of server-side tickers.",
@@ -1067,10 +2397,18 @@ func RegisterGameCommands() {
 			},
 			Handler: handleTickersCommand,
 		},
+		{
+			Name: "equipment",
+			Help: "Display the items you have equipped.",
+			Permissions: &CommandPermissions{
+				RequireCharacter: true,
+			},
+			Handler: handleEquipmentCommand,
+		},
 		{
 			Name:     "equip",
 			Help:     "Display equipment or equip an item.",
-			AltNames: []string{"eq"},
+			AltNames: []string{"eq", "wear", "wield"},
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -1087,7 +2425,7 @@ func RegisterGameCommands() {
 		{
 			Name:     "remove",
 			Help:     "Remove an equipped item.",
-			AltNames: []string{"eq"},
+			AltNames: []string{"unequip"},
 			Permissions: &CommandPermissions{
 				RequireCharacter: true,
 			},
@@ -1100,6 +2438,486 @@ func RegisterGameCommands() {
 			},
 			Handler: handleRemoveCommand,
 		},
+		{
+			Name: "bulk",
+			Help: "Select multiple rooms or instances and edit them all at once.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Subcommands: []*Command{
+				{
+					Name: "add",
+					Help: "Add objects to your selection.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "target",
+							Help: "A room (\".\" or x,y,z), a range of rooms (x,y,z:x,y,z), or an instance uuid.",
+						},
+					},
+					Handler: handleBulkAddCommand,
+				},
+				{
+					Name: "remove",
+					Help: "Remove objects from your selection.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "target",
+							Help: "A room (\".\" or x,y,z), a range of rooms (x,y,z:x,y,z), or an instance uuid.",
+						},
+					},
+					Handler: handleBulkRemoveCommand,
+				},
+				{
+					Name:     "clear",
+					Help:     "Clear your selection.",
+					NoPrefix: true,
+					Handler:  handleBulkClearCommand,
+				},
+				{
+					Name:    "list",
+					Help:    "List the objects in your selection.",
+					Handler: handleBulkListCommand,
+				},
+				{
+					Name: "preview",
+					Help: "Preview an attribute change to every selected object.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "property",
+						},
+						{
+							Name:             "value",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					Handler: handleBulkPreviewCommand,
+				},
+				{
+					Name: "set",
+					Help: "Set an attribute on every selected object.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "property",
+						},
+						{
+							Name:             "value",
+							IncludeRemaining: true,
+							Optional:         true,
+						},
+					},
+					DryRun:  true,
+					Handler: handleBulkSetCommand,
+				},
+			},
+		},
+		{
+			Name: "publish",
+			Help: "Publish unpublished room changes so they are visible to players.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "scope",
+					Help:     "Either \"here\" (default), \"area\", or \"list\" to see rooms with unpublished changes.",
+					Optional: true,
+				},
+			},
+			Handler: handlePublishCommand,
+		},
+		{
+			Name: "discard",
+			Help: "Discard unpublished room changes.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_BUILD",
+			},
+			Arguments: []*CommandArgument{
+				{
+					Name:     "scope",
+					Help:     "Either \"here\" (default) or \"area\".",
+					Optional: true,
+				},
+			},
+			Handler: handleDiscardCommand,
+		},
+		{
+			Name: "admin",
+			Help: "Perform server administration tasks.",
+			Permissions: &CommandPermissions{
+				RequireCharacter:  true,
+				RequirePermission: "CAN_SYSOP",
+			},
+			Subcommands: []*Command{
+				{
+					Name: "promote",
+					Help: "Promote an area from the staging world into the live world.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "area",
+						},
+						{
+							Name:     "force",
+							Help:     "Use 'force' to overwrite objects that were changed on the live world.",
+							Optional: true,
+						},
+					},
+					Handler: handleAdminPromoteCommand,
+				},
+				{
+					Name:    "worldstats",
+					Help:    "Summarize the content within the game world.",
+					Handler: handleAdminWorldStatsCommand,
+				},
+				{
+					Name: "economy",
+					Help: "Report the money created and destroyed by each source, to help balance the economy.",
+					Arguments: []*CommandArgument{
+						{
+							Name:     "days",
+							Help:     "How many days to report on, which defaults to a week.",
+							Optional: true,
+						},
+					},
+					Handler: handleAdminEconomyCommand,
+				},
+				{
+					Name:    "seasons",
+					Help:    "List the seasonal events and whether they are active.",
+					Handler: handleAdminSeasonsCommand,
+				},
+				{
+					Name:    "multibox",
+					Help:    "List the addresses with more than one character logged in.",
+					Handler: handleAdminMultiboxCommand,
+				},
+				{
+					Name:    "validate",
+					Help:    "Check the game data for references to objects that no longer exist.",
+					Handler: handleAdminValidateCommand,
+				},
+				{
+					Name:    "script",
+					Help:    "Edit the global script that hooks into server events.",
+					Handler: handleAdminScriptCommand,
+				},
+				{
+					Name: "motd",
+					Help: "View or change the message of the day shown at login.",
+					Arguments: []*CommandArgument{
+						{
+							Name:             "message",
+							Help:             "The new message of the day, or 'clear' to remove it.",
+							Optional:         true,
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleAdminMOTDCommand,
+				},
+				{
+					Name: "announce",
+					Help: "Broadcast announcements to every player.",
+					Subcommands: []*Command{
+						{
+							Name: "now",
+							Help: "Broadcast an announcement immediately.",
+							Arguments: []*CommandArgument{
+								{
+									Name:             "message",
+									IncludeRemaining: true,
+								},
+							},
+							Handler: handleAdminAnnounceNowCommand,
+						},
+						{
+							Name: "add",
+							Help: "Schedule an announcement to be broadcast on an interval.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "interval",
+									Help: "How often to broadcast the announcement (ie: 30m, 2h).",
+								},
+								{
+									Name:             "message",
+									IncludeRemaining: true,
+								},
+							},
+							Handler: handleAdminAnnounceAddCommand,
+						},
+						{
+							Name:    "list",
+							Help:    "List the scheduled announcements.",
+							Handler: handleAdminAnnounceListCommand,
+						},
+						{
+							Name: "remove",
+							Help: "Remove a scheduled announcement.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "id",
+								},
+							},
+							Handler: handleAdminAnnounceRemoveCommand,
+						},
+					},
+				},
+				{
+					Name: "broadcast",
+					Help: "Broadcast a styled message to the players matching a filter.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "filter",
+							Help: "Who receives the message: all, or comma-separated terms such as area=Wobgi_Jungle, permission=CAN_BUILD or level<10.",
+						},
+						{
+							Name: "style",
+							Help: "How the message looks: info, event, warning or urgent.",
+						},
+						{
+							Name:             "message",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleAdminBroadcastCommand,
+				},
+				{
+					Name: "force",
+					Help: "Run a command as another character, such as to get them unstuck.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+						{
+							Name:             "command",
+							Help:             "The command to run, which can't be one that needs a permission or changes a password.",
+							IncludeRemaining: true,
+						},
+					},
+					Handler: handleAdminForceCommand,
+				},
+				{
+					Name: "inspect",
+					Help: "View a character's attributes, inventory, location, connection and recent commands.",
+					Arguments: []*CommandArgument{
+						{
+							Name: "character",
+						},
+					},
+					Handler: handleAdminInspectCommand,
+				},
+				{
+					Name:    "freeze",
+					Help:    "Pause mobs, spawns and timers for maintenance, while chat keeps working.",
+					Handler: handleAdminFreezeCommand,
+				},
+				{
+					Name:    "unfreeze",
+					Help:    "Resume the world after it was frozen.",
+					Handler: handleAdminUnfreezeCommand,
+				},
+				{
+					Name: "replay",
+					Help: "Record a character's session and replay it, to reproduce reported bugs.",
+					Subcommands: []*Command{
+						{
+							Name: "record",
+							Help: "Start recording everything a character sends and receives.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "character",
+								},
+							},
+							Handler: handleAdminReplayRecordCommand,
+						},
+						{
+							Name: "stop",
+							Help: "Stop recording a character's session.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "character",
+								},
+							},
+							Handler: handleAdminReplayStopCommand,
+						},
+						{
+							Name:    "list",
+							Help:    "List the session recordings.",
+							Handler: handleAdminReplayListCommand,
+						},
+						{
+							Name: "view",
+							Help: "Replay a session recording.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "id",
+								},
+							},
+							Handler: handleAdminReplayViewCommand,
+						},
+					},
+				},
+				{
+					Name: "quarantine",
+					Help: "Review items that were quarantined because they were found in more than one place or in none.",
+					Subcommands: []*Command{
+						{
+							Name:    "list",
+							Help:    "List the quarantined items.",
+							Handler: handleAdminQuarantineListCommand,
+						},
+						{
+							Name:    "check",
+							Help:    "Check for duplicated or lost items now, instead of waiting for the periodic check.",
+							Handler: handleAdminQuarantineCheckCommand,
+						},
+						{
+							Name: "release",
+							Help: "Move a quarantined item into your inventory.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "uuid",
+								},
+							},
+							Handler: handleAdminQuarantineReleaseCommand,
+						},
+						{
+							Name:     "destroy",
+							Help:     "Remove a quarantined item from the game.",
+							NoPrefix: true,
+							Arguments: []*CommandArgument{
+								{
+									Name: "uuid",
+								},
+							},
+							Handler: handleAdminQuarantineDestroyCommand,
+						},
+					},
+				},
+				{
+					Name: "names",
+					Help: "Manage the words and patterns that character names cannot use.",
+					Subcommands: []*Command{
+						{
+							Name:    "list",
+							Help:    "List the blocked words and reserved name patterns.",
+							Handler: handleAdminNamesListCommand,
+						},
+						{
+							Name: "block",
+							Help: "Block a word from appearing within character names.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "word",
+								},
+							},
+							Handler: handleAdminNamesBlockCommand,
+						},
+						{
+							Name: "unblock",
+							Help: "Allow a blocked word within character names again.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "word",
+								},
+							},
+							Handler: handleAdminNamesUnblockCommand,
+						},
+						{
+							Name: "reserve",
+							Help: "Reserve names matching a regular expression for staff.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "pattern",
+									Help: "A case-insensitive regular expression (ie: ^admin).",
+								},
+							},
+							Handler: handleAdminNamesReserveCommand,
+						},
+						{
+							Name: "unreserve",
+							Help: "Remove a reserved name pattern.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "pattern",
+								},
+							},
+							Handler: handleAdminNamesUnreserveCommand,
+						},
+						{
+							Name: "check",
+							Help: "Check whether a name can be used by a new character.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "name",
+								},
+							},
+							Handler: handleAdminNamesCheckCommand,
+						},
+					},
+				},
+				{
+					Name: "filter",
+					Help: "Manage the chat filter and review the messages it flagged.",
+					Subcommands: []*Command{
+						{
+							Name:    "list",
+							Help:    "List the words the chat filter acts on.",
+							Handler: handleAdminFilterListCommand,
+						},
+						{
+							Name: "set",
+							Help: "Add a word to the chat filter, or change what it does.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "pattern",
+									Help: "A word, or the start of a word followed by * (ie: darn*).",
+								},
+								{
+									Name: "action",
+									Help: "Either mask, flag or block.",
+								},
+							},
+							Handler: handleAdminFilterSetCommand,
+						},
+						{
+							Name: "remove",
+							Help: "Remove a word from the chat filter.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "pattern",
+								},
+							},
+							Handler: handleAdminFilterRemoveCommand,
+						},
+						{
+							Name: "flags",
+							Help: "List the flagged messages waiting for review.",
+							Arguments: []*CommandArgument{
+								{
+									Name:     "all",
+									Help:     "Include the messages that were already reviewed.",
+									Optional: true,
+								},
+							},
+							Handler: handleAdminFilterFlagsCommand,
+						},
+						{
+							Name: "review",
+							Help: "Mark a flagged message as reviewed.",
+							Arguments: []*CommandArgument{
+								{
+									Name: "id",
+								},
+							},
+							Handler: handleAdminFilterReviewCommand,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	// Register commands for communicating on channels.
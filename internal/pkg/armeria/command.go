@@ -15,6 +15,9 @@ type Command struct {
 	AltNames    []string                `json:"altNames"`
 	Help        string                  `json:"help"`
 	Hidden      bool                    `json:"-"`
+	NoForce     bool                    `json:"-"`
+	NoPrefix    bool                    `json:"-"`
+	DryRun      bool                    `json:"-"`
 	Alias       string                  `json:"alias"`
 	Permissions *CommandPermissions     `json:"permissions"`
 	Arguments   []*CommandArgument      `json:"args"`
@@ -43,6 +46,51 @@ type CommandContext struct {
 	Character       *Character
 	Args            map[string]string
 	HandlerStart    time.Time
+	// ForcedBy is the staff member who made the character run the command, if any.
+	ForcedBy *Character
+	// Simulation records the changes the command would make, if it was run with DryRunFlag.
+	Simulation *Simulation
+	// EditVersion is the version of the object the object editor was showing when it sent the command, or zero if
+	// the command wasn't sent by the editor.
+	EditVersion int
+}
+
+// CanBuildIn returns true if the Character can build within the Area. A nil Area requires build permissions
+// that aren't scoped to an area. If the Character can't build, the player is told why.
+func (ctx *CommandContext) CanBuildIn(a *Area) bool {
+	if ctx.Character.HasPermissionIn("CAN_BUILD", a) {
+		return true
+	}
+
+	if a == nil {
+		ctx.Player.client.ShowColorizedText("Your build permissions are limited to specific areas.", ColorError)
+	} else {
+		ctx.Player.client.ShowColorizedText(
+			fmt.Sprintf("You don't have permission to build in %s.", TextStyle(a.Name(), WithBold())),
+			ColorError,
+		)
+	}
+
+	return false
+}
+
+// ShowAmbiguousTarget lets the player know which objects their search matched when it was too vague to pick one of
+// them. It returns true if the search was ambiguous.
+func (ctx *CommandContext) ShowAmbiguousTarget(result *ObjectContainerResult) bool {
+	if len(result.Ambiguous) == 0 {
+		return false
+	}
+
+	var names []string
+	for _, o := range result.Ambiguous {
+		names = append(names, o.FormattedName())
+	}
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf("Which one do you mean: %s? Be more specific, or pick one by number (ie: 2.torch).", strings.Join(names, ", ")),
+		ColorError,
+	)
+
+	return true
 }
 
 // CheckPermissions returns whether or not a parent can see/use the command.
@@ -71,6 +119,17 @@ func (cmd *Command) CheckPermissions(p *Player) bool {
 	return true
 }
 
+// Forceable returns true if staff can run the command as another character. Commands that need a permission, or
+// that are marked NoForce (ie: changing a password), can't be forced, and neither can their sub-commands.
+func (cmd *Command) Forceable() bool {
+	for c := cmd; c != nil; c = c.Parent {
+		if c.NoForce || (c.Permissions != nil && len(c.Permissions.RequirePermission) > 0) {
+			return false
+		}
+	}
+	return true
+}
+
 // ShowSubcommandHelp returns the list of sub-commands that the parent has access to as a string.
 func (cmd *Command) ShowSubcommandHelp(p *Player, commandsEntered []string) string {
 	if len(cmd.Subcommands) == 0 {
@@ -88,7 +147,7 @@ func (cmd *Command) ShowSubcommandHelp(p *Player, commandsEntered []string) stri
 
 	var rows []string
 	for _, scmd := range cmd.Subcommands {
-		if cmd.CheckPermissions(p) {
+		if scmd.CheckPermissions(p) {
 			rows = append(rows, TableRow(
 				TableCell{content: TextStyle(scmd.Name, WithBold())},
 				TableCell{content: scmd.Help},
@@ -122,6 +181,15 @@ func (cmd *Command) ShowArgumentHelp(commandsEntered []string) string {
 		))
 	}
 
+	if cmd.DryRun {
+		argumentStrings = append(argumentStrings, fmt.Sprintf("[%s]", DryRunFlag))
+		argumentRows = append(argumentRows, TableRow(
+			TableCell{content: TextStyle(DryRunFlag, WithBold())},
+			TableCell{content: TextStyle("Optional", WithItalics())},
+			TableCell{content: "Show what would change, without changing anything."},
+		))
+	}
+
 	output := []string{
 		cmd.Help,
 		fmt.Sprintf(
@@ -171,6 +239,7 @@ func (cmd *Command) LogCtx(ctx *CommandContext) {
 			zap.String("command", ctx.Command.Parent.Name),
 			zap.String("sub-command", ctx.Command.Name),
 			zap.Strings("arguments", args),
+			zap.Bool("dry-run", ctx.DryRun()),
 			zap.Duration("duration", handlerDuration),
 		)
 	} else {
@@ -178,7 +247,37 @@ func (cmd *Command) LogCtx(ctx *CommandContext) {
 			zap.String("character", c),
 			zap.String("command", ctx.Command.Name),
 			zap.Strings("arguments", args),
+			zap.Bool("dry-run", ctx.DryRun()),
 			zap.Duration("duration", handlerDuration),
 		)
 	}
+
+	if ctx.Character != nil && (ctx.PlayerInitiated || ctx.ForcedBy != nil) {
+		text := cmd.Text(ctx.Args)
+		if ctx.DryRun() {
+			text += " " + DryRunFlag
+		}
+		if ctx.ForcedBy != nil {
+			text += fmt.Sprintf(" (forced by %s)", ctx.ForcedBy.Name())
+		}
+		ctx.Character.RecordCommand(text)
+	}
+
+	if ctx.ForcedBy != nil {
+		Armeria.log.Warn("command was forced",
+			zap.String("character", c),
+			zap.String("forced-by", ctx.ForcedBy.Name()),
+			zap.String("command", cmd.FullName()),
+			zap.Strings("arguments", args),
+		)
+	}
+}
+
+// FullName returns the name of the command along with the names of its parents (ie: "room set").
+func (cmd *Command) FullName() string {
+	names := []string{cmd.Name}
+	for p := cmd.Parent; p != nil; p = p.Parent {
+		names = append([]string{p.Name}, names...)
+	}
+	return strings.Join(names, " ")
 }
@@ -3,6 +3,8 @@ package armeria
 import (
 	"armeria/internal/pkg/misc"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -47,50 +49,103 @@ func (m *CommandManager) FindCommand(p *Player, searchWithin []*Command, cmd str
 	sections := strings.Fields(cmd)
 	cmdName := strings.ToLower(sections[0])
 
-	for _, cmd := range searchWithin {
-		if strings.ToLower(cmd.Name) == cmdName || misc.Contains(cmd.AltNames, cmdName) {
-			// Handle permissions
-			if !cmd.CheckPermissions(p) {
-				return nil, nil, CommandErrNoPerms
-			}
+	match, errorMsg := matchCommand(p, searchWithin, cmdName, alreadyProcessed)
+	if match == nil {
+		return nil, nil, errorMsg
+	}
+	if strings.ToLower(match.Name) != cmdName && !misc.Contains(match.AltNames, cmdName) {
+		// Matched by prefix, so use the full name from here on.
+		cmdName = strings.ToLower(match.Name)
+	}
 
-			// Handle sub-commands
-			if cmd.Subcommands != nil {
-				processedCommands := append(alreadyProcessed, cmdName)
-				if len(sections) == 1 {
-					return nil, nil, cmd.ShowSubcommandHelp(p, processedCommands)
+	// Handle permissions
+	if !match.CheckPermissions(p) {
+		return nil, nil, CommandErrNoPerms
+	}
 
-				}
-				return m.FindCommand(p, cmd.Subcommands, strings.Join(sections[1:], " "), processedCommands)
-			}
+	// Handle sub-commands
+	if match.Subcommands != nil {
+		processedCommands := append(alreadyProcessed, cmdName)
+		if len(sections) == 1 {
+			return nil, nil, match.ShowSubcommandHelp(p, processedCommands)
+
+		}
+		return m.FindCommand(p, match.Subcommands, strings.Join(sections[1:], " "), processedCommands)
+	}
+
+	// Parse and store arguments, if any
+	commandArgs := make(map[string]string)
+	parsedArgs := misc.ParseArguments(sections[1:])
+	if match.Arguments != nil {
+		if len(parsedArgs) > 0 && parsedArgs[len(parsedArgs)-1] == "--help" {
+			return nil, nil, match.ShowArgumentHelp(append(alreadyProcessed, cmdName))
+		}
 
-			// Parse and store arguments, if any
-			commandArgs := make(map[string]string)
-			parsedArgs := misc.ParseArguments(sections[1:])
-			if cmd.Arguments != nil {
-				if len(parsedArgs) > 0 && parsedArgs[len(parsedArgs)-1] == "--help" {
-					return nil, nil, cmd.ShowArgumentHelp(append(alreadyProcessed, cmdName))
-				}
-
-				for pos, arg := range cmd.Arguments {
-					if !arg.Optional && len(parsedArgs) < (pos+1) {
-						return nil, nil, cmd.ShowArgumentHelp(append(alreadyProcessed, cmdName))
-					}
-					if arg.IncludeRemaining {
-						commandArgs[arg.Name] = strings.Join(parsedArgs[pos:], " ")
-					} else if len(parsedArgs) >= pos+1 {
-						commandArgs[arg.Name] = parsedArgs[pos]
-					} else {
-						commandArgs[arg.Name] = ""
-					}
-				}
+		for pos, arg := range match.Arguments {
+			if !arg.Optional && len(parsedArgs) < (pos+1) {
+				return nil, nil, match.ShowArgumentHelp(append(alreadyProcessed, cmdName))
+			}
+			if arg.IncludeRemaining {
+				commandArgs[arg.Name] = strings.Join(parsedArgs[pos:], " ")
+			} else if len(parsedArgs) >= pos+1 {
+				commandArgs[arg.Name] = parsedArgs[pos]
+			} else {
+				commandArgs[arg.Name] = ""
 			}
+		}
+	}
+
+	return match, commandArgs, ""
+}
 
-			return cmd, commandArgs, ""
+// matchCommand returns the command that a name refers to. An exact match on the name or an alternate name wins;
+// otherwise, a command the player can use that starts with the name is matched, so that "inv" finds "inventory".
+// If nothing matches, or the name starts more than one command, nil is returned with an error message.
+func matchCommand(p *Player, searchWithin []*Command, name string, alreadyProcessed []string) (*Command, string) {
+	if cmd := exactCommand(searchWithin, name); cmd != nil {
+		return cmd, ""
+	}
+
+	var matches []*Command
+	for _, cmd := range searchWithin {
+		// Destructive commands have to be typed in full, so that a shortened command can't run them by accident.
+		if cmd.Hidden || cmd.NoPrefix || !cmd.CheckPermissions(p) {
+			continue
+		}
+
+		names := append([]string{strings.ToLower(cmd.Name)}, cmd.AltNames...)
+		for _, n := range names {
+			if strings.HasPrefix(n, name) {
+				matches = append(matches, cmd)
+				break
+			}
 		}
 	}
 
-	return nil, nil, CommandErrInvalid
+	switch len(matches) {
+	case 0:
+		return nil, CommandErrInvalid
+	case 1:
+		return matches[0], ""
+	}
+
+	var names []string
+	for _, cmd := range matches {
+		path := append(append([]string{}, alreadyProcessed...), cmd.Name)
+		names = append(names, TextStyle("/"+strings.Join(path, " "), WithBold()))
+	}
+	return nil, fmt.Sprintf("That command is ambiguous. Did you mean %s?", strings.Join(names, ", "))
+}
+
+// exactCommand returns the command with a name or alternate name, or nil if there isn't one.
+func exactCommand(searchWithin []*Command, name string) *Command {
+	name = strings.ToLower(name)
+	for _, cmd := range searchWithin {
+		if strings.ToLower(cmd.Name) == name || misc.Contains(cmd.AltNames, name) {
+			return cmd
+		}
+	}
+	return nil
 }
 
 // ProcessCommand will evaluate and process a command sent by the parent either
@@ -101,11 +156,28 @@ func (m *CommandManager) ProcessCommand(p *Player, command string, playerInitiat
 		return
 	}
 
+	// Verbs that items nearby can be interacted with (ie: "pull lever") work like commands, but never take the place
+	// of a real one.
+	if c := p.Character(); c != nil && exactCommand(m.commands, sections[0]) == nil {
+		handled, err := c.Interact(sections[0], strings.Join(sections[1:], " "))
+		if err != nil {
+			p.client.ShowColorizedText(err.Error(), ColorError)
+		}
+		if handled {
+			return
+		}
+	}
+
+	sections, editVersion := stripEditVersionFlag(sections)
+	sections, dryRun := stripDryRunFlag(sections)
 	cmd, cmdArgs, errorMsg := m.FindCommand(p, m.commands, strings.Join(sections, " "), []string{})
 
 	if cmd == nil {
 		p.client.ShowColorizedText(errorMsg, ColorCmdHelp)
 		return
+	} else if dryRun && !cmd.DryRun {
+		p.client.ShowColorizedText(fmt.Sprintf("That command can't be run with %s.", DryRunFlag), ColorError)
+		return
 	}
 
 	ctx := &CommandContext{
@@ -113,6 +185,11 @@ func (m *CommandManager) ProcessCommand(p *Player, command string, playerInitiat
 		Player:          p,
 		Args:            cmdArgs,
 		PlayerInitiated: playerInitiated,
+		EditVersion:     editVersion,
+	}
+
+	if dryRun {
+		ctx.Simulation = NewSimulation()
 	}
 
 	if p.Character() != nil {
@@ -127,6 +204,14 @@ func (m *CommandManager) ProcessCommand(p *Player, command string, playerInitiat
 	ctx.HandlerStart = time.Now()
 	cmd.Handler(ctx)
 	cmd.LogCtx(ctx)
+
+	if ctx.DryRun() {
+		p.client.ShowColorizedText(ctx.Simulation.Summary(), ColorCmdHelp)
+	}
+
+	if ctx.Character != nil {
+		ctx.Character.AdvanceTutorial(ctx)
+	}
 }
 
 func (m *CommandManager) CharacterCommandDictionaryJSON(p *Player) string {
@@ -152,3 +237,59 @@ func (m *CommandManager) CharacterCommandDictionaryJSON(p *Player) string {
 
 	return string(commandMapJSON)
 }
+
+// ForceCommand makes a character run a command on behalf of a staff member, as though they had entered it
+// themselves. Only commands that are Forceable can be run this way, and other sysops can't be forced. Errors are
+// meant for the staff member.
+func (m *CommandManager) ForceCommand(by *Character, c *Character, command string) error {
+	if !c.Online() {
+		return fmt.Errorf("%s isn't online", c.Name())
+	} else if c == by {
+		return errors.New("you can't force yourself")
+	} else if c.HasGlobalPermission("CAN_SYSOP") {
+		return errors.New("other sysops can't be forced")
+	}
+
+	command = strings.TrimPrefix(strings.TrimSpace(command), "/")
+	if len(command) == 0 {
+		return errors.New("you must enter a command")
+	}
+
+	cmd, cmdArgs, errorMsg := m.FindCommand(c.Player(), m.commands, command, []string{})
+	if errorMsg == CommandErrInvalid || errorMsg == CommandErrNoPerms {
+		return fmt.Errorf("%s can't use that command", c.Name())
+	} else if cmd == nil {
+		return errors.New("that command is incomplete or ambiguous")
+	} else if !cmd.Forceable() {
+		return fmt.Errorf("/%s can't be forced", strings.ToLower(cmd.FullName()))
+	}
+
+	if len(cmd.Alias) > 0 {
+		return m.ForceCommand(by, c, cmd.Alias)
+	}
+
+	c.Player().client.ShowColorizedText(
+		fmt.Sprintf("%s made you run %s.", by.FormattedName(), TextStylePlayer("/"+command, WithBold())),
+		ColorCmdHelp,
+	)
+
+	NotifyStaff(fmt.Sprintf(
+		"%s forced %s to run %s.",
+		by.FormattedName(),
+		c.FormattedName(),
+		TextStylePlayer("/"+command, WithBold()),
+	))
+
+	ctx := &CommandContext{
+		Command:   cmd,
+		Player:    c.Player(),
+		Character: c,
+		Args:      cmdArgs,
+		ForcedBy:  by,
+	}
+	ctx.HandlerStart = time.Now()
+	cmd.Handler(ctx)
+	cmd.LogCtx(ctx)
+
+	return nil
+}
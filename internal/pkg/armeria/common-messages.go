@@ -1,8 +1,11 @@
 package armeria
 
+// Common messages are shown by several commands. They are keys within the message catalogs, so they need to be
+// translated with Tr before they are shown.
 const (
-	CommonTargetNotFoundHere      string = "You don't see anyone here by that name."
-	CommonItemNotFoundOnCharacter string = "You don't have an item by that name."
-	CommonInvalidDirection        string = "You cannot go that way."
-	CommonInventoryFilled         string = "You have no room in your inventory for that."
+	CommonTargetNotFoundHere      string = "common.targetNotFoundHere"
+	CommonItemNotFoundOnCharacter string = "common.itemNotFoundOnCharacter"
+	CommonInvalidDirection        string = "common.invalidDirection"
+	CommonInventoryFilled         string = "common.inventoryFilled"
+	CommonTransientRoom           string = "common.transientRoom"
 )
@@ -3,15 +3,54 @@ package armeria
 import (
 	"io/ioutil"
 	"log"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
 
 type config struct {
-	HTTPPort   int    `yaml:"httpPort"`
-	PublicPath string `yaml:"publicPath"`
-	Production bool   `yaml:"production"`
-	DataPath   string `yaml:"dataPath"`
+	HTTPPort    int    `yaml:"httpPort"`
+	PublicPath  string `yaml:"publicPath"`
+	Production  bool   `yaml:"production"`
+	DataPath    string `yaml:"dataPath"`
+	StagingPath string `yaml:"stagingPath"`
+	// ChatHistoryOnDisk stores each character's chat history on disk, so it survives server restarts.
+	ChatHistoryOnDisk bool `yaml:"chatHistoryOnDisk"`
+	// IdleWarning is how long a character can go without sending a command before being warned about idling.
+	IdleWarning time.Duration `yaml:"idleWarning"`
+	// IdleTimeout is how long a character can go without sending a command before being logged out. Idle
+	// characters are never logged out when this is zero.
+	IdleTimeout time.Duration `yaml:"idleTimeout"`
+	// MaxPlayers is how many characters can be logged in at once before players are placed in a login queue. There
+	// is no limit when this is zero.
+	MaxPlayers int `yaml:"maxPlayers"`
+	// MultiboxPolicy is what happens when a second character logs in from the same address: allow, warn, or block.
+	MultiboxPolicy string `yaml:"multiboxPolicy"`
+	// APIOnly stops the server from serving the web client's static files, so they can be served by a separate
+	// process or CDN. Only the websocket and API routes are served.
+	APIOnly bool `yaml:"apiOnly"`
+	// TrustedProxies are the addresses (or CIDR ranges) of reverse proxies in front of the server. The
+	// X-Forwarded-For header is only used to find a player's address when the request came through one of them.
+	TrustedProxies []string `yaml:"trustedProxies"`
+	// ClusterRedis is the address of a Redis server used as the message bus between the nodes of an experimental
+	// cluster. Clustering is disabled when this is empty.
+	ClusterRedis string `yaml:"clusterRedis"`
+	// ClusterNode is the name of this node within the cluster, which defaults to the host name.
+	ClusterNode string `yaml:"clusterNode"`
+	// ClusterAreas are the names of the areas this node hosts. Characters heading into other areas are handed off
+	// to the node hosting them. The node hosts every area when this is empty.
+	ClusterAreas []string `yaml:"clusterAreas"`
+	// ClusterAddress is the websocket address that clients reach this node at (ie: wss://node1.armeria.io/ws). It's
+	// needed when the node hosts cluster areas.
+	ClusterAddress string `yaml:"clusterAddress"`
+	// Metrics serves the server's performance counters at /metrics, in the Prometheus text format.
+	Metrics bool `yaml:"metrics"`
+	// Seasons are the time-bounded events that are active between two dates each year.
+	Seasons []*Season `yaml:"seasons"`
+	// Quotas limit how many items, mobs, and rooms builders can create.
+	Quotas *CreationQuotas `yaml:"quotas"`
+	// StartingKits are the room, money, items and attributes new characters start with, keyed by class or "default".
+	StartingKits StartingKits `yaml:"startingKits"`
 }
 
 func parseConfigFile(filePath string) config {
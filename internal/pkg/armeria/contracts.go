@@ -0,0 +1,533 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	ContractStatusOpen      = "open"
+	ContractStatusDelivered = "delivered"
+	ContractStatusDisputed  = "disputed"
+	ContractStatusComplete  = "complete"
+	ContractStatusRefunded  = "refunded"
+
+	// ContractMaxDays is the longest a contract can stay open for.
+	ContractMaxDays = 14
+	// ContractReleaseDelay is how long the payment for a delivered contract is held before it is released to the
+	// crafter, giving the poster time to dispute the delivery. Collecting the item releases the payment at once.
+	ContractReleaseDelay = 24 * time.Hour
+)
+
+// ContractManager holds the work orders posted to the contract boards. A contract is a request for an item, paid
+// for up front: the payment is held in escrow by the board until a crafter delivers the item, and then released to
+// them. Money in escrow stays within the economy, so it's only recorded in the economy ledger if it can't be paid
+// out because the character it belongs to no longer exists.
+type ContractManager struct {
+	sync.RWMutex
+	dataFile        string
+	UnsafeContracts []*Contract `json:"contracts"`
+	UnsafeNextID    int         `json:"nextId"`
+}
+
+// Contract is a request for an item posted by a character.
+type Contract struct {
+	ID int `json:"id"`
+	// PosterID is the uuid of the character who posted the contract.
+	PosterID string `json:"poster"`
+	// CrafterID is the uuid of the character who delivered the item.
+	CrafterID string    `json:"crafter"`
+	Item      string    `json:"item"`
+	Payment   float64   `json:"payment"`
+	Status    string    `json:"status"`
+	Created   time.Time `json:"created"`
+	Expires   time.Time `json:"expires"`
+	// Delivered is when the item was delivered, and the payment release countdown began.
+	Delivered *time.Time `json:"delivered,omitempty"`
+	// Collected is true once the poster has taken the delivered item from the board.
+	Collected bool   `json:"collected"`
+	Dispute   string `json:"dispute,omitempty"`
+}
+
+// NewContractManager creates a new ContractManager.
+func NewContractManager() *ContractManager {
+	m := &ContractManager{
+		dataFile: fmt.Sprintf("%s/contracts.json", Armeria.dataPath),
+	}
+
+	m.LoadContracts()
+
+	return m
+}
+
+// LoadContracts loads the contracts from disk into memory.
+func (m *ContractManager) LoadContracts() {
+	m.Lock()
+	defer m.Unlock()
+
+	contractsFile, err := os.Open(m.dataFile)
+	defer contractsFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(contractsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("contracts loaded",
+		zap.Int("count", len(m.UnsafeContracts)),
+	)
+}
+
+// SaveContracts writes the in-memory contracts to disk.
+func (m *ContractManager) SaveContracts() {
+	m.RLock()
+	defer m.RUnlock()
+
+	contractsFile, err := os.Create(m.dataFile)
+	defer contractsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := contractsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = contractsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Contract returns the Contract with a particular ID, or nil if it doesn't exist.
+func (m *ContractManager) Contract(id int) *Contract {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, ct := range m.UnsafeContracts {
+		if ct.ID == id {
+			return ct
+		}
+	}
+
+	return nil
+}
+
+// Contracts returns the contracts with one of the statuses, or every contract when no statuses are given.
+func (m *ContractManager) Contracts(statuses ...string) []*Contract {
+	m.RLock()
+	defer m.RUnlock()
+
+	var contracts []*Contract
+	for _, ct := range m.UnsafeContracts {
+		if len(statuses) == 0 || misc.Contains(statuses, ct.Status) {
+			contracts = append(contracts, ct)
+		}
+	}
+
+	return contracts
+}
+
+// ContractsInvolving returns the contracts a Character posted or delivered, which aren't finished with.
+func (m *ContractManager) ContractsInvolving(c *Character) []*Contract {
+	m.RLock()
+	defer m.RUnlock()
+
+	var contracts []*Contract
+	for _, ct := range m.UnsafeContracts {
+		if ct.PosterID != c.ID() && ct.CrafterID != c.ID() {
+			continue
+		}
+		if ct.Status == ContractStatusRefunded || (ct.Status == ContractStatusComplete && ct.Collected) {
+			continue
+		}
+		contracts = append(contracts, ct)
+	}
+
+	return contracts
+}
+
+// Post creates a Contract for an item, taking the payment from the poster into escrow.
+func (m *ContractManager) Post(poster *Character, item *Item, payment float64, days int) (*Contract, error) {
+	if payment < 0.01 {
+		return nil, errors.New("the payment must be at least $0.01")
+	}
+	if days < 1 || days > ContractMaxDays {
+		return nil, fmt.Errorf("contracts can be open for 1 to %d days", ContractMaxDays)
+	}
+	if err := poster.HoldMoney(payment); err != nil {
+		return nil, errors.New("you can't afford that payment")
+	}
+
+	now := time.Now()
+	m.Lock()
+	m.UnsafeNextID++
+	ct := &Contract{
+		ID:       m.UnsafeNextID,
+		PosterID: poster.ID(),
+		Item:     item.Name(),
+		Payment:  payment,
+		Status:   ContractStatusOpen,
+		Created:  now,
+		Expires:  now.Add(time.Duration(days) * 24 * time.Hour),
+	}
+	m.UnsafeContracts = append(m.UnsafeContracts, ct)
+	m.Unlock()
+
+	Armeria.log.Info("contract posted",
+		zap.Int("id", ct.ID),
+		zap.String("poster", poster.Name()),
+		zap.String("item", ct.Item),
+		zap.Float64("payment", payment),
+	)
+
+	return ct, nil
+}
+
+// Cancel withdraws an open Contract, refunding the payment to its poster.
+func (m *ContractManager) Cancel(ct *Contract) error {
+	m.Lock()
+	if ct.Status != ContractStatusOpen {
+		m.Unlock()
+		return errors.New("only open contracts can be cancelled")
+	}
+	ct.Status = ContractStatusRefunded
+	m.Unlock()
+
+	m.refund(ct)
+	return nil
+}
+
+// Deliver fulfills an open Contract with an item from the crafter's inventory. The item is held by the board until
+// the poster collects it.
+func (m *ContractManager) Deliver(ct *Contract, crafter *Character) error {
+	if ct.PosterID == crafter.ID() {
+		return errors.New("you can't fulfill your own contract")
+	}
+
+	var ii *ItemInstance
+	for _, held := range crafter.itemsNamed(ct.Item) {
+		if !held.IsQuestItem() {
+			ii = held
+			break
+		}
+	}
+	if ii == nil {
+		return fmt.Errorf("you need a %s to fulfill it", ct.Item)
+	}
+
+	now := time.Now()
+	m.Lock()
+	if ct.Status != ContractStatusOpen {
+		m.Unlock()
+		return errors.New("that contract isn't open")
+	}
+	ct.Status = ContractStatusDelivered
+	ct.CrafterID = crafter.ID()
+	ct.Delivered = &now
+	m.Unlock()
+
+	crafter.Inventory().Remove(ii.ID())
+	ii.Delete()
+	if crafter.Online() {
+		crafter.Player().client.SyncInventory()
+	}
+
+	Armeria.log.Info("contract delivered",
+		zap.Int("id", ct.ID),
+		zap.String("crafter", crafter.Name()),
+	)
+
+	notifyContractCharacter(ct.Poster(), ct, fmt.Sprintf(
+		"%s delivered your %s. Collect it from a contract board.",
+		crafter.FormattedName(),
+		TextStyle(ct.Item, WithBold()),
+	))
+
+	return nil
+}
+
+// Collect gives the item delivered for a Contract to its poster, and releases the payment to the crafter if it is
+// still held.
+func (m *ContractManager) Collect(ct *Contract, poster *Character) error {
+	// The contract is marked as collected before the item is created, so collecting twice at once only gives one.
+	m.Lock()
+	status := ct.Status
+	if (status != ContractStatusDelivered && status != ContractStatusComplete) || ct.Collected {
+		m.Unlock()
+		return errors.New("there is nothing to collect for that contract")
+	}
+	ct.Collected = true
+	m.Unlock()
+
+	uncollect := func() {
+		m.Lock()
+		ct.Collected = false
+		m.Unlock()
+	}
+
+	item := Armeria.itemManager.ItemByName(ct.Item)
+	if item == nil {
+		uncollect()
+		return errors.New("that item no longer exists; petition staff for help")
+	}
+
+	ii := item.CreateInstance()
+	if err := poster.Inventory().Add(ii.ID()); err != nil {
+		item.DeleteInstance(ii)
+		uncollect()
+		return errors.New("you have no room in your inventory")
+	}
+	poster.Player().client.SyncInventory()
+
+	if status == ContractStatusDelivered {
+		m.release(ct)
+	}
+
+	return nil
+}
+
+// Dispute stops the payment for a delivered Contract from being released, and asks staff to look into it.
+func (m *ContractManager) Dispute(ct *Contract, reason string) error {
+	m.Lock()
+	if ct.Status != ContractStatusDelivered || ct.Collected {
+		m.Unlock()
+		return errors.New("only deliveries that haven't been collected or paid for can be disputed")
+	}
+	ct.Status = ContractStatusDisputed
+	ct.Dispute = reason
+	m.Unlock()
+
+	Armeria.log.Info("contract disputed",
+		zap.Int("id", ct.ID),
+		zap.String("reason", reason),
+	)
+
+	NotifyStaff(fmt.Sprintf(
+		"Contract #%d was disputed: %s (%s)",
+		ct.ID,
+		TextStylePlayer(reason),
+		TextStyle(fmt.Sprintf("/contract show %d", ct.ID), WithBold()),
+	))
+	notifyContractCharacter(ct.Crafter(), ct, "The poster disputed your delivery. Staff will look into it.")
+
+	return nil
+}
+
+// Resolve settles a disputed Contract. In favor of the crafter, the payment is released to them and the poster can
+// collect the item. Otherwise the payment is refunded to the poster, and the item is returned to the crafter.
+func (m *ContractManager) Resolve(ct *Contract, forCrafter bool) error {
+	m.Lock()
+	if ct.Status != ContractStatusDisputed {
+		m.Unlock()
+		return errors.New("that contract isn't disputed")
+	}
+	ct.Status = ContractStatusDelivered
+	m.Unlock()
+
+	if forCrafter {
+		m.release(ct)
+		notifyContractCharacter(ct.Poster(), ct, "Staff settled the dispute in the crafter's favor.")
+		return nil
+	}
+
+	m.Lock()
+	ct.Status = ContractStatusRefunded
+	m.Unlock()
+
+	m.refund(ct)
+	if crafter := ct.Crafter(); crafter != nil {
+		if item := Armeria.itemManager.ItemByName(ct.Item); item != nil {
+			ii := item.CreateInstance()
+			if err := crafter.Inventory().Add(ii.ID()); err != nil {
+				item.DeleteInstance(ii)
+			} else if crafter.Online() {
+				crafter.Player().client.SyncInventory()
+			}
+		}
+		notifyContractCharacter(crafter, ct, "Staff settled the dispute in the poster's favor. Your item was returned.")
+	}
+
+	return nil
+}
+
+// release pays the crafter of a delivered Contract from escrow.
+func (m *ContractManager) release(ct *Contract) {
+	m.Lock()
+	if ct.Status != ContractStatusDelivered {
+		m.Unlock()
+		return
+	}
+	ct.Status = ContractStatusComplete
+	m.Unlock()
+
+	crafter := ct.Crafter()
+	if crafter == nil {
+		Armeria.economyManager.Record(MoneySourceContracts, -ct.Payment)
+		return
+	}
+	if err := crafter.ReleaseMoney(ct.Payment); err != nil {
+		// Keep the payment in escrow, and try again when contracts are next settled.
+		m.Lock()
+		ct.Status = ContractStatusDelivered
+		m.Unlock()
+		Armeria.log.Error("contract payment could not be released",
+			zap.Int("id", ct.ID),
+			zap.String("crafter", crafter.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	Armeria.log.Info("contract payment released",
+		zap.Int("id", ct.ID),
+		zap.String("crafter", crafter.Name()),
+		zap.Float64("payment", ct.Payment),
+	)
+
+	notifyContractCharacter(crafter, ct, fmt.Sprintf(
+		"You were paid %s for delivering a %s.",
+		crafter.Colorize(misc.Money.FormatMoney(ct.Payment), ColorMoney),
+		TextStyle(ct.Item, WithBold()),
+	))
+}
+
+// refund returns the payment for a Contract from escrow to its poster.
+func (m *ContractManager) refund(ct *Contract) {
+	poster := ct.Poster()
+	if poster == nil {
+		Armeria.economyManager.Record(MoneySourceContracts, -ct.Payment)
+		return
+	}
+	if err := poster.ReleaseMoney(ct.Payment); err != nil {
+		Armeria.log.Error("contract payment could not be refunded",
+			zap.Int("id", ct.ID),
+			zap.String("poster", poster.Name()),
+			zap.Error(err),
+		)
+		NotifyStaff(fmt.Sprintf(
+			"The %s payment for contract #%d couldn't be refunded to %s: %s.",
+			misc.Money.FormatMoney(ct.Payment),
+			ct.ID,
+			poster.Name(),
+			err,
+		))
+		return
+	}
+
+	Armeria.log.Info("contract payment refunded",
+		zap.Int("id", ct.ID),
+		zap.String("poster", poster.Name()),
+		zap.Float64("payment", ct.Payment),
+	)
+
+	notifyContractCharacter(poster, ct, fmt.Sprintf(
+		"Your payment of %s was refunded.",
+		poster.Colorize(misc.Money.FormatMoney(ct.Payment), ColorMoney),
+	))
+}
+
+// Poster returns the Character who posted the Contract, or nil if they no longer exist.
+func (ct *Contract) Poster() *Character {
+	if o, rt := Armeria.registry.Get(ct.PosterID); rt == RegistryTypeCharacter {
+		return o.(*Character)
+	}
+
+	return nil
+}
+
+// Crafter returns the Character who delivered the Contract's item, or nil if it hasn't been delivered or they no
+// longer exist.
+func (ct *Contract) Crafter() *Character {
+	if o, rt := Armeria.registry.Get(ct.CrafterID); rt == RegistryTypeCharacter {
+		return o.(*Character)
+	}
+
+	return nil
+}
+
+// notifyContractCharacter lets a character involved in a Contract know about an update, if they are online.
+func notifyContractCharacter(c *Character, ct *Contract, message string) {
+	if c == nil || !c.Online() {
+		return
+	}
+
+	c.Player().client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s %s",
+			TextStyle(
+				fmt.Sprintf("[Contract #%d]", ct.ID),
+				WithBold(),
+				WithLinkCmd(fmt.Sprintf("/contract show %d", ct.ID)),
+			),
+			message,
+		),
+		ColorCmdHelp,
+	)
+}
+
+// ContractBoardHere returns true if there is a contract board in the Character's room.
+func (c *Character) ContractBoardHere() bool {
+	for _, ii := range c.Room().Here().Items() {
+		if ii.Attribute(AttributeType) == ItemTypeContractBoard {
+			return true
+		}
+	}
+	return false
+}
+
+// SettleContracts refunds the contracts that expired before being delivered, and pays the crafters of the
+// deliveries that weren't disputed within ContractReleaseDelay.
+func SettleContracts() {
+	m := Armeria.contractManager
+	for _, ct := range m.Contracts(ContractStatusOpen, ContractStatusDelivered) {
+		m.RLock()
+		status, expires, delivered := ct.Status, ct.Expires, ct.Delivered
+		m.RUnlock()
+
+		switch {
+		case status == ContractStatusOpen && time.Now().After(expires):
+			// The contract may have been delivered since its status was read.
+			m.Lock()
+			if ct.Status != ContractStatusOpen {
+				m.Unlock()
+				continue
+			}
+			ct.Status = ContractStatusRefunded
+			m.Unlock()
+			Armeria.log.Info("contract expired", zap.Int("id", ct.ID))
+			m.refund(ct)
+		case status == ContractStatusDelivered && delivered != nil && time.Since(*delivered) >= ContractReleaseDelay:
+			m.release(ct)
+		}
+	}
+}
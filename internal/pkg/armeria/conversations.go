@@ -117,6 +117,9 @@ func (convo *Conversation) Start() {
 			case <-convo.doneCh:
 				return
 			case <-convo.ticker.C:
+				if Armeria.tickManager.Frozen() {
+					continue
+				}
 				convo.IncTickCount()
 				go CallMobFunc(
 					convo.Character(),
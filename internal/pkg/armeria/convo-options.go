@@ -0,0 +1,138 @@
+package armeria
+
+import (
+	"strconv"
+	"time"
+)
+
+// ConvoOption is a conversation option a mob offered to a Character, which they can select by clicking on it or by
+// replying with its shortcut number.
+type ConvoOption struct {
+	ID       string
+	Text     string
+	Shortcut int
+}
+
+// ConvoOptions is the set of conversation options a mob is waiting for a Character to choose from. The options
+// expire when the mob's conversation timeout elapses, or when either of them leaves the room.
+type ConvoOptions struct {
+	Mob     *MobInstance
+	Group   int64
+	Options []*ConvoOption
+	timer   *time.Timer
+}
+
+// OfferConvoOption adds an option to the set of conversation options a mob is offering the Character, starting a
+// new set if the previous one was for another mob. It returns the option, numbered in the order it was offered,
+// along with the group it belongs to.
+func (c *Character) OfferConvoOption(mi *MobInstance, id, text string) (*ConvoOption, int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	set := c.unsafeConvoOptions
+	if set == nil || set.Mob != mi {
+		if set != nil {
+			set.timer.Stop()
+		}
+
+		set = &ConvoOptions{
+			Mob:   mi,
+			Group: time.Now().UnixNano(),
+		}
+		if timeout := mi.AttributeInt(AttributeConvoTimeout); timeout > 0 {
+			set.timer = time.AfterFunc(time.Duration(timeout)*time.Second, func() {
+				c.expireConvoOptions(set)
+			})
+		} else {
+			set.timer = time.NewTimer(0)
+			set.timer.Stop()
+		}
+		c.unsafeConvoOptions = set
+	}
+
+	for _, o := range set.Options {
+		if o.ID == id {
+			return o, set.Group
+		}
+	}
+
+	option := &ConvoOption{ID: id, Text: text, Shortcut: len(set.Options) + 1}
+	set.Options = append(set.Options, option)
+	return option, set.Group
+}
+
+// ConvoOption returns the option the Character can currently select from a mob, by its id or shortcut number, or
+// nil if the mob isn't offering it.
+func (c *Character) ConvoOption(mi *MobInstance, idOrShortcut string) *ConvoOption {
+	c.RLock()
+	defer c.RUnlock()
+
+	set := c.unsafeConvoOptions
+	if set == nil || (mi != nil && set.Mob != mi) {
+		return nil
+	}
+
+	shortcut, _ := strconv.Atoi(idOrShortcut)
+	for _, o := range set.Options {
+		if o.ID == idOrShortcut || o.Shortcut == shortcut {
+			return o
+		}
+	}
+	return nil
+}
+
+// ConvoOptionsMob returns the mob the Character has conversation options from, or nil if there are none.
+func (c *Character) ConvoOptionsMob() *MobInstance {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.unsafeConvoOptions == nil {
+		return nil
+	}
+	return c.unsafeConvoOptions.Mob
+}
+
+// ClearConvoOptions withdraws the conversation options the Character was offered, and greys them out on the
+// client so they can't be selected.
+func (c *Character) ClearConvoOptions() {
+	c.Lock()
+	set := c.unsafeConvoOptions
+	c.unsafeConvoOptions = nil
+	c.Unlock()
+
+	if set == nil {
+		return
+	}
+
+	set.timer.Stop()
+	if c.Online() {
+		c.Player().client.ExpireConvoOptions(set.Group)
+	}
+}
+
+// expireConvoOptions clears a set of conversation options that went unanswered for too long, and lets the mob's
+// script fall back on something else.
+func (c *Character) expireConvoOptions(set *ConvoOptions) {
+	c.RLock()
+	current := c.unsafeConvoOptions == set
+	c.RUnlock()
+
+	if !current {
+		return
+	}
+
+	c.ClearConvoOptions()
+	if c.Online() {
+		go CallMobFunc(c, set.Mob, "conversation_timeout")
+	}
+}
+
+// ClearConvoOptions withdraws the conversation options the MobInstance offered to anyone, such as when it leaves
+// the room.
+func (mi *MobInstance) ClearConvoOptions() {
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if c.ConvoOptionsMob() == mi {
+			c.ClearConvoOptions()
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Corpse is the remains of a slain mob, which can be harvested for materials until it decays.
+type Corpse struct {
+	Mob       *Mob
+	DecaysAt  time.Time
+	Harvested bool
+}
+
+// CorpseManager tracks the corpses that have been left behind by slain mobs. Corpses aren't kept across restarts.
+type CorpseManager struct {
+	sync.RWMutex
+	unsafeCorpses map[string]*Corpse
+}
+
+// NewCorpseManager returns a new CorpseManager.
+func NewCorpseManager() *CorpseManager {
+	return &CorpseManager{
+		unsafeCorpses: make(map[string]*Corpse),
+	}
+}
+
+// Corpse returns the Corpse for an item instance, or nil if the item isn't a tracked corpse.
+func (m *CorpseManager) Corpse(ii *ItemInstance) *Corpse {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.unsafeCorpses[ii.ID()]
+}
+
+// Add starts tracking a corpse left behind by a mob.
+func (m *CorpseManager) Add(ii *ItemInstance, mob *Mob, decay time.Duration) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.unsafeCorpses[ii.ID()] = &Corpse{
+		Mob:      mob,
+		DecaysAt: time.Now().Add(decay),
+	}
+}
+
+// Remove stops tracking a corpse.
+func (m *CorpseManager) Remove(ii *ItemInstance) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.unsafeCorpses, ii.ID())
+}
+
+// markHarvested marks a corpse as harvested, and returns false if someone else got to it first.
+func (m *CorpseManager) markHarvested(ii *ItemInstance) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	corpse, ok := m.unsafeCorpses[ii.ID()]
+	if !ok || corpse.Harvested {
+		return false
+	}
+
+	corpse.Harvested = true
+	return true
+}
+
+// Die kills the MobInstance. The killer, if any, is credited with the kill, and the mob leaves its corpse and loot
+// behind in the room.
+func (mi *MobInstance) Die(killer *Character) {
+	room := mi.Room()
+	if room == nil {
+		return
+	}
+
+	if killer != nil {
+		killer.RecordKill(mi.Parent)
+		killer.GiveExperience(mi.AttributeInt(AttributeExperience), fmt.Sprintf("for slaying %s", mi.Name()))
+	}
+
+	for _, c := range room.Here().Characters(true) {
+		c.Player().client.ShowText(fmt.Sprintf("%s dies.", mi.FormattedName()))
+	}
+
+	mi.leaveCorpse(room)
+	mi.dropLoot(room)
+
+	room.Here().Remove(mi.ID())
+	mi.Delete()
+
+	for _, c := range room.Here().Characters(true) {
+		c.Player().client.SyncRoomObjects()
+	}
+}
+
+// leaveCorpse places the MobInstance's corpse in a Room, if the mob has one.
+func (mi *MobInstance) leaveCorpse(room *Room) {
+	name := mi.Attribute(AttributeCorpse)
+	if len(name) == 0 {
+		return
+	}
+
+	item := Armeria.itemManager.ItemByName(name)
+	if item == nil {
+		Armeria.log.Error("mob has a corpse that doesn't exist",
+			zap.String("mob", mi.Name()),
+			zap.String("corpse", name),
+		)
+		return
+	}
+
+	ii := item.CreateInstance()
+	if err := room.Here().Add(ii.ID()); err != nil {
+		item.DeleteInstance(ii)
+		return
+	}
+
+	Armeria.corpseManager.Add(ii, mi.Parent, time.Duration(mi.AttributeInt(AttributeCorpseDecay))*time.Second)
+}
+
+// hasTool returns true if the Character is carrying or wearing an item with the given name.
+func (c *Character) hasTool(name string) bool {
+	for _, container := range []*ObjectContainer{c.Inventory(), c.Equipment()} {
+		if container.GetByName(name).Type != RegistryTypeUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// Harvest attempts to harvest materials from a corpse using the skinning skill, along with whatever tool the
+// slain mob calls for. On success, an item from the mob's harvest table is added to the Character's inventory and
+// the corpse can't be harvested again. It returns the item that was harvested, or nil if the attempt failed.
+func (c *Character) Harvest(ii *ItemInstance) (*ItemInstance, error) {
+	corpse := Armeria.corpseManager.Corpse(ii)
+	if corpse == nil {
+		return nil, errors.New("it isn't a corpse")
+	}
+	if corpse.Harvested {
+		return nil, errors.New("it has already been harvested")
+	}
+
+	table, err := ParseWeightedTable(corpse.Mob.Attribute(AttributeHarvestYield))
+	if err != nil {
+		return nil, errors.New("it has nothing worth harvesting")
+	}
+
+	tool := corpse.Mob.Attribute(AttributeHarvestTool)
+	if len(tool) > 0 && !c.hasTool(tool) {
+		return nil, fmt.Errorf("you need %s to harvest it", TextStyle(tool, WithBold()))
+	}
+
+	if c.Inventory().Count() >= c.Inventory().MaxSize() {
+		return nil, errors.New("you have no room in your inventory")
+	}
+
+	level := c.GatheringSkill(GatheringSkillSkinning)
+	harvestLevel, _ := strconv.Atoi(corpse.Mob.Attribute(AttributeHarvestLevel))
+	if misc.RandomInt(100) >= gatheringChance(level, harvestLevel) {
+		return nil, nil
+	}
+
+	item := Armeria.itemManager.ItemByName(table.Pick())
+	if item == nil {
+		Armeria.log.Error("corpse yielded an item that doesn't exist",
+			zap.String("mob", corpse.Mob.Name()),
+			zap.String("yield", corpse.Mob.Attribute(AttributeHarvestYield)),
+		)
+		return nil, errors.New("it has nothing worth harvesting")
+	}
+
+	if !Armeria.corpseManager.markHarvested(ii) {
+		return nil, errors.New("it has already been harvested")
+	}
+
+	harvested := item.CreateInstance()
+	if err := c.Inventory().Add(harvested.ID()); err != nil {
+		item.DeleteInstance(harvested)
+		return nil, errors.New("you have no room in your inventory")
+	}
+
+	if c.improveGatheringSkill(GatheringSkillSkinning) && c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"Your %s skill improved to %s.",
+				GatheringSkillSkinning,
+				TextStyle(strconv.Itoa(c.GatheringSkill(GatheringSkillSkinning)), WithBold()),
+			),
+			ColorSuccess,
+		)
+	}
+
+	return harvested, nil
+}
+
+// DecayCorpses removes the corpses that have been lying around for longer than their mob's decay time, along with
+// any corpses that were left over from before the server restarted.
+func DecayCorpses() {
+	for _, o := range Armeria.registry.GetAllFromType(RegistryTypeItemInstance) {
+		ii := o.(*ItemInstance)
+		if ii.Attribute(AttributeType) != ItemTypeCorpse {
+			continue
+		}
+
+		corpse := Armeria.corpseManager.Corpse(ii)
+		if corpse != nil && time.Now().Before(corpse.DecaysAt) {
+			continue
+		}
+
+		Armeria.corpseManager.Remove(ii)
+
+		oc := Armeria.registry.GetObjectContainer(ii.ID())
+		if oc != nil {
+			oc.Remove(ii.ID())
+			if room := oc.ParentRoom(); room != nil {
+				for _, c := range room.Here().Characters(true) {
+					c.Player().client.ShowText(fmt.Sprintf("%s rots away.", ii.FormattedName()))
+					c.Player().client.SyncRoomObjects()
+				}
+			} else if c := oc.ParentCharacter(); c != nil && c.Online() {
+				c.Player().client.ShowText(fmt.Sprintf("%s rots away.", ii.FormattedName()))
+				c.Player().client.SyncInventory()
+			}
+		}
+		ii.Parent.DeleteInstance(ii)
+	}
+}
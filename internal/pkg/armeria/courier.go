@@ -0,0 +1,164 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// QuestDelivery is a timed quest objective to deliver an item to a mob before the deadline.
+type QuestDelivery struct {
+	Item      string    `json:"item"`
+	Mob       string    `json:"mob"`
+	Deadline  time.Time `json:"deadline"`
+	Delivered bool      `json:"delivered"`
+}
+
+// Remaining returns how long is left to make the delivery, or zero if time is up.
+func (d *QuestDelivery) Remaining() time.Duration {
+	if left := time.Until(d.Deadline); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// Delivery returns a copy of the Character's delivery objective for a quest, or nil if it doesn't have one.
+func (c *Character) Delivery(quest string) *QuestDelivery {
+	c.RLock()
+	defer c.RUnlock()
+
+	d, ok := c.UnsafeQuestDeliveries[strings.ToLower(quest)]
+	if !ok {
+		return nil
+	}
+	copied := *d
+	return &copied
+}
+
+// StartDelivery gives the Character a timed objective, as part of a quest they are on, to deliver an item to a mob.
+// Starting a delivery again replaces the previous one and restarts the clock.
+func (c *Character) StartDelivery(quest, item, mob string, d time.Duration) error {
+	quest = strings.ToLower(quest)
+	if !c.OnQuest(quest) {
+		return errors.New("not on the quest")
+	}
+	if Armeria.itemManager.ItemByName(item) == nil {
+		return errors.New("item does not exist")
+	}
+	if Armeria.mobManager.MobByName(mob) == nil {
+		return errors.New("mob does not exist")
+	}
+
+	c.Lock()
+	if c.UnsafeQuestDeliveries == nil {
+		c.UnsafeQuestDeliveries = make(map[string]*QuestDelivery)
+	}
+	c.UnsafeQuestDeliveries[quest] = &QuestDelivery{
+		Item:     item,
+		Mob:      mob,
+		Deadline: time.Now().Add(d),
+	}
+	c.Unlock()
+
+	if c.Online() {
+		c.Player().client.SetQuestTimer(quest, fmt.Sprintf("Deliver %s to %s", item, mob), d)
+	}
+
+	return nil
+}
+
+// CompleteDelivery checks whether giving an item to a mob fulfils one of the Character's delivery objectives, and
+// marks the objective as delivered if it does. It returns the name of the quest the delivery was for, or an
+// empty string if the item wasn't part of a delivery.
+func (c *Character) CompleteDelivery(ii *ItemInstance, mi *MobInstance) string {
+	c.Lock()
+	quest := ""
+	for q, d := range c.UnsafeQuestDeliveries {
+		if d.Delivered || d.Remaining() == 0 {
+			continue
+		}
+		if strings.ToLower(d.Item) == strings.ToLower(ii.Name()) && strings.ToLower(d.Mob) == strings.ToLower(mi.Name()) {
+			d.Delivered = true
+			quest = q
+			break
+		}
+	}
+	c.Unlock()
+
+	if len(quest) > 0 && c.Online() {
+		c.Player().client.SetQuestTimer(quest, "", 0)
+	}
+
+	return quest
+}
+
+// clearDelivery removes the Character's delivery objective for a quest, and stops the timer on the client.
+func (c *Character) clearDelivery(quest string) {
+	c.Lock()
+	_, ok := c.UnsafeQuestDeliveries[quest]
+	delete(c.UnsafeQuestDeliveries, quest)
+	c.Unlock()
+
+	if ok && c.Online() {
+		c.Player().client.SetQuestTimer(quest, "", 0)
+	}
+}
+
+// FailExpiredDeliveries resets the Character's delivery objectives that ran out of time. The undelivered item is
+// taken back, so the objective has to be started over.
+func (c *Character) FailExpiredDeliveries() {
+	c.RLock()
+	var expired []string
+	for q, d := range c.UnsafeQuestDeliveries {
+		if !d.Delivered && d.Remaining() == 0 {
+			expired = append(expired, q)
+		}
+	}
+	c.RUnlock()
+
+	for _, q := range expired {
+		d := c.Delivery(q)
+		c.clearDelivery(q)
+
+		if c.Online() {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf(
+					"You ran out of time to deliver %s to %s. That part of the %s quest has been reset.",
+					TextStyle(d.Item, WithBold()),
+					TextStyle(d.Mob, WithBold()),
+					TextStyle(q, WithBold()),
+				),
+				ColorError,
+			)
+		}
+
+		c.removeQuestItems(func(ii *ItemInstance) bool {
+			return ii.Attribute(AttributeQuestItem) == q && strings.ToLower(ii.Name()) == strings.ToLower(d.Item)
+		})
+	}
+}
+
+// SyncQuestTimers sends the Character's running delivery timers to the client.
+func (c *Character) SyncQuestTimers() {
+	c.RLock()
+	deliveries := make(map[string]QuestDelivery, len(c.UnsafeQuestDeliveries))
+	for q, d := range c.UnsafeQuestDeliveries {
+		deliveries[q] = *d
+	}
+	c.RUnlock()
+
+	for q, d := range deliveries {
+		if !d.Delivered && d.Remaining() > 0 {
+			c.Player().client.SetQuestTimer(q, fmt.Sprintf("Deliver %s to %s", d.Item, d.Mob), d.Remaining())
+		}
+	}
+}
+
+// CheckQuestDeliveries fails the delivery objectives that ran out of time for every online character. Characters
+// who are offline are checked when they log in.
+func CheckQuestDeliveries() {
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		c.FailExpiredDeliveries()
+	}
+}
@@ -0,0 +1,261 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// CraftQueueMax is the number of items a character can have queued for crafting at once.
+	CraftQueueMax = 5
+	// CraftingTickInterval is how often the crafting queues of online characters progress.
+	CraftingTickInterval = time.Second
+)
+
+// RecipeIngredient is an item, and how many of it, used up when crafting.
+type RecipeIngredient struct {
+	Name  string
+	Count int
+}
+
+// Recipe is the list of ingredients needed to craft an item. It is written as comma-separated name:count pairs
+// (ie: "Iron Ore:2,Oak Log:1").
+type Recipe []*RecipeIngredient
+
+// ParseRecipe parses a Recipe from its string form.
+func ParseRecipe(s string) (Recipe, error) {
+	var recipe Recipe
+	for _, pair := range strings.Split(s, ",") {
+		sections := strings.Split(pair, ":")
+		if len(sections) != 2 {
+			return nil, fmt.Errorf("%q must be formatted as name:count", pair)
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(sections[1]))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("the count of %q must be a whole number above 0", sections[0])
+		}
+
+		recipe = append(recipe, &RecipeIngredient{Name: strings.TrimSpace(sections[0]), Count: count})
+	}
+
+	return recipe, nil
+}
+
+// String returns the ingredients of the recipe in a readable form (ie: "2x Iron Ore, 1x Oak Log").
+func (r Recipe) String() string {
+	var ingredients []string
+	for _, i := range r {
+		ingredients = append(ingredients, fmt.Sprintf("%dx %s", i.Count, i.Name))
+	}
+	return strings.Join(ingredients, ", ")
+}
+
+// CraftJob is an item waiting in a Character's crafting queue. Remaining and Total are in seconds. The names of the
+// ingredients used up are kept so they can be returned if the job is cancelled.
+type CraftJob struct {
+	Item        string   `json:"item"`
+	Remaining   int      `json:"remaining"`
+	Total       int      `json:"total"`
+	Ingredients []string `json:"ingredients"`
+}
+
+// CraftableItems returns the items that have a crafting recipe.
+func CraftableItems() []*Item {
+	var items []*Item
+	for _, i := range Armeria.itemManager.Items() {
+		if len(i.Attribute(AttributeCraftRecipe)) > 0 {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+// CraftQueue returns a copy of the Character's crafting queue. The first job is the one being crafted.
+func (c *Character) CraftQueue() []CraftJob {
+	c.RLock()
+	defer c.RUnlock()
+
+	queue := make([]CraftJob, len(c.UnsafeCraftQueue))
+	for i, j := range c.UnsafeCraftQueue {
+		queue[i] = *j
+	}
+	return queue
+}
+
+// QueueCraft uses up the ingredients for an item from the Character's inventory, and adds the item to the end of
+// their crafting queue.
+func (c *Character) QueueCraft(item *Item) error {
+	recipe, err := ParseRecipe(item.Attribute(AttributeCraftRecipe))
+	if err != nil || len(item.Attribute(AttributeCraftRecipe)) == 0 {
+		return errors.New("that can't be crafted")
+	}
+
+	if len(c.CraftQueue()) >= CraftQueueMax {
+		return fmt.Errorf("you can only have %d items queued at once", CraftQueueMax)
+	}
+
+	var used []*ItemInstance
+	for _, ingredient := range recipe {
+		var held []*ItemInstance
+		for _, ii := range c.itemsNamed(ingredient.Name) {
+			if !ii.IsQuestItem() {
+				held = append(held, ii)
+			}
+		}
+		if len(held) < ingredient.Count {
+			return fmt.Errorf("you need %s", recipe)
+		}
+		used = append(used, held[:ingredient.Count]...)
+	}
+
+	seconds, _ := strconv.Atoi(item.Attribute(AttributeCraftTime))
+	job := &CraftJob{
+		Item:      item.Name(),
+		Remaining: seconds,
+		Total:     seconds,
+	}
+	for _, ii := range used {
+		job.Ingredients = append(job.Ingredients, ii.Name())
+		c.Inventory().Remove(ii.ID())
+		ii.Delete()
+	}
+
+	c.Lock()
+	c.UnsafeCraftQueue = append(c.UnsafeCraftQueue, job)
+	c.Unlock()
+
+	Armeria.log.Info("character queued craft",
+		zap.String("character", c.Name()),
+		zap.String("item", item.Name()),
+	)
+
+	if c.Online() {
+		c.Player().client.SyncInventory()
+		c.Player().client.SyncCraftQueue()
+	}
+
+	return nil
+}
+
+// CancelCraft removes a job from the Character's crafting queue, by index, and returns its ingredients to their
+// inventory.
+func (c *Character) CancelCraft(index int) (*CraftJob, error) {
+	queue := c.CraftQueue()
+	if index < 0 || index >= len(queue) {
+		return nil, errors.New("there is nothing at that position in your crafting queue")
+	}
+
+	job := queue[index]
+	if c.Inventory().MaxSize()-c.Inventory().Count() < len(job.Ingredients) {
+		return nil, errors.New("you need more room in your inventory to get the ingredients back")
+	}
+
+	c.Lock()
+	c.UnsafeCraftQueue = append(c.UnsafeCraftQueue[:index], c.UnsafeCraftQueue[index+1:]...)
+	c.Unlock()
+
+	for _, name := range job.Ingredients {
+		item := Armeria.itemManager.ItemByName(name)
+		if item == nil {
+			continue
+		}
+		ii := item.CreateInstance()
+		if err := c.Inventory().Add(ii.ID()); err != nil {
+			item.DeleteInstance(ii)
+		}
+	}
+
+	if c.Online() {
+		c.Player().client.SyncInventory()
+		c.Player().client.SyncCraftQueue()
+	}
+
+	return &job, nil
+}
+
+// progressCrafting advances the job at the front of the Character's crafting queue by a number of seconds. A
+// finished item is added to their inventory, or waits in the queue until they have room for it.
+func (c *Character) progressCrafting(seconds int) {
+	c.Lock()
+	if len(c.UnsafeCraftQueue) == 0 {
+		c.Unlock()
+		return
+	}
+	job := c.UnsafeCraftQueue[0]
+	wasWaiting := job.Remaining <= 0
+	job.Remaining -= seconds
+	if job.Remaining < 0 {
+		job.Remaining = 0
+	}
+	finished := job.Remaining == 0
+	c.Unlock()
+
+	if !finished {
+		c.Player().client.SyncCraftQueue()
+		return
+	}
+
+	item := Armeria.itemManager.ItemByName(job.Item)
+	if item == nil {
+		Armeria.log.Error("character crafted an item that doesn't exist",
+			zap.String("character", c.Name()),
+			zap.String("item", job.Item),
+		)
+	} else {
+		ii := item.CreateInstance()
+		if err := c.Inventory().Add(ii.ID()); err != nil {
+			item.DeleteInstance(ii)
+			if !wasWaiting {
+				c.Player().client.ShowColorizedText(
+					fmt.Sprintf("Your %s is ready, but you have no room in your inventory for it.", job.Item),
+					ColorError,
+				)
+			}
+			return
+		}
+
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("You finished crafting a %s.", ii.FormattedName()),
+			ColorSuccess,
+		)
+	}
+
+	// The job may have been cancelled while the item was being made.
+	c.Lock()
+	if len(c.UnsafeCraftQueue) > 0 && c.UnsafeCraftQueue[0] == job {
+		c.UnsafeCraftQueue = c.UnsafeCraftQueue[1:]
+	}
+	c.Unlock()
+
+	c.Player().client.SyncInventory()
+	c.Player().client.SyncCraftQueue()
+}
+
+// CraftQueueJSON returns the JSON used for rendering the Character's crafting queue on the client.
+func (c *Character) CraftQueueJSON() string {
+	queueJSON, err := json.Marshal(c.CraftQueue())
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal crafting queue data",
+			zap.String("character", c.UUID),
+			zap.Error(err),
+		)
+	}
+
+	return string(queueJSON)
+}
+
+// ProgressCrafting advances the crafting queues of online characters. Crafting doesn't progress while a character
+// is offline.
+func ProgressCrafting() {
+	seconds := int(CraftingTickInterval / time.Second)
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		c.progressCrafting(seconds)
+	}
+}
@@ -0,0 +1,381 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// CreationExpiry is how long an unfinished character creation can be resumed after it was last updated.
+	CreationExpiry = 30 * time.Minute
+)
+
+// CreationManager tracks the characters that are in the process of being created.
+type CreationManager struct {
+	sync.RWMutex
+	unsafeCreations map[string]*CharacterCreation
+}
+
+// CharacterCreation is an unfinished character that is being built one step at a time. It is identified by a
+// token, which the client can use to resume the creation after a disconnect.
+type CharacterCreation struct {
+	sync.RWMutex
+	unsafeToken   string
+	unsafePlayer  *Player
+	unsafeStep    int
+	unsafeValues  map[string]string
+	unsafeUpdated time.Time
+}
+
+// CreationStep is a single step of character creation.
+type CreationStep struct {
+	Name   string
+	Prompt string
+	// Secret is true when the client should mask the value being typed.
+	Secret  bool
+	Options func() []string
+	// Validate returns the normalized value, or an error explaining why the value is invalid.
+	Validate func(cc *CharacterCreation, value string) (string, error)
+}
+
+// CreationState is the state of a character creation that is sent to the client.
+type CreationState struct {
+	Token      string   `json:"token"`
+	Step       string   `json:"step"`
+	StepNumber int      `json:"stepNumber"`
+	TotalSteps int      `json:"totalSteps"`
+	Prompt     string   `json:"prompt"`
+	Options    []string `json:"options"`
+	Secret     bool     `json:"secret"`
+	Error      string   `json:"error"`
+	Complete   bool     `json:"complete"`
+}
+
+var (
+	characterClasses = []string{"cleric", "mage", "rogue", "warrior"}
+
+	characterNameRegex     = regexp.MustCompile(`^[A-Za-z]{3,20}$`)
+	characterPasswordRegex = regexp.MustCompile(`^\S{6,64}$`)
+
+	creationSteps = []*CreationStep{
+		{
+			Name:   "name",
+			Prompt: "What is your character's name? Names are 3 to 20 letters long.",
+			Validate: func(cc *CharacterCreation, value string) (string, error) {
+				name, err := formatCharacterName(value)
+				if err != nil {
+					return "", err
+				}
+				if err := Armeria.nameManager.CheckName(name, nil); err != nil {
+					return "", err
+				}
+				if Armeria.creationManager.NameReserved(name, cc) {
+					return "", errors.New("a character with that name already exists")
+				}
+				return name, nil
+			},
+		},
+		{
+			Name:   "password",
+			Prompt: "Choose a password of at least 6 characters, without spaces.",
+			Secret: true,
+			Validate: func(cc *CharacterCreation, value string) (string, error) {
+				if !characterPasswordRegex.MatchString(value) {
+					return "", errors.New("passwords must be 6 to 64 characters long, without spaces")
+				}
+				return value, nil
+			},
+		},
+		{
+			Name:    "pronouns",
+			Prompt:  "Which pronouns should others use for your character?",
+			Options: PronounSetNames,
+			Validate: func(cc *CharacterCreation, value string) (string, error) {
+				value = strings.ToLower(value)
+				if !misc.Contains(PronounSetNames(), value) {
+					return "", fmt.Errorf("choose one of: %s", strings.Join(PronounSetNames(), ", "))
+				}
+				return value, nil
+			},
+		},
+		{
+			Name:    "class",
+			Prompt:  "Which class will your character be?",
+			Options: CharacterClasses,
+			Validate: func(cc *CharacterCreation, value string) (string, error) {
+				value = strings.ToLower(value)
+				if !misc.Contains(CharacterClasses(), value) {
+					return "", fmt.Errorf("choose one of: %s", strings.Join(CharacterClasses(), ", "))
+				}
+				return value, nil
+			},
+		},
+		{
+			Name:   "appearance",
+			Prompt: "Describe your character's appearance in a sentence or two.",
+			Validate: func(cc *CharacterCreation, value string) (string, error) {
+				value = strings.TrimSpace(value)
+				if len(value) < 10 || len(value) > 500 {
+					return "", errors.New("descriptions must be 10 to 500 characters long")
+				}
+				return value, nil
+			},
+		},
+	}
+)
+
+// CharacterClasses returns the classes a character can choose from.
+func CharacterClasses() []string {
+	return characterClasses
+}
+
+// formatCharacterName checks that a character name is 3 to 20 letters long, and returns it capitalized.
+func formatCharacterName(name string) (string, error) {
+	if !characterNameRegex.MatchString(name) {
+		return "", errors.New("names must be 3 to 20 letters long, without spaces or symbols")
+	}
+	return strings.ToUpper(name[0:1]) + strings.ToLower(name[1:]), nil
+}
+
+// NewCreationManager returns a new CreationManager.
+func NewCreationManager() *CreationManager {
+	return &CreationManager{
+		unsafeCreations: make(map[string]*CharacterCreation),
+	}
+}
+
+// Start begins a new character creation for a Player, discarding any creation the Player already started.
+func (m *CreationManager) Start(p *Player) *CharacterCreation {
+	if existing := m.CreationByPlayer(p); existing != nil {
+		m.Delete(existing)
+	}
+
+	cc := &CharacterCreation{
+		unsafeToken:   uuid.New().String(),
+		unsafePlayer:  p,
+		unsafeValues:  make(map[string]string),
+		unsafeUpdated: time.Now(),
+	}
+
+	m.Lock()
+	m.unsafeCreations[cc.unsafeToken] = cc
+	m.Unlock()
+
+	cc.ShowStep("")
+
+	return cc
+}
+
+// Resume attaches a Player to an unfinished character creation. It returns false if the token doesn't match a
+// creation that can still be resumed.
+func (m *CreationManager) Resume(p *Player, token string) bool {
+	m.expire()
+
+	m.RLock()
+	cc := m.unsafeCreations[token]
+	m.RUnlock()
+
+	if cc == nil {
+		p.client.SetCreationState(&CreationState{Token: token, Complete: true})
+		return false
+	}
+
+	cc.Lock()
+	cc.unsafePlayer = p
+	cc.unsafeUpdated = time.Now()
+	cc.Unlock()
+
+	p.client.ShowColorizedText("Picking up where you left off with your new character.", ColorSuccess)
+	cc.ShowStep("")
+
+	return true
+}
+
+// CreationByPlayer returns the character creation a Player is working on.
+func (m *CreationManager) CreationByPlayer(p *Player) *CharacterCreation {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, cc := range m.unsafeCreations {
+		if cc.Player() == p {
+			return cc
+		}
+	}
+
+	return nil
+}
+
+// NameReserved returns true if a name was already chosen by another unfinished character creation.
+func (m *CreationManager) NameReserved(name string, except *CharacterCreation) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, cc := range m.unsafeCreations {
+		if cc != except && strings.ToLower(cc.Value("name")) == strings.ToLower(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delete removes a character creation.
+func (m *CreationManager) Delete(cc *CharacterCreation) {
+	m.Lock()
+	defer m.Unlock()
+
+	delete(m.unsafeCreations, cc.Token())
+}
+
+// expire removes the character creations that can no longer be resumed.
+func (m *CreationManager) expire() {
+	m.Lock()
+	defer m.Unlock()
+
+	for token, cc := range m.unsafeCreations {
+		if time.Since(cc.Updated()) > CreationExpiry {
+			delete(m.unsafeCreations, token)
+		}
+	}
+}
+
+// Token returns the token used to resume the character creation.
+func (cc *CharacterCreation) Token() string {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	return cc.unsafeToken
+}
+
+// Player returns the Player creating the character.
+func (cc *CharacterCreation) Player() *Player {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	return cc.unsafePlayer
+}
+
+// Value returns the value chosen for a step.
+func (cc *CharacterCreation) Value(step string) string {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	return cc.unsafeValues[step]
+}
+
+// Updated returns when the character creation last changed.
+func (cc *CharacterCreation) Updated() time.Time {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	return cc.unsafeUpdated
+}
+
+// Step returns the current CreationStep.
+func (cc *CharacterCreation) Step() *CreationStep {
+	cc.RLock()
+	defer cc.RUnlock()
+
+	return creationSteps[cc.unsafeStep]
+}
+
+// ShowStep prompts the Player for the current step, along with an error from the previous attempt.
+func (cc *CharacterCreation) ShowStep(errorMsg string) {
+	p := cc.Player()
+	step := cc.Step()
+
+	cc.RLock()
+	state := &CreationState{
+		Token:      cc.unsafeToken,
+		Step:       step.Name,
+		StepNumber: cc.unsafeStep + 1,
+		TotalSteps: len(creationSteps),
+		Prompt:     step.Prompt,
+		Secret:     step.Secret,
+		Error:      errorMsg,
+	}
+	cc.RUnlock()
+
+	if step.Options != nil {
+		state.Options = step.Options()
+	}
+
+	if len(errorMsg) > 0 {
+		p.client.ShowColorizedText(fmt.Sprintf("That won't work: %s.", errorMsg), ColorError)
+	}
+
+	prompt := fmt.Sprintf(
+		"%s %s",
+		TextStyle(fmt.Sprintf("[Step %d/%d]", state.StepNumber, state.TotalSteps), WithBold()),
+		state.Prompt,
+	)
+	if len(state.Options) > 0 {
+		prompt = fmt.Sprintf("%s (%s)", prompt, strings.Join(state.Options, ", "))
+	}
+	p.client.ShowColorizedText(prompt, ColorCmdHelp)
+	p.client.SetCreationState(state)
+}
+
+// Submit validates a value for the current step and moves on to the next step. The character is created once
+// the final step is complete.
+func (cc *CharacterCreation) Submit(value string) {
+	step := cc.Step()
+
+	normalized, err := step.Validate(cc, strings.TrimSpace(value))
+	if err != nil {
+		cc.ShowStep(err.Error())
+		return
+	}
+
+	cc.Lock()
+	cc.unsafeValues[step.Name] = normalized
+	cc.unsafeStep++
+	cc.unsafeUpdated = time.Now()
+	done := cc.unsafeStep == len(creationSteps)
+	cc.Unlock()
+
+	if !done {
+		cc.ShowStep("")
+		return
+	}
+
+	cc.finish()
+}
+
+// finish creates the character, places it at the starting location, and logs the Player in.
+func (cc *CharacterCreation) finish() {
+	p := cc.Player()
+	Armeria.creationManager.Delete(cc)
+
+	kit := Armeria.startingKits.For(cc.Value("class"))
+	start := kit.StartRoom()
+	if start == nil {
+		p.client.ShowColorizedText("There is nowhere for new characters to start. Please try again later.", ColorError)
+		return
+	}
+
+	c := Armeria.characterManager.CreateCharacter(cc.Value("name"), cc.Value("password"))
+	_ = c.SetAttribute(AttributePronouns, cc.Value("pronouns"))
+	_ = c.SetAttribute(AttributeClass, cc.Value("class"))
+	_ = c.SetAttribute(AttributeDescription, cc.Value("appearance"))
+	c.ApplyStartingKit(kit)
+	_ = start.Here().Add(c.ID())
+
+	CallGlobalFunc(c, "on_character_create")
+
+	p.client.SetCreationState(&CreationState{Token: cc.Token(), Complete: true})
+
+	Armeria.log.Info("character creation completed",
+		zap.String("character", c.Name()),
+	)
+
+	Armeria.loginQueue.Admit(p, c)
+}
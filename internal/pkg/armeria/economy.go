@@ -0,0 +1,324 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MoneySource is where money entering or leaving the economy came from, or went to.
+type MoneySource string
+
+const (
+	MoneySourceVendors     MoneySource = "vendors"
+	MoneySourceCasino      MoneySource = "casino"
+	MoneySourceLottery     MoneySource = "lottery"
+	MoneySourceAdjustments MoneySource = "adjustments"
+	MoneySourceScripts     MoneySource = "scripts"
+	MoneySourceQuests      MoneySource = "quests"
+	MoneySourceContracts   MoneySource = "contracts"
+	MoneySourceStartingKit MoneySource = "starting kits"
+
+	// EconomyHistoryDays is how many days of money flows the economy ledger keeps.
+	EconomyHistoryDays = 90
+	// EconomyReportDays is how many days /admin economy reports on by default.
+	EconomyReportDays = 7
+)
+
+// EconomyManager keeps the economy ledger: how much money each MoneySource created and destroyed each day (in
+// UTC), so that designers can balance the sinks and faucets of the economy.
+type EconomyManager struct {
+	sync.RWMutex
+	dataFile   string
+	UnsafeDays map[string]map[MoneySource]*MoneyFlow `json:"days"`
+}
+
+// MoneyFlow is the money created and destroyed by a MoneySource.
+type MoneyFlow struct {
+	Created      float64 `json:"created"`
+	Destroyed    float64 `json:"destroyed"`
+	Transactions int     `json:"transactions"`
+}
+
+// Net returns the money created less the money destroyed.
+func (f *MoneyFlow) Net() float64 {
+	return f.Created - f.Destroyed
+}
+
+// add adds another flow to this one.
+func (f *MoneyFlow) add(other *MoneyFlow) {
+	f.Created += other.Created
+	f.Destroyed += other.Destroyed
+	f.Transactions += other.Transactions
+}
+
+// NewEconomyManager creates a new EconomyManager.
+func NewEconomyManager() *EconomyManager {
+	m := &EconomyManager{
+		dataFile: fmt.Sprintf("%s/economy.json", Armeria.dataPath),
+	}
+
+	m.LoadEconomy()
+
+	return m
+}
+
+// LoadEconomy loads the economy ledger from disk into memory.
+func (m *EconomyManager) LoadEconomy() {
+	m.Lock()
+	defer m.Unlock()
+
+	economyFile, err := os.Open(m.dataFile)
+	defer economyFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(economyFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeDays == nil {
+		m.UnsafeDays = make(map[string]map[MoneySource]*MoneyFlow)
+	}
+
+	Armeria.log.Info("economy ledger loaded",
+		zap.Int("days", len(m.UnsafeDays)),
+	)
+}
+
+// SaveEconomy writes the in-memory economy ledger to disk.
+func (m *EconomyManager) SaveEconomy() {
+	m.RLock()
+	defer m.RUnlock()
+
+	economyFile, err := os.Create(m.dataFile)
+	defer economyFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := economyFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = economyFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// economyDay returns the key of the day a time falls on within the economy ledger.
+func economyDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// Record adds money created (a positive amount) or destroyed (a negative amount) by a MoneySource to today's
+// ledger. Days older than EconomyHistoryDays are forgotten.
+func (m *EconomyManager) Record(source MoneySource, amount float64) {
+	if amount == 0 {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	today := economyDay(time.Now())
+	if _, ok := m.UnsafeDays[today]; !ok {
+		m.UnsafeDays[today] = make(map[MoneySource]*MoneyFlow)
+
+		oldest := economyDay(time.Now().AddDate(0, 0, -EconomyHistoryDays))
+		for day := range m.UnsafeDays {
+			if day < oldest {
+				delete(m.UnsafeDays, day)
+			}
+		}
+	}
+
+	flow, ok := m.UnsafeDays[today][source]
+	if !ok {
+		flow = &MoneyFlow{}
+		m.UnsafeDays[today][source] = flow
+	}
+	if amount > 0 {
+		flow.Created += amount
+	} else {
+		flow.Destroyed -= amount
+	}
+	flow.Transactions++
+}
+
+// Flows returns the money flows of each day within the last number of days (including today), by day and then by
+// MoneySource.
+func (m *EconomyManager) Flows(days int) map[string]map[MoneySource]*MoneyFlow {
+	m.RLock()
+	defer m.RUnlock()
+
+	since := economyDay(time.Now().AddDate(0, 0, 1-days))
+	flows := make(map[string]map[MoneySource]*MoneyFlow)
+	for day, sources := range m.UnsafeDays {
+		if day < since {
+			continue
+		}
+		flows[day] = make(map[MoneySource]*MoneyFlow)
+		for source, flow := range sources {
+			f := *flow
+			flows[day][source] = &f
+		}
+	}
+	return flows
+}
+
+// moneySupply returns the total money held by characters, and the number of characters holding it.
+func moneySupply() (float64, int) {
+	total := 0.0
+	chars := Armeria.characterManager.Characters()
+	for _, c := range chars {
+		total += c.Money()
+	}
+	return total, len(chars)
+}
+
+// signedMoney formats an amount of money with its sign, such as "+$5.00".
+func signedMoney(amount float64) string {
+	if amount < 0 {
+		return "-" + misc.Money.FormatMoney(-amount)
+	}
+	return "+" + misc.Money.FormatMoney(amount)
+}
+
+// economySummary reports on the money flows of the last number of days: the money each MoneySource created and
+// destroyed, the money flowing in and out each day, and indicators of inflation.
+func economySummary(days int) string {
+	flows := Armeria.economyManager.Flows(days)
+
+	bySource := make(map[MoneySource]*MoneyFlow)
+	total := &MoneyFlow{}
+	var dates []string
+	for day, sources := range flows {
+		dates = append(dates, day)
+		for source, flow := range sources {
+			if _, ok := bySource[source]; !ok {
+				bySource[source] = &MoneyFlow{}
+			}
+			bySource[source].add(flow)
+			total.add(flow)
+		}
+	}
+	if len(dates) == 0 {
+		return fmt.Sprintf("No money has changed hands within the last %d day(s).", days)
+	}
+	sort.Strings(dates)
+
+	var sources []MoneySource
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return bySource[sources[i]].Net() > bySource[sources[j]].Net()
+	})
+
+	flowHeader := func(first string) string {
+		return TableRow(
+			TableCell{content: first, header: true},
+			TableCell{content: "Created", header: true},
+			TableCell{content: "Destroyed", header: true},
+			TableCell{content: "Net", header: true},
+			TableCell{content: "Transactions", header: true},
+		)
+	}
+	flowRow := func(first string, f *MoneyFlow) string {
+		return TableRow(
+			TableCell{content: first},
+			TableCell{content: misc.Money.FormatMoney(f.Created)},
+			TableCell{content: misc.Money.FormatMoney(f.Destroyed)},
+			TableCell{content: signedMoney(f.Net())},
+			TableCell{content: strconv.Itoa(f.Transactions)},
+		)
+	}
+
+	sourceRows := []string{flowHeader("Source")}
+	for _, source := range sources {
+		sourceRows = append(sourceRows, flowRow(TextCapitalization(string(source)), bySource[source]))
+	}
+	sourceRows = append(sourceRows, flowRow(TextStyle("Total", WithBold()), total))
+
+	dayRows := []string{flowHeader("Day")}
+	for _, day := range dates {
+		f := &MoneyFlow{}
+		for _, flow := range flows[day] {
+			f.add(flow)
+		}
+		dayRows = append(dayRows, flowRow(day, f))
+	}
+
+	supply, chars := moneySupply()
+	indicators := []string{
+		fmt.Sprintf("Money held by characters: %s across %d character(s)", misc.Money.FormatMoney(supply), chars),
+	}
+	if start := supply - total.Net(); start > 0 {
+		indicators = append(indicators, fmt.Sprintf(
+			"Change in money supply: %s (%+.1f%%)",
+			signedMoney(total.Net()),
+			total.Net()/start*100,
+		))
+	} else {
+		indicators = append(indicators, fmt.Sprintf("Change in money supply: %s", signedMoney(total.Net())))
+	}
+	if total.Created > 0 {
+		indicators = append(indicators, fmt.Sprintf(
+			"Sink-to-faucet ratio: %.2f (below 1 means money is piling up faster than it is spent)",
+			total.Destroyed/total.Created,
+		))
+	}
+	if top := bySource[sources[0]]; top.Net() > 0 {
+		indicators = append(indicators, fmt.Sprintf(
+			"Biggest faucet: %s (%s)",
+			TextCapitalization(string(sources[0])),
+			signedMoney(top.Net()),
+		))
+	}
+	if bottom := bySource[sources[len(sources)-1]]; bottom.Net() < 0 {
+		indicators = append(indicators, fmt.Sprintf(
+			"Biggest sink: %s (%s)",
+			TextCapitalization(string(sources[len(sources)-1])),
+			signedMoney(bottom.Net()),
+		))
+	}
+
+	return fmt.Sprintf(
+		"[b]Money flows over the last %d day(s):[/b]\n%s\n\n[b]Inflation indicators:[/b]\n%s\n\n[b]By day:[/b]\n%s",
+		days,
+		TextTable(sourceRows...),
+		strings.Join(indicators, "\n"),
+		TextTable(dayRows...),
+	)
+}
@@ -0,0 +1,194 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EditVersionFlag is the flag the object editor adds to the end of the commands it sends (ie:
+	// "--if-version=3"), so that a change is rejected if someone else changed the same attribute since the editor
+	// was opened.
+	EditVersionFlag = "--if-version="
+	// EditHistoryLimit is the maximum number of edits remembered for each object.
+	EditHistoryLimit = 50
+)
+
+// EditHistory tracks a version counter for each object edited since the server started, along with the recent
+// edits that moved each one forward. It is used for optimistic concurrency within the object editor: two builders
+// editing the same object see a conflict, rather than silently overwriting each other's changes.
+// Objects are tracked by their edit key (see editKey).
+type EditHistory struct {
+	sync.RWMutex
+	objects map[string]*objectEdits
+}
+
+// objectEdits is the version and recent edits of a single object.
+type objectEdits struct {
+	version int
+	edits   []*ObjectEdit
+}
+
+// ObjectEdit is a single attribute change made to an object by a builder.
+type ObjectEdit struct {
+	Version   int
+	By        string
+	Attribute string
+	OldValue  string
+	NewValue  string
+	Time      time.Time
+}
+
+// NewEditHistory creates a new EditHistory with no edits.
+func NewEditHistory() *EditHistory {
+	return &EditHistory{
+		objects: make(map[string]*objectEdits),
+	}
+}
+
+// editKey returns the key that edits to an object are tracked under, from the object type used by the object
+// editor. Mobs and items are identified by name, and everything else by uuid.
+func editKey(objectType, id string) string {
+	return objectType + "/" + strings.ToLower(id)
+}
+
+// editorDataKey returns the edit key of the object shown in the object editor.
+func editorDataKey(data *ObjectEditorData) string {
+	switch data.ObjectType {
+	case "mob", "item":
+		return editKey(data.ObjectType, data.Name)
+	}
+	return editKey(data.ObjectType, data.UUID)
+}
+
+// Version returns the current version of an object. Objects that haven't been edited are at version 1.
+func (h *EditHistory) Version(key string) int {
+	h.RLock()
+	defer h.RUnlock()
+
+	if o, ok := h.objects[key]; ok {
+		return o.version
+	}
+	return 1
+}
+
+// Record remembers an attribute change made to an object by a Character, and returns the object's new version.
+func (h *EditHistory) Record(key string, by *Character, attr, oldValue, newValue string) int {
+	h.Lock()
+	defer h.Unlock()
+
+	o, ok := h.objects[key]
+	if !ok {
+		o = &objectEdits{version: 1}
+		h.objects[key] = o
+	}
+
+	o.version++
+	o.edits = append(o.edits, &ObjectEdit{
+		Version:   o.version,
+		By:        by.Name(),
+		Attribute: attr,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Time:      time.Now(),
+	})
+	if len(o.edits) > EditHistoryLimit {
+		o.edits = o.edits[len(o.edits)-EditHistoryLimit:]
+	}
+
+	return o.version
+}
+
+// Conflicts returns the edits made to an attribute on an object after the given version by anyone other than the
+// Character, which a change made from that version would overwrite.
+func (h *EditHistory) Conflicts(key string, version int, c *Character, attr string) []*ObjectEdit {
+	h.RLock()
+	defer h.RUnlock()
+
+	o, ok := h.objects[key]
+	if !ok {
+		return nil
+	}
+
+	var conflicts []*ObjectEdit
+	for _, e := range o.edits {
+		if e.Version > version && e.Attribute == attr && e.By != c.Name() {
+			conflicts = append(conflicts, e)
+		}
+	}
+	return conflicts
+}
+
+// stripEditVersionFlag removes EditVersionFlag from the end of the sections of a command, and returns the version
+// it carried, or zero if it wasn't there.
+func stripEditVersionFlag(sections []string) ([]string, int) {
+	if len(sections) < 2 {
+		return sections, 0
+	}
+
+	last := strings.ToLower(sections[len(sections)-1])
+	if !strings.HasPrefix(last, EditVersionFlag) {
+		return sections, 0
+	}
+
+	v, err := strconv.Atoi(strings.TrimPrefix(last, EditVersionFlag))
+	if err != nil || v < 1 {
+		return sections, 0
+	}
+	return sections[:len(sections)-1], v
+}
+
+// CheckEditVersion returns true if the command can change an attribute on an object. A change sent from the object
+// editor is rejected if someone else changed the same attribute since the editor was opened, in which case the
+// player is shown what changed and their editor is refreshed with the latest values.
+func (ctx *CommandContext) CheckEditVersion(editorData func() *ObjectEditorData, attr string) bool {
+	if ctx.EditVersion == 0 {
+		return true
+	}
+
+	data := editorData()
+	conflicts := Armeria.editHistory.Conflicts(editorDataKey(data), ctx.EditVersion, ctx.Character, attr)
+	if len(conflicts) == 0 {
+		return true
+	}
+
+	rows := []string{TableRow(
+		TableCell{content: "Builder", header: true},
+		TableCell{content: "When", header: true},
+		TableCell{content: "Old Value", header: true},
+		TableCell{content: "New Value", header: true},
+	)}
+	for _, e := range conflicts {
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(e.By, WithBold())},
+			TableCell{content: fmt.Sprintf("%s ago", TextDuration(time.Since(e.Time)))},
+			TableCell{content: TextAttributeValue(e.OldValue)},
+			TableCell{content: TextAttributeValue(e.NewValue)},
+		))
+	}
+
+	ctx.Player.client.ShowColorizedText(
+		fmt.Sprintf(
+			"Your change to %s wasn't saved, because it was changed by someone else after you opened the editor:\n%s",
+			TextStyle(attr, WithBold()),
+			TextTable(rows...),
+		),
+		ColorError,
+	)
+
+	if ctx.Character.TempAttribute(TempAttributeEditorOpen) == "true" {
+		ctx.Player.client.ShowObjectEditor(data)
+	}
+
+	return false
+}
+
+// RecordEdit remembers an attribute change the command made to an object, moving the object to a new version.
+func (ctx *CommandContext) RecordEdit(key, attr, oldValue, newValue string) {
+	if oldValue != newValue {
+		Armeria.editHistory.Record(key, ctx.Character, attr, oldValue, newValue)
+	}
+}
@@ -1,8 +1,23 @@
 package armeria
 
+import (
+	"encoding/json"
+
+	"go.uber.org/zap"
+)
+
 type EquipmentSlot string
 
 const (
+	EquipSlotHead         EquipmentSlot = "head"
+	EquipSlotNeck         EquipmentSlot = "neck"
+	EquipSlotChest        EquipmentSlot = "chest"
+	EquipSlotHands        EquipmentSlot = "hands"
+	EquipSlotLegs         EquipmentSlot = "legs"
+	EquipSlotFeet         EquipmentSlot = "feet"
+	EquipSlotFinger       EquipmentSlot = "finger"
+	EquipSlotWeapon       EquipmentSlot = "weapon"
+	EquipSlotOffhand      EquipmentSlot = "offhand"
 	EquipSlotWalletBank   EquipmentSlot = "wallet-bank"
 	EquipSlotWalletAccess EquipmentSlot = "wallet-access"
 )
@@ -10,6 +25,15 @@ const (
 // ValidEquipmentSlots returns the valid slots for equippable items.
 func ValidEquipmentSlots() []EquipmentSlot {
 	return []EquipmentSlot{
+		EquipSlotHead,
+		EquipSlotNeck,
+		EquipSlotChest,
+		EquipSlotHands,
+		EquipSlotLegs,
+		EquipSlotFeet,
+		EquipSlotFinger,
+		EquipSlotWeapon,
+		EquipSlotOffhand,
 		EquipSlotWalletBank,
 		EquipSlotWalletAccess,
 	}
@@ -27,6 +51,8 @@ func ValidEquipmentSlotsAsString() []string {
 // EquipSlotMax returns the number of items that can be equipped to a given slot.
 func EquipSlotMax(slot EquipmentSlot) int {
 	switch slot {
+	case EquipSlotFinger:
+		return 2
 	case EquipSlotWalletAccess:
 		return 5
 	}
@@ -37,6 +63,24 @@ func EquipSlotMax(slot EquipmentSlot) int {
 // EquipSlotFormalName returns the formal name, with proper capitalization, for a given slot.
 func EquipSlotFormalName(slot EquipmentSlot) string {
 	switch slot {
+	case EquipSlotHead:
+		return "Head"
+	case EquipSlotNeck:
+		return "Neck"
+	case EquipSlotChest:
+		return "Chest"
+	case EquipSlotHands:
+		return "Hands"
+	case EquipSlotLegs:
+		return "Legs"
+	case EquipSlotFeet:
+		return "Feet"
+	case EquipSlotFinger:
+		return "Fingers"
+	case EquipSlotWeapon:
+		return "Weapon"
+	case EquipSlotOffhand:
+		return "Off-hand"
 	case EquipSlotWalletBank:
 		return "Wallet (Bank Card)"
 	case EquipSlotWalletAccess:
@@ -45,3 +89,28 @@ func EquipSlotFormalName(slot EquipmentSlot) string {
 
 	return string(slot)
 }
+
+// EquipmentJSON returns the JSON used for rendering the Character's equipment on the client.
+func (c *Character) EquipmentJSON() string {
+	var equipment []map[string]interface{}
+
+	for _, ii := range c.Equipment().Items() {
+		equipment = append(equipment, map[string]interface{}{
+			"uuid":    ii.ID(),
+			"name":    ii.Name(),
+			"picture": ii.Attribute(AttributePicture),
+			"slot":    c.Equipment().SlotName(ii.ID()),
+			"color":   ii.RarityColor(),
+		})
+	}
+
+	equipmentJSON, err := json.Marshal(equipment)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal equipment data",
+			zap.String("character", c.UUID),
+			zap.Error(err),
+		)
+	}
+
+	return string(equipmentJSON)
+}
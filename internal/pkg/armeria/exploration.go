@@ -0,0 +1,107 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// explorationKey returns the key a Room is stored under within an area's explored rooms.
+func explorationKey(r *Room) string {
+	return fmt.Sprintf("%d,%d,%d", r.Coords.X(), r.Coords.Y(), r.Coords.Z())
+}
+
+// Explore marks a Room as visited by the Character. When the room hasn't been visited before, the minimap is
+// re-synced to reveal it and any titles earned by exploring the whole area are granted. It returns false if the
+// room was already explored.
+func (c *Character) Explore(r *Room) bool {
+	if r.Transient() {
+		return false
+	}
+
+	area := r.ParentArea.Name()
+	key := explorationKey(r)
+
+	c.Lock()
+	if c.UnsafeExplored == nil {
+		c.UnsafeExplored = make(map[string]map[string]bool)
+	}
+	if c.UnsafeExplored[area] == nil {
+		c.UnsafeExplored[area] = make(map[string]bool)
+	}
+	known := c.UnsafeExplored[area][key]
+	c.UnsafeExplored[area][key] = true
+	c.Unlock()
+
+	if known {
+		return false
+	}
+
+	if c.Online() {
+		c.Player().client.SyncMap()
+	}
+
+	if explored, total := c.ExplorationProgress(r.ParentArea); explored == total {
+		c.completeExploration(r.ParentArea)
+	}
+
+	return true
+}
+
+// ExploredRooms returns a copy of the rooms the Character has explored within an area, keyed by coordinates.
+func (c *Character) ExploredRooms(area string) map[string]bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	explored := make(map[string]bool)
+	for k, v := range c.UnsafeExplored[area] {
+		explored[k] = v
+	}
+
+	return explored
+}
+
+// ExplorationProgress returns the number of rooms the Character has explored within an Area, and the total
+// number of rooms that can be explored there.
+func (c *Character) ExplorationProgress(a *Area) (int, int) {
+	known := c.ExploredRooms(a.Name())
+
+	explored, total := 0, 0
+	for _, r := range a.Rooms() {
+		if r.Transient() {
+			continue
+		}
+		total++
+		if known[explorationKey(r)] {
+			explored++
+		}
+	}
+
+	return explored, total
+}
+
+// completeExploration is called when the Character has explored every room within an Area, and grants any titles
+// earned by doing so.
+func (c *Character) completeExploration(a *Area) {
+	Armeria.log.Info("character explored area",
+		zap.String("character", c.Name()),
+		zap.String("area", a.Name()),
+	)
+
+	for _, t := range Armeria.titleManager.Titles() {
+		if t.ExploreArea == "" || strings.ToLower(t.ExploreArea) != strings.ToLower(a.Name()) {
+			continue
+		}
+		if c.GrantTitle(t) && c.Online() {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf(
+					"You earned the title %s for exploring all of %s!",
+					TextStyle(t.Name, WithBold()),
+					a.Name(),
+				),
+				ColorSuccess,
+			)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// FrozenNotice is shown to players while the world is frozen.
+	FrozenNotice = "The world is frozen for maintenance. You can still chat, but mobs, spawns and timers are " +
+		"paused until it thaws."
+)
+
+// Freeze pauses the tickers that advance the world, such as mob movement and spawning, along with mob scripts.
+// Connections, commands and chat keep working, so that staff can fix data issues without the world changing
+// underneath them. The world thaws when the server restarts.
+func (m *TickManager) Freeze(by *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.frozen {
+		return errors.New("the world is already frozen")
+	}
+
+	m.frozen = true
+	m.frozenBy = by.Name()
+	m.frozenAt = time.Now()
+
+	Armeria.log.Info("world frozen",
+		zap.String("by", by.Name()),
+	)
+
+	return nil
+}
+
+// Unfreeze resumes the tickers and mob scripts paused by Freeze.
+func (m *TickManager) Unfreeze(by *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.frozen {
+		return errors.New("the world isn't frozen")
+	}
+
+	m.frozen = false
+
+	Armeria.log.Info("world unfrozen",
+		zap.String("by", by.Name()),
+		zap.Duration("duration", time.Since(m.frozenAt)),
+	)
+
+	return nil
+}
+
+// Frozen returns true if the world is frozen. The world is never frozen while the tickers aren't running, such as
+// within the admin tools.
+func (m *TickManager) Frozen() bool {
+	if m == nil {
+		return false
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.frozen
+}
+
+// FrozenStatus describes who froze the world and how long ago, or returns an empty string if it isn't frozen.
+func (m *TickManager) FrozenStatus() string {
+	m.RLock()
+	defer m.RUnlock()
+
+	if !m.frozen {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"The world was frozen by %s %s ago.",
+		TextStyle(m.frozenBy, WithBold()),
+		TextDuration(time.Since(m.frozenAt)),
+	)
+}
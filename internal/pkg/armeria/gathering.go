@@ -0,0 +1,206 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	GatheringSkillFishing   = "fishing"
+	GatheringSkillMining    = "mining"
+	GatheringSkillHerbalism = "herbalism"
+	GatheringSkillSkinning  = "skinning"
+
+	// MaxGatheringSkill is the highest level a gathering skill can reach.
+	MaxGatheringSkill = 100
+)
+
+// GatheringSkills returns the skills used to gather from gathering nodes and harvest from corpses.
+func GatheringSkills() []string {
+	return []string{GatheringSkillFishing, GatheringSkillMining, GatheringSkillHerbalism, GatheringSkillSkinning}
+}
+
+// WeightedEntry is a single result within a WeightedTable.
+type WeightedEntry struct {
+	Name   string
+	Weight int
+}
+
+// WeightedTable is a list of results where each result is picked in proportion to its weight. It is written as
+// comma-separated name:weight pairs (ie: "Raw Trout:60,Old Boot:10").
+type WeightedTable []*WeightedEntry
+
+// ParseWeightedTable parses a WeightedTable from its string form.
+func ParseWeightedTable(s string) (WeightedTable, error) {
+	var table WeightedTable
+	for _, pair := range strings.Split(s, ",") {
+		sections := strings.Split(pair, ":")
+		if len(sections) != 2 {
+			return nil, fmt.Errorf("%q must be formatted as name:weight", pair)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(sections[1]))
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("the weight of %q must be a whole number above 0", sections[0])
+		}
+
+		table = append(table, &WeightedEntry{Name: strings.TrimSpace(sections[0]), Weight: weight})
+	}
+
+	return table, nil
+}
+
+// Pick returns the name of a random result from the table, or an empty string if the table is empty.
+func (t WeightedTable) Pick() string {
+	total := 0
+	for _, e := range t {
+		total += e.Weight
+	}
+	if total == 0 {
+		return ""
+	}
+
+	pick := misc.RandomInt(total)
+	for _, e := range t {
+		if pick < e.Weight {
+			return e.Name
+		}
+		pick -= e.Weight
+	}
+
+	return ""
+}
+
+// GatheringManager tracks the gathering nodes that have been depleted and are waiting to respawn.
+type GatheringManager struct {
+	sync.RWMutex
+	unsafeDepleted map[string]time.Time
+}
+
+// NewGatheringManager returns a new GatheringManager.
+func NewGatheringManager() *GatheringManager {
+	return &GatheringManager{
+		unsafeDepleted: make(map[string]time.Time),
+	}
+}
+
+// RespawnsIn returns how long until a depleted gathering node can be gathered from again, or zero if it is ready.
+func (m *GatheringManager) RespawnsIn(node *ItemInstance) time.Duration {
+	m.RLock()
+	defer m.RUnlock()
+
+	until, ok := m.unsafeDepleted[node.ID()]
+	if !ok || time.Now().After(until) {
+		return 0
+	}
+
+	return time.Until(until)
+}
+
+// Deplete marks a gathering node as depleted until its respawn timer elapses.
+func (m *GatheringManager) Deplete(node *ItemInstance) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.unsafeDepleted[node.ID()] = time.Now().Add(time.Duration(node.AttributeInt(AttributeGatherRespawn)) * time.Second)
+}
+
+// GatheringSkill returns the Character's level in a gathering skill.
+func (c *Character) GatheringSkill(skill string) int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeGatheringSkills[skill]
+}
+
+// improveGatheringSkill raises the Character's level in a gathering skill by one, up to MaxGatheringSkill. Higher
+// levels are harder to improve. It returns true if the skill improved.
+func (c *Character) improveGatheringSkill(skill string) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	level := c.UnsafeGatheringSkills[skill]
+	if level >= MaxGatheringSkill || misc.RandomInt(MaxGatheringSkill) < level {
+		return false
+	}
+
+	if c.UnsafeGatheringSkills == nil {
+		c.UnsafeGatheringSkills = make(map[string]int)
+	}
+	c.UnsafeGatheringSkills[skill] = level + 1
+	Armeria.characterManager.AutoSave(c, AutoSaveReasonSkill)
+
+	return true
+}
+
+// gatheringChance returns the percent chance of successfully gathering from a node tuned for a given skill level.
+func gatheringChance(skill, nodeLevel int) int {
+	chance := 50 + (skill-nodeLevel)*2
+	if chance < 10 {
+		return 10
+	} else if chance > 95 {
+		return 95
+	}
+	return chance
+}
+
+// Gather attempts to gather from a gathering node using the node's skill. On success, an item from the node's
+// yield table is added to the Character's inventory and the node is depleted until it respawns. It returns the
+// item that was gathered, or nil if the attempt failed.
+func (c *Character) Gather(node *ItemInstance) (*ItemInstance, error) {
+	if wait := Armeria.gatheringManager.RespawnsIn(node); wait > 0 {
+		return nil, fmt.Errorf("it has been picked clean, and will recover in %s", TextDuration(wait))
+	}
+
+	table, err := ParseWeightedTable(node.Attribute(AttributeGatherYield))
+	if err != nil {
+		return nil, errors.New("it has nothing to gather")
+	}
+
+	if c.Inventory().Count() >= c.Inventory().MaxSize() {
+		return nil, errors.New("you have no room in your inventory")
+	}
+
+	skill := node.Attribute(AttributeGatherSkill)
+	level := c.GatheringSkill(skill)
+	if misc.RandomInt(100) >= gatheringChance(level, node.AttributeInt(AttributeGatherLevel)) {
+		return nil, nil
+	}
+
+	item := Armeria.itemManager.ItemByName(table.Pick())
+	if item == nil {
+		Armeria.log.Error("gathering node yielded an item that doesn't exist",
+			zap.String("node", node.ID()),
+			zap.String("yield", node.Attribute(AttributeGatherYield)),
+		)
+		return nil, errors.New("it has nothing to gather")
+	}
+
+	ii := item.CreateInstance()
+	if err := c.Inventory().Add(ii.ID()); err != nil {
+		item.DeleteInstance(ii)
+		return nil, errors.New("you have no room in your inventory")
+	}
+
+	Armeria.gatheringManager.Deplete(node)
+
+	if c.improveGatheringSkill(skill) && c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"Your %s skill improved to %s.",
+				skill,
+				TextStyle(strconv.Itoa(c.GatheringSkill(skill)), WithBold()),
+			),
+			ColorSuccess,
+		)
+	}
+
+	return ii, nil
+}
@@ -0,0 +1,117 @@
+package armeria
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"go.uber.org/zap"
+)
+
+// GlobalScriptName is the name used to refer to the global script from the script editor.
+const GlobalScriptName = "global"
+
+// globalScriptFile returns the full path to the global Lua script file.
+func globalScriptFile() string {
+	return fmt.Sprintf("%s/scripts/%s.lua", Armeria.dataPath, GlobalScriptName)
+}
+
+// ReadGlobalScript returns the contents of the global script from disk, or an empty string if there isn't one.
+func ReadGlobalScript() string {
+	b, err := ioutil.ReadFile(globalScriptFile())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// WriteGlobalScript writes the global script to disk.
+func WriteGlobalScript(script string) {
+	_ = ioutil.WriteFile(globalScriptFile(), []byte(script), 0644)
+}
+
+// LuaCharacterText (c_text) shows text to a Character, if they are online.
+func LuaCharacterText(L *lua.LState) int {
+	uuid := L.ToString(1)
+	text := L.ToString(2)
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil || !c.Online() {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	c.Player().client.ShowText(TextTemplate(text, c))
+
+	L.Push(lua.LNumber(0))
+	return 1
+}
+
+// CallGlobalFunc calls a function in the global script, which is used to hook into server events that aren't tied
+// to a mob. The invoker can be nil for events that aren't caused by a character.
+func CallGlobalFunc(invoker *Character, funcName string, args ...lua.LValue) {
+	script := ReadGlobalScript()
+	if len(script) == 0 {
+		return
+	}
+
+	L := NewScriptState()
+	defer L.Close()
+
+	// Set a max timeout for script execution.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	L.SetContext(ctx)
+
+	// Set global variables.
+	if invoker != nil {
+		L.SetGlobal("invoker_uuid", lua.LString(invoker.ID()))
+		L.SetGlobal("invoker_name", lua.LString(invoker.Name()))
+	}
+
+	// Set global functions.
+	L.SetGlobal("sleep", L.NewFunction(LuaSleep))
+	L.SetGlobal("c_attr", L.NewFunction(LuaCharacterAttribute))
+	L.SetGlobal("c_set_attr", L.NewFunction(LuaSetCharacterAttribute))
+	L.SetGlobal("c_money", L.NewFunction(LuaMoney))
+	L.SetGlobal("c_add_money", L.NewFunction(LuaAddMoney))
+	L.SetGlobal("c_deduct_money", L.NewFunction(LuaDeductMoney))
+	L.SetGlobal("c_text", L.NewFunction(LuaCharacterText))
+	L.SetGlobal("season_active", L.NewFunction(LuaSeasonActive))
+	L.SetGlobal("ws_get", L.NewFunction(LuaWorldStateGet))
+	L.SetGlobal("ws_set", L.NewFunction(LuaWorldStateSet))
+
+	err := L.DoString(script)
+	if err == nil {
+		if L.GetGlobal(funcName).Type() == lua.LTNil {
+			return
+		}
+
+		err = L.CallByParam(lua.P{
+			Fn:      L.GetGlobal(funcName),
+			NRet:    0,
+			Protect: true,
+		}, args...)
+	}
+	if err == nil {
+		return
+	}
+
+	Armeria.log.Error("error executing function in global lua script",
+		zap.String("script", globalScriptFile()),
+		zap.String("function", funcName),
+		zap.Error(err),
+	)
+	if invoker != nil && invoker.Online() && invoker.HasPermission("CAN_SYSOP") {
+		invoker.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"There was an error running %s in the global script.\n\n%s",
+				TextStyle(funcName+"()", WithBold()),
+				err.Error(),
+			),
+			ColorError,
+		)
+	}
+}
@@ -0,0 +1,187 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// DefaultLanguage is the language used for characters that haven't picked one, and for any message that hasn't
+// been translated.
+const DefaultLanguage = "en"
+
+// englishCatalog holds the English text of every translatable message. Messages with a count have a ".one" and
+// ".other" form, and may have a ".zero" form.
+var englishCatalog = map[string]string{
+	CommonTargetNotFoundHere:      "You don't see anyone here by that name.",
+	CommonItemNotFoundOnCharacter: "You don't have an item by that name.",
+	CommonInvalidDirection:        "You cannot go that way.",
+	CommonInventoryFilled:         "You have no room in your inventory for that.",
+	CommonTransientRoom:           "Wilderness rooms are generated from the terrain. Create a room here to customize it.",
+
+	"move.walk":          "You walk %s.",
+	"move.enteredArea":   "You've just entered %s.",
+	"who.online.one":     "There is %s character online.",
+	"who.online.other":   "There are %s characters online.",
+	"language.current":   "Your language is %s. Languages available: %s.",
+	"language.invalid":   "That language isn't available.",
+	"language.changed":   "Your language has been changed to %s.",
+	"language.unchanged": "That's already your language.",
+}
+
+// LanguageManager holds the message catalogs used to translate messages for characters. Catalogs are loaded from
+// the languages folder within the data path, where each file is named after its language (ie: es.json) and maps
+// message keys to translated text.
+type LanguageManager struct {
+	sync.RWMutex
+	unsafeCatalogs map[string]map[string]string
+}
+
+// NewLanguageManager creates a new LanguageManager.
+func NewLanguageManager() *LanguageManager {
+	m := &LanguageManager{
+		unsafeCatalogs: map[string]map[string]string{
+			DefaultLanguage: englishCatalog,
+		},
+	}
+
+	m.LoadLanguages()
+
+	return m
+}
+
+// LoadLanguages loads the message catalogs from disk into memory.
+func (m *LanguageManager) LoadLanguages() {
+	m.Lock()
+	defer m.Unlock()
+
+	files, err := filepath.Glob(fmt.Sprintf("%s/languages/*.json", Armeria.dataPath))
+	if err != nil {
+		Armeria.log.Fatal("failed to find language files", zap.Error(err))
+	}
+
+	for _, f := range files {
+		raw, err := ioutil.ReadFile(f)
+		if err != nil {
+			Armeria.log.Fatal("failed to load data file",
+				zap.String("file", f),
+				zap.Error(err),
+			)
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(raw, &catalog); err != nil {
+			Armeria.log.Fatal("failed to decode data file",
+				zap.String("file", f),
+				zap.Error(err),
+			)
+		}
+
+		lang := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		if lang == DefaultLanguage {
+			// English can be adjusted, but any message missing from the file still needs its text.
+			for k, v := range englishCatalog {
+				if _, ok := catalog[k]; !ok {
+					catalog[k] = v
+				}
+			}
+		}
+		m.unsafeCatalogs[lang] = catalog
+	}
+
+	Armeria.log.Info("languages loaded",
+		zap.Int("count", len(m.unsafeCatalogs)),
+	)
+}
+
+// Languages returns the languages that have a message catalog, in alphabetical order.
+func (m *LanguageManager) Languages() []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	var langs []string
+	for l := range m.unsafeCatalogs {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+
+	return langs
+}
+
+// Message returns the text of a message in a language, falling back to English when it hasn't been translated, and
+// to the key itself when it doesn't exist.
+func (m *LanguageManager) Message(lang, key string) string {
+	m.RLock()
+	defer m.RUnlock()
+
+	if text, ok := m.unsafeCatalogs[lang][key]; ok {
+		return text
+	}
+	if text, ok := m.unsafeCatalogs[DefaultLanguage][key]; ok {
+		return text
+	}
+
+	return key
+}
+
+// HasMessage returns true if a message exists in a language or in English.
+func (m *LanguageManager) HasMessage(lang, key string) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.unsafeCatalogs[lang][key]
+	if !ok {
+		_, ok = m.unsafeCatalogs[DefaultLanguage][key]
+	}
+	return ok
+}
+
+// Language returns the language a Character reads messages in.
+func (c *Character) Language() string {
+	if c == nil {
+		return DefaultLanguage
+	}
+
+	return c.Attribute(AttributeLanguage)
+}
+
+// Tr translates a message for a Character, formatting it with any arguments. The Character can be nil to use
+// English.
+func Tr(c *Character, key string, args ...interface{}) string {
+	text := Armeria.languageManager.Message(c.Language(), key)
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
+
+// TrN translates a message with a count for a Character, picking the ".zero", ".one" or ".other" form of the
+// message to suit the count.
+func TrN(c *Character, key string, count int, args ...interface{}) string {
+	form := key + ".other"
+	if count == 0 && Armeria.languageManager.HasMessage(c.Language(), key+".zero") {
+		form = key + ".zero"
+	} else if count == 1 {
+		form = key + ".one"
+	}
+
+	return Tr(c, form, args...)
+}
+
+// validLanguage returns true if a message catalog exists for a language.
+func validLanguage(lang string) bool {
+	for _, l := range Armeria.languageManager.Languages() {
+		if l == lang {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,144 @@
+package armeria
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RecentCommandLimit is the maximum number of commands kept in each character's recent command history.
+	RecentCommandLimit = 25
+)
+
+// RecentCommand is a command a character ran, as shown to staff when they inspect the character.
+type RecentCommand struct {
+	Text string
+	Time time.Time
+}
+
+// RecordCommand remembers a command the Character ran. The oldest commands are forgotten once the history reaches
+// RecentCommandLimit.
+func (c *Character) RecordCommand(text string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.unsafeRecentCommands = append(c.unsafeRecentCommands, &RecentCommand{Text: text, Time: time.Now()})
+	if len(c.unsafeRecentCommands) > RecentCommandLimit {
+		c.unsafeRecentCommands = c.unsafeRecentCommands[len(c.unsafeRecentCommands)-RecentCommandLimit:]
+	}
+}
+
+// RecentCommands returns a copy of the commands the Character ran since the server started, newest first.
+func (c *Character) RecentCommands() []RecentCommand {
+	c.RLock()
+	defer c.RUnlock()
+
+	commands := make([]RecentCommand, len(c.unsafeRecentCommands))
+	for i, rc := range c.unsafeRecentCommands {
+		commands[len(commands)-1-i] = *rc
+	}
+	return commands
+}
+
+// TempAttributes returns a copy of the Character's temporary attributes.
+func (c *Character) TempAttributes() map[string]string {
+	c.RLock()
+	defer c.RUnlock()
+
+	attrs := make(map[string]string)
+	for k, v := range c.UnsafeTempAttributes {
+		attrs[k] = v
+	}
+	return attrs
+}
+
+// InspectData returns the read-only panel shown to staff who inspect the Character. It uses the object editor,
+// with each property group shown as a tab.
+func (c *Character) InspectData() *ObjectEditorData {
+	var props []*ObjectEditorDataProperty
+	add := func(group, name, value string) {
+		props = append(props, &ObjectEditorDataProperty{
+			Group:    group,
+			Name:     name,
+			Value:    value,
+			PropType: "readonly",
+		})
+	}
+
+	for _, attrName := range AttributeList(ObjectTypeCharacter) {
+		add("Attributes", attrName, c.Attribute(attrName))
+	}
+
+	temp := c.TempAttributes()
+	var tempNames []string
+	for name := range temp {
+		tempNames = append(tempNames, name)
+	}
+	sort.Strings(tempNames)
+	for _, name := range tempNames {
+		add("Temporary", name, temp[name])
+	}
+
+	for _, ii := range c.Inventory().Items() {
+		add("Inventory", ii.Name(), "slot "+strconv.Itoa(c.Inventory().Slot(ii.ID())))
+	}
+	for _, ii := range c.Equipment().Items() {
+		add("Inventory", ii.Name(), "equipped ("+c.Equipment().SlotName(ii.ID())+")")
+	}
+
+	if r := c.Room(); r != nil {
+		add("Location", "room", r.Attribute(AttributeTitle))
+		add("Location", "coordinates", r.LocationString())
+	}
+	if w := c.WildernessLocation(); len(w) > 0 {
+		add("Location", "wilderness", w)
+	}
+	if v := c.Vehicle(); v != nil {
+		add("Location", "vehicle", v.Name())
+	}
+	if l := c.Leader(); l != nil {
+		add("Location", "following", l.Name())
+	}
+
+	if c.Online() {
+		p := c.Player()
+		add("Connection", "status", "online")
+		add("Connection", "ip", p.IP())
+		add("Connection", "idle", TextDuration(time.Since(p.LastCommand())))
+		add("Connection", "latency", p.LatencyString())
+	} else {
+		add("Connection", "status", "offline")
+		add("Connection", "last seen", fmt.Sprintf("%s ago", TextDuration(time.Since(c.LastSeen()))))
+	}
+
+	for _, rc := range c.RecentCommands() {
+		add("Commands", fmt.Sprintf("%s ago", TextDuration(time.Since(rc.Time))), rc.Text)
+	}
+
+	return &ObjectEditorData{
+		UUID:       c.ID(),
+		Name:       c.Name(),
+		ObjectType: "inspect",
+		Properties: props,
+		Tabbed:     true,
+	}
+}
+
+// Text returns the command as it would be entered with the given arguments. The values of arguments marked NoLog
+// are hidden.
+func (cmd *Command) Text(args map[string]string) string {
+	sections := []string{"/" + strings.ToLower(cmd.FullName())}
+	for _, a := range cmd.Arguments {
+		v := args[a.Name]
+		if len(v) == 0 {
+			continue
+		} else if a.NoLog {
+			v = "***"
+		}
+		sections = append(sections, v)
+	}
+	return strings.Join(sections, " ")
+}
@@ -0,0 +1,233 @@
+package armeria
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+	"go.uber.org/zap"
+)
+
+var (
+	verbRegex    = regexp.MustCompile(`^[a-z]+$`)
+	handlerRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// ParseVerbs parses the verbs an item can be interacted with, written as a comma-separated list. Each verb calls
+// the function named on_<verb> in the item's script, unless another function is given after a colon (ie:
+// "pull,push:pull" calls on_pull for both).
+func ParseVerbs(s string) (map[string]string, error) {
+	verbs := make(map[string]string)
+	if len(strings.TrimSpace(s)) == 0 {
+		return verbs, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		sections := strings.Split(strings.TrimSpace(part), ":")
+		verb := strings.ToLower(strings.TrimSpace(sections[0]))
+		handler := "on_" + verb
+		if len(sections) == 2 {
+			handler = strings.TrimSpace(sections[1])
+		} else if len(sections) > 2 {
+			return nil, fmt.Errorf("%q must be formatted as verb or verb:function", part)
+		}
+
+		if !verbRegex.MatchString(verb) {
+			return nil, fmt.Errorf("%q must be a single word of letters", verb)
+		}
+		if !handlerRegex.MatchString(handler) {
+			return nil, fmt.Errorf("%q is not a valid function name", handler)
+		}
+		if exactCommand(Armeria.commandManager.Commands(), verb) != nil {
+			return nil, fmt.Errorf("%q is already a command", verb)
+		}
+
+		verbs[verb] = handler
+	}
+
+	return verbs, nil
+}
+
+// VerbHandler returns the script function that handles a verb on the ItemInstance, or an empty string if it can't
+// be interacted with that way.
+func (ii *ItemInstance) VerbHandler(verb string) string {
+	verbs, err := ParseVerbs(ii.Attribute(AttributeVerbs))
+	if err != nil {
+		return ""
+	}
+	return verbs[strings.ToLower(verb)]
+}
+
+// interactables returns the items within the Character's reach that can be interacted with using a verb: the
+// visible items in the room, followed by the items they are carrying or wearing.
+func (c *Character) interactables(verb string) []*ItemInstance {
+	var items []*ItemInstance
+	for _, ii := range c.Room().Here().Items() {
+		if ii.PhasedVisibleTo(c) && len(ii.VerbHandler(verb)) > 0 {
+			items = append(items, ii)
+		}
+	}
+	for _, container := range []*ObjectContainer{c.Inventory(), c.Equipment()} {
+		for _, ii := range container.Items() {
+			if len(ii.VerbHandler(verb)) > 0 {
+				items = append(items, ii)
+			}
+		}
+	}
+	return items
+}
+
+// Interact runs the script behind a verb on an item within the Character's reach, such as "pull lever". The
+// target can be left empty when only one item nearby supports the verb. It returns false if nothing nearby
+// supports the verb, so the text can be treated as an ordinary command instead. Errors are meant for the player.
+func (c *Character) Interact(verb, target string) (bool, error) {
+	verb = strings.ToLower(verb)
+	items := c.interactables(verb)
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	var ii *ItemInstance
+	if len(target) == 0 {
+		if len(items) > 1 {
+			return true, fmt.Errorf("%s what?", TextCapitalization(verb))
+		}
+		ii = items[0]
+	} else {
+		for _, container := range []*ObjectContainer{c.Room().Here(), c.Inventory(), c.Equipment()} {
+			result := container.GetLoose(target)
+			if result.Type != RegistryTypeItemInstance {
+				continue
+			}
+			if found := result.Object.(*ItemInstance); container != c.Room().Here() || found.PhasedVisibleTo(c) {
+				ii = found
+				break
+			}
+		}
+
+		if ii == nil {
+			return true, errors.New("You don't see anything by that name.")
+		} else if len(ii.VerbHandler(verb)) == 0 {
+			return true, fmt.Errorf("You can't %s that.", verb)
+		}
+	}
+
+	go CallItemFunc(c, ii, ii.VerbHandler(verb))
+	return true, nil
+}
+
+// ItemScriptFile returns the full path to the Lua script file for an Item.
+func ItemScriptFile(i *Item) string {
+	return fmt.Sprintf(
+		"%s/scripts/item-%s.lua",
+		Armeria.dataPath,
+		strings.ToLower(strings.ReplaceAll(i.Name(), " ", "-")),
+	)
+}
+
+// ReadItemScript returns the script contents for an item from disk.
+func ReadItemScript(i *Item) string {
+	b, err := ioutil.ReadFile(ItemScriptFile(i))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// WriteItemScript writes an item script to disk.
+func WriteItemScript(i *Item, script string) {
+	_ = ioutil.WriteFile(ItemScriptFile(i), []byte(script), 0644)
+}
+
+// LuaItemRoomText (room_text) sends arbitrary text to the room the invoker is in.
+func LuaItemRoomText(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		return 0
+	}
+
+	text := TextTemplate(L.ToString(1), c)
+	for _, char := range c.Room().Here().Characters(true) {
+		char.Player().client.ShowText(text)
+	}
+
+	return 0
+}
+
+// CallItemFunc handles executing item scripts within the Lua environment.
+func CallItemFunc(invoker *Character, ii *ItemInstance, funcName string, args ...lua.LValue) {
+	L := NewScriptState()
+	defer L.Close()
+
+	// Set a max timeout for script execution.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	L.SetContext(ctx)
+
+	// Set global variables.
+	L.SetGlobal("invoker_uuid", lua.LString(invoker.ID()))
+	L.SetGlobal("invoker_name", lua.LString(invoker.Name()))
+	L.SetGlobal("item_uuid", lua.LString(ii.ID()))
+	L.SetGlobal("item_name", lua.LString(ii.Name()))
+
+	// Set global functions.
+	L.SetGlobal("sleep", L.NewFunction(LuaSleep))
+	L.SetGlobal("c_attr", L.NewFunction(LuaCharacterAttribute))
+	L.SetGlobal("c_set_attr", L.NewFunction(LuaSetCharacterAttribute))
+	L.SetGlobal("c_give_xp", L.NewFunction(LuaGiveExperience))
+	L.SetGlobal("c_money", L.NewFunction(LuaMoney))
+	L.SetGlobal("c_add_money", L.NewFunction(LuaAddMoney))
+	L.SetGlobal("c_deduct_money", L.NewFunction(LuaDeductMoney))
+	L.SetGlobal("loot_roll", L.NewFunction(LuaLootRoll))
+	L.SetGlobal("c_text", L.NewFunction(LuaCharacterText))
+	L.SetGlobal("room_text", L.NewFunction(LuaItemRoomText))
+	L.SetGlobal("season_active", L.NewFunction(LuaSeasonActive))
+	L.SetGlobal("q_start", L.NewFunction(LuaQuestStart))
+	L.SetGlobal("q_active", L.NewFunction(LuaQuestActive))
+	L.SetGlobal("q_advance", L.NewFunction(LuaQuestAdvance))
+	L.SetGlobal("q_complete", L.NewFunction(LuaQuestComplete))
+	L.SetGlobal("q_completed", L.NewFunction(LuaQuestCompleted))
+	L.SetGlobal("rep_get", L.NewFunction(LuaReputationGet))
+	L.SetGlobal("rep_add", L.NewFunction(LuaReputationAdd))
+	L.SetGlobal("meets", L.NewFunction(LuaMeets))
+	L.SetGlobal("ws_get", L.NewFunction(LuaWorldStateGet))
+	L.SetGlobal("ws_set", L.NewFunction(LuaWorldStateSet))
+
+	err := L.DoString(ReadItemScript(ii.Parent))
+	if err == nil {
+		if L.GetGlobal(funcName).Type() == lua.LTNil {
+			err = fmt.Errorf("the function %s() is not defined", funcName)
+		} else {
+			err = L.CallByParam(lua.P{
+				Fn:      L.GetGlobal(funcName),
+				NRet:    0,
+				Protect: true,
+			}, args...)
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	Armeria.log.Error("error executing function in lua script",
+		zap.String("script", ItemScriptFile(ii.Parent)),
+		zap.String("function", funcName),
+		zap.Error(err),
+	)
+	if invoker.Online() && invoker.HasPermission("CAN_BUILD") {
+		invoker.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"There was an error running %s on item %s.\n\n%s",
+				TextStyle(funcName+"()", WithBold()),
+				TextStyle(ii.Name(), WithBold()),
+				err.Error(),
+			),
+			ColorError,
+		)
+	}
+}
@@ -0,0 +1,17 @@
+package armeria
+
+const (
+	// InvisiblePermission is the permission staff need to turn invisible, and to see other invisible staff.
+	InvisiblePermission = "CAN_INVIS"
+)
+
+// Invisible returns true if the Character is hiding from players.
+func (c *Character) Invisible() bool {
+	return len(c.TempAttribute(TempAttributeInvisible)) > 0
+}
+
+// VisibleTo returns true if a viewer can see the Character in room lists, /who and connection messages. Invisible
+// staff are still seen by themselves and by other staff who can turn invisible.
+func (c *Character) VisibleTo(viewer *Character) bool {
+	return !c.Invisible() || c == viewer || viewer.HasPermission(InvisiblePermission)
+}
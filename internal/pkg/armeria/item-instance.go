@@ -1,9 +1,7 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -18,9 +16,10 @@ var _ ContainerObject = (*ItemInstance)(nil)
 // ItemInstance is an instance of an Item.
 type ItemInstance struct {
 	sync.RWMutex
-	UUID             string            `json:"uuid"`
-	UnsafeAttributes map[string]string `json:"attributes"`
-	Parent           *Item             `json:"-"`
+	UUID              string            `json:"uuid"`
+	UnsafeAttributes  map[string]string `json:"attributes"`
+	UnsafeVehicleStep int               `json:"vehicleStep,omitempty"`
+	Parent            *Item             `json:"-"`
 }
 
 // Init is called when the ItemInstance is created or loaded from disk.
@@ -51,7 +50,7 @@ func (ii *ItemInstance) Name() string {
 // FormattedName returns the formatted Item name.
 func (ii *ItemInstance) FormattedName() string {
 	return TextStyle(
-		fmt.Sprintf("[%s]", ii.Parent.Name()),
+		fmt.Sprintf("[%s]", TextStylePlayer(ii.Parent.Name())),
 		WithItemTooltip(ii.ID()),
 		WithContextMenu(
 			ii.Name(),
@@ -68,23 +67,31 @@ func (ii *ItemInstance) FormattedName() string {
 
 // SetAttribute sets a permanent attribute on the ItemInstance.
 func (ii *ItemInstance) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeItemInstance, name, value, ii.Attribute); err != nil {
+		return err
+	}
+
+	old := ii.Attribute(name)
+
 	ii.Lock()
-	defer ii.Unlock()
 
 	if ii.UnsafeAttributes == nil {
 		ii.UnsafeAttributes = make(map[string]string)
 	}
 
-	if !misc.Contains(AttributeList(ObjectTypeItemInstance), name) {
-		return errors.New("attribute name is invalid")
-	}
-
 	ii.UnsafeAttributes[name] = value
+	ii.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeItemInstance, ii, name, old, ii.Attribute(name))
 	return nil
 }
 
 // Attribute returns an attribute on the ItemInstance, and falls back to the parent Item.
 func (ii *ItemInstance) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeItemInstance, ii, name); ok {
+		return v
+	}
+
 	ii.RLock()
 	defer ii.RUnlock()
 
@@ -200,8 +207,9 @@ func (ii *ItemInstance) EditorData() *ObjectEditorData {
 	}
 }
 
-// TooltipContentJSON generates the HTML string to be sent to the game client in JSON format.
-func (ii *ItemInstance) TooltipContentJSON() string {
+// TooltipContentJSON generates the HTML string to be sent to the game client in JSON format. When the Character has a
+// comparable item equipped, each stat includes its difference from the equipped item.
+func (ii *ItemInstance) TooltipContentJSON(c *Character) string {
 	qualitiesSlice := make([]string, 0)
 
 	if ii.Attribute(AttributeHoldable) == "false" {
@@ -212,8 +220,38 @@ func (ii *ItemInstance) TooltipContentJSON() string {
 		qualitiesSlice = append(qualitiesSlice, "Not Visible")
 	}
 
-	if len(ii.Attribute(AttributeEquipSlot)) > 0 {
-		qualitiesSlice = append(qualitiesSlice, "Equippable")
+	if slot := ii.Attribute(AttributeEquipSlot); len(slot) > 0 {
+		qualitiesSlice = append(qualitiesSlice, "Equips to "+EquipSlotFormalName(EquipmentSlot(slot)))
+	}
+
+	stats := ii.Stats()
+	statsSlice := make([]string, 0)
+	compared := c.ComparableItem(ii)
+	if compared != nil {
+		// Stats only on the equipped item are shown as zero, so the player can see what they'd lose.
+		for _, st := range compared.Stats() {
+			if stats.Value(st.Name) == 0 {
+				stats = append(stats, &ItemStat{Name: st.Name})
+			}
+		}
+	}
+	for _, st := range stats {
+		line := fmt.Sprintf("%s: %s", TextCapitalization(st.Name), TextNumber(st.Value))
+		if compared != nil {
+			line += " " + itemStatDelta(st.Value, compared.Stats().Value(st.Name))
+		}
+		statsSlice = append(statsSlice, line)
+	}
+	if compared != nil {
+		statsSlice = append(statsSlice, fmt.Sprintf("<i>Compared to your %s</i>", compared.Name()))
+	}
+
+	requirementsSlice := make([]string, 0)
+	if skill := ii.Attribute(AttributeGatherSkill); len(skill) > 0 {
+		requirementsSlice = append(
+			requirementsSlice,
+			fmt.Sprintf("Requires %s %d", TextCapitalization(skill), ii.AttributeInt(AttributeGatherLevel)),
+		)
 	}
 
 	tt := map[string]string{
@@ -222,11 +260,15 @@ func (ii *ItemInstance) TooltipContentJSON() string {
 			`
 			<div class="name" style="color:%s">%s</div>
 			<div class="type">%s</div>
+			<div class="stats">%s</div>
+			<div class="requirements">%s</div>
 			<div class="qualities">%s</div>
 			`,
 			ii.RarityColor(),
 			ii.Name(),
 			ii.RarityName(),
+			strings.Join(statsSlice, "<br />"),
+			strings.Join(requirementsSlice, "<br />"),
 			strings.Join(qualitiesSlice, "<br />"),
 		),
 		"rarity":  ii.RarityColor(),
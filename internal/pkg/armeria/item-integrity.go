@@ -0,0 +1,334 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ItemIntegrityRecheckDelay is how long the integrity check waits before checking a misplaced item again, so
+	// that an item caught in the middle of moving between containers isn't mistaken for a duplicate or a lost item.
+	ItemIntegrityRecheckDelay = 2 * time.Second
+)
+
+// QuarantineManager holds item instances that were found in more than one container, or in no container at all,
+// most likely because of a duplication bug. Quarantined items are out of reach of players until staff review them.
+type QuarantineManager struct {
+	sync.RWMutex
+	dataFile        string
+	UnsafeContainer *ObjectContainer   `json:"container"`
+	UnsafeItems     []*QuarantinedItem `json:"items"`
+}
+
+// QuarantinedItem describes an item instance within the quarantine, and where it was found.
+type QuarantinedItem struct {
+	UUID       string    `json:"uuid"`
+	Name       string    `json:"name"`
+	Containers []string  `json:"containers"`
+	Time       time.Time `json:"time"`
+}
+
+// NewQuarantineManager creates a new QuarantineManager.
+func NewQuarantineManager() *QuarantineManager {
+	m := &QuarantineManager{
+		dataFile: fmt.Sprintf("%s/quarantine.json", Armeria.dataPath),
+	}
+
+	m.LoadQuarantine()
+
+	return m
+}
+
+// LoadQuarantine loads the quarantine from disk into memory.
+func (m *QuarantineManager) LoadQuarantine() {
+	m.Lock()
+	defer m.Unlock()
+
+	quarantineFile, err := os.Open(m.dataFile)
+	defer quarantineFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(quarantineFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeContainer == nil {
+		m.UnsafeContainer = NewObjectContainer(0)
+	}
+	m.UnsafeContainer.AttachParent(m, ContainerParentTypeQuarantine)
+	m.UnsafeContainer.Sync()
+
+	Armeria.log.Info("quarantine loaded",
+		zap.Int("count", len(m.UnsafeItems)),
+	)
+}
+
+// SaveQuarantine writes the in-memory quarantine to disk.
+func (m *QuarantineManager) SaveQuarantine() {
+	m.RLock()
+	defer m.RUnlock()
+
+	quarantineFile, err := os.Create(m.dataFile)
+	defer quarantineFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := quarantineFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = quarantineFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Container returns the container that quarantined items are kept in.
+func (m *QuarantineManager) Container() *ObjectContainer {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeContainer
+}
+
+// Items returns a copy of the quarantined items, oldest first.
+func (m *QuarantineManager) Items() []QuarantinedItem {
+	m.RLock()
+	defer m.RUnlock()
+
+	var items []QuarantinedItem
+	for _, qi := range m.UnsafeItems {
+		items = append(items, *qi)
+	}
+	return items
+}
+
+// Quarantine takes an item instance out of every container it is in, and moves it into the quarantine.
+func (m *QuarantineManager) Quarantine(ii *ItemInstance, containers []*ObjectContainer) {
+	var found []string
+	for _, oc := range containers {
+		found = append(found, describeContainer(oc))
+		oc.Remove(ii.ID())
+		syncContainer(oc)
+	}
+
+	_ = m.Container().Add(ii.ID())
+
+	m.forget(ii.ID())
+	m.Lock()
+	m.UnsafeItems = append(m.UnsafeItems, &QuarantinedItem{
+		UUID:       ii.ID(),
+		Name:       ii.Name(),
+		Containers: found,
+		Time:       time.Now(),
+	})
+	m.Unlock()
+
+	Armeria.log.Warn("misplaced item quarantined",
+		zap.String("uuid", ii.ID()),
+		zap.String("item", ii.Name()),
+		zap.Strings("containers", found),
+	)
+}
+
+// Release takes an item instance out of the quarantine and puts it in a container.
+func (m *QuarantineManager) Release(uuid string, to *ObjectContainer) error {
+	if !m.Container().Contains(uuid) {
+		return errors.New("that item isn't quarantined")
+	}
+
+	m.Container().Remove(uuid)
+	if err := to.Add(uuid); err != nil {
+		_ = m.Container().Add(uuid)
+		return err
+	}
+
+	m.forget(uuid)
+	syncContainer(to)
+
+	return nil
+}
+
+// Destroy deletes a quarantined item instance from the game.
+func (m *QuarantineManager) Destroy(uuid string) error {
+	o, rt := Armeria.registry.Get(uuid)
+	if rt != RegistryTypeItemInstance || !m.Container().Contains(uuid) {
+		return errors.New("that item isn't quarantined")
+	}
+
+	m.Container().Remove(uuid)
+	o.(*ItemInstance).Delete()
+	m.forget(uuid)
+
+	return nil
+}
+
+// forget removes the record of why an item was quarantined.
+func (m *QuarantineManager) forget(uuid string) {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, qi := range m.UnsafeItems {
+		if qi.UUID == uuid {
+			m.UnsafeItems = append(m.UnsafeItems[:i], m.UnsafeItems[i+1:]...)
+			return
+		}
+	}
+}
+
+// itemContainers returns every container that players can reach items within, along with the quarantine.
+func itemContainers() []*ObjectContainer {
+	containers := []*ObjectContainer{Armeria.quarantineManager.Container()}
+	for _, a := range Armeria.worldManager.Areas() {
+		for _, r := range a.Rooms() {
+			containers = append(containers, r.Here())
+		}
+	}
+	for _, r := range Armeria.wildernessManager.Rooms() {
+		containers = append(containers, r.Here())
+	}
+	for _, c := range Armeria.characterManager.Characters() {
+		containers = append(containers, c.Inventory(), c.Equipment())
+	}
+	for _, m := range Armeria.mobManager.Mobs() {
+		for _, mi := range m.Instances() {
+			containers = append(containers, mi.Inventory())
+		}
+	}
+	return containers
+}
+
+// misplacedItems returns the item instances that are held more than once or not held at all, along with the
+// containers holding them. An item listed twice within the same container counts as a duplicate.
+func misplacedItems() map[*ItemInstance][]*ObjectContainer {
+	holders := make(map[*ItemInstance][]*ObjectContainer)
+	for _, o := range Armeria.registry.GetAllFromType(RegistryTypeItemInstance) {
+		holders[o.(*ItemInstance)] = nil
+	}
+	for _, oc := range itemContainers() {
+		for _, ii := range oc.Items() {
+			holders[ii] = append(holders[ii], oc)
+		}
+	}
+
+	for ii, containers := range holders {
+		if len(containers) == 1 {
+			delete(holders, ii)
+		}
+	}
+	return holders
+}
+
+// CheckItemIntegrity verifies that every item instance is held by exactly one container. Items held more than once,
+// or not held at all, are quarantined, and staff are alerted. It returns the number of items quarantined.
+func CheckItemIntegrity() int {
+	suspects := misplacedItems()
+	if len(suspects) == 0 {
+		return 0
+	}
+
+	time.Sleep(ItemIntegrityRecheckDelay)
+
+	quarantined := 0
+	for ii, containers := range misplacedItems() {
+		if _, suspect := suspects[ii]; !suspect {
+			continue
+		}
+		Armeria.quarantineManager.Quarantine(ii, containers)
+		quarantined++
+	}
+
+	if quarantined > 0 {
+		NotifyStaff(fmt.Sprintf(
+			"%d duplicated or lost item(s) were found and quarantined. Type %s to review them.",
+			quarantined,
+			TextStyle("/admin quarantine list", WithLinkCmd("/admin quarantine list")),
+		))
+	}
+
+	return quarantined
+}
+
+// ItemIntegrity runs CheckItemIntegrity as a ticker.
+func ItemIntegrity() {
+	CheckItemIntegrity()
+}
+
+// describeContainer describes where a container is, for staff reviewing a quarantined item.
+func describeContainer(oc *ObjectContainer) string {
+	if oc == Armeria.quarantineManager.Container() {
+		return "quarantine"
+	} else if r := oc.ParentRoom(); r != nil {
+		return fmt.Sprintf("room %s (%s)", r.Attribute(AttributeTitle), r.LocationString())
+	} else if c := oc.ParentCharacter(); c != nil {
+		if c.Equipment() == oc {
+			return fmt.Sprintf("%s's equipment", c.Name())
+		}
+		return fmt.Sprintf("%s's inventory", c.Name())
+	} else if mi := oc.ParentMobInstance(); mi != nil {
+		return fmt.Sprintf("mob %s (%s)", mi.Name(), mi.ID())
+	}
+	return "unknown container"
+}
+
+// syncContainer refreshes the players who can see the contents of a container.
+func syncContainer(oc *ObjectContainer) {
+	if r := oc.ParentRoom(); r != nil {
+		syncRoomObjectsFor(r)
+	} else if c := oc.ParentCharacter(); c != nil && c.Online() {
+		c.Player().client.SyncInventory()
+	}
+}
+
+// quarantineSummary lists the quarantined items as a table.
+func quarantineSummary(items []QuarantinedItem) string {
+	rows := []string{TableRow(
+		TableCell{content: "Item", header: true},
+		TableCell{content: "UUID", header: true},
+		TableCell{content: "Found In", header: true},
+		TableCell{content: "Quarantined", header: true},
+	)}
+	for _, qi := range items {
+		found := strings.Join(qi.Containers, ", ")
+		if len(qi.Containers) == 0 {
+			found = "nowhere"
+		}
+		rows = append(rows, TableRow(
+			TableCell{content: TextStyle(qi.Name, WithBold())},
+			TableCell{content: qi.UUID},
+			TableCell{content: found},
+			TableCell{content: TextRelativeTime(qi.Time)},
+		))
+	}
+	return TextTable(rows...)
+}
@@ -0,0 +1,99 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ItemStat is a single named stat on an item, such as armor:5.
+type ItemStat struct {
+	Name  string
+	Value int
+}
+
+// ItemStats is the list of stats on an item, in the order they were defined.
+type ItemStats []*ItemStat
+
+// ParseItemStats parses stats formatted as name:value,name:value. Values may be negative.
+func ParseItemStats(s string) (ItemStats, error) {
+	var stats ItemStats
+	if len(strings.TrimSpace(s)) == 0 {
+		return stats, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		sections := strings.Split(pair, ":")
+		if len(sections) != 2 {
+			return nil, fmt.Errorf("%q must be formatted as name:value", pair)
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(sections[1]))
+		if err != nil {
+			return nil, fmt.Errorf("the value of %q must be a whole number", sections[0])
+		}
+
+		stats = append(stats, &ItemStat{Name: strings.ToLower(strings.TrimSpace(sections[0])), Value: value})
+	}
+
+	return stats, nil
+}
+
+// Value returns the value of a stat, or 0 if the stat isn't present.
+func (s ItemStats) Value(name string) int {
+	for _, st := range s {
+		if st.Name == name {
+			return st.Value
+		}
+	}
+	return 0
+}
+
+// Stats returns the parsed stats of the ItemInstance. Invalid stats are ignored.
+func (ii *ItemInstance) Stats() ItemStats {
+	stats, _ := ParseItemStats(ii.Attribute(AttributeStats))
+	return stats
+}
+
+// ComparableItem returns the item the Character has equipped in the same slot as ii, or nil if there isn't one
+// (or ii is the equipped item).
+func (c *Character) ComparableItem(ii *ItemInstance) *ItemInstance {
+	slot := ii.Attribute(AttributeEquipSlot)
+	if c == nil || len(slot) == 0 {
+		return nil
+	}
+
+	for _, r := range c.Equipment().AtSlotName(EquipmentSlot(slot)) {
+		if eq := r.Object.(*ItemInstance); eq.ID() != ii.ID() {
+			return eq
+		}
+	}
+
+	return nil
+}
+
+// RefreshComparisons re-sends the tooltips of the Character's items that compare against the given equipment slot,
+// since the client caches them and the equipped item has changed.
+func (c *Character) RefreshComparisons(slot string) {
+	if c.Player() == nil {
+		return
+	}
+
+	for _, ii := range append(c.Inventory().Items(), c.Equipment().Items()...) {
+		if ii.Attribute(AttributeEquipSlot) == slot {
+			c.Player().client.SetItemTooltipHTML(ii)
+		}
+	}
+}
+
+// itemStatDelta formats the difference between two stat values for a tooltip, such as "(+2)".
+func itemStatDelta(value, equipped int) string {
+	delta := value - equipped
+	switch {
+	case delta > 0:
+		return fmt.Sprintf(`<span style="color:#0f0">(+%d)</span>`, delta)
+	case delta < 0:
+		return fmt.Sprintf(`<span style="color:#f33">(%d)</span>`, delta)
+	}
+	return `<span style="color:#999">(=)</span>`
+}
@@ -1,7 +1,6 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"sync"
 
 	"github.com/google/uuid"
@@ -17,11 +16,15 @@ type Item struct {
 }
 
 const (
-	ItemTypeGeneric    string = "generic"
-	ItemTypeMobSpawner        = "mob-spawner"
-	ItemTypeTrashCan          = "trash-can"
-	ItemTypeBreadcrumb        = "mob-breadcrumb"
-	ItemTypeBankCard          = "bank-card"
+	ItemTypeGeneric       string = "generic"
+	ItemTypeMobSpawner           = "mob-spawner"
+	ItemTypeTrashCan             = "trash-can"
+	ItemTypeBreadcrumb           = "mob-breadcrumb"
+	ItemTypeBankCard             = "bank-card"
+	ItemTypeGatheringNode        = "gathering-node"
+	ItemTypeVehicle              = "vehicle"
+	ItemTypeCorpse               = "corpse"
+	ItemTypeContractBoard        = "contract-board"
 
 	ItemRarityCommon   string = "common"
 	ItemRarityUncommon        = "uncommon"
@@ -35,6 +38,10 @@ func ItemTypes() []string {
 		ItemTypeBreadcrumb,
 		ItemTypeTrashCan,
 		ItemTypeBankCard,
+		ItemTypeGatheringNode,
+		ItemTypeVehicle,
+		ItemTypeCorpse,
+		ItemTypeContractBoard,
 	}
 }
 
@@ -80,6 +87,21 @@ func (i *Item) CreateInstance() *ItemInstance {
 	return ii
 }
 
+// AddInstance adds an existing ItemInstance in-memory, such as one carried by a character arriving from another
+// node in a cluster.
+func (i *Item) AddInstance(ii *ItemInstance) {
+	i.Lock()
+	defer i.Unlock()
+
+	if ii.UnsafeAttributes == nil {
+		ii.UnsafeAttributes = make(map[string]string)
+	}
+	ii.Parent = i
+	i.UnsafeInstances = append(i.UnsafeInstances, ii)
+
+	ii.Init()
+}
+
 // DeleteInstance uninitializes the ItemInstance, unregisters it from the registrar, and
 // removes it from memory.
 func (i *Item) DeleteInstance(ii *ItemInstance) bool {
@@ -105,6 +127,10 @@ func (i *Item) DeleteInstance(ii *ItemInstance) bool {
 
 // Attribute returns a permanent attribute.
 func (i *Item) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeItem, i, name); ok {
+		return v
+	}
+
 	i.RLock()
 	defer i.RUnlock()
 
@@ -116,18 +142,20 @@ func (i *Item) Attribute(name string) string {
 }
 
 // SetAttribute sets a permanent attribute and only valid attributes can be set.
-func (i *Item) SetAttribute(name string, value string) {
-	i.Lock()
-	defer i.Unlock()
-
-	if !misc.Contains(AttributeList(ObjectTypeItem), name) {
-		Armeria.log.Fatal("attempted to set invalid attribute",
-			zap.String("attribute", name),
-			zap.String("value", value),
-		)
+func (i *Item) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeItem, name, value, i.Attribute); err != nil {
+		return err
 	}
 
+	old := i.Attribute(name)
+
+	i.Lock()
+
 	i.UnsafeAttributes[name] = value
+	i.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeItem, i, name, old, i.Attribute(name))
+	return nil
 }
 
 // EditorData returns the JSON used for the object editor.
@@ -171,6 +171,33 @@ func (m *ItemManager) CreateItem(name string) *Item {
 	}
 }
 
+// CloneItem creates a new Item with a copy of an existing Item's attributes and script, but doesn't add it to
+// memory.
+func (m *ItemManager) CloneItem(i *Item, name string) *Item {
+	clone := m.CreateItem(name)
+
+	i.RLock()
+	for k, v := range i.UnsafeAttributes {
+		clone.UnsafeAttributes[k] = v
+	}
+	i.RUnlock()
+
+	// The clone gets its own copy of the picture, since the file is deleted along with the object that owns it.
+	if picture := clone.UnsafeAttributes[AttributePicture]; len(picture) > 0 {
+		if key := CopyObjectPictureOnDisk(picture, "item", name); len(key) > 0 {
+			clone.UnsafeAttributes[AttributePicture] = key
+		} else {
+			delete(clone.UnsafeAttributes, AttributePicture)
+		}
+	}
+
+	if script := ReadItemScript(i); len(script) > 0 {
+		WriteItemScript(clone, script)
+	}
+
+	return clone
+}
+
 // AddItem adds a new Item reference to memory.
 func (m *ItemManager) AddItem(i *Item) {
 	m.Lock()
@@ -0,0 +1,115 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const (
+	// LatencyPingInterval is how often each player's connection is pinged to measure its round-trip time.
+	LatencyPingInterval = 15 * time.Second
+	// HighLatency is the round-trip time above which a connection is considered slow.
+	HighLatency = 500 * time.Millisecond
+	// HighLatencySamples is how many pings in a row must be slow before the high latency is logged.
+	HighLatencySamples = 4
+
+	// LatencyPermission is the permission staff need to see the latency of other players.
+	LatencyPermission = "CAN_CHAREDIT"
+)
+
+// SendPing sends a websocket ping to the Player, carrying the time it was sent so that the round-trip time can be
+// worked out from the pong.
+func (p *Player) SendPing() error {
+	payload := []byte(strconv.FormatInt(time.Now().UnixNano(), 10))
+	return p.socket.WriteControl(websocket.PingMessage, payload, time.Now().Add(10*time.Second))
+}
+
+// handlePong records the round-trip time of a ping sent by SendPing.
+func (p *Player) handlePong(payload string) error {
+	sent, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	p.RecordLatency(time.Since(time.Unix(0, sent)))
+	return nil
+}
+
+// RecordLatency records a round-trip time measured for the Player. High latency is logged once it has lasted for
+// HighLatencySamples pings in a row, along with how many other players are slow at the same time: if most players
+// are slow, the server is likely lagging rather than the player's network.
+func (p *Player) RecordLatency(rtt time.Duration) {
+	p.Lock()
+	p.latency = rtt
+	if rtt < HighLatency {
+		recovered := p.highLatencyCount >= HighLatencySamples
+		p.highLatencyCount = 0
+		p.Unlock()
+
+		if recovered {
+			Armeria.log.Info("player latency recovered",
+				zap.String("character", p.CharacterName()),
+				zap.Duration("rtt", rtt),
+			)
+		}
+		return
+	}
+
+	p.highLatencyCount++
+	sustained := p.highLatencyCount == HighLatencySamples
+	p.Unlock()
+
+	if !sustained {
+		return
+	}
+
+	slow, total := 0, 0
+	for _, other := range Armeria.playerManager.Players() {
+		if other == p {
+			continue
+		}
+		total++
+		if other.Latency() >= HighLatency {
+			slow++
+		}
+	}
+
+	Armeria.log.Warn("sustained high player latency",
+		zap.String("character", p.CharacterName()),
+		zap.String("ip", p.IP()),
+		zap.Duration("rtt", rtt),
+		zap.Int("otherSlowPlayers", slow),
+		zap.Int("otherPlayers", total),
+	)
+}
+
+// Latency returns the round-trip time most recently measured for the Player, or zero if it hasn't been measured
+// yet.
+func (p *Player) Latency() time.Duration {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.latency
+}
+
+// LatencyString returns the Player's latency for display, in milliseconds.
+func (p *Player) LatencyString() string {
+	latency := p.Latency()
+	if latency == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%dms", latency.Milliseconds())
+}
+
+// CharacterName returns the name of the Character the Player is logged in as, or "Anonymous" if they haven't
+// logged in.
+func (p *Player) CharacterName() string {
+	if c := p.Character(); c != nil {
+		return c.Name()
+	}
+	return "Anonymous"
+}
@@ -0,0 +1,166 @@
+package armeria
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LedgerPricing are the rules a Ledger follows when its prices drift with supply. Every item characters sell to the
+// ledger's vendors adds to the item's supply, lowering its prices, and every item they buy takes from it, raising
+// them. Supply fades back towards zero over time, so prices recover. Since each ledger keeps its own supply, the
+// same item can be cheap in one region and dear in another.
+type LedgerPricing struct {
+	// Sensitivity is how much the prices change (as a percentage) for each item of supply.
+	Sensitivity float64 `json:"sensitivity"`
+	// HalfLife is how many hours it takes for half of the supply to fade.
+	HalfLife float64 `json:"halfLife"`
+	// Min is the lowest the prices can drift to, as a percentage of the ledger's prices.
+	Min float64 `json:"min"`
+	// Max is the highest the prices can drift to, as a percentage of the ledger's prices.
+	Max float64 `json:"max"`
+}
+
+// ParseLedgerPricing parses pricing rules formatted as name:value,name:value, where the names are sensitivity,
+// halflife, min and max. Rules that aren't given keep their default values.
+func ParseLedgerPricing(s string) (*LedgerPricing, error) {
+	p := &LedgerPricing{
+		Sensitivity: 2,
+		HalfLife:    24,
+		Min:         50,
+		Max:         200,
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		sections := strings.Split(strings.TrimSpace(part), ":")
+		if len(sections) != 2 {
+			return nil, fmt.Errorf("%q must be formatted as name:value", part)
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(sections[1]), 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("the value of %q must be a positive number", part)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(sections[0])) {
+		case "sensitivity":
+			p.Sensitivity = value
+		case "halflife":
+			if value == 0 {
+				return nil, fmt.Errorf("the half-life must be more than zero hours")
+			}
+			p.HalfLife = value
+		case "min":
+			p.Min = value
+		case "max":
+			p.Max = value
+		default:
+			return nil, fmt.Errorf("%q is not a sensitivity, halflife, min or max rule", part)
+		}
+	}
+
+	if p.Min > 100 || p.Max < 100 {
+		return nil, fmt.Errorf("the min must be at most 100 and the max at least 100")
+	}
+
+	return p, nil
+}
+
+// String returns the pricing rules in the format understood by ParseLedgerPricing.
+func (p *LedgerPricing) String() string {
+	return fmt.Sprintf(
+		"sensitivity:%s,halflife:%s,min:%s,max:%s",
+		strconv.FormatFloat(p.Sensitivity, 'f', -1, 64),
+		strconv.FormatFloat(p.HalfLife, 'f', -1, 64),
+		strconv.FormatFloat(p.Min, 'f', -1, 64),
+		strconv.FormatFloat(p.Max, 'f', -1, 64),
+	)
+}
+
+// multiplier returns how much the prices are scaled by for an amount of supply.
+func (p *LedgerPricing) multiplier(supply float64) float64 {
+	m := 1 - supply*p.Sensitivity/100
+	return math.Max(p.Min/100, math.Min(p.Max/100, m))
+}
+
+// Pricing returns the dynamic pricing rules of the ledger, or nil if its prices are fixed.
+func (l *Ledger) Pricing() *LedgerPricing {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.UnsafePricing
+}
+
+// SetPricing sets the dynamic pricing rules of the ledger. Setting nil fixes the prices, and forgets the supply of
+// every item.
+func (l *Ledger) SetPricing(p *LedgerPricing) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.UnsafePricing = p
+	if p == nil {
+		for _, entry := range l.UnsafeEntries {
+			entry.Supply = 0
+			entry.SupplyUpdated = nil
+		}
+	}
+}
+
+// supply returns the supply of an item, after it faded since it last changed. The caller must hold the lock.
+func (l *Ledger) supply(entry *LedgerEntry) float64 {
+	if l.UnsafePricing == nil || entry.SupplyUpdated == nil {
+		return 0
+	}
+
+	hours := time.Since(*entry.SupplyUpdated).Hours()
+	return entry.Supply * math.Pow(0.5, hours/l.UnsafePricing.HalfLife)
+}
+
+// Supply returns how many of an item characters have recently sold to the ledger's vendors, less the number they
+// bought. It is always zero when the ledger's prices are fixed.
+func (l *Ledger) Supply(entry *LedgerEntry) float64 {
+	l.RLock()
+	defer l.RUnlock()
+
+	return l.supply(entry)
+}
+
+// price returns a price from the ledger, adjusted for the supply of the item.
+func (l *Ledger) price(entry *LedgerEntry, base float64) float64 {
+	l.RLock()
+	defer l.RUnlock()
+
+	if l.UnsafePricing == nil || base == 0 {
+		return base
+	}
+
+	price := math.Round(base*l.UnsafePricing.multiplier(l.supply(entry))*100) / 100
+	return math.Max(price, 0.01)
+}
+
+// BuyPrice returns what characters pay for an item on the ledger right now.
+func (l *Ledger) BuyPrice(entry *LedgerEntry) float64 {
+	return l.price(entry, entry.BuyPrice)
+}
+
+// SellPrice returns what characters are paid for an item on the ledger right now.
+func (l *Ledger) SellPrice(entry *LedgerEntry) float64 {
+	return l.price(entry, entry.SellPrice)
+}
+
+// RecordTrade changes the supply of an item on the ledger: positive when characters sell it to a vendor, and
+// negative when they buy it. Nothing is recorded when the ledger's prices are fixed.
+func (l *Ledger) RecordTrade(entry *LedgerEntry, amount float64) {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.UnsafePricing == nil {
+		return
+	}
+
+	now := time.Now()
+	entry.Supply = l.supply(entry) + amount
+	entry.SupplyUpdated = &now
+}
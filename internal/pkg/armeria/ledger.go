@@ -3,17 +3,27 @@ package armeria
 import (
 	"strings"
 	"sync"
+	"time"
 )
 
 type LedgerEntry struct {
 	ItemName  string  `json:"name"`
 	BuyPrice  float64 `json:"buy_price"`
 	SellPrice float64 `json:"sell_price"`
+	Requires  string  `json:"requires,omitempty"`
+	// Stock is how many of the item each vendor holds, or zero if it never runs out. Each one bought restocks
+	// Restock seconds later.
+	Stock   int `json:"stock,omitempty"`
+	Restock int `json:"restock,omitempty"`
+	// Supply and SupplyUpdated are only used by ledgers with dynamic pricing.
+	Supply        float64    `json:"supply,omitempty"`
+	SupplyUpdated *time.Time `json:"supplyUpdated,omitempty"`
 }
 type Ledger struct {
 	sync.RWMutex
 	UnsafeName    string         `json:"name"`
 	UnsafeEntries []*LedgerEntry `json:"entries"`
+	UnsafePricing *LedgerPricing `json:"pricing,omitempty"`
 }
 
 // Name returns the name of the ledger.
@@ -0,0 +1,93 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// MaxLevel is the highest level a Character can reach.
+const MaxLevel = 50
+
+// ExperienceForLevel returns the total experience a Character needs to reach a level. Each level takes 100 more
+// experience than the one before it, so level 2 needs 100, level 3 needs 300, and level 4 needs 600.
+func ExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return 50 * level * (level - 1)
+}
+
+// Level returns the Character's level. Characters start at level 1.
+func (c *Character) Level() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	if c.UnsafeLevel < 1 {
+		return 1
+	}
+	return c.UnsafeLevel
+}
+
+// Experience returns the total experience the Character has earned.
+func (c *Character) Experience() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeExperience
+}
+
+// GiveExperience adds experience to the Character, raising their level for each threshold they reach, up to
+// MaxLevel. The reason is shown to the character (ie: "for slaying Cat"), and can be empty. It returns the
+// Character's level afterwards.
+func (c *Character) GiveExperience(amount int, reason string) int {
+	if amount <= 0 {
+		return c.Level()
+	}
+
+	c.Lock()
+	old := c.UnsafeLevel
+	if old < 1 {
+		old = 1
+	}
+	c.UnsafeExperience += amount
+	level := old
+	for level < MaxLevel && c.UnsafeExperience >= ExperienceForLevel(level+1) {
+		level++
+	}
+	c.UnsafeLevel = level
+	c.Unlock()
+
+	if c.Online() {
+		text := fmt.Sprintf("You gained %d experience.", amount)
+		if len(reason) > 0 {
+			text = fmt.Sprintf("You gained %d experience %s.", amount, reason)
+		}
+		c.Player().client.ShowColorizedText(text, ColorSuccess)
+	}
+
+	if level == old {
+		return level
+	}
+
+	Armeria.log.Info("character levelled up",
+		zap.String("character", c.Name()),
+		zap.Int("level", level),
+	)
+	Armeria.characterManager.AutoSave(c, AutoSaveReasonLevel)
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("You are now level %s!", TextStyle(strconv.Itoa(level), WithBold())),
+			ColorSuccess,
+		)
+		for _, char := range c.Room().Here().Characters(true, c) {
+			char.Player().client.ShowText(
+				fmt.Sprintf("%s is now level %d.", c.FormattedName(), level),
+			)
+		}
+	}
+
+	return level
+}
@@ -0,0 +1,205 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// LinkProblem is a dangling reference found within the game data.
+type LinkProblem struct {
+	Location string
+	Problem  string
+	Fix      string
+}
+
+// CheckLinks scans the game data for references to objects that no longer exist.
+func CheckLinks() []*LinkProblem {
+	var problems []*LinkProblem
+	problems = append(problems, checkItemLinks()...)
+	problems = append(problems, checkInstanceLinks()...)
+	problems = append(problems, checkContainerLinks()...)
+	problems = append(problems, checkExitLinks()...)
+	problems = append(problems, checkScriptLinks()...)
+	problems = append(problems, checkStartingKitLinks()...)
+	problems = append(problems, checkRoomSpawnLinks()...)
+	problems = append(problems, checkLootLinks()...)
+	return problems
+}
+
+// checkItemLinks finds mob spawners for mobs that don't exist.
+func checkItemLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	for _, i := range Armeria.itemManager.Items() {
+		if m := i.Attribute(AttributeSpawnMob); len(m) > 0 && Armeria.mobManager.MobByName(m) == nil {
+			problems = append(problems, &LinkProblem{
+				Location: fmt.Sprintf("item %s", i.Name()),
+				Problem:  fmt.Sprintf("spawns the missing mob %s", m),
+				Fix:      fmt.Sprintf("/item set %s %s [mob]", i.Name(), AttributeSpawnMob),
+			})
+		}
+	}
+
+	return problems
+}
+
+// checkInstanceLinks finds the item and mob instances in the registry that are orphaned: ones that aren't in any
+// container, or that their item or mob no longer keeps track of, so they won't be saved.
+func checkInstanceLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	check := func(id, kind, name string, tracked bool) {
+		if !tracked {
+			problems = append(problems, &LinkProblem{
+				Location: fmt.Sprintf("%s instance %s (%s)", kind, id, name),
+				Problem:  fmt.Sprintf("isn't one of the instances of the %s %s", kind, name),
+				Fix:      "restart the server, since it is only in memory",
+			})
+		} else if Armeria.registry.GetObjectContainer(id) == nil {
+			problems = append(problems, &LinkProblem{
+				Location: fmt.Sprintf("%s instance %s (%s)", kind, id, name),
+				Problem:  "isn't in any container",
+				Fix:      "it is deleted by the hourly dangling instance cleanup",
+			})
+		}
+	}
+
+	for _, o := range Armeria.registry.GetAllFromType(RegistryTypeItemInstance) {
+		ii := o.(*ItemInstance)
+		tracked := false
+		for _, inst := range ii.Parent.Instances() {
+			if inst == ii {
+				tracked = true
+				break
+			}
+		}
+		check(ii.ID(), "item", ii.Name(), tracked && Armeria.itemManager.ItemByName(ii.Name()) == ii.Parent)
+	}
+
+	for _, o := range Armeria.registry.GetAllFromType(RegistryTypeMobInstance) {
+		mi := o.(*MobInstance)
+		tracked := mi.Parent.Instance(mi.ID()) == mi && Armeria.mobManager.MobByName(mi.Name()) == mi.Parent
+		check(mi.ID(), "mob", mi.Name(), tracked)
+	}
+
+	return problems
+}
+
+// checkContainerLinks finds containers that reference objects that aren't in the registry.
+func checkContainerLinks() []*LinkProblem {
+	containers := make(map[string]*ObjectContainer)
+
+	for _, a := range Armeria.worldManager.Areas() {
+		for _, r := range a.Rooms() {
+			containers[fmt.Sprintf("room %s", r.LocationString())] = r.Here()
+		}
+	}
+	for _, c := range Armeria.characterManager.Characters() {
+		containers[fmt.Sprintf("inventory of %s", c.Name())] = c.Inventory()
+		containers[fmt.Sprintf("equipment of %s", c.Name())] = c.Equipment()
+	}
+	for _, m := range Armeria.mobManager.Mobs() {
+		for _, mi := range m.Instances() {
+			containers[fmt.Sprintf("inventory of mob %s (%s)", mi.Name(), mi.ID())] = mi.Inventory()
+		}
+	}
+
+	var problems []*LinkProblem
+	for loc, oc := range containers {
+		oc.RLock()
+		for _, ocd := range oc.UnsafeObjects {
+			if _, rt := Armeria.registry.Get(ocd.UUID); rt == RegistryTypeUnknown {
+				problems = append(problems, &LinkProblem{
+					Location: loc,
+					Problem:  fmt.Sprintf("contains the missing object %s", ocd.UUID),
+					Fix:      "remove the entry from the data file while the server is stopped",
+				})
+			}
+		}
+		oc.RUnlock()
+	}
+
+	return problems
+}
+
+// checkExitLinks finds explicit room exits that lead to rooms that don't exist.
+func checkExitLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	for _, a := range Armeria.worldManager.Areas() {
+		for _, r := range a.Rooms() {
+			for _, dir := range []string{"north", "south", "east", "west", "up", "down"} {
+				exit := r.Attribute(dir)
+				if len(exit) == 0 || exit[0:1] == "!" || r.ConnectedRoom(dir) != nil {
+					continue
+				}
+
+				problems = append(problems, &LinkProblem{
+					Location: fmt.Sprintf("room %s", r.LocationString()),
+					Problem:  fmt.Sprintf("has a %s exit to the missing room %s", dir, exit),
+					Fix:      fmt.Sprintf("/tp %s, then /room set . %s \"\"", r.LocationString(), dir),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkScriptLinks finds scripts on disk that don't belong to anything: mob and item scripts for mobs and items
+// that don't exist, and scripts that aren't named like any kind of script.
+func checkScriptLinks() []*LinkProblem {
+	files, err := ioutil.ReadDir(fmt.Sprintf("%s/scripts", Armeria.dataPath))
+	if err != nil {
+		return nil
+	}
+
+	scripts := []string{filepath.Base(globalScriptFile())}
+	for _, m := range Armeria.mobManager.Mobs() {
+		scripts = append(scripts, filepath.Base(m.ScriptFile()))
+	}
+	for _, i := range Armeria.itemManager.Items() {
+		scripts = append(scripts, filepath.Base(ItemScriptFile(i)))
+	}
+
+	var problems []*LinkProblem
+	for _, f := range files {
+		if f.IsDir() || misc.Contains(scripts, f.Name()) {
+			continue
+		}
+
+		problem := "isn't a mob, item or global script"
+		if strings.HasPrefix(f.Name(), "mob-") {
+			problem = "belongs to a mob that doesn't exist"
+		} else if strings.HasPrefix(f.Name(), "item-") {
+			problem = "belongs to an item that doesn't exist"
+		}
+
+		problems = append(problems, &LinkProblem{
+			Location: fmt.Sprintf("script %s", f.Name()),
+			Problem:  problem,
+			Fix:      "rename the script to match a mob or item, or delete it",
+		})
+	}
+
+	return problems
+}
+
+// LogLinkProblems checks for dangling references and logs any that are found.
+func LogLinkProblems() {
+	problems := CheckLinks()
+	for _, p := range problems {
+		Armeria.log.Warn("dangling reference found",
+			zap.String("location", p.Location),
+			zap.String("problem", p.Problem),
+			zap.String("fix", p.Fix),
+		)
+	}
+
+	Armeria.log.Info("link check completed", zap.Int("problems", len(problems)))
+}
@@ -0,0 +1,169 @@
+package armeria
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// LoginQueue holds the players waiting for a spot to open up when the server is at its population cap.
+type LoginQueue struct {
+	sync.RWMutex
+	maxPlayers  int
+	unsafeQueue []*QueuedLogin
+}
+
+// QueuedLogin is a Player waiting to log in as a Character.
+type QueuedLogin struct {
+	Player    *Player
+	Character *Character
+}
+
+// NewLoginQueue returns a new LoginQueue. The server has no population cap when maxPlayers is zero.
+func NewLoginQueue(maxPlayers int) *LoginQueue {
+	return &LoginQueue{
+		maxPlayers: maxPlayers,
+	}
+}
+
+// Full returns true if the server has reached its population cap.
+func (q *LoginQueue) Full() bool {
+	return q.maxPlayers > 0 && len(Armeria.characterManager.OnlineCharacters()) >= q.maxPlayers
+}
+
+// Length returns the number of players waiting in the queue.
+func (q *LoginQueue) Length() int {
+	q.RLock()
+	defer q.RUnlock()
+
+	return len(q.unsafeQueue)
+}
+
+// Admit logs a Player in as a Character, or places them in the queue when the server is full or others are
+// already waiting. Characters with the CAN_SYSOP permission skip the queue. The multiboxing policy is enforced
+// before the Player is admitted.
+func (q *LoginQueue) Admit(p *Player, c *Character) {
+	if !MultiboxAllowed(p, c) {
+		return
+	}
+
+	if c.HasGlobalPermission("CAN_SYSOP") || (!q.Full() && q.Length() == 0) {
+		EnterGame(p, c)
+		return
+	}
+
+	// A player can only wait for one character at a time.
+	q.Remove(p)
+
+	q.Lock()
+	for _, ql := range q.unsafeQueue {
+		if ql.Character == c {
+			q.Unlock()
+			p.client.ShowColorizedText("This character is already waiting to log in.", ColorError)
+			return
+		}
+	}
+	q.unsafeQueue = append(q.unsafeQueue, &QueuedLogin{Player: p, Character: c})
+	position := len(q.unsafeQueue)
+	q.Unlock()
+
+	Armeria.log.Info("character queued to log in",
+		zap.String("character", c.Name()),
+		zap.Int("position", position),
+	)
+
+	p.client.ShowColorizedText(
+		fmt.Sprintf("Armeria is full right now, so %s has been placed in the login queue.", c.FormattedName()),
+		ColorCmdHelp,
+	)
+	showQueuePosition(p, position)
+
+	q.Process()
+}
+
+// Remove takes a Player out of the queue, and lets everyone who was behind them know their new position. It
+// returns false if the Player wasn't waiting.
+func (q *LoginQueue) Remove(p *Player) bool {
+	q.Lock()
+	var behind []*QueuedLogin
+	index := -1
+	for i, ql := range q.unsafeQueue {
+		if ql.Player == p {
+			index = i
+			q.unsafeQueue = append(q.unsafeQueue[:i], q.unsafeQueue[i+1:]...)
+			behind = make([]*QueuedLogin, len(q.unsafeQueue)-i)
+			copy(behind, q.unsafeQueue[i:])
+			break
+		}
+	}
+	q.Unlock()
+
+	if index < 0 {
+		return false
+	}
+
+	for i, ql := range behind {
+		showQueuePosition(ql.Player, index+i+1)
+	}
+
+	return true
+}
+
+// Process logs in the players at the front of the queue while there is room on the server, and lets everyone still
+// waiting know their new position. The multiboxing policy is enforced again, since other characters may have logged
+// in from the same address while the player was waiting.
+func (q *LoginQueue) Process() {
+	var admitted []*QueuedLogin
+
+	q.Lock()
+	for len(q.unsafeQueue) > 0 && !q.Full() {
+		ql := q.unsafeQueue[0]
+		q.unsafeQueue = q.unsafeQueue[1:]
+		if ql.Character.Player() != nil {
+			ql.Player.client.ShowColorizedText("This character is already logged in.", ColorError)
+			continue
+		}
+		if !MultiboxAllowed(ql.Player, ql.Character) {
+			continue
+		}
+		admitted = append(admitted, ql)
+		// Attach the character now so it counts towards the population cap.
+		ql.Player.AttachCharacter(ql.Character)
+		ql.Character.SetPlayer(ql.Player)
+	}
+	waiting := make([]*QueuedLogin, len(q.unsafeQueue))
+	copy(waiting, q.unsafeQueue)
+	q.Unlock()
+
+	for _, ql := range admitted {
+		ql.Player.client.ShowColorizedText("A spot opened up, and you're next in line!", ColorSuccess)
+		EnterGame(ql.Player, ql.Character)
+	}
+
+	if len(admitted) == 0 {
+		return
+	}
+
+	for i, ql := range waiting {
+		showQueuePosition(ql.Player, i+1)
+	}
+}
+
+// showQueuePosition lets a Player know where they are in the login queue.
+func showQueuePosition(p *Player, position int) {
+	p.client.ShowColorizedText(
+		fmt.Sprintf("You are %s in line to log in.", TextStyle(fmt.Sprintf("#%d", position), WithBold())),
+		ColorCmdHelp,
+	)
+}
+
+// EnterGame logs a Player in as a Character.
+func EnterGame(p *Player, c *Character) {
+	p.AttachCharacter(c)
+	c.SetPlayer(p)
+
+	p.client.ShowColorizedText(fmt.Sprintf("You've entered Armeria as %s!", c.FormattedName()), ColorSuccess)
+
+	c.LoggedIn()
+}
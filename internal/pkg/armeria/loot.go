@@ -0,0 +1,504 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LootEntryItem drops a number of an item.
+	LootEntryItem = "item"
+	// LootEntryTable rolls another loot table a number of times.
+	LootEntryTable = "table"
+	// LootEntryNothing drops nothing, making the other entries less likely.
+	LootEntryNothing = "nothing"
+
+	// LootMaxRolls is the most times a loot table can be rolled each time it drops loot.
+	LootMaxRolls = 5
+	// LootMaxQuantity is the most of an item, or rolls of another table, a single entry can drop.
+	LootMaxQuantity = 20
+	// LootMaxDepth is how deeply loot tables can reference other loot tables.
+	LootMaxDepth = 5
+	// LootMaxDrops is the most items a single drop of loot can contain, across every table it rolls.
+	LootMaxDrops = 50
+	// LootMaxTableRolls is the most times tables can be rolled for a single drop of loot, so tables that reference
+	// each other many times over can't tie up the server even when they drop nothing.
+	LootMaxTableRolls = 200
+)
+
+// LootEntries returns the kinds of entries a LootTable can have.
+func LootEntries() []string {
+	return []string{LootEntryItem, LootEntryTable, LootEntryNothing}
+}
+
+// LootManager holds the loot tables defined by builders.
+type LootManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeTables []*LootTable `json:"tables"`
+}
+
+// LootTable is a list of weighted entries that loot is picked from. The table is rolled Rolls times each time it
+// drops loot.
+type LootTable struct {
+	Name    string       `json:"name"`
+	Rolls   int          `json:"rolls"`
+	Entries []*LootEntry `json:"entries"`
+}
+
+// LootEntry is a possible result of rolling a LootTable, picked in proportion to its weight. Item entries drop
+// between Min and Max of the item, and table entries roll the named table between Min and Max times.
+type LootEntry struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name,omitempty"`
+	Weight int    `json:"weight"`
+	Min    int    `json:"min"`
+	Max    int    `json:"max"`
+}
+
+// NewLootManager creates a new LootManager.
+func NewLootManager() *LootManager {
+	m := &LootManager{
+		dataFile: fmt.Sprintf("%s/loot.json", Armeria.dataPath),
+	}
+
+	m.LoadLoot()
+
+	return m
+}
+
+// LoadLoot loads the loot tables from disk into memory.
+func (m *LootManager) LoadLoot() {
+	m.Lock()
+	defer m.Unlock()
+
+	lootFile, err := os.Open(m.dataFile)
+	defer lootFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(lootFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("loot tables loaded",
+		zap.Int("count", len(m.UnsafeTables)),
+	)
+}
+
+// SaveLoot writes the in-memory loot tables to disk.
+func (m *LootManager) SaveLoot() {
+	m.RLock()
+	defer m.RUnlock()
+
+	lootFile, err := os.Create(m.dataFile)
+	defer lootFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := lootFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = lootFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Tables returns every loot table.
+func (m *LootManager) Tables() []*LootTable {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeTables
+}
+
+// TableByName returns the matching LootTable, by name, or nil if it doesn't exist.
+func (m *LootManager) TableByName(name string) *LootTable {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, t := range m.UnsafeTables {
+		if t.Name == strings.ToLower(name) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// AddTable adds a new, empty LootTable.
+func (m *LootManager) AddTable(name string) *LootTable {
+	m.Lock()
+	defer m.Unlock()
+
+	t := &LootTable{Name: strings.ToLower(name), Rolls: 1}
+	m.UnsafeTables = append(m.UnsafeTables, t)
+	return t
+}
+
+// RemoveTable removes a LootTable. Tables that are still used by mobs or other tables can't be removed.
+func (m *LootManager) RemoveTable(t *LootTable) error {
+	if users := lootTableUsers(t.Name); len(users) > 0 {
+		return fmt.Errorf("it is still used by %s", strings.Join(users, ", "))
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, existing := range m.UnsafeTables {
+		if existing == t {
+			m.UnsafeTables = append(m.UnsafeTables[:i], m.UnsafeTables[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// SetRolls sets how many times a LootTable is rolled each time it drops loot.
+func (m *LootManager) SetRolls(t *LootTable, rolls int) error {
+	if rolls < 1 || rolls > LootMaxRolls {
+		return fmt.Errorf("the rolls must be from 1 to %d", LootMaxRolls)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	t.Rolls = rolls
+	return nil
+}
+
+// SetEntry adds an entry to a LootTable, or changes the weight and quantity of an existing entry with the same kind
+// and name. The entry is removed when weight is zero.
+func (m *LootManager) SetEntry(t *LootTable, kind, name string, weight, min, max int) error {
+	if !misc.Contains(LootEntries(), kind) {
+		return fmt.Errorf("the entry must be one of: %s", strings.Join(LootEntries(), ", "))
+	}
+	if min < 1 || max < min || max > LootMaxQuantity {
+		return fmt.Errorf("the quantity must be from 1 to %d", LootMaxQuantity)
+	}
+
+	switch kind {
+	case LootEntryItem:
+		item := Armeria.itemManager.ItemByName(name)
+		if item == nil {
+			return errors.New("that item doesn't exist")
+		}
+		name = item.Name()
+	case LootEntryTable:
+		name = strings.ToLower(name)
+		if m.TableByName(name) == nil {
+			return errors.New("that loot table doesn't exist")
+		}
+		if lootTableReaches(name, t.Name, 0) {
+			return errors.New("loot tables can't roll themselves")
+		}
+	case LootEntryNothing:
+		name = ""
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, e := range t.Entries {
+		if e.Kind != kind || strings.ToLower(e.Name) != strings.ToLower(name) {
+			continue
+		}
+		if weight <= 0 {
+			t.Entries = append(t.Entries[:i], t.Entries[i+1:]...)
+		} else {
+			e.Weight, e.Min, e.Max = weight, min, max
+		}
+		return nil
+	}
+
+	if weight <= 0 {
+		return errors.New("the loot table doesn't have that entry")
+	}
+	t.Entries = append(t.Entries, &LootEntry{Kind: kind, Name: name, Weight: weight, Min: min, Max: max})
+	return nil
+}
+
+// lootTableReaches returns true if rolling the table named from can end up rolling the table named to.
+func lootTableReaches(from, to string, depth int) bool {
+	if from == to {
+		return true
+	}
+	t := Armeria.lootManager.TableByName(from)
+	if t == nil || depth > LootMaxDepth {
+		return false
+	}
+	for _, e := range t.entries() {
+		if e.Kind == LootEntryTable && lootTableReaches(e.Name, to, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// lootTableUsers returns the mobs and loot tables that drop loot from a table.
+func lootTableUsers(name string) []string {
+	var users []string
+	for _, m := range Armeria.mobManager.Mobs() {
+		if strings.ToLower(m.Attribute(AttributeLoot)) == name {
+			users = append(users, fmt.Sprintf("mob %s", m.Name()))
+		}
+	}
+	for _, t := range Armeria.lootManager.Tables() {
+		for _, e := range t.entries() {
+			if e.Kind == LootEntryTable && e.Name == name {
+				users = append(users, fmt.Sprintf("loot table %s", t.Name))
+			}
+		}
+	}
+	return users
+}
+
+// entries returns a copy of the LootTable's entries.
+func (t *LootTable) entries() []*LootEntry {
+	Armeria.lootManager.RLock()
+	defer Armeria.lootManager.RUnlock()
+
+	entries := make([]*LootEntry, 0, len(t.Entries))
+	for _, e := range t.Entries {
+		entry := *e
+		entries = append(entries, &entry)
+	}
+	return entries
+}
+
+// Quantity describes how many the entry drops, such as "1-3".
+func (e *LootEntry) Quantity() string {
+	if e.Min == e.Max {
+		return strconv.Itoa(e.Min)
+	}
+	return fmt.Sprintf("%d-%d", e.Min, e.Max)
+}
+
+// ParseLootQuantity parses a quantity written as a number (ie: "2") or a range (ie: "1-3").
+func ParseLootQuantity(s string) (int, int, error) {
+	sections := strings.SplitN(s, "-", 2)
+	min, err := strconv.Atoi(strings.TrimSpace(sections[0]))
+	if err != nil {
+		return 0, 0, errors.New("the quantity must be a number or a range (ie: 1-3)")
+	}
+	max := min
+	if len(sections) == 2 {
+		max, err = strconv.Atoi(strings.TrimSpace(sections[1]))
+		if err != nil {
+			return 0, 0, errors.New("the quantity must be a number or a range (ie: 1-3)")
+		}
+	}
+	return min, max, nil
+}
+
+// lootBudget is what's left of the LootMaxDrops and LootMaxTableRolls limits while a drop of loot is being rolled.
+type lootBudget struct {
+	drops int
+	rolls int
+}
+
+// Roll picks loot from the LootTable, and returns the names of the items that dropped. An item is listed once for
+// each of it that dropped, up to LootMaxDrops items.
+func (t *LootTable) Roll() []string {
+	return t.roll(0, &lootBudget{drops: LootMaxDrops, rolls: LootMaxTableRolls})
+}
+
+// roll picks loot from the LootTable, following references to other tables up to LootMaxDepth deep. The budget is
+// shared with every table rolled for the same drop.
+func (t *LootTable) roll(depth int, budget *lootBudget) []string {
+	var items []string
+	if depth > LootMaxDepth {
+		return items
+	}
+
+	entries := t.entries()
+	Armeria.lootManager.RLock()
+	rolls := t.Rolls
+	Armeria.lootManager.RUnlock()
+
+	total := 0
+	for _, e := range entries {
+		total += e.Weight
+	}
+	if total == 0 {
+		return items
+	}
+
+	for r := 0; r < rolls && budget.drops > 0 && budget.rolls > 0; r++ {
+		budget.rolls--
+		pick := misc.RandomInt(total)
+		for _, e := range entries {
+			if pick >= e.Weight {
+				pick -= e.Weight
+				continue
+			}
+
+			quantity := e.Min + misc.RandomInt(e.Max-e.Min+1)
+			switch e.Kind {
+			case LootEntryItem:
+				for i := 0; i < quantity && budget.drops > 0; i++ {
+					items = append(items, e.Name)
+					budget.drops--
+				}
+			case LootEntryTable:
+				if nested := Armeria.lootManager.TableByName(e.Name); nested != nil {
+					for i := 0; i < quantity && budget.drops > 0 && budget.rolls > 0; i++ {
+						items = append(items, nested.roll(depth+1, budget)...)
+					}
+				}
+			}
+			break
+		}
+	}
+
+	return items
+}
+
+// ValidLootTableName returns true if a loot table name only uses lowercase letters, numbers and dashes.
+func ValidLootTableName(name string) bool {
+	return ValidQuestName(name)
+}
+
+// createLoot creates instances of the named items, skipping items that no longer exist.
+func createLoot(names []string) []*ItemInstance {
+	var loot []*ItemInstance
+	for _, name := range names {
+		item := Armeria.itemManager.ItemByName(name)
+		if item == nil {
+			Armeria.log.Error("loot table dropped an item that doesn't exist",
+				zap.String("item", name),
+			)
+			continue
+		}
+		loot = append(loot, item.CreateInstance())
+	}
+	return loot
+}
+
+// dropLoot rolls the MobInstance's loot table, if it has one, and leaves the loot in a Room.
+func (mi *MobInstance) dropLoot(room *Room) {
+	name := mi.Attribute(AttributeLoot)
+	if len(name) == 0 {
+		return
+	}
+
+	t := Armeria.lootManager.TableByName(name)
+	if t == nil {
+		Armeria.log.Error("mob has a loot table that doesn't exist",
+			zap.String("mob", mi.Name()),
+			zap.String("loot", name),
+		)
+		return
+	}
+
+	var dropped []string
+	for _, ii := range createLoot(t.Roll()) {
+		if err := room.Here().Add(ii.ID()); err != nil {
+			ii.Parent.DeleteInstance(ii)
+			continue
+		}
+		dropped = append(dropped, ii.FormattedName())
+	}
+
+	if len(dropped) == 0 {
+		return
+	}
+	for _, c := range room.Here().Characters(true) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("%s dropped %s.", mi.FormattedName(), strings.Join(dropped, ", ")),
+		)
+	}
+}
+
+// GiveLoot rolls a LootTable and adds the loot to the Character's inventory. Loot that doesn't fit is left in the
+// Character's room. It returns the loot that was given.
+func (c *Character) GiveLoot(t *LootTable) []*ItemInstance {
+	loot := createLoot(t.Roll())
+	for _, ii := range loot {
+		if err := c.Inventory().Add(ii.ID()); err == nil {
+			continue
+		}
+		if r := c.Room(); r == nil || r.Here().Add(ii.ID()) != nil {
+			ii.Parent.DeleteInstance(ii)
+		}
+	}
+
+	if c.Online() && len(loot) > 0 {
+		c.Player().client.SyncInventory()
+		if r := c.Room(); r != nil {
+			for _, rc := range r.Here().Characters(true) {
+				rc.Player().client.SyncRoomObjects()
+			}
+		}
+	}
+
+	return loot
+}
+
+// checkLootLinks finds mobs and loot tables that drop loot from tables or items that don't exist.
+func checkLootLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	for _, m := range Armeria.mobManager.Mobs() {
+		if name := m.Attribute(AttributeLoot); len(name) > 0 && Armeria.lootManager.TableByName(name) == nil {
+			problems = append(problems, &LinkProblem{
+				Location: fmt.Sprintf("mob %s", m.Name()),
+				Problem:  fmt.Sprintf("drops loot from the missing loot table %s", name),
+				Fix:      fmt.Sprintf("/mob set %s %s [table]", m.Name(), AttributeLoot),
+			})
+		}
+	}
+
+	for _, t := range Armeria.lootManager.Tables() {
+		for _, e := range t.entries() {
+			missing := false
+			switch e.Kind {
+			case LootEntryItem:
+				missing = Armeria.itemManager.ItemByName(e.Name) == nil
+			case LootEntryTable:
+				missing = Armeria.lootManager.TableByName(e.Name) == nil
+			}
+			if missing {
+				problems = append(problems, &LinkProblem{
+					Location: fmt.Sprintf("loot table %s", t.Name),
+					Problem:  fmt.Sprintf("drops the missing %s %s", e.Kind, e.Name),
+					Fix:      fmt.Sprintf("/loot entry %s %s 0 1 %s", t.Name, e.Kind, e.Name),
+				})
+			}
+		}
+	}
+
+	return problems
+}
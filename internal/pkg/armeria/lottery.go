@@ -0,0 +1,250 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// LotteryTicketPrice is the cost of a single lottery ticket.
+	LotteryTicketPrice float64 = 5
+	// LotteryHouseCut is the share of the pot kept by the house when the lottery is drawn.
+	LotteryHouseCut float64 = 0.1
+	// LotteryDrawInterval is how often the lottery is drawn.
+	LotteryDrawInterval = 24 * time.Hour
+)
+
+// LotteryManager holds the tickets bought for the next lottery drawing.
+type LotteryManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeTickets  map[string]int `json:"tickets"`
+	UnsafeNextDraw time.Time      `json:"nextDraw"`
+	UnsafeRollover float64        `json:"rollover,omitempty"`
+}
+
+// NewLotteryManager creates a new LotteryManager.
+func NewLotteryManager() *LotteryManager {
+	m := &LotteryManager{
+		dataFile: fmt.Sprintf("%s/lottery.json", Armeria.dataPath),
+	}
+
+	m.LoadLottery()
+
+	return m
+}
+
+// LoadLottery loads the lottery tickets from disk into memory.
+func (m *LotteryManager) LoadLottery() {
+	m.Lock()
+	defer m.Unlock()
+
+	lotteryFile, err := os.Open(m.dataFile)
+	defer lotteryFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(lotteryFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeTickets == nil {
+		m.UnsafeTickets = make(map[string]int)
+	}
+
+	Armeria.log.Info("lottery loaded",
+		zap.Int("players", len(m.UnsafeTickets)),
+	)
+}
+
+// SaveLottery writes the in-memory lottery tickets to disk.
+func (m *LotteryManager) SaveLottery() {
+	m.RLock()
+	defer m.RUnlock()
+
+	lotteryFile, err := os.Create(m.dataFile)
+	defer lotteryFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := lotteryFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = lotteryFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Pot returns the total amount spent on tickets for the next drawing, along with any pot rolled over from earlier
+// drawings that couldn't be paid out.
+func (m *LotteryManager) Pot() float64 {
+	m.RLock()
+	defer m.RUnlock()
+
+	var tickets int
+	for _, t := range m.UnsafeTickets {
+		tickets += t
+	}
+
+	return float64(tickets)*LotteryTicketPrice + m.UnsafeRollover
+}
+
+// Tickets returns the number of tickets a Character has bought for the next drawing.
+func (m *LotteryManager) Tickets(c *Character) int {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeTickets[c.ID()]
+}
+
+// NextDraw returns when the lottery will next be drawn.
+func (m *LotteryManager) NextDraw() time.Time {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeNextDraw
+}
+
+// BuyTickets adds tickets for a Character to the next drawing. It returns false if the Character can't afford them.
+func (m *LotteryManager) BuyTickets(c *Character, count int) bool {
+	if err := c.DeductMoney(float64(count)*LotteryTicketPrice, MoneySourceLottery); err != nil {
+		return false
+	}
+
+	m.Lock()
+	m.UnsafeTickets[c.ID()] += count
+	m.Unlock()
+
+	return true
+}
+
+// Draw picks a winning ticket at random, pays the pot (less the house cut) to its owner, and starts a new drawing.
+// It returns the winning Character and their winnings, or nil if nobody won. When the winner can't be paid, such as
+// when their character was deleted, the pot rolls over to the next drawing.
+func (m *LotteryManager) Draw() (*Character, float64) {
+	m.Lock()
+	tickets := m.UnsafeTickets
+	rollover := m.UnsafeRollover
+	m.UnsafeTickets = make(map[string]int)
+	m.UnsafeRollover = 0
+	m.UnsafeNextDraw = time.Now().Add(LotteryDrawInterval)
+	m.Unlock()
+
+	var ids []string
+	total := 0
+	for id, t := range tickets {
+		ids = append(ids, id)
+		total += t
+	}
+	if total == 0 {
+		m.rollOver(rollover)
+		return nil, 0
+	}
+	pot := float64(total)*LotteryTicketPrice + rollover
+
+	// Walk the tickets in a stable order, so the winning ticket only depends on the random pick.
+	sort.Strings(ids)
+	pick := misc.RandomInt(total)
+
+	var winner *Character
+	for _, id := range ids {
+		if pick < tickets[id] {
+			if o, rt := Armeria.registry.Get(id); rt == RegistryTypeCharacter {
+				winner = o.(*Character)
+			}
+			break
+		}
+		pick -= tickets[id]
+	}
+	if winner == nil {
+		Armeria.log.Info("lottery pot rolled over, as the winning character no longer exists",
+			zap.Float64("pot", pot),
+		)
+		m.rollOver(pot)
+		return nil, 0
+	}
+
+	winnings := pot * (1 - LotteryHouseCut)
+	if err := winner.AddMoney(winnings, MoneySourceLottery); err != nil {
+		Armeria.log.Info("lottery pot rolled over, as the winner couldn't be paid",
+			zap.String("winner", winner.Name()),
+			zap.Float64("pot", pot),
+			zap.Error(err),
+		)
+		m.rollOver(pot)
+		return nil, 0
+	}
+	if winner.Online() {
+		winner.Player().client.SyncMoney()
+	}
+
+	Armeria.log.Info("lottery drawn",
+		zap.String("winner", winner.Name()),
+		zap.Int("tickets", total),
+		zap.Float64("winnings", winnings),
+	)
+
+	return winner, winnings
+}
+
+// rollOver adds an unpaid pot to the next drawing.
+func (m *LotteryManager) rollOver(pot float64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeRollover += pot
+}
+
+// DrawLottery draws the lottery when it is due, and announces the winner.
+func DrawLottery() {
+	if time.Now().Before(Armeria.lotteryManager.NextDraw()) {
+		return
+	}
+
+	winner, winnings := Armeria.lotteryManager.Draw()
+	if winner == nil {
+		return
+	}
+
+	AnnounceLotteryWinner(winner, winnings)
+}
+
+// AnnounceLotteryWinner lets every online character know who won the lottery.
+func AnnounceLotteryWinner(winner *Character, winnings float64) {
+	Announce(fmt.Sprintf(
+		"%s won %s in the lottery! Tickets for the next drawing are on sale now.",
+		winner.FormattedName(),
+		TextStyle(misc.Money.FormatMoney(winnings), WithBold()),
+	))
+}
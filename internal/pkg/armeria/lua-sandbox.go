@@ -0,0 +1,130 @@
+package armeria
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	lua "github.com/yuin/gopher-lua"
+	"go.uber.org/zap"
+)
+
+// sandboxLibs are the standard libraries that scripts can use. The os, io, debug and channel libraries are left out
+// so that scripts can't reach outside of the game.
+var sandboxLibs = []struct {
+	name string
+	open lua.LGFunction
+}{
+	{lua.LoadLibName, lua.OpenPackage},
+	{lua.BaseLibName, lua.OpenBase},
+	{lua.TabLibName, lua.OpenTable},
+	{lua.StringLibName, lua.OpenString},
+	{lua.MathLibName, lua.OpenMath},
+	{lua.CoroutineLibName, lua.OpenCoroutine},
+}
+
+// NewScriptState returns a Lua state with the curated set of libraries that game scripts are allowed to use.
+func NewScriptState() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+
+	for _, lib := range sandboxLibs {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+
+	// Remove the functions that read from the filesystem. Modules can still be required from package.preload.
+	L.SetGlobal("dofile", lua.LNil)
+	L.SetGlobal("loadfile", lua.LNil)
+	pkg := L.GetGlobal(lua.LoadLibName).(*lua.LTable)
+	pkg.RawSetString("loadlib", lua.LNil)
+	pkg.RawSetString("path", lua.LString(""))
+	pkg.RawSetString("cpath", lua.LString(""))
+	if loaders, ok := L.GetField(L.Get(lua.RegistryIndex), "_LOADERS").(*lua.LTable); ok {
+		for i := loaders.Len(); i > 1; i-- {
+			loaders.RawSetInt(i, lua.LNil)
+		}
+	}
+
+	// Send print() to the server log instead of stdout.
+	L.SetGlobal("print", L.NewFunction(LuaPrint))
+
+	L.SetGlobal("ustring", L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"len":     LuaUStringLen,
+		"sub":     LuaUStringSub,
+		"upper":   LuaUStringUpper,
+		"lower":   LuaUStringLower,
+		"reverse": LuaUStringReverse,
+	}))
+
+	return L
+}
+
+// LuaPrint (print) writes its arguments to the server log.
+func LuaPrint(L *lua.LState) int {
+	var parts []string
+	for i := 1; i <= L.GetTop(); i++ {
+		parts = append(parts, L.ToStringMeta(L.Get(i)).String())
+	}
+
+	Armeria.log.Info("lua print",
+		zap.String("text", strings.Join(parts, "\t")),
+	)
+	return 0
+}
+
+// LuaUStringLen (ustring.len) returns the number of characters in a string, rather than the number of bytes.
+func LuaUStringLen(L *lua.LState) int {
+	L.Push(lua.LNumber(utf8.RuneCountInString(L.CheckString(1))))
+	return 1
+}
+
+// LuaUStringSub (ustring.sub) returns the characters of a string between two positions, which work the same as
+// they do in string.sub but count characters instead of bytes.
+func LuaUStringSub(L *lua.LState) int {
+	runes := []rune(L.CheckString(1))
+	length := len(runes)
+	start := L.OptInt(2, 1)
+	end := L.OptInt(3, -1)
+
+	if start < 0 {
+		start += length + 1
+	}
+	if end < 0 {
+		end += length + 1
+	}
+	if start < 1 {
+		start = 1
+	}
+	if end > length {
+		end = length
+	}
+
+	if start > end {
+		L.Push(lua.LString(""))
+	} else {
+		L.Push(lua.LString(string(runes[start-1 : end])))
+	}
+	return 1
+}
+
+// LuaUStringUpper (ustring.upper) returns a string with every letter in upper case, including non-ASCII letters.
+func LuaUStringUpper(L *lua.LState) int {
+	L.Push(lua.LString(strings.ToUpper(L.CheckString(1))))
+	return 1
+}
+
+// LuaUStringLower (ustring.lower) returns a string with every letter in lower case, including non-ASCII letters.
+func LuaUStringLower(L *lua.LState) int {
+	L.Push(lua.LString(strings.ToLower(L.CheckString(1))))
+	return 1
+}
+
+// LuaUStringReverse (ustring.reverse) returns a string with its characters in reverse order.
+func LuaUStringReverse(L *lua.LState) int {
+	runes := []rune(L.CheckString(1))
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	L.Push(lua.LString(string(runes)))
+	return 1
+}
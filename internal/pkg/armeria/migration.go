@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"strconv"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 
 // SchemaVersion defines the current version of the schema. If the file system is using an older version, a
 // migration will be performed.
-const SchemaVersion int = 6
+const SchemaVersion int = 22
 
 // schemaVersionOnDisk reads the schema version from disk and returns it as an int.
 func schemaVersionOnDisk() int {
@@ -77,6 +78,11 @@ func migrateCharacters(to int) {
 		Armeria.log.Fatal("error unmarshalling characters.json", zap.Error(err))
 	}
 
+	if to == 22 {
+		// quest progress moves from a list by objective index to a map by objective key
+		migrateQuestProgress(s.Characters, b)
+	}
+
 	for _, c := range s.Characters {
 		switch to {
 		case 2:
@@ -85,6 +91,17 @@ func migrateCharacters(to int) {
 		case 3:
 			// set UnsafeSettings to an initialized map
 			c.UnsafeSettings = map[string]string{}
+		case 8:
+			// the displayed title becomes the first earned title
+			if t := c.UnsafeAttributes["title"]; len(t) > 0 {
+				c.UnsafeTitles = []string{t}
+			}
+		case 21:
+			// money moves from an attribute to the balance, which is kept in cents
+			if m, err := strconv.ParseFloat(c.UnsafeAttributes["money"], 64); err == nil {
+				c.UnsafeMoney = int64(math.Round(m * 100))
+			}
+			delete(c.UnsafeAttributes, "money")
 		}
 
 		Armeria.log.Info("character migration successful",
@@ -103,6 +120,49 @@ func migrateCharacters(to int) {
 	}
 }
 
+// migrateQuestProgress converts the characters' quest progress, stored by objective index within characters.json,
+// to progress keyed by each objective of the quest catalog.
+func migrateQuestProgress(characters []*Character, charactersJSON []byte) {
+	legacy := struct {
+		Characters []struct {
+			UUID          string `json:"uuid"`
+			QuestProgress map[string]struct {
+				Progress []int `json:"progress"`
+			} `json:"questProgress"`
+		} `json:"characters"`
+	}{}
+	if err := json.Unmarshal(charactersJSON, &legacy); err != nil {
+		Armeria.log.Fatal("error unmarshalling characters.json", zap.Error(err))
+	}
+
+	catalog := struct {
+		Quests []*Quest `json:"quests"`
+	}{}
+	b, err := ioutil.ReadFile(Armeria.dataPath + "/quests.json")
+	if err != nil {
+		Armeria.log.Fatal("error reading quests.json", zap.Error(err))
+	}
+	if err := json.Unmarshal(b, &catalog); err != nil {
+		Armeria.log.Fatal("error unmarshalling quests.json", zap.Error(err))
+	}
+
+	for i, c := range characters {
+		for name, qi := range c.UnsafeQuestProgress {
+			qi.Progress = make(map[string]int)
+			for _, q := range catalog.Quests {
+				if q.Name != name {
+					continue
+				}
+				for o, count := range legacy.Characters[i].QuestProgress[name].Progress {
+					if o < len(q.Objectives) && count > 0 {
+						qi.Progress[q.Objectives[o].Key()] = count
+					}
+				}
+			}
+		}
+	}
+}
+
 // migrateMobs handles migrations for mobs.
 func migrateMobs(to int) {
 	s := struct {
@@ -205,6 +265,197 @@ func migrateLedgers(to int) {
 	}
 }
 
+// migratePromotions handles migrations for promotion baselines.
+func migratePromotions(to int) {
+	if to == 7 {
+		pm := &PromotionManager{
+			dataFile:        fmt.Sprintf("%s/promotions.json", Armeria.dataPath),
+			UnsafeBaselines: make(map[string]string),
+		}
+		pm.SavePromotions()
+		Armeria.log.Info("initial promotion baselines created successfully")
+	}
+}
+
+// migrateTitles handles migrations for the title catalog.
+func migrateTitles(to int) {
+	if to == 8 {
+		s := struct {
+			Characters []*Character `json:"characters"`
+		}{}
+
+		b, err := ioutil.ReadFile(Armeria.dataPath + "/characters.json")
+		if err != nil {
+			Armeria.log.Fatal("error reading characters.json", zap.Error(err))
+		}
+
+		err = json.Unmarshal(b, &s)
+		if err != nil {
+			Armeria.log.Fatal("error unmarshalling characters.json", zap.Error(err))
+		}
+
+		// seed the catalog with the titles that characters are already displaying
+		tm := &TitleManager{
+			dataFile:     fmt.Sprintf("%s/titles.json", Armeria.dataPath),
+			UnsafeTitles: []*Title{},
+		}
+		for _, c := range s.Characters {
+			if t := c.UnsafeAttributes["title"]; len(t) > 0 && tm.TitleByName(t) == nil {
+				tm.AddTitle(&Title{Name: t})
+			}
+		}
+		tm.SaveTitles()
+		Armeria.log.Info("initial title catalog created successfully")
+	}
+}
+
+// migrateAnnouncements handles migrations for the message of the day and announcements.
+func migrateAnnouncements(to int) {
+	if to == 9 {
+		am := &AnnouncementManager{
+			dataFile:            fmt.Sprintf("%s/announcements.json", Armeria.dataPath),
+			UnsafeAnnouncements: []*Announcement{},
+		}
+		am.SaveAnnouncements()
+		Armeria.log.Info("initial announcements created successfully")
+	}
+}
+
+// migrateNames handles migrations for the character name rules.
+func migrateNames(to int) {
+	if to == 10 {
+		nm := &NameManager{
+			dataFile:       fmt.Sprintf("%s/names.json", Armeria.dataPath),
+			UnsafeBlocked:  defaultBlockedNames,
+			UnsafeReserved: defaultReservedNames,
+		}
+		nm.SaveNames()
+		Armeria.log.Info("initial name rules created successfully")
+	}
+}
+
+// migrateLottery handles migrations for the lottery.
+func migrateLottery(to int) {
+	if to == 11 {
+		lm := &LotteryManager{
+			dataFile:      fmt.Sprintf("%s/lottery.json", Armeria.dataPath),
+			UnsafeTickets: make(map[string]int),
+		}
+		lm.SaveLottery()
+		Armeria.log.Info("initial lottery created successfully")
+	}
+}
+
+// migratePetitions handles migrations for petitions.
+func migratePetitions(to int) {
+	if to == 12 {
+		pm := &PetitionManager{
+			dataFile:        fmt.Sprintf("%s/petitions.json", Armeria.dataPath),
+			UnsafePetitions: []*Petition{},
+		}
+		pm.SavePetitions()
+		Armeria.log.Info("initial petitions created successfully")
+	}
+}
+
+// migrateWorldState handles migrations for the world state.
+func migrateWorldState(to int) {
+	if to == 13 {
+		wsm := &WorldStateManager{
+			dataFile:     fmt.Sprintf("%s/world-state.json", Armeria.dataPath),
+			UnsafeValues: map[string]string{},
+		}
+		wsm.SaveWorldState()
+		Armeria.log.Info("initial world state created successfully")
+	}
+}
+
+// migrateChatFilter handles migrations for the chat filter.
+func migrateChatFilter(to int) {
+	if to == 14 {
+		cfm := &ChatFilterManager{
+			dataFile:    fmt.Sprintf("%s/chat-filter.json", Armeria.dataPath),
+			UnsafeRules: defaultChatFilterRules,
+			UnsafeFlags: []*ChatFilterFlag{},
+		}
+		cfm.SaveChatFilter()
+		Armeria.log.Info("initial chat filter created successfully")
+	}
+}
+
+// migrateNotes handles migrations for staff notes.
+func migrateNotes(to int) {
+	if to == 15 {
+		nm := &NoteManager{
+			dataFile:    fmt.Sprintf("%s/notes.json", Armeria.dataPath),
+			UnsafeNotes: []*Note{},
+		}
+		nm.SaveNotes()
+		Armeria.log.Info("initial notes created successfully")
+	}
+}
+
+// migrateQuarantine handles migrations for the item quarantine.
+func migrateQuarantine(to int) {
+	if to == 16 {
+		qm := &QuarantineManager{
+			dataFile:        fmt.Sprintf("%s/quarantine.json", Armeria.dataPath),
+			UnsafeContainer: NewObjectContainer(0),
+			UnsafeItems:     []*QuarantinedItem{},
+		}
+		qm.SaveQuarantine()
+		Armeria.log.Info("initial quarantine created successfully")
+	}
+}
+
+// migrateEconomy handles migrations for the economy ledger.
+func migrateEconomy(to int) {
+	if to == 17 {
+		em := &EconomyManager{
+			dataFile:   fmt.Sprintf("%s/economy.json", Armeria.dataPath),
+			UnsafeDays: make(map[string]map[MoneySource]*MoneyFlow),
+		}
+		em.SaveEconomy()
+		Armeria.log.Info("initial economy ledger created successfully")
+	}
+}
+
+// migrateQuests handles migrations for the quest catalog.
+func migrateQuests(to int) {
+	if to == 18 {
+		qm := &QuestManager{
+			dataFile:     fmt.Sprintf("%s/quests.json", Armeria.dataPath),
+			UnsafeQuests: []*Quest{},
+		}
+		qm.SaveQuests()
+		Armeria.log.Info("initial quest catalog created successfully")
+	}
+}
+
+// migrateContracts handles migrations for the contract boards.
+func migrateContracts(to int) {
+	if to == 19 {
+		cm := &ContractManager{
+			dataFile:        fmt.Sprintf("%s/contracts.json", Armeria.dataPath),
+			UnsafeContracts: []*Contract{},
+		}
+		cm.SaveContracts()
+		Armeria.log.Info("initial contracts created successfully")
+	}
+}
+
+// migrateLoot handles migrations for the loot tables.
+func migrateLoot(to int) {
+	if to == 20 {
+		lm := &LootManager{
+			dataFile:     fmt.Sprintf("%s/loot.json", Armeria.dataPath),
+			UnsafeTables: []*LootTable{},
+		}
+		lm.SaveLoot()
+		Armeria.log.Info("initial loot tables created successfully")
+	}
+}
+
 // Migrate performs a sequential data migration.
 func Migrate() {
 	sv := schemaVersionOnDisk()
@@ -220,6 +471,20 @@ func Migrate() {
 		migrateMobs(i)
 		migrateLedgers(i)
 		migrateItems(i)
+		migratePromotions(i)
+		migrateTitles(i)
+		migrateAnnouncements(i)
+		migrateNames(i)
+		migrateLottery(i)
+		migratePetitions(i)
+		migrateWorldState(i)
+		migrateChatFilter(i)
+		migrateNotes(i)
+		migrateQuarantine(i)
+		migrateEconomy(i)
+		migrateQuests(i)
+		migrateContracts(i)
+		migrateLoot(i)
 	}
 
 	writeSchemaVersionToDisk(SchemaVersion)
@@ -0,0 +1,78 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	PointOfInterestShop    string = "shop"
+	PointOfInterestTrainer string = "trainer"
+	PointOfInterestQuest   string = "quest"
+	PointOfInterestBank    string = "bank"
+	PointOfInterestInn     string = "inn"
+)
+
+// PointsOfInterest returns the types of points of interest a room can be marked as on the minimap.
+func PointsOfInterest() []string {
+	return []string{
+		PointOfInterestShop,
+		PointOfInterestTrainer,
+		PointOfInterestQuest,
+		PointOfInterestBank,
+		PointOfInterestInn,
+	}
+}
+
+// MapRegion is a label drawn on the minimap, anchored to the room at its coordinates.
+type MapRegion struct {
+	Label string `json:"label"`
+	X     int    `json:"x"`
+	Y     int    `json:"y"`
+	Z     int    `json:"z"`
+}
+
+// ParseMapRegions parses region labels formatted as label@x,y,z;label@x,y,z.
+func ParseMapRegions(s string) ([]*MapRegion, error) {
+	var regions []*MapRegion
+	if len(strings.TrimSpace(s)) == 0 {
+		return regions, nil
+	}
+
+	for _, entry := range strings.Split(s, ";") {
+		sections := strings.Split(entry, "@")
+		if len(sections) != 2 || len(strings.TrimSpace(sections[0])) == 0 {
+			return nil, fmt.Errorf("%q must be formatted as label@x,y,z", entry)
+		}
+
+		coords := strings.Split(sections[1], ",")
+		if len(coords) != 3 {
+			return nil, fmt.Errorf("the location of %q must be formatted as x,y,z", sections[0])
+		}
+
+		var xyz [3]int
+		for i, c := range coords {
+			v, err := strconv.Atoi(strings.TrimSpace(c))
+			if err != nil {
+				return nil, fmt.Errorf("the location of %q must be whole numbers", sections[0])
+			}
+			xyz[i] = v
+		}
+
+		regions = append(regions, &MapRegion{
+			Label: strings.TrimSpace(sections[0]),
+			X:     xyz[0],
+			Y:     xyz[1],
+			Z:     xyz[2],
+		})
+	}
+
+	return regions, nil
+}
+
+// Regions returns the parsed region labels of the Area. Invalid labels are ignored.
+func (a *Area) Regions() []*MapRegion {
+	regions, _ := ParseMapRegions(a.Attribute(AttributeRegions))
+	return regions
+}
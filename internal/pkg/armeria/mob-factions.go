@@ -0,0 +1,60 @@
+package armeria
+
+import (
+	"fmt"
+)
+
+// Faction returns the faction the MobInstance belongs to, or an empty string if it doesn't belong to one.
+func (mi *MobInstance) Faction() string {
+	return mi.Attribute(AttributeFaction)
+}
+
+// CallForHelp alerts the mobs of the same faction in the same and adjacent rooms, which converge on the
+// MobInstance's room and pursue the attacker. It returns the number of mobs that answered the call.
+func (mi *MobInstance) CallForHelp(attacker *Character) int {
+	faction := mi.Faction()
+	room := mi.Room()
+	if len(faction) == 0 || room == nil {
+		return 0
+	}
+
+	for _, c := range room.Here().Characters(true) {
+		c.Player().client.ShowColorizedText(fmt.Sprintf("%s calls for help!", mi.FormattedName()), ColorError)
+	}
+
+	answered := 0
+	answer := func(ally *MobInstance) {
+		if ally == mi || ally.Faction() != faction || ally.Pursuing() != nil || ally.Owner() != nil {
+			return
+		}
+		ally.Pursue(attacker)
+		answered++
+	}
+
+	for _, ally := range room.Here().Mobs() {
+		answer(ally)
+	}
+
+	for _, dir := range pathDirections {
+		adjacent := room.ConnectedRoom(dir)
+		if adjacent == nil || adjacent.ParentArea != room.ParentArea {
+			continue
+		}
+
+		// Allies need a way back through to the room that called for help.
+		direction := DirectionTo(adjacent, room)
+		if len(direction) == 0 {
+			continue
+		}
+
+		for _, ally := range adjacent.Here().Mobs() {
+			if ally.Faction() != faction || ally.Pursuing() != nil || ally.Owner() != nil {
+				continue
+			}
+			ally.Walk(room, direction)
+			answer(ally)
+		}
+	}
+
+	return answered
+}
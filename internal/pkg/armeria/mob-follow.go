@@ -0,0 +1,193 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+)
+
+const (
+	// MobFollowDistance is the furthest (in rooms) a mob will search for the character it is following.
+	MobFollowDistance = 20
+	// MobReturnDistance is the furthest (in rooms) a mob will search for the way back to its home.
+	MobReturnDistance = 100
+)
+
+// Following returns the Character the MobInstance is following, or nil if it isn't following anyone.
+func (mi *MobInstance) Following() *Character {
+	mi.RLock()
+	id := mi.UnsafeFollowing
+	mi.RUnlock()
+
+	if len(id) == 0 {
+		return nil
+	}
+
+	return Armeria.characterManager.CharacterById(id)
+}
+
+// Follow makes the MobInstance follow a Character between rooms.
+func (mi *MobInstance) Follow(c *Character) {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeFollowing = c.ID()
+	mi.UnsafeReturning = false
+	mi.UnsafePursuing = ""
+}
+
+// Unfollow stops the MobInstance from following anyone.
+func (mi *MobInstance) Unfollow() {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeFollowing = ""
+	mi.UnsafePursuing = ""
+}
+
+// Home returns the Room containing the mob spawner that spawned the MobInstance, or the Room whose spawns spawned
+// it. It returns nil if the MobInstance wasn't spawned by either.
+func (mi *MobInstance) Home() *Room {
+	o, rt := Armeria.registry.Get(mi.MobSpawnerUUID())
+	switch rt {
+	case RegistryTypeItemInstance:
+		return o.(*ItemInstance).Room()
+	case RegistryTypeRoom:
+		return o.(*Room)
+	}
+
+	return nil
+}
+
+// Returning returns true if the MobInstance is walking back to its Home.
+func (mi *MobInstance) Returning() bool {
+	mi.RLock()
+	defer mi.RUnlock()
+
+	return mi.UnsafeReturning
+}
+
+// ReturnHome stops the MobInstance from following anyone, and sends it walking back to its Home.
+func (mi *MobInstance) ReturnHome() {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeFollowing = ""
+	mi.UnsafePursuing = ""
+	mi.UnsafeReturning = true
+}
+
+// stopReturning stops the MobInstance from walking back to its Home.
+func (mi *MobInstance) stopReturning() {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeReturning = false
+}
+
+// Walk moves the MobInstance through an exit to an adjacent Room, letting the characters in both rooms know.
+func (mi *MobInstance) Walk(to *Room, direction string) {
+	from := mi.Room()
+	from.Here().Remove(mi.ID())
+	_ = to.Here().Add(mi.ID())
+	mi.ClearConvoOptions()
+
+	mobNameString := fmt.Sprintf("A %s", mi.FormattedName())
+	if mi.Attribute(AttributeGender) != "thing" {
+		mobNameString = mi.FormattedName()
+	}
+	for _, c := range from.Here().Characters(true) {
+		c.Player().client.ShowText(
+			TextStyle(
+				fmt.Sprintf("%s travels %s.", mobNameString, misc.MoveToStringFromDir("to the", direction)),
+				WithUserColor(c, ColorMovement),
+			),
+		)
+		c.Player().client.SyncRoomObjects()
+	}
+	for _, c := range to.Here().Characters(true) {
+		c.Player().client.ShowText(
+			TextStyle(
+				fmt.Sprintf(
+					"%s entered from %s.",
+					mobNameString,
+					misc.MoveToStringFromDir("the", misc.OppositeDirection(direction)),
+				),
+				WithUserColor(c, ColorMovement),
+			),
+		)
+		c.Player().client.SyncRoomObjects()
+	}
+}
+
+// followCharacter moves the mobs following a Character right behind them, when they walk to an adjacent room.
+// Mobs that fall behind catch up with MoveFollowers. Mobs pursuing the Character chase after them.
+func followCharacter(c *Character, from, to *Room) {
+	direction := DirectionTo(from, to)
+
+	for _, mi := range from.Here().Mobs() {
+		if mi.Pursuing() == c {
+			if len(direction) == 0 {
+				mi.GiveUpPursuit()
+			} else {
+				mi.chase(c, from, to, direction)
+			}
+			continue
+		}
+
+		if len(direction) > 0 && walkable(to) && (mi.Following() == c || mi.Owner() == c) {
+			mi.Walk(to, direction)
+		}
+	}
+}
+
+// MoveFollowers moves the mobs that have fallen behind the character they are following one step closer to them,
+// and the mobs returning home one step closer to their mob spawner. Mobs head home when the character they are
+// following leaves the game. Mobs that lose track of the character they are pursuing give up the chase, and
+// companions find their way back to their owner.
+func MoveFollowers() {
+	for _, m := range Armeria.mobManager.Mobs() {
+		for _, mi := range m.Instances() {
+			from := mi.Room()
+			if from == nil || !HostsArea(from.ParentArea) {
+				continue
+			}
+
+			if c := mi.Pursuing(); c != nil {
+				mi.checkPursuit(c)
+				continue
+			}
+
+			var to *Room
+			maxSteps := MobFollowDistance
+			if c := mi.Following(); c != nil {
+				if !c.Online() {
+					mi.ReturnHome()
+					continue
+				}
+				to = c.Room()
+			} else if owner := mi.Owner(); owner != nil {
+				// Companions wait where they are while their owner is away.
+				if !owner.Online() || owner.Room() == from {
+					continue
+				}
+				to = owner.Room()
+			} else if mi.Returning() {
+				to = mi.Home()
+				maxSteps = MobReturnDistance
+				if to == nil || to == from {
+					mi.stopReturning()
+					continue
+				}
+			} else {
+				continue
+			}
+
+			path := PathBetween(from, to, maxSteps)
+			if len(path) == 0 {
+				continue
+			}
+
+			mi.Walk(from.ConnectedRoom(path[0]), path[0])
+		}
+	}
+}
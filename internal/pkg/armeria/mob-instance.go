@@ -1,12 +1,11 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Force verify that MobInstance implements ContainerObject.
@@ -22,6 +21,14 @@ type MobInstance struct {
 	UnsafeMobSpawnerUUID string            `json:"spawnerUUID"`
 	UnsafeMoveTicks      int               `json:"moveTicks"`
 	UnsafeConvoText      map[string]string `json:"-"`
+	UnsafeConvoRequires  map[string]string `json:"-"`
+	UnsafeFollowing      string            `json:"-"`
+	UnsafeReturning      bool              `json:"-"`
+	UnsafePursuing       string            `json:"-"`
+	UnsafePursuitSteps   int               `json:"-"`
+	UnsafePursuitUntil   time.Time         `json:"-"`
+	UnsafeOwner          string            `json:"owner,omitempty"`
+	UnsafeWaryUntil      time.Time         `json:"-"`
 }
 
 // Init is called when the MobInstance is created or loaded from disk.
@@ -34,11 +41,13 @@ func (mi *MobInstance) Init() {
 	mi.UnsafeInventory.Sync()
 	// Initialize some properties.
 	mi.UnsafeConvoText = make(map[string]string)
+	mi.UnsafeConvoRequires = make(map[string]string)
 }
 
 // Deinit is called when the MobInstance is deleted.
 func (mi *MobInstance) Deinit() {
 	Armeria.registry.Unregister(mi.ID())
+	mi.ClearConvoOptions()
 }
 
 // ID returns the UUID of the instance.
@@ -59,7 +68,7 @@ func (mi *MobInstance) Name() string {
 // FormattedName returns the formatted Mob name.
 func (mi *MobInstance) FormattedName() string {
 	return TextStyle(
-		mi.Parent.Name(),
+		TextStylePlayer(mi.Parent.Name()),
 		WithContextMenu(
 			mi.Name(),
 			"mob",
@@ -98,11 +107,20 @@ func (mi *MobInstance) ResetMoveTicks() {
 	mi.UnsafeMoveTicks = 0
 }
 
-// SetConvoText sets the display text for a particular conversation option. Used for caching.
-func (mi *MobInstance) SetConvoText(optionId, displayText string) {
+// SetConvoText sets the display text for a particular conversation option, along with the requirements a character
+// must meet to select it. Used for caching.
+func (mi *MobInstance) SetConvoText(optionId, displayText, requires string) {
 	mi.Lock()
 	defer mi.Unlock()
 	mi.UnsafeConvoText[optionId] = displayText
+	mi.UnsafeConvoRequires[optionId] = requires
+}
+
+// ConvoRequires retrieves the requirements for selecting a particular conversation option.
+func (mi *MobInstance) ConvoRequires(optionId string) string {
+	mi.RLock()
+	defer mi.RUnlock()
+	return mi.UnsafeConvoRequires[optionId]
 }
 
 // ConvoText retrieves the cached display text for a particular conversation option. No entry returns a blank string.
@@ -133,23 +151,31 @@ func (mi *MobInstance) SetMobSpawnerUUID(uuid string) {
 
 // SetAttribute sets a permanent attribute on the MobInstance.
 func (mi *MobInstance) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeMobInstance, name, value, mi.Attribute); err != nil {
+		return err
+	}
+
+	old := mi.Attribute(name)
+
 	mi.Lock()
-	defer mi.Unlock()
 
 	if mi.UnsafeAttributes == nil {
 		mi.UnsafeAttributes = make(map[string]string)
 	}
 
-	if !misc.Contains(AttributeList(ObjectTypeMobInstance), name) {
-		return errors.New("attribute name is invalid")
-	}
-
 	mi.UnsafeAttributes[strings.ToLower(name)] = value
+	mi.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeMobInstance, mi, name, old, mi.Attribute(name))
 	return nil
 }
 
 // Attribute returns an attribute on the MobInstance, and falls back to the parent Mob.
 func (mi *MobInstance) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeMobInstance, mi, name); ok {
+		return v
+	}
+
 	mi.RLock()
 	defer mi.RUnlock()
 
@@ -181,6 +207,19 @@ func (mi *MobInstance) AttributeInt(name string) int {
 	return i
 }
 
+// Attackable returns true if characters can attack the MobInstance. Unless its attackable attribute says
+// otherwise, every mob can be attacked except for vendors.
+func (mi *MobInstance) Attackable() bool {
+	switch mi.Attribute(AttributeAttackable) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	return len(mi.VendorLedgers()) == 0
+}
+
 // InstanceAttribute returns an attribute on the MobInstance, with no fallback to the parent Mob.
 func (mi *MobInstance) InstanceAttribute(name string) string {
 	mi.RLock()
@@ -247,41 +286,14 @@ func (mi *MobInstance) EditorData() *ObjectEditorData {
 	}
 }
 
-func (mi *MobInstance) Pronoun(pt PronounType) string {
-	gender := mi.Attribute(AttributeGender)
-	if gender == "male" {
-		if pt == PronounSubjective {
-			return "he"
-		} else if pt == PronounPossessiveAbsolute {
-			return "his"
-		} else if pt == PronounPossessiveAdjective {
-			return "his"
-		} else if pt == PronounObjective {
-			return "him"
-		}
-	} else if gender == "female" {
-		if pt == PronounSubjective {
-			return "she"
-		} else if pt == PronounPossessiveAbsolute {
-			return "hers"
-		} else if pt == PronounPossessiveAdjective {
-			return "her"
-		} else if pt == PronounObjective {
-			return "her"
-		}
-	} else if gender == "thing" {
-		if pt == PronounSubjective {
-			return "it"
-		} else if pt == PronounPossessiveAbsolute {
-			return "its"
-		} else if pt == PronounPossessiveAdjective {
-			return "its"
-		} else if pt == PronounObjective {
-			return "it"
-		}
-	}
+// PronounSet returns the pronouns used to refer to the mob instance, based on its gender.
+func (mi *MobInstance) PronounSet() *PronounSet {
+	return pronounSetFor("", mi.Attribute(AttributeGender))
+}
 
-	return ""
+// Pronoun is used to determine the appropriate pronoun for the mob instance.
+func (mi *MobInstance) Pronoun(pt PronounType) string {
+	return mi.PronounSet().Pronoun(pt)
 }
 
 // Delete removes the mob instance from the game. It should be manually removed from containers
@@ -0,0 +1,100 @@
+package armeria
+
+import (
+	"fmt"
+	"time"
+)
+
+// Pursuing returns the Character the MobInstance is chasing, or nil if it isn't chasing anyone.
+func (mi *MobInstance) Pursuing() *Character {
+	mi.RLock()
+	id := mi.UnsafePursuing
+	mi.RUnlock()
+
+	if len(id) == 0 {
+		return nil
+	}
+
+	return Armeria.characterManager.CharacterById(id)
+}
+
+// Pursue makes the MobInstance chase a Character through exits when they leave the room, for up to the mob's
+// pursuit range. The mob gives up and heads home when the leash runs out.
+func (mi *MobInstance) Pursue(c *Character) {
+	leash := time.Duration(mi.AttributeInt(AttributeLeash)) * time.Second
+
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafePursuing = c.ID()
+	mi.UnsafePursuitSteps = 0
+	mi.UnsafePursuitUntil = time.Now().Add(leash)
+	mi.UnsafeFollowing = ""
+	mi.UnsafeReturning = false
+}
+
+// pursuitExpired returns true if the MobInstance has been chasing someone for longer than its leash allows.
+func (mi *MobInstance) pursuitExpired() bool {
+	mi.RLock()
+	defer mi.RUnlock()
+
+	return time.Now().After(mi.UnsafePursuitUntil)
+}
+
+// canChase returns true if the MobInstance can chase its target from one Room to the next. Mobs stay within
+// their own area, and won't go further than their pursuit range.
+func (mi *MobInstance) canChase(from, to *Room) bool {
+	if to == nil || to.ParentArea != from.ParentArea || !walkable(to) {
+		return false
+	}
+
+	mi.RLock()
+	steps := mi.UnsafePursuitSteps
+	mi.RUnlock()
+
+	return steps < mi.AttributeInt(AttributePursuitRange)
+}
+
+// chase moves the MobInstance after the Character it is pursuing, who just walked from one Room to the next.
+func (mi *MobInstance) chase(c *Character, from, to *Room, direction string) {
+	if mi.pursuitExpired() || !mi.canChase(from, to) {
+		mi.GiveUpPursuit()
+		return
+	}
+
+	mi.Lock()
+	mi.UnsafePursuitSteps++
+	mi.Unlock()
+
+	mi.Walk(to, direction)
+	c.Player().client.ShowColorizedText(fmt.Sprintf("%s chases after you!", mi.FormattedName()), ColorError)
+}
+
+// GiveUpPursuit stops the MobInstance from chasing anyone, and sends it walking back to its Home.
+func (mi *MobInstance) GiveUpPursuit() {
+	target := mi.Pursuing()
+
+	mi.Lock()
+	mi.UnsafePursuing = ""
+	mi.Unlock()
+
+	text := fmt.Sprintf("%s gives up the chase.", mi.FormattedName())
+	for _, c := range mi.Room().Here().Characters(true) {
+		c.Player().client.ShowText(text)
+	}
+	if target != nil && target.Online() && target.Room() != mi.Room() {
+		target.Player().client.ShowText(text)
+	}
+
+	if mi.Home() != nil && mi.Home() != mi.Room() {
+		mi.ReturnHome()
+	}
+}
+
+// checkPursuit makes the MobInstance give up the chase when its leash runs out, or when the Character it is
+// pursuing got away without walking through an exit (such as by logging out or teleporting).
+func (mi *MobInstance) checkPursuit(c *Character) {
+	if mi.pursuitExpired() || !c.Online() || c.Room() != mi.Room() {
+		mi.GiveUpPursuit()
+	}
+}
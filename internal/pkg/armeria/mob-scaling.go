@@ -0,0 +1,71 @@
+package armeria
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	// SpawnScalingNone spawns mobs as they are.
+	SpawnScalingNone = "none"
+	// SpawnScalingParty makes mobs tougher for each character in the area beyond the first.
+	SpawnScalingParty = "party"
+	// SpawnScalingLevel makes mobs tougher for each level the characters in the area have, on average, beyond the
+	// first.
+	SpawnScalingLevel = "level"
+)
+
+// SpawnScalingModes returns the ways a mob spawner can scale the difficulty of the mobs it spawns.
+func SpawnScalingModes() []string {
+	return []string{
+		SpawnScalingNone,
+		SpawnScalingParty,
+		SpawnScalingLevel,
+	}
+}
+
+// SpawnScale returns how much tougher the mobs spawned by a mob spawner should be than their base health and
+// damage, based on the characters in the spawner's area. A scale of 1 leaves the mobs as they are.
+func SpawnScale(spawner *ItemInstance) float64 {
+	r := spawner.Room()
+	if r == nil {
+		return 1
+	}
+
+	switch spawner.Attribute(AttributeSpawnScaling) {
+	case SpawnScalingParty:
+		extra := len(r.ParentArea.Characters()) - 1
+		if extra <= 0 {
+			return 1
+		}
+		return 1 + float64(extra*spawner.AttributeInt(AttributeSpawnScaleRate))/100
+	case SpawnScalingLevel:
+		chars := r.ParentArea.Characters()
+		if len(chars) == 0 {
+			return 1
+		}
+		total := 0
+		for _, c := range chars {
+			total += c.Level() - 1
+		}
+		return 1 + float64(total)/float64(len(chars))*float64(spawner.AttributeInt(AttributeSpawnScaleRate))/100
+	}
+
+	return 1
+}
+
+// Scale sets the MobInstance's health, damage and experience to its mob's base values multiplied by a scale.
+func (mi *MobInstance) Scale(scale float64) {
+	if scale == 1 {
+		return
+	}
+
+	for _, attr := range []string{AttributeHealth, AttributeDamage, AttributeExperience} {
+		base := mi.Parent.Attribute(attr)
+		n, err := strconv.Atoi(base)
+		if err != nil {
+			continue
+		}
+		_ = mi.SetAttribute(attr, strconv.Itoa(int(math.Round(float64(n)*scale))))
+	}
+}
@@ -1,7 +1,6 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -21,6 +20,9 @@ type Mob struct {
 	UnsafeInstances   []*MobInstance    `json:"instances"`
 	UnsafeScript      string            `json:"-"`
 	UnsafeScriptFuncs []string          `json:"-"`
+	// UnsafeMemories holds what the Mob remembers about each character, keyed by character uuid.
+	UnsafeMemories map[string]map[string]string `json:"memories,omitempty"`
+	scriptStats    map[string]*ScriptFuncStats
 }
 
 // Init is called when the Mob is created or loaded from disk.
@@ -37,6 +39,10 @@ func (m *Mob) Name() string {
 
 // Attribute returns a permanent attribute.
 func (m *Mob) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeMob, m, name); ok {
+		return v
+	}
+
 	m.RLock()
 	defer m.RUnlock()
 
@@ -48,18 +54,51 @@ func (m *Mob) Attribute(name string) string {
 }
 
 // SetAttribute sets a permanent attribute and only valid attributes can be set.
-func (m *Mob) SetAttribute(name string, value string) {
+func (m *Mob) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeMob, name, value, m.Attribute); err != nil {
+		return err
+	}
+
+	old := m.Attribute(name)
+
+	m.Lock()
+
+	m.UnsafeAttributes[name] = value
+	m.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeMob, m, name, old, m.Attribute(name))
+	return nil
+}
+
+// Recall returns something the Mob remembers about a Character, or an empty string if it doesn't remember.
+func (m *Mob) Recall(c *Character, key string) string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeMemories[c.ID()][key]
+}
+
+// Remember stores something for the Mob to remember about a Character, shared by all of its instances. Remembering
+// an empty value forgets the key.
+func (m *Mob) Remember(c *Character, key, value string) {
 	m.Lock()
 	defer m.Unlock()
 
-	if !misc.Contains(AttributeList(ObjectTypeMob), name) {
-		Armeria.log.Fatal("attempted to set invalid attribute",
-			zap.String("attribute", name),
-			zap.String("value", value),
-		)
+	if len(value) == 0 {
+		delete(m.UnsafeMemories[c.ID()], key)
+		if len(m.UnsafeMemories[c.ID()]) == 0 {
+			delete(m.UnsafeMemories, c.ID())
+		}
+		return
 	}
 
-	m.UnsafeAttributes[name] = value
+	if m.UnsafeMemories == nil {
+		m.UnsafeMemories = make(map[string]map[string]string)
+	}
+	if m.UnsafeMemories[c.ID()] == nil {
+		m.UnsafeMemories[c.ID()] = make(map[string]string)
+	}
+	m.UnsafeMemories[c.ID()][key] = value
 }
 
 // EditorData returns the JSON used for the object editor.
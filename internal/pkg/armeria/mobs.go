@@ -145,6 +145,31 @@ func (m *MobManager) CreateMob(name string) *Mob {
 	return mob
 }
 
+// CloneMob creates a new Mob with a copy of an existing Mob's attributes and script, but doesn't add it to
+// memory.
+func (m *MobManager) CloneMob(mob *Mob, name string) *Mob {
+	clone := m.CreateMob(name)
+
+	mob.RLock()
+	for k, v := range mob.UnsafeAttributes {
+		clone.UnsafeAttributes[k] = v
+	}
+	mob.RUnlock()
+
+	// The clone gets its own copy of the picture, since the file is deleted along with the object that owns it.
+	if picture := clone.UnsafeAttributes[AttributePicture]; len(picture) > 0 {
+		if key := CopyObjectPictureOnDisk(picture, "mob", name); len(key) > 0 {
+			clone.UnsafeAttributes[AttributePicture] = key
+		} else {
+			delete(clone.UnsafeAttributes, AttributePicture)
+		}
+	}
+
+	WriteMobScript(clone, mob.Script())
+
+	return clone
+}
+
 // AddMob adds a new Mob reference to memory.
 func (m *MobManager) AddMob(mob *Mob) {
 	m.Lock()
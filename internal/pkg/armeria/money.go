@@ -0,0 +1,196 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// MaxMoney is the most money a character can hold.
+const MaxMoney float64 = 1000000000
+
+var (
+	// ErrInsufficientFunds is returned when a character can't afford to pay an amount of money.
+	ErrInsufficientFunds = errors.New("not enough money")
+	// ErrInvalidMoneyAmount is returned when an amount of money is negative or too large.
+	ErrInvalidMoneyAmount = errors.New("invalid amount of money")
+	// ErrMoneyLimit is returned when a character would end up with more than MaxMoney.
+	ErrMoneyLimit = errors.New("more money than a character can hold")
+)
+
+// moneyToCents converts an amount of money into whole cents, rounding to the nearest cent.
+func moneyToCents(amount float64) (int64, error) {
+	if math.IsNaN(amount) || amount < 0 || amount > MaxMoney {
+		return 0, ErrInvalidMoneyAmount
+	}
+	return int64(math.Round(amount * 100)), nil
+}
+
+// centsToMoney converts whole cents into an amount of money.
+func centsToMoney(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// ParseMoney parses an amount of money given by a player, with or without a leading dollar sign (ie: "$5.50"). The
+// amount must be at least a cent.
+func ParseMoney(s string) (float64, error) {
+	amount, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(s), "$"), 64)
+	if err != nil {
+		return 0, errors.New("the amount must be a number")
+	}
+	cents, err := moneyToCents(amount)
+	if err != nil || cents < 1 {
+		return 0, fmt.Errorf("the amount must be between $0.01 and %s", misc.Money.FormatMoney(MaxMoney))
+	}
+	return centsToMoney(cents), nil
+}
+
+// Money returns the Character's balance.
+func (c *Character) Money() float64 {
+	c.RLock()
+	defer c.RUnlock()
+
+	return centsToMoney(c.UnsafeMoney)
+}
+
+// AddMoney adds money to the Character, which is recorded in the economy ledger as created by the source.
+func (c *Character) AddMoney(amount float64, source MoneySource) error {
+	cents, err := c.changeMoney(amount, false)
+	if err != nil {
+		return err
+	}
+
+	Armeria.economyManager.Record(source, centsToMoney(cents))
+	return nil
+}
+
+// DeductMoney removes money from the Character, which is recorded in the economy ledger as destroyed by the
+// source. ErrInsufficientFunds is returned, and nothing is removed, if they can't afford it.
+func (c *Character) DeductMoney(amount float64, source MoneySource) error {
+	cents, err := c.changeMoney(amount, true)
+	if err != nil {
+		return err
+	}
+
+	Armeria.economyManager.Record(source, -centsToMoney(cents))
+	return nil
+}
+
+// refundMoney gives back money that was deducted from the Character moments ago. It fits within MaxMoney unless
+// they were paid in the meantime, so a failure is logged rather than the money disappearing silently.
+func (c *Character) refundMoney(amount float64, source MoneySource) {
+	if err := c.AddMoney(amount, source); err != nil {
+		Armeria.log.Error("money could not be refunded",
+			zap.String("character", c.Name()),
+			zap.Float64("amount", amount),
+			zap.Error(err),
+		)
+	}
+}
+
+// TransferMoney moves money from the Character to another Character. The money stays within the economy, so it
+// isn't recorded in the economy ledger.
+func (c *Character) TransferMoney(to *Character, amount float64) error {
+	if c == to {
+		return errors.New("you can't give money to yourself")
+	}
+
+	if _, err := c.changeMoney(amount, true); err != nil {
+		return err
+	}
+	if _, err := to.changeMoney(amount, false); err != nil {
+		// The recipient can't hold it, so give it back.
+		if _, refundErr := c.changeMoney(amount, false); refundErr != nil {
+			Armeria.log.Error("money could not be refunded",
+				zap.String("character", c.Name()),
+				zap.Float64("amount", amount),
+				zap.Error(refundErr),
+			)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// HoldMoney takes money from the Character to be held in escrow, such as a stake in a wager. Money in escrow stays
+// within the economy, so it isn't recorded in the economy ledger. ErrInsufficientFunds is returned, and nothing is
+// taken, if they can't afford it.
+func (c *Character) HoldMoney(amount float64) error {
+	_, err := c.changeMoney(amount, true)
+	return err
+}
+
+// ReleaseMoney pays money held in escrow out to the Character. Like HoldMoney, it isn't recorded in the economy
+// ledger.
+func (c *Character) ReleaseMoney(amount float64) error {
+	_, err := c.changeMoney(amount, false)
+	return err
+}
+
+// returnHeldMoney gives back money the Character put into escrow moments ago. It fits within MaxMoney unless they
+// were paid in the meantime, so a failure is logged rather than the money disappearing silently.
+func (c *Character) returnHeldMoney(amount float64) {
+	if err := c.ReleaseMoney(amount); err != nil {
+		Armeria.log.Error("held money could not be returned",
+			zap.String("character", c.Name()),
+			zap.Float64("amount", amount),
+			zap.Error(err),
+		)
+	}
+}
+
+// SetMoney sets the Character's balance, such as when staff correct it. The difference is recorded in the economy
+// ledger against the source.
+func (c *Character) SetMoney(amount float64, source MoneySource) error {
+	cents, err := moneyToCents(amount)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	old := c.UnsafeMoney
+	c.UnsafeMoney = cents
+	c.Unlock()
+
+	Armeria.economyManager.Record(source, centsToMoney(cents-old))
+	Armeria.characterManager.AutoSave(c, AutoSaveReasonMoney)
+	return nil
+}
+
+// changeMoney adds (or, when deducting, removes) an amount of money from the Character's balance, returning the
+// number of cents that changed hands. Money changing hands is saved straight away, rather than risking it being
+// lost in a crash.
+func (c *Character) changeMoney(amount float64, deduct bool) (int64, error) {
+	cents, err := moneyToCents(amount)
+	if err != nil {
+		return 0, err
+	}
+	if cents == 0 {
+		return 0, nil
+	}
+
+	c.Lock()
+	if deduct {
+		if cents > c.UnsafeMoney {
+			c.Unlock()
+			return 0, ErrInsufficientFunds
+		}
+		c.UnsafeMoney -= cents
+	} else {
+		if centsToMoney(c.UnsafeMoney+cents) > MaxMoney {
+			c.Unlock()
+			return 0, ErrMoneyLimit
+		}
+		c.UnsafeMoney += cents
+	}
+	c.Unlock()
+
+	Armeria.characterManager.AutoSave(c, AutoSaveReasonMoney)
+	return cents, nil
+}
@@ -0,0 +1,79 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// MultiboxAllow lets multiple characters log in from the same address.
+	MultiboxAllow = "allow"
+	// MultiboxWarn lets multiple characters log in from the same address, but warns the player and logs it.
+	MultiboxWarn = "warn"
+	// MultiboxBlock prevents more than one character from logging in from the same address.
+	MultiboxBlock = "block"
+)
+
+// MultiboxPolicies returns the valid multiboxing policies.
+func MultiboxPolicies() []string {
+	return []string{MultiboxAllow, MultiboxWarn, MultiboxBlock}
+}
+
+// CharactersFromIP returns the online characters played from an IP address.
+func CharactersFromIP(ip string) []*Character {
+	var chars []*Character
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if c.Player().IP() == ip {
+			chars = append(chars, c)
+		}
+	}
+	return chars
+}
+
+// MultiboxAllowed enforces the multiboxing policy when a Player selects a Character. It returns false if the
+// Character cannot log in because another character is already being played from the same address. Characters
+// with the CAN_MULTIBOX permission are exempt.
+func MultiboxAllowed(p *Player, c *Character) bool {
+	if Armeria.multiboxPolicy == MultiboxAllow || c.HasGlobalPermission("CAN_MULTIBOX") {
+		return true
+	}
+
+	others := CharactersFromIP(p.IP())
+	if len(others) == 0 {
+		return true
+	}
+
+	var names []string
+	for _, o := range others {
+		names = append(names, o.FormattedName())
+	}
+
+	Armeria.log.Warn("multiple characters from the same address",
+		zap.String("character", c.Name()),
+		zap.String("ip", p.IP()),
+		zap.Int("others", len(others)),
+		zap.String("policy", Armeria.multiboxPolicy),
+	)
+
+	if Armeria.multiboxPolicy == MultiboxBlock {
+		p.client.ShowColorizedText(
+			fmt.Sprintf(
+				"Only one character can be played at a time, and %s is already logged in from your address.",
+				strings.Join(names, ", "),
+			),
+			ColorError,
+		)
+		return false
+	}
+
+	p.client.ShowColorizedText(
+		fmt.Sprintf(
+			"You're also playing %s from the same address. Staff may review characters played together.",
+			strings.Join(names, ", "),
+		),
+		ColorError,
+	)
+	return true
+}
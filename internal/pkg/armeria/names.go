@@ -0,0 +1,223 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// NameManager holds the rules that character names must follow.
+type NameManager struct {
+	sync.RWMutex
+	dataFile       string
+	UnsafeBlocked  []string `json:"blocked"`
+	UnsafeReserved []string `json:"reserved"`
+}
+
+var (
+	// defaultBlockedNames are words that cannot appear anywhere within a character name.
+	defaultBlockedNames = []string{"fuck", "shit", "cunt", "bitch", "nazi", "penis", "vagina"}
+	// defaultReservedNames are patterns matching names that only staff can give out.
+	defaultReservedNames = []string{`^admin`, `^mod(erator)?s?$`, `^staff`, `^gm$`, `^sysop`, `^armeria$`, `^system$`}
+
+	// leetReplacer undoes common letter substitutions used to sneak blocked words past the filter.
+	leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+)
+
+// NewNameManager creates a new NameManager.
+func NewNameManager() *NameManager {
+	m := &NameManager{
+		dataFile: fmt.Sprintf("%s/names.json", Armeria.dataPath),
+	}
+
+	m.LoadNames()
+
+	return m
+}
+
+// LoadNames loads the name rules from disk into memory.
+func (m *NameManager) LoadNames() {
+	m.Lock()
+	defer m.Unlock()
+
+	namesFile, err := os.Open(m.dataFile)
+	defer namesFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(namesFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	for _, pattern := range m.UnsafeReserved {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			Armeria.log.Fatal("invalid reserved name pattern",
+				zap.String("pattern", pattern),
+				zap.Error(err),
+			)
+		}
+	}
+
+	Armeria.log.Info("name rules loaded",
+		zap.Int("blocked", len(m.UnsafeBlocked)),
+		zap.Int("reserved", len(m.UnsafeReserved)),
+	)
+}
+
+// SaveNames writes the in-memory name rules to disk.
+func (m *NameManager) SaveNames() {
+	m.RLock()
+	defer m.RUnlock()
+
+	namesFile, err := os.Create(m.dataFile)
+	defer namesFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := namesFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = namesFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Blocked returns the words that cannot appear within a character name.
+func (m *NameManager) Blocked() []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeBlocked
+}
+
+// Reserved returns the patterns of names that are reserved for staff.
+func (m *NameManager) Reserved() []string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeReserved
+}
+
+// Block adds a word that cannot appear within a character name. It returns false if the word is already blocked.
+func (m *NameManager) Block(word string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	word = strings.ToLower(word)
+	if misc.Contains(m.UnsafeBlocked, word) {
+		return false
+	}
+
+	m.UnsafeBlocked = append(m.UnsafeBlocked, word)
+	return true
+}
+
+// Unblock removes a blocked word. It returns false if the word wasn't blocked.
+func (m *NameManager) Unblock(word string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, w := range m.UnsafeBlocked {
+		if w == strings.ToLower(word) {
+			m.UnsafeBlocked = append(m.UnsafeBlocked[:i], m.UnsafeBlocked[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Reserve adds a pattern of names that are reserved for staff.
+func (m *NameManager) Reserve(pattern string) error {
+	if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+		return err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if misc.Contains(m.UnsafeReserved, pattern) {
+		return errors.New("that pattern is already reserved")
+	}
+
+	m.UnsafeReserved = append(m.UnsafeReserved, pattern)
+	return nil
+}
+
+// Unreserve removes a reserved name pattern. It returns false if the pattern wasn't reserved.
+func (m *NameManager) Unreserve(pattern string) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	for i, p := range m.UnsafeReserved {
+		if p == pattern {
+			m.UnsafeReserved = append(m.UnsafeReserved[:i], m.UnsafeReserved[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckName returns an error explaining why a character name cannot be used. The except Character is skipped when
+// checking for similar names, so a character can be renamed to a variation of their own name.
+func (m *NameManager) CheckName(name string, except *Character) error {
+	normalized := leetReplacer.Replace(strings.ToLower(name))
+
+	for _, word := range m.Blocked() {
+		if strings.Contains(normalized, word) {
+			return errors.New("that name isn't allowed")
+		}
+	}
+
+	for _, pattern := range m.Reserved() {
+		if regexp.MustCompile("(?i)" + pattern).MatchString(name) {
+			return errors.New("that name is reserved")
+		}
+	}
+
+	for _, c := range Armeria.characterManager.Characters() {
+		if c == except {
+			continue
+		}
+		if strings.ToLower(c.Name()) == strings.ToLower(name) {
+			return errors.New("a character with that name already exists")
+		}
+		if len(name) >= 4 && misc.Levenshtein(c.Name(), name) <= 1 {
+			return fmt.Errorf("that name is too similar to %s", c.Name())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	NoteTargetRoom = "room"
+	NoteTargetMob  = "mob"
+	NoteTargetItem = "item"
+
+	// NotePermission is the permission staff need to read and write notes.
+	NotePermission = "CAN_BUILD"
+)
+
+// NoteManager holds the notes that staff leave for each other, such as building TODOs.
+type NoteManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeNotes  []*Note `json:"notes"`
+	UnsafeNextID int     `json:"nextId"`
+}
+
+// Note is a message left by a staff member, either on the global backlog or attached to a room, mob or item.
+type Note struct {
+	ID int `json:"id"`
+	// Author is the uuid of the character who wrote the note.
+	Author string `json:"author"`
+	Text   string `json:"text"`
+	// TargetType is empty for notes on the global backlog.
+	TargetType string `json:"targetType"`
+	// Target is the uuid of a room, or the name of a mob or item.
+	Target     string    `json:"target"`
+	Created    time.Time `json:"created"`
+	ResolvedBy string    `json:"resolvedBy"`
+	Resolved   time.Time `json:"resolved"`
+}
+
+// NoteEditorData is a note as shown within the object editor.
+type NoteEditorData struct {
+	ID     int    `json:"id"`
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// NewNoteManager creates a new NoteManager.
+func NewNoteManager() *NoteManager {
+	m := &NoteManager{
+		dataFile: fmt.Sprintf("%s/notes.json", Armeria.dataPath),
+	}
+
+	m.LoadNotes()
+
+	return m
+}
+
+// LoadNotes loads the notes from disk into memory.
+func (m *NoteManager) LoadNotes() {
+	m.Lock()
+	defer m.Unlock()
+
+	notesFile, err := os.Open(m.dataFile)
+	defer notesFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(notesFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("notes loaded",
+		zap.Int("count", len(m.UnsafeNotes)),
+	)
+}
+
+// SaveNotes writes the in-memory notes to disk.
+func (m *NoteManager) SaveNotes() {
+	m.RLock()
+	defer m.RUnlock()
+
+	notesFile, err := os.Create(m.dataFile)
+	defer notesFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := notesFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = notesFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Add writes a new note. The target type and target are left empty for notes on the global backlog.
+func (m *NoteManager) Add(c *Character, targetType, target, text string) *Note {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeNextID++
+	n := &Note{
+		ID:         m.UnsafeNextID,
+		Author:     c.ID(),
+		Text:       text,
+		TargetType: targetType,
+		Target:     target,
+		Created:    time.Now(),
+	}
+	m.UnsafeNotes = append(m.UnsafeNotes, n)
+
+	return n
+}
+
+// Resolve marks a note as done.
+func (m *NoteManager) Resolve(id int, c *Character) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, n := range m.UnsafeNotes {
+		if n.ID == id {
+			if len(n.ResolvedBy) > 0 {
+				return errors.New("that note was already resolved")
+			}
+			n.ResolvedBy = c.ID()
+			n.Resolved = time.Now()
+			return nil
+		}
+	}
+
+	return errors.New("there is no note with that id")
+}
+
+// Notes returns a copy of the notes that match, oldest first.
+func (m *NoteManager) Notes(match func(n *Note) bool) []Note {
+	m.RLock()
+	defer m.RUnlock()
+
+	var notes []Note
+	for _, n := range m.UnsafeNotes {
+		if match(n) {
+			notes = append(notes, *n)
+		}
+	}
+	return notes
+}
+
+// OpenNotesFor returns the unresolved notes attached to an object.
+func (m *NoteManager) OpenNotesFor(targetType, target string) []Note {
+	return m.Notes(func(n *Note) bool {
+		return !n.IsResolved() && n.TargetType == targetType && strings.ToLower(n.Target) == strings.ToLower(target)
+	})
+}
+
+// EditorNotes returns the unresolved notes attached to the object being edited in the object editor.
+func (m *NoteManager) EditorNotes(data *ObjectEditorData) []*NoteEditorData {
+	var notes []Note
+	switch data.ObjectType {
+	case "room":
+		notes = m.OpenNotesFor(NoteTargetRoom, data.UUID)
+	case "mob", "specific-mob":
+		notes = m.OpenNotesFor(NoteTargetMob, data.Name)
+	case "item", "specific-item":
+		notes = m.OpenNotesFor(NoteTargetItem, data.Name)
+	}
+
+	editorNotes := []*NoteEditorData{}
+	for _, n := range notes {
+		editorNotes = append(editorNotes, &NoteEditorData{
+			ID:     n.ID,
+			Author: n.AuthorName(),
+			Text:   n.Text,
+		})
+	}
+	return editorNotes
+}
+
+// IsResolved returns true if the note has been marked as done.
+func (n *Note) IsResolved() bool {
+	return len(n.ResolvedBy) > 0
+}
+
+// AuthorName returns the name of the character who wrote the note.
+func (n *Note) AuthorName() string {
+	if c := Armeria.characterManager.CharacterById(n.Author); c != nil {
+		return c.Name()
+	}
+	return "(deleted)"
+}
+
+// TargetName returns a description of what the note is attached to.
+func (n *Note) TargetName() string {
+	switch n.TargetType {
+	case NoteTargetRoom:
+		if o, rt := Armeria.registry.Get(n.Target); rt == RegistryTypeRoom {
+			room := o.(*Room)
+			return fmt.Sprintf("%s (%s)", room.Attribute(AttributeTitle), room.LocationString())
+		}
+		return "(deleted room)"
+	case NoteTargetMob, NoteTargetItem:
+		return fmt.Sprintf("%s %s", n.TargetType, n.Target)
+	}
+	return "backlog"
+}
+
+// ParseNoteTarget works out what a note is attached to from the start of its text, written as "<target>: <note>".
+// The target can be "here" for the current room, or the name of a mob or item. Text that doesn't start with a
+// target is a note for the global backlog.
+func ParseNoteTarget(c *Character, text string) (targetType, target, note string) {
+	sections := strings.SplitN(text, ":", 2)
+	if len(sections) != 2 {
+		return "", "", text
+	}
+
+	name := strings.TrimSpace(sections[0])
+	note = strings.TrimSpace(sections[1])
+	if strings.ToLower(name) == "here" {
+		return NoteTargetRoom, c.Room().ID(), note
+	} else if m := Armeria.mobManager.MobByName(name); m != nil {
+		return NoteTargetMob, m.Name(), note
+	} else if i := Armeria.itemManager.ItemByName(name); i != nil {
+		return NoteTargetItem, i.Name(), note
+	}
+
+	return "", "", text
+}
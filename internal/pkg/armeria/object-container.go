@@ -3,6 +3,7 @@ package armeria
 import (
 	"armeria/internal/pkg/misc"
 	"errors"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -29,6 +30,8 @@ type ObjectContainerResult struct {
 	Object     ContainerObject
 	Definition *ObjectContainerDefinition
 	Type       RegistryType
+	// Ambiguous holds the objects a loose search matched when it couldn't pick one of them.
+	Ambiguous []ContainerObject
 }
 
 // ContainerObject is an interface that describes an object that can go within an ObjectContainer.
@@ -66,6 +69,7 @@ const (
 	ContainerParentTypeRoom ContainerParentType = iota
 	ContainerParentTypeCharacter
 	ContainerParentTypeMobInstance
+	ContainerParentTypeQuarantine
 )
 
 // NewObjectContainer will return a new object container with the specified max size.
@@ -197,25 +201,126 @@ func (oc *ObjectContainer) GetByAny(uuidOrName string) *ObjectContainerResult {
 }
 
 // GetLoose attempts to retrieve an object by it's uuid, and then loosely by it's name. Loosely refers to partial
-// name matching. If you don't want to support partial name matching, use ObjectContainer.GetByAny() instead.
+// name matching: an exact name wins, followed by names that start with the search, followed by names with a word
+// that starts with it (ie: "swo" finds "rusty sword"). If the search matches objects with different names, no
+// object is returned and the matches are listed in Ambiguous. A specific match can be picked with an ordinal, so
+// "2.sword" is the second object that "sword" matches. If you don't want to support partial name matching, use
+// ObjectContainer.GetByAny() instead.
 func (oc *ObjectContainer) GetLoose(id string) *ObjectContainerResult {
 	if misc.IsUUID(id) {
 		return oc.Get(id)
 	}
 
+	ordinal, all, name := ParseTargetSelector(id)
+	if all || len(strings.TrimSpace(name)) == 0 {
+		return &ObjectContainerResult{Type: RegistryTypeUnknown}
+	}
+
+	matches := oc.looseMatches(name)
+	if ordinal > 0 {
+		if ordinal > len(matches) {
+			return &ObjectContainerResult{Type: RegistryTypeUnknown}
+		}
+		return matches[ordinal-1]
+	} else if len(matches) == 0 {
+		return &ObjectContainerResult{Type: RegistryTypeUnknown}
+	}
+
+	// Several copies of the same object aren't ambiguous, since any of them will do.
+	var distinct []ContainerObject
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		name := strings.ToLower(m.Object.Name())
+		if !seen[name] {
+			seen[name] = true
+			distinct = append(distinct, m.Object)
+		}
+	}
+
+	if len(distinct) > 1 {
+		return &ObjectContainerResult{Type: RegistryTypeUnknown, Ambiguous: distinct}
+	}
+	return matches[0]
+}
+
+// GetAllLoose retrieves every object that a group selector matches, such as "all.coin" for everything that "coin"
+// loosely matches, or "all" for everything in the container. Anything else is looked up with
+// ObjectContainer.GetLoose(), returning either the one object found or nothing.
+func (oc *ObjectContainer) GetAllLoose(id string) []*ObjectContainerResult {
+	if _, all, name := ParseTargetSelector(id); all {
+		return oc.looseMatches(name)
+	}
+
+	if result := oc.GetLoose(id); result.Type != RegistryTypeUnknown {
+		return []*ObjectContainerResult{result}
+	}
+	return nil
+}
+
+// looseMatches returns the objects whose names loosely match, in the order they are in the container. Objects with
+// the exact name are returned if there are any; otherwise, names that start with the search are returned, and then
+// names with a later word that starts with it. An empty name matches everything.
+func (oc *ObjectContainer) looseMatches(name string) []*ObjectContainerResult {
 	oc.RLock()
 	defer oc.RUnlock()
 
-	match := strings.ToLower(id)
+	match := strings.ToLower(strings.TrimSpace(name))
+
+	var exact, prefixed, worded []*ObjectContainerResult
 	for _, ocd := range oc.UnsafeObjects {
 		o, ot := Armeria.registry.Get(ocd.UUID)
+		result := &ObjectContainerResult{Object: o.(ContainerObject), Definition: ocd, Type: ot}
 		iname := strings.ToLower(o.(ContainerObject).Name())
-		if len(match) <= len(iname) && iname[0:len(match)] == match {
-			return &ObjectContainerResult{Object: o.(ContainerObject), Definition: ocd, Type: ot}
+		if iname == match {
+			exact = append(exact, result)
+		} else if strings.HasPrefix(iname, match) {
+			prefixed = append(prefixed, result)
+		} else if wordPrefixed(iname, match) {
+			worded = append(worded, result)
 		}
 	}
 
-	return &ObjectContainerResult{Type: RegistryTypeUnknown}
+	if len(exact) > 0 {
+		return exact
+	} else if len(prefixed) > 0 {
+		return prefixed
+	}
+	return worded
+}
+
+// ParseTargetSelector splits a target into its selector and name. "2.sword" selects the second match for "sword",
+// "all.coin" selects every match for "coin", and "all" selects everything. The ordinal is zero when no ordinal was
+// given.
+func ParseTargetSelector(id string) (ordinal int, all bool, name string) {
+	id = strings.TrimSpace(id)
+	if strings.ToLower(id) == "all" {
+		return 0, true, ""
+	}
+
+	dot := strings.Index(id, ".")
+	if dot <= 0 {
+		return 0, false, id
+	}
+
+	selector, rest := strings.ToLower(id[:dot]), id[dot+1:]
+	if selector == "all" {
+		return 0, true, rest
+	}
+	if n, err := strconv.Atoi(selector); err == nil && n > 0 {
+		return n, false, rest
+	}
+	return 0, false, id
+}
+
+// wordPrefixed returns true if any word after the first in the name starts with the search, which can span
+// several words (ie: "red sw" in "big red sword").
+func wordPrefixed(name, search string) bool {
+	for i := 0; i < len(name); i++ {
+		if name[i] == ' ' && strings.HasPrefix(name[i+1:], search) {
+			return true
+		}
+	}
+	return false
 }
 
 // Slot returns the slot that the uuid is within. If the uuid does not exist, slot 0 will be returned,
@@ -434,10 +539,11 @@ func (oc *ObjectContainer) Add(uuid string) error {
 	}
 
 	oc.Lock()
-	defer oc.Unlock()
 	oc.UnsafeObjects = append(oc.UnsafeObjects, ocd)
+	oc.Unlock()
 
 	Armeria.registry.RegisterContainerObject(uuid, oc)
+	autoSaveRareItem(oc, uuid)
 
 	return nil
 }
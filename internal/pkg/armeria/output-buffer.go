@@ -0,0 +1,45 @@
+package armeria
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// OutputBufferLimit is the maximum number of lines of output kept for each character.
+	OutputBufferLimit = 1000
+)
+
+// AddOutput records text that was displayed to the Character, as plain text, one entry per line.
+func (c *Character) AddOutput(text string) {
+	var lines []string
+	for _, line := range strings.Split(TextPlain(text), "\n") {
+		if len(strings.TrimSpace(line)) > 0 {
+			lines = append(lines, line)
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.unsafeOutput = append(c.unsafeOutput, lines...)
+	if len(c.unsafeOutput) > OutputBufferLimit {
+		c.unsafeOutput = c.unsafeOutput[len(c.unsafeOutput)-OutputBufferLimit:]
+	}
+}
+
+// SearchOutput returns up to the last n lines of output displayed to the Character that match a regular expression,
+// oldest first.
+func (c *Character) SearchOutput(re *regexp.Regexp, n int) []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	var matches []string
+	for i := len(c.unsafeOutput) - 1; i >= 0 && len(matches) < n; i-- {
+		if re.MatchString(c.unsafeOutput[i]) {
+			matches = append([]string{c.unsafeOutput[i]}, matches...)
+		}
+	}
+
+	return matches
+}
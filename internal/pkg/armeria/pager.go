@@ -0,0 +1,83 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PagerPageSize is the number of lines or table rows shown on each page of long output.
+const PagerPageSize = 25
+
+// LinePages splits lines of output into pages.
+func LinePages(lines []string, perPage int) []string {
+	var pages []string
+	for len(lines) > 0 {
+		n := perPage
+		if n > len(lines) {
+			n = len(lines)
+		}
+		pages = append(pages, strings.Join(lines[:n], "\n"))
+		lines = lines[n:]
+	}
+	return pages
+}
+
+// TablePages splits the rows of a table into pages, each with its own copy of the header row.
+func TablePages(header string, rows []string, perPage int) []string {
+	if len(rows) == 0 {
+		return []string{TextTable(header)}
+	}
+
+	var pages []string
+	for len(rows) > 0 {
+		n := perPage
+		if n > len(rows) {
+			n = len(rows)
+		}
+		pages = append(pages, TextTable(append([]string{header}, rows[:n]...)...))
+		rows = rows[n:]
+	}
+	return pages
+}
+
+// ShowPages shows the first page of long output to the Player, and keeps the rest to be shown with /more. Pages
+// with more to follow end with a button to show the next page.
+func (p *Player) ShowPages(pages []string) {
+	p.Lock()
+	p.pages = pages
+	p.page = 0
+	p.Unlock()
+
+	p.ShowNextPage()
+}
+
+// ShowNextPage shows the next page of output waiting for the Player. It returns false if there isn't one.
+func (p *Player) ShowNextPage() bool {
+	p.Lock()
+	if p.page >= len(p.pages) {
+		p.Unlock()
+		return false
+	}
+	text := p.pages[p.page]
+	p.page++
+	page, total := p.page, len(p.pages)
+	if page == total {
+		p.pages = nil
+		p.page = 0
+	}
+	p.Unlock()
+
+	p.client.ShowText(text)
+	if page < total {
+		p.client.ShowText(
+			fmt.Sprintf(
+				"%s Page %d of %d.",
+				TextStyle("Show more", WithButton("/more", "")),
+				page,
+				total,
+			),
+		)
+	}
+
+	return true
+}
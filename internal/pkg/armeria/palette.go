@@ -0,0 +1,159 @@
+package armeria
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PalettePreset is a named set of colors that a character can apply to their palette at once.
+type PalettePreset struct {
+	Name        string
+	Description string
+	Colors      map[int]string
+}
+
+var (
+	// paletteColorNames are the names used to refer to each color when customizing a palette.
+	paletteColorNames = map[int]string{
+		ColorRoomTitle:       "roomTitle",
+		ColorSay:             "say",
+		ColorMovement:        "movement",
+		ColorMovementAlt:     "movementAlt",
+		ColorError:           "error",
+		ColorRoomDirs:        "roomDirs",
+		ColorWhisper:         "whisper",
+		ColorSuccess:         "success",
+		ColorCmdHelp:         "cmdHelp",
+		ColorChannelGeneral:  "channelGeneral",
+		ColorChannelCore:     "channelCore",
+		ColorChannelBuilders: "channelBuilders",
+		ColorMoney:           "money",
+	}
+
+	palettePresets = []*PalettePreset{
+		{
+			Name:        "default",
+			Description: "The standard Armeria colors.",
+			Colors: map[int]string{
+				ColorRoomTitle:       "#6e94ff",
+				ColorSay:             "#ffeb3b",
+				ColorMovement:        "#00bcd4",
+				ColorMovementAlt:     "#00ffc6",
+				ColorError:           "#e91e63",
+				ColorRoomDirs:        "#4c9af3",
+				ColorWhisper:         "#b730f7",
+				ColorSuccess:         "#8ee22b",
+				ColorCmdHelp:         "#e9761e",
+				ColorChannelGeneral:  "#009688",
+				ColorChannelCore:     "#ff5722",
+				ColorChannelBuilders: "#007cff",
+				ColorMoney:           "#fec205",
+			},
+		},
+		{
+			Name:        "high-contrast",
+			Description: "Bright, saturated colors that stand out against a dark background.",
+			Colors: map[int]string{
+				ColorRoomTitle:       "#ffffff",
+				ColorSay:             "#ffff00",
+				ColorMovement:        "#00ffff",
+				ColorMovementAlt:     "#00ff00",
+				ColorError:           "#ff4040",
+				ColorRoomDirs:        "#80c0ff",
+				ColorWhisper:         "#ff80ff",
+				ColorSuccess:         "#00ff00",
+				ColorCmdHelp:         "#ffa500",
+				ColorChannelGeneral:  "#008080",
+				ColorChannelCore:     "#c04000",
+				ColorChannelBuilders: "#0050c0",
+				ColorMoney:           "#ffd700",
+			},
+		},
+		{
+			Name:        "colorblind",
+			Description: "Colors that remain distinguishable with the common forms of color blindness.",
+			Colors: map[int]string{
+				ColorRoomTitle:       "#56b4e9",
+				ColorSay:             "#f0e442",
+				ColorMovement:        "#0072b2",
+				ColorMovementAlt:     "#56b4e9",
+				ColorError:           "#d55e00",
+				ColorRoomDirs:        "#56b4e9",
+				ColorWhisper:         "#cc79a7",
+				ColorSuccess:         "#009e73",
+				ColorCmdHelp:         "#e69f00",
+				ColorChannelGeneral:  "#009e73",
+				ColorChannelCore:     "#d55e00",
+				ColorChannelBuilders: "#0072b2",
+				ColorMoney:           "#e69f00",
+			},
+		},
+	}
+
+	paletteColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// PaletteColorByName returns the color constant for a palette color name.
+func PaletteColorByName(name string) (int, bool) {
+	for color, n := range paletteColorNames {
+		if strings.ToLower(n) == strings.ToLower(name) {
+			return color, true
+		}
+	}
+
+	return 0, false
+}
+
+// PaletteColorNames returns the names of the colors that can be customized, sorted alphabetically.
+func PaletteColorNames() []string {
+	var names []string
+	for _, n := range paletteColorNames {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// PalettePresetByName returns the matching PalettePreset, by name.
+func PalettePresetByName(name string) *PalettePreset {
+	for _, p := range palettePresets {
+		if p.Name == strings.ToLower(name) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Palette returns the colors the Character has customized, keyed by color constant.
+func (c *Character) Palette() map[int]string {
+	palette := make(map[int]string)
+	for _, entry := range strings.Split(c.Attribute(AttributePalette), ",") {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if color, ok := PaletteColorByName(kv[0]); ok {
+			palette[color] = kv[1]
+		}
+	}
+
+	return palette
+}
+
+// SetPalette replaces the colors the Character has customized.
+func (c *Character) SetPalette(palette map[int]string) error {
+	var entries []string
+	for color, hex := range palette {
+		if !paletteColorRegex.MatchString(hex) {
+			return fmt.Errorf("%s is not a valid hex color", hex)
+		}
+		entries = append(entries, fmt.Sprintf("%s=%s", paletteColorNames[color], strings.ToLower(hex)))
+	}
+	sort.Strings(entries)
+
+	return c.SetAttribute(AttributePalette, strings.Join(entries, ","))
+}
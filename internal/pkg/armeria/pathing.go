@@ -0,0 +1,161 @@
+package armeria
+
+import (
+	"container/heap"
+)
+
+// PathSearchLimit is the most rooms that are searched when finding a path, so searches through the wilderness don't
+// generate the whole area.
+const PathSearchLimit = 5000
+
+// pathDirections are the directions that are searched when finding a path between rooms.
+var pathDirections = []string{
+	NorthDirection,
+	SouthDirection,
+	EastDirection,
+	WestDirection,
+	UpDirection,
+	DownDirection,
+}
+
+// pathNode is a Room that has been reached while finding a path.
+type pathNode struct {
+	room   *Room
+	parent *pathNode
+	dir    string
+	steps  int
+	cost   int
+	score  int
+	index  int
+}
+
+// pathQueue is a priority queue of the nodes to search next, ordered by their estimated total cost.
+type pathQueue []*pathNode
+
+func (q pathQueue) Len() int           { return len(q) }
+func (q pathQueue) Less(i, j int) bool { return q[i].score < q[j].score }
+func (q pathQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *pathQueue) Push(x interface{}) {
+	n := x.(*pathNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// PathBetween returns the directions to walk through exits to get from one Room to another, taking no more than
+// maxSteps steps. It returns nil if the rooms are the same, or there isn't a path between them.
+//
+// Paths are found with A*, where each step costs one plus the seconds it takes to travel through the room's
+// terrain. Rooms that can't be walked into, such as train tracks and deep water, are avoided.
+func PathBetween(from, to *Room, maxSteps int) []string {
+	if from == nil || to == nil || from == to {
+		return nil
+	}
+
+	best := map[*Room]int{from: 0}
+	queue := &pathQueue{{room: from, score: pathEstimate(from, to)}}
+	searched := 0
+	for queue.Len() > 0 && searched < PathSearchLimit {
+		n := heap.Pop(queue).(*pathNode)
+		if n.room == to {
+			return n.path()
+		}
+		if n.cost > best[n.room] || n.steps >= maxSteps {
+			continue
+		}
+		searched++
+
+		for _, dir := range pathDirections {
+			next := n.room.ConnectedRoom(dir)
+			if next == nil || !walkable(next) {
+				continue
+			}
+
+			cost := n.cost + pathCost(next)
+			if c, ok := best[next]; ok && c <= cost {
+				continue
+			}
+			best[next] = cost
+
+			heap.Push(queue, &pathNode{
+				room:   next,
+				parent: n,
+				dir:    dir,
+				steps:  n.steps + 1,
+				cost:   cost,
+				score:  cost + pathEstimate(next, to),
+			})
+		}
+	}
+
+	return nil
+}
+
+// path returns the directions walked to reach the node.
+func (n *pathNode) path() []string {
+	path := make([]string, n.steps)
+	for ; n.parent != nil; n = n.parent {
+		path[n.steps-1] = n.dir
+	}
+	return path
+}
+
+// pathCost returns the cost of walking into a Room.
+func pathCost(r *Room) int {
+	return 1 + int(r.TravelTime().Seconds())
+}
+
+// pathEstimate returns the estimated cost of walking between two rooms, which is the number of rooms between them
+// on the grid. Rooms in different areas can be connected by exits anywhere, so there is no estimate between them.
+func pathEstimate(from, to *Room) int {
+	if from.ParentArea != to.ParentArea {
+		return 0
+	}
+
+	d := from.DistanceBetween(to)
+	return abs(d.X()) + abs(d.Y()) + abs(d.Z())
+}
+
+// abs returns the absolute value of an int.
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// walkable returns true if a Room can be walked into.
+func walkable(r *Room) bool {
+	if r.Attribute(AttributeType) == "track" {
+		return false
+	}
+
+	if t := TerrainByName(r.Attribute(AttributeTerrain)); t != nil && !t.Passable {
+		return false
+	}
+
+	return true
+}
+
+// DirectionTo returns the direction of an exit leading from one Room directly to another, or an empty string if
+// they aren't connected.
+func DirectionTo(from, to *Room) string {
+	for _, dir := range pathDirections {
+		if from.ConnectedRoom(dir) == to {
+			return dir
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,296 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	PetitionStatusOpen    = "open"
+	PetitionStatusClaimed = "claimed"
+	PetitionStatusClosed  = "closed"
+
+	// PetitionPermission is the permission staff need to handle petitions.
+	PetitionPermission = "CAN_CHAREDIT"
+)
+
+// PetitionManager holds the help tickets that characters have sent to staff.
+type PetitionManager struct {
+	sync.RWMutex
+	dataFile        string
+	UnsafePetitions []*Petition `json:"petitions"`
+	UnsafeNextID    int         `json:"nextId"`
+}
+
+// Petition is a help ticket sent to staff by a character.
+type Petition struct {
+	ID int `json:"id"`
+	// CharacterID is the uuid of the character who sent the petition.
+	CharacterID string              `json:"character"`
+	Text        string              `json:"text"`
+	Status      string              `json:"status"`
+	ClaimedBy   string              `json:"claimedBy"`
+	Created     time.Time           `json:"created"`
+	Responses   []*PetitionResponse `json:"responses"`
+	// Unread is true when staff have responded since the character last viewed the petition.
+	Unread bool `json:"unread"`
+}
+
+// PetitionResponse is a reply to a Petition.
+type PetitionResponse struct {
+	Author string    `json:"author"`
+	Text   string    `json:"text"`
+	Time   time.Time `json:"time"`
+}
+
+// NewPetitionManager creates a new PetitionManager.
+func NewPetitionManager() *PetitionManager {
+	m := &PetitionManager{
+		dataFile: fmt.Sprintf("%s/petitions.json", Armeria.dataPath),
+	}
+
+	m.LoadPetitions()
+
+	return m
+}
+
+// LoadPetitions loads the petitions from disk into memory.
+func (m *PetitionManager) LoadPetitions() {
+	m.Lock()
+	defer m.Unlock()
+
+	petitionsFile, err := os.Open(m.dataFile)
+	defer petitionsFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(petitionsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("petitions loaded",
+		zap.Int("count", len(m.UnsafePetitions)),
+	)
+}
+
+// SavePetitions writes the in-memory petitions to disk.
+func (m *PetitionManager) SavePetitions() {
+	m.RLock()
+	defer m.RUnlock()
+
+	petitionsFile, err := os.Create(m.dataFile)
+	defer petitionsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := petitionsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = petitionsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Create opens a new Petition for a Character and notifies the online staff.
+func (m *PetitionManager) Create(c *Character, text string) *Petition {
+	m.Lock()
+	m.UnsafeNextID++
+	p := &Petition{
+		ID:          m.UnsafeNextID,
+		CharacterID: c.ID(),
+		Text:        text,
+		Status:      PetitionStatusOpen,
+		Created:     time.Now(),
+	}
+	m.UnsafePetitions = append(m.UnsafePetitions, p)
+	m.Unlock()
+
+	Armeria.log.Info("petition created",
+		zap.Int("id", p.ID),
+		zap.String("character", c.Name()),
+	)
+
+	NotifyStaff(fmt.Sprintf(
+		"%s sent petition %s: %s",
+		c.FormattedName(),
+		TextStyle(fmt.Sprintf("#%d", p.ID), WithLinkCmd(fmt.Sprintf("/ticket view %d", p.ID))),
+		TextStylePlayer(text),
+	))
+
+	return p
+}
+
+// Petition returns the Petition with a particular ID, or nil if it doesn't exist.
+func (m *PetitionManager) Petition(id int) *Petition {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, p := range m.UnsafePetitions {
+		if p.ID == id {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Petitions returns the petitions, optionally including the closed ones.
+func (m *PetitionManager) Petitions(includeClosed bool) []*Petition {
+	m.RLock()
+	defer m.RUnlock()
+
+	var petitions []*Petition
+	for _, p := range m.UnsafePetitions {
+		if includeClosed || p.Status != PetitionStatusClosed {
+			petitions = append(petitions, p)
+		}
+	}
+
+	return petitions
+}
+
+// PetitionsBy returns the petitions sent by a Character.
+func (m *PetitionManager) PetitionsBy(c *Character) []*Petition {
+	m.RLock()
+	defer m.RUnlock()
+
+	var petitions []*Petition
+	for _, p := range m.UnsafePetitions {
+		if p.CharacterID == c.ID() {
+			petitions = append(petitions, p)
+		}
+	}
+
+	return petitions
+}
+
+// UnreadCount returns the number of a Character's petitions with responses they haven't seen.
+func (m *PetitionManager) UnreadCount(c *Character) int {
+	count := 0
+	for _, p := range m.PetitionsBy(c) {
+		if m.Unread(p) {
+			count++
+		}
+	}
+	return count
+}
+
+// Unread returns true if staff have responded to a Petition since its character last viewed it.
+func (m *PetitionManager) Unread(p *Petition) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	return p.Unread
+}
+
+// MarkRead marks a Petition's responses as seen by its character.
+func (m *PetitionManager) MarkRead(p *Petition) {
+	m.Lock()
+	defer m.Unlock()
+
+	p.Unread = false
+}
+
+// Claim assigns a Petition to a staff member.
+func (m *PetitionManager) Claim(p *Petition, staff *Character) {
+	m.Lock()
+	p.Status = PetitionStatusClaimed
+	p.ClaimedBy = staff.Name()
+	m.Unlock()
+
+	notifyPetitioner(p, fmt.Sprintf("%s is now handling your petition.", staff.FormattedName()))
+}
+
+// Respond adds a staff response to a Petition and notifies its character.
+func (m *PetitionManager) Respond(p *Petition, staff *Character, text string) {
+	m.Lock()
+	p.Responses = append(p.Responses, &PetitionResponse{
+		Author: staff.Name(),
+		Text:   text,
+		Time:   time.Now(),
+	})
+	p.Unread = true
+	m.Unlock()
+
+	notifyPetitioner(p, fmt.Sprintf("%s responded: %s", staff.FormattedName(), TextStylePlayer(text)))
+}
+
+// Close closes a Petition and notifies its character.
+func (m *PetitionManager) Close(p *Petition, staff *Character) {
+	m.Lock()
+	p.Status = PetitionStatusClosed
+	m.Unlock()
+
+	notifyPetitioner(p, fmt.Sprintf("%s closed your petition.", staff.FormattedName()))
+}
+
+// Character returns the Character who sent the Petition, or nil if they no longer exist.
+func (p *Petition) Character() *Character {
+	if o, rt := Armeria.registry.Get(p.CharacterID); rt == RegistryTypeCharacter {
+		return o.(*Character)
+	}
+
+	return nil
+}
+
+// notifyPetitioner lets the character who sent a Petition know about an update, if they are online.
+func notifyPetitioner(p *Petition, message string) {
+	c := p.Character()
+	if c == nil || !c.Online() {
+		return
+	}
+
+	c.Player().client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s %s",
+			TextStyle(
+				fmt.Sprintf("[Petition #%d]", p.ID),
+				WithBold(),
+				WithLinkCmd(fmt.Sprintf("/petitions %d", p.ID)),
+			),
+			message,
+		),
+		ColorCmdHelp,
+	)
+}
+
+// NotifyStaff sends a message to every online character that can handle petitions.
+func NotifyStaff(message string) {
+	for _, c := range Armeria.characterManager.OnlineCharacters() {
+		if c.HasGlobalPermission(PetitionPermission) {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("%s %s", TextStyle("[Staff]", WithBold()), message),
+				ColorCmdHelp,
+			)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/md5"
 	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -12,7 +13,20 @@ import (
 
 // StoreObjectPicture handles the client-initiated process of storing an object picture.
 func StoreObjectPicture(p *Player, o map[string]interface{}) {
-	// TODO: check permissions
+	objectType := o["objectType"].(string)
+	name := o["name"].(string)
+
+	var allowed bool
+	switch objectType {
+	case "character":
+		allowed = p.Character().HasPermission("CAN_CHAREDIT")
+	case "mob", "item":
+		allowed = p.Character().HasGlobalPermission("CAN_BUILD")
+	}
+	if !allowed {
+		p.client.ShowColorizedText("You don't have permission to change the picture of that object.", ColorError)
+		return
+	}
 
 	k := SaveObjectPictureToDisk(o)
 	if len(k) == 0 {
@@ -20,16 +34,23 @@ func StoreObjectPicture(p *Player, o map[string]interface{}) {
 		return
 	}
 
-	objectType := o["objectType"].(string)
-	name := o["name"].(string)
-
 	var oldKey string
 	var editorData *ObjectEditorData
+	// rejectPicture removes the uploaded picture when it can't be set, unless the object was already using it.
+	rejectPicture := func(err error) {
+		p.client.ShowColorizedText(fmt.Sprintf("The picture could not be set: %s.", err), ColorError)
+		if k != oldKey {
+			DeleteObjectPictureFromDisk(k)
+		}
+	}
 	switch objectType {
 	case "character":
 		c := Armeria.characterManager.CharacterByName(name)
 		oldKey = c.Attribute(AttributePicture)
-		_ = c.SetAttribute(AttributePicture, k)
+		if err := c.SetAttribute(AttributePicture, k); err != nil {
+			rejectPicture(err)
+			return
+		}
 		editorData = c.EditorData()
 		p.client.ShowColorizedText(
 			fmt.Sprintf("A picture has been uploaded and set for character %s.", c.FormattedName()),
@@ -43,7 +64,10 @@ func StoreObjectPicture(p *Player, o map[string]interface{}) {
 	case "mob":
 		m := Armeria.mobManager.MobByName(name)
 		oldKey = m.Attribute(AttributePicture)
-		m.SetAttribute(AttributePicture, k)
+		if err := m.SetAttribute(AttributePicture, k); err != nil {
+			rejectPicture(err)
+			return
+		}
 		editorData = m.EditorData()
 		p.client.ShowColorizedText(
 			fmt.Sprintf("A picture has been uploaded and set for mob %s.", TextStyle(m.Name(), WithBold())),
@@ -57,7 +81,10 @@ func StoreObjectPicture(p *Player, o map[string]interface{}) {
 	case "item":
 		i := Armeria.itemManager.ItemByName(name)
 		oldKey = i.Attribute(AttributePicture)
-		i.SetAttribute(AttributePicture, k)
+		if err := i.SetAttribute(AttributePicture, k); err != nil {
+			rejectPicture(err)
+			return
+		}
 		editorData = i.EditorData()
 		p.client.ShowColorizedText(
 			fmt.Sprintf("A picture has been uploaded and set for item %s.", TextStyle(i.Name(), WithBold())),
@@ -154,6 +181,36 @@ func SaveObjectPictureToDisk(o map[string]interface{}) string {
 	return key
 }
 
+// CopyObjectPictureOnDisk copies an object picture to a new key for another object, so that each object owns its
+// own picture file. It returns the new key, or an empty string if the picture couldn't be copied.
+func CopyObjectPictureOnDisk(k string, objectType string, name string) string {
+	// Keys are formatted as <type>-<name>-<hash>.<ext>, and the copy keeps the same hash and extension.
+	suffix := k[strings.LastIndex(k, "-")+1:]
+	normalizedName := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	key := fmt.Sprintf("%s-%s-%s", objectType, normalizedName, suffix)
+
+	from := fmt.Sprintf("%s/%s", Armeria.objectImagesPath, k)
+	to := fmt.Sprintf("%s/%s", Armeria.objectImagesPath, key)
+	b, err := ioutil.ReadFile(from)
+	if err == nil {
+		err = ioutil.WriteFile(to, b, 0644)
+	}
+	if err != nil {
+		Armeria.log.Error("error copying object picture",
+			zap.String("from", from),
+			zap.String("to", to),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	Armeria.log.Info("copied object picture on disk",
+		zap.String("file", to),
+	)
+
+	return key
+}
+
 // DeleteObjectPictureFromDisk removes an object picture from the disk.
 func DeleteObjectPictureFromDisk(k string) {
 	pictureFile := fmt.Sprintf("%s/%s", Armeria.objectImagesPath, k)
@@ -14,9 +14,16 @@ type Player struct {
 	sync.RWMutex
 	client           ClientActions
 	socket           *websocket.Conn
+	ip               string
 	pumpsInitialized bool
 	sendData         chan *OutgoingDataStructure
 	character        *Character
+	lastCommand      time.Time
+	idleWarned       bool
+	pages            []string
+	page             int
+	latency          time.Duration
+	highLatencyCount int
 }
 
 type IncomingDataStructure struct {
@@ -34,6 +41,7 @@ func (p *Player) readPump() {
 
 	// Set max size of a single message to 512KB
 	p.socket.SetReadLimit(512 * bytefmt.KILOBYTE)
+	p.socket.SetPongHandler(p.handlePong)
 
 	for {
 		messageRead := &IncomingDataStructure{}
@@ -45,8 +53,11 @@ func (p *Player) readPump() {
 			break
 		}
 
+		Armeria.sessionRecorder.RecordInbound(p, messageRead)
+
 		switch messageRead.Type {
 		case "command":
+			p.SetLastCommand(time.Now())
 			cmd := messageRead.Payload.(string)
 			Armeria.commandManager.ProcessCommand(p, cmd[1:], true)
 		case "objectEditorOpen":
@@ -67,6 +78,16 @@ func (p *Player) readPump() {
 			}
 			ii := o.(*ItemInstance)
 			p.client.SetItemTooltipHTML(ii)
+		case "creationSubmit":
+			if cc := Armeria.creationManager.CreationByPlayer(p); cc != nil {
+				cc.Submit(messageRead.Payload.(string))
+			} else {
+				p.client.ShowColorizedText("You aren't creating a character. Use /create to begin.", ColorError)
+			}
+		case "creationResume":
+			if p.Character() == nil {
+				Armeria.creationManager.Resume(p, messageRead.Payload.(string))
+			}
 		case "ping":
 			p.client.SendPong()
 		default:
@@ -78,8 +99,18 @@ func (p *Player) readPump() {
 func (p *Player) writePump() {
 	defer Armeria.playerManager.DisconnectPlayer(p)
 
+	pingTicker := time.NewTicker(LatencyPingInterval)
+	defer pingTicker.Stop()
+
 	for {
 		select {
+		case <-pingTicker.C:
+			if err := p.SendPing(); err != nil {
+				Armeria.log.Debug("error sending ping",
+					zap.Error(err),
+				)
+				return
+			}
 		case message, channelOpen := <-p.sendData:
 			// Has the sendData chan been closed?
 			if !channelOpen {
@@ -129,6 +160,7 @@ func (p *Player) SetupPumps() {
 
 // CallClientAction sends a socket event to call a Vuex action on the webapp.
 func (p *Player) CallClientAction(actionName string, payload interface{}) {
+	Armeria.sessionRecorder.RecordOutbound(p, actionName, payload)
 	p.sendData <- &OutgoingDataStructure{Action: actionName, Payload: payload}
 }
 
@@ -137,6 +169,39 @@ func (p *Player) Connected() {
 
 }
 
+// LastCommand returns the time the Player last sent a command.
+func (p *Player) LastCommand() time.Time {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.lastCommand
+}
+
+// SetLastCommand sets the time the Player last sent a command, and resets the idle warning.
+func (p *Player) SetLastCommand(t time.Time) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.lastCommand = t
+	p.idleWarned = false
+}
+
+// IdleWarned returns true if the Player was already warned about idling.
+func (p *Player) IdleWarned() bool {
+	p.RLock()
+	defer p.RUnlock()
+
+	return p.idleWarned
+}
+
+// SetIdleWarned sets whether the Player was warned about idling.
+func (p *Player) SetIdleWarned(warned bool) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.idleWarned = warned
+}
+
 func (p *Player) AttachCharacter(c *Character) {
 	p.Lock()
 	defer p.Unlock()
@@ -151,6 +216,12 @@ func (p *Player) Character() *Character {
 	return p.character
 }
 
+// IP returns the address the Player is connected from. Behind a trusted reverse proxy, this is the address the
+// proxy forwarded the connection for.
+func (p *Player) IP() string {
+	return p.ip
+}
+
 func (p *Player) PlayerInfoJSON() string {
 	pi := map[string]string{
 		"uuid": p.Character().ID(),
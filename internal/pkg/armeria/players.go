@@ -2,6 +2,7 @@ package armeria
 
 import (
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -21,14 +22,16 @@ func NewPlayerManager() *PlayerManager {
 }
 
 // NewPlayer creates a new Player instance, adds it to memory, and returns Player.
-func (m *PlayerManager) NewPlayer(conn *websocket.Conn) *Player {
+func (m *PlayerManager) NewPlayer(conn *websocket.Conn, ip string) *Player {
 	m.Lock()
 	defer m.Unlock()
 
 	p := &Player{
 		socket:           conn,
+		ip:               ip,
 		pumpsInitialized: false,
 		sendData:         make(chan *OutgoingDataStructure, 256),
+		lastCommand:      time.Now(),
 	}
 
 	p.client = NewClientActions(p)
@@ -36,15 +39,30 @@ func (m *PlayerManager) NewPlayer(conn *websocket.Conn) *Player {
 	m.players[p] = true
 
 	Armeria.log.Info("player connected",
-		zap.String("ip", conn.RemoteAddr().String()),
+		zap.String("ip", ip),
 		zap.Int("players", len(m.players)),
 	)
 
 	return p
 }
 
+// Players returns the players connected to the game.
+func (m *PlayerManager) Players() []*Player {
+	m.RLock()
+	defer m.RUnlock()
+
+	var players []*Player
+	for p := range m.players {
+		players = append(players, p)
+	}
+	return players
+}
+
 // DisconnectPlayer will gracefully remove the parent from the game and terminate the socket connection
 func (m *PlayerManager) DisconnectPlayer(p *Player) {
+	// Let the players waiting in the login queue take the spot that was freed up, once the disconnect is done.
+	defer Armeria.loginQueue.Process()
+
 	m.Lock()
 	defer m.Unlock()
 
@@ -52,6 +70,8 @@ func (m *PlayerManager) DisconnectPlayer(p *Player) {
 		return
 	}
 
+	Armeria.loginQueue.Remove(p)
+
 	if p.character != nil {
 		// Notify unsafeCharacter of logout
 		p.character.LoggedOut()
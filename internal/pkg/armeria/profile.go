@@ -0,0 +1,145 @@
+package armeria
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// CharacterProfile is the public view of a Character, shared on the web when the character has opted in.
+type CharacterProfile struct {
+	Name        string              `json:"name"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Biography   []string            `json:"biography"`
+	Species     string              `json:"species"`
+	Class       string              `json:"class"`
+	Pronouns    string              `json:"pronouns"`
+	Level       int                 `json:"level"`
+	Skills      map[string]int      `json:"skills"`
+	Titles      []string            `json:"titles"`
+	Equipment   []*ProfileEquipment `json:"equipment"`
+	Online      bool                `json:"online"`
+	LastSeen    time.Time           `json:"lastSeen"`
+}
+
+// ProfileEquipment is an item equipped by a Character, as shown on their profile.
+type ProfileEquipment struct {
+	Slot   string `json:"slot"`
+	Name   string `json:"name"`
+	Rarity string `json:"rarity"`
+	Color  string `json:"color"`
+}
+
+var profileTemplate = template.Must(template.New("profile").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<title>{{.Name}} - Armeria</title>
+	<style>
+		body { background: #111; color: #ddd; font-family: sans-serif; max-width: 640px; margin: 40px auto; }
+		h1 { margin-bottom: 0; }
+		.title { color: #bdbdbd; font-style: italic; }
+		.meta { color: #999; margin: 8px 0 24px; }
+		table { border-collapse: collapse; width: 100%; }
+		td, th { text-align: left; padding: 4px 8px; border-bottom: 1px solid #333; }
+	</style>
+</head>
+<body>
+	<h1>{{.Name}}</h1>
+	{{if .Title}}<div class="title">{{.Title}}</div>{{end}}
+	<div class="meta">
+		Level {{.Level}} {{.Species}} {{.Class}} ({{.Pronouns}}) &middot;
+		{{if .Online}}Online now{{else}}Last seen {{.LastSeen.Format "Jan 2, 2006"}}{{end}}
+	</div>
+	{{if .Description}}<p>{{.Description}}</p>{{end}}
+	{{range .Biography}}<p>{{.}}</p>{{end}}
+	<h2>Equipment</h2>
+	{{if .Equipment}}<table>
+		{{range .Equipment}}<tr><td>{{.Slot}}</td><td style="color:#{{.Color}}">{{.Name}}</td><td>{{.Rarity}}</td></tr>{{end}}
+	</table>{{else}}<p>Nothing equipped.</p>{{end}}
+	<h2>Skills</h2>
+	<table>
+		{{range $skill, $level := .Skills}}<tr><td>{{$skill}}</td><td>{{$level}}</td></tr>{{end}}
+	</table>
+	<h2>Titles</h2>
+	{{if .Titles}}<ul>{{range .Titles}}<li>{{.}}</li>{{end}}</ul>{{else}}<p>No titles earned yet.</p>{{end}}
+</body>
+</html>
+`))
+
+// Profile returns the public profile of the Character.
+func (c *Character) Profile() *CharacterProfile {
+	p := &CharacterProfile{
+		Name:        c.Name(),
+		Title:       c.Attribute(AttributeTitle),
+		Description: c.Attribute(AttributeDescription),
+		Biography:   append([]string{}, c.VisibleBiography()...),
+		Species:     c.Attribute(AttributeSpecies),
+		Class:       c.Attribute(AttributeClass),
+		Pronouns:    c.Attribute(AttributePronouns),
+		Level:       c.Level(),
+		Skills:      make(map[string]int),
+		Titles:      append([]string{}, c.Titles()...),
+		Equipment:   make([]*ProfileEquipment, 0),
+		Online:      c.Online() && !c.Invisible(),
+		LastSeen:    c.LastSeen(),
+	}
+
+	for _, skill := range GatheringSkills() {
+		p.Skills[skill] = c.GatheringSkill(skill)
+	}
+
+	for _, slot := range ValidEquipmentSlots() {
+		for _, r := range c.Equipment().AtSlotName(slot) {
+			ii := r.Object.(*ItemInstance)
+			p.Equipment = append(p.Equipment, &ProfileEquipment{
+				Slot:   EquipSlotFormalName(slot),
+				Name:   ii.Name(),
+				Rarity: ii.RarityName(),
+				Color:  ii.RarityColor(),
+			})
+		}
+	}
+
+	return p
+}
+
+// publicProfile returns the profile of the named character, or nil if they don't exist or haven't opted in to
+// sharing it.
+func publicProfile(name string) *CharacterProfile {
+	c := Armeria.characterManager.CharacterByName(name)
+	if c == nil || c.Setting(SettingPublicProfile) != "true" {
+		return nil
+	}
+
+	return c.Profile()
+}
+
+// HandleProfile renders a character's public profile page.
+func HandleProfile(w http.ResponseWriter, r *http.Request) {
+	p := publicProfile(mux.Vars(r)["name"])
+	if p == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = profileTemplate.Execute(w, p)
+}
+
+// HandleProfileJSON returns a character's public profile as JSON, for use by community tools.
+func HandleProfileJSON(w http.ResponseWriter, r *http.Request) {
+	p := publicProfile(mux.Vars(r)["name"])
+	if p == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	b, _ := json.Marshal(p)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,502 @@
+package armeria
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// PromotionManager promotes areas that were built within the staging data directory into the live world. A
+// baseline hash of every promoted object is kept so that objects edited directly on the live world since the
+// last promotion can be detected and are not silently overwritten.
+type PromotionManager struct {
+	sync.RWMutex
+	dataFile        string
+	stagingPath     string
+	UnsafeBaselines map[string]string `json:"baselines"`
+}
+
+// PromotionResult summarizes the changes made to the live world by a promotion.
+type PromotionResult struct {
+	RoomsCreated int
+	RoomsUpdated int
+	RoomsRemoved int
+	Items        int
+	Mobs         int
+	Scripts      int
+	Objects      int
+}
+
+// PromotionConflictError is returned when objects were edited on the live world since they were last promoted.
+type PromotionConflictError struct {
+	Objects []string
+}
+
+// Error returns the error message for a PromotionConflictError.
+func (e *PromotionConflictError) Error() string {
+	return fmt.Sprintf("live objects changed since the last promotion: %s", strings.Join(e.Objects, ", "))
+}
+
+var (
+	ErrStagingNotConfigured = errors.New("no staging path configured")
+	ErrStagingAreaNotFound  = errors.New("area does not exist in staging")
+	ErrStagingRoomOccupied  = errors.New("a room removed in staging is not empty on the live world")
+)
+
+// stagedObject is a mob or item instance placed within a staged room.
+type stagedObject struct {
+	item       *Item
+	mob        *Mob
+	attributes map[string]string
+}
+
+// stagingSnapshot is a read-only copy of the staging data files. Nothing within it is registered.
+type stagingSnapshot struct {
+	World []*Area `json:"world"`
+	Items []*Item `json:"items"`
+	Mobs  []*Mob  `json:"mobs"`
+}
+
+// NewPromotionManager creates a new PromotionManager.
+func NewPromotionManager(stagingPath string) *PromotionManager {
+	m := &PromotionManager{
+		dataFile:    fmt.Sprintf("%s/promotions.json", Armeria.dataPath),
+		stagingPath: stagingPath,
+	}
+
+	m.LoadPromotions()
+
+	return m
+}
+
+// LoadPromotions loads the promotion baselines from disk into memory.
+func (m *PromotionManager) LoadPromotions() {
+	m.Lock()
+	defer m.Unlock()
+
+	promotionsFile, err := os.Open(m.dataFile)
+	defer promotionsFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(promotionsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeBaselines == nil {
+		m.UnsafeBaselines = make(map[string]string)
+	}
+
+	Armeria.log.Info("promotion baselines loaded",
+		zap.Int("count", len(m.UnsafeBaselines)),
+	)
+}
+
+// SavePromotions writes the in-memory promotion baselines to disk.
+func (m *PromotionManager) SavePromotions() {
+	m.RLock()
+	defer m.RUnlock()
+
+	promotionsFile, err := os.Create(m.dataFile)
+	defer promotionsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := promotionsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = promotionsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// loadStagingSnapshot reads the world, items, and mobs data files from the staging directory.
+func (m *PromotionManager) loadStagingSnapshot() (*stagingSnapshot, error) {
+	s := &stagingSnapshot{}
+
+	for _, f := range []string{"world.json", "items.json", "mobs.json"} {
+		b, err := ioutil.ReadFile(fmt.Sprintf("%s/%s", m.stagingPath, f))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = json.Unmarshal(b, s); err != nil {
+			return nil, fmt.Errorf("error decoding staging %s: %s", f, err)
+		}
+	}
+
+	return s, nil
+}
+
+// stagingScript returns the contents of a mob's script file within the staging directory.
+func (m *PromotionManager) stagingScript(mobName string) string {
+	b, err := ioutil.ReadFile(fmt.Sprintf(
+		"%s/scripts/mob-%s.lua",
+		m.stagingPath,
+		strings.ToLower(strings.ReplaceAll(mobName, " ", "-")),
+	))
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// promotionHash returns a stable hash for the given object contents.
+func promotionHash(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return fmt.Sprintf("%x", sha1.Sum(b))
+}
+
+// roomPromotionHash returns the hash of the promotable contents of a Room.
+func roomPromotionHash(r *Room) string {
+	r.RLock()
+	defer r.RUnlock()
+
+	return promotionHash(map[string]interface{}{
+		"attributes": r.UnsafeAttributes,
+		"coords":     fmt.Sprintf("%d,%d,%d", r.Coords.X(), r.Coords.Y(), r.Coords.Z()),
+	})
+}
+
+// conflicted returns true if the live object has changed since it was last promoted, and the staged copy
+// would overwrite that change. This DOES NOT request a lock and IS NOT thread safe.
+func (m *PromotionManager) conflicted(key, live, staged string) bool {
+	base, ok := m.UnsafeBaselines[key]
+	return ok && live != base && live != staged
+}
+
+// PromoteArea copies an Area, and the items, mobs, and mob scripts it references, from the staging directory
+// into the live world. Every change is verified before anything is applied, so a promotion either applies
+// fully or not at all. Conflicts are only ignored when force is true. Rooms new to the live world are given new
+// instances of the objects placed in them on staging, while the contents of rooms already on the live world are
+// left alone.
+func (m *PromotionManager) PromoteArea(name string, force bool) (*PromotionResult, error) {
+	if len(m.stagingPath) == 0 {
+		return nil, ErrStagingNotConfigured
+	}
+
+	s, err := m.loadStagingSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	var sa *Area
+	for _, a := range s.World {
+		if strings.ToLower(a.UnsafeName) == strings.ToLower(name) {
+			sa = a
+			break
+		}
+	}
+	if sa == nil {
+		return nil, ErrStagingAreaNotFound
+	}
+
+	// Collect the items and mobs referenced by objects within the staged area.
+	stagedItems := make(map[string]*Item)
+	stagedMobs := make(map[string]*Mob)
+	stagedContents := make(map[string][]*stagedObject)
+	for _, r := range sa.UnsafeRooms {
+		if r.UnsafeHere == nil {
+			continue
+		}
+		for _, o := range r.UnsafeHere.UnsafeObjects {
+			for _, i := range s.Items {
+				for _, ii := range i.UnsafeInstances {
+					if ii.UUID == o.UUID {
+						stagedItems[i.UnsafeName] = i
+						stagedContents[r.UUID] = append(
+							stagedContents[r.UUID],
+							&stagedObject{item: i, attributes: ii.UnsafeAttributes},
+						)
+					}
+				}
+			}
+			for _, mob := range s.Mobs {
+				for _, mi := range mob.UnsafeInstances {
+					if mi.UUID == o.UUID {
+						stagedMobs[mob.UnsafeName] = mob
+						stagedContents[r.UUID] = append(
+							stagedContents[r.UUID],
+							&stagedObject{mob: mob, attributes: mi.UnsafeAttributes},
+						)
+					}
+				}
+			}
+		}
+	}
+	for _, i := range stagedItems {
+		if spawns := i.UnsafeAttributes[AttributeSpawnMob]; len(spawns) > 0 {
+			for _, mob := range s.Mobs {
+				if strings.ToLower(mob.UnsafeName) == strings.ToLower(spawns) {
+					stagedMobs[mob.UnsafeName] = mob
+				}
+			}
+		}
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	var conflicts []string
+	baselines := make(map[string]string)
+
+	for _, i := range stagedItems {
+		key := "item:" + i.UnsafeName
+		staged := promotionHash(i.UnsafeAttributes)
+		baselines[key] = staged
+		for attr, val := range i.UnsafeAttributes {
+			if err := ValidateAttribute(ObjectTypeItem, attr, val, nil); err != nil {
+				return nil, fmt.Errorf("staged item %s has an invalid %s attribute: %s", i.UnsafeName, attr, err)
+			}
+		}
+		if li := Armeria.itemManager.ItemByName(i.UnsafeName); li != nil {
+			li.RLock()
+			live := promotionHash(li.UnsafeAttributes)
+			li.RUnlock()
+			if m.conflicted(key, live, staged) {
+				conflicts = append(conflicts, key)
+			}
+		}
+	}
+
+	for _, mob := range stagedMobs {
+		key := "mob:" + mob.UnsafeName
+		staged := promotionHash(mob.UnsafeAttributes)
+		baselines[key] = staged
+		for attr, val := range mob.UnsafeAttributes {
+			if err := ValidateAttribute(ObjectTypeMob, attr, val, nil); err != nil {
+				return nil, fmt.Errorf("staged mob %s has an invalid %s attribute: %s", mob.UnsafeName, attr, err)
+			}
+		}
+		scriptKey := "script:" + mob.UnsafeName
+		stagedScript := promotionHash(m.stagingScript(mob.UnsafeName))
+		baselines[scriptKey] = stagedScript
+		if lm := Armeria.mobManager.MobByName(mob.UnsafeName); lm != nil {
+			lm.RLock()
+			live := promotionHash(lm.UnsafeAttributes)
+			lm.RUnlock()
+			if m.conflicted(key, live, staged) {
+				conflicts = append(conflicts, key)
+			}
+			if m.conflicted(scriptKey, promotionHash(lm.Script()), stagedScript) {
+				conflicts = append(conflicts, scriptKey)
+			}
+		}
+	}
+
+	la := Armeria.worldManager.AreaByName(sa.UnsafeName)
+	stagedRooms := make(map[string]*Room)
+	for _, r := range sa.UnsafeRooms {
+		for attr, val := range r.UnsafeAttributes {
+			if err := ValidateAttribute(ObjectTypeRoom, attr, val, nil); err != nil {
+				return nil, fmt.Errorf("staged room %s has an invalid %s attribute: %s", r.UUID, attr, err)
+			}
+		}
+		key := "room:" + r.UUID
+		staged := roomPromotionHash(r)
+		baselines[key] = staged
+		stagedRooms[r.UUID] = r
+		if o, rt := Armeria.registry.Get(r.UUID); rt == RegistryTypeRoom {
+			if m.conflicted(key, roomPromotionHash(o.(*Room)), staged) {
+				conflicts = append(conflicts, key)
+			}
+		}
+	}
+
+	var removed []*Room
+	if la != nil {
+		for _, r := range la.Rooms() {
+			if _, ok := stagedRooms[r.ID()]; ok {
+				continue
+			}
+			if r.Here().Count() > 0 {
+				return nil, ErrStagingRoomOccupied
+			}
+			if m.conflicted("room:"+r.ID(), roomPromotionHash(r), "") {
+				conflicts = append(conflicts, "room:"+r.ID())
+			}
+			removed = append(removed, r)
+		}
+	}
+
+	if len(conflicts) > 0 && !force {
+		sort.Strings(conflicts)
+		return nil, &PromotionConflictError{Objects: conflicts}
+	}
+
+	// Everything has been verified; apply the changes.
+	result := &PromotionResult{}
+
+	for _, i := range stagedItems {
+		li := Armeria.itemManager.ItemByName(i.UnsafeName)
+		if li == nil {
+			li = Armeria.itemManager.CreateItem(i.UnsafeName)
+			Armeria.itemManager.AddItem(li)
+		}
+		// Staged attributes were validated above, and are copied as a whole so that rules between attributes
+		// don't depend on the order they are set in.
+		li.Lock()
+		for attr, val := range i.UnsafeAttributes {
+			li.UnsafeAttributes[attr] = val
+		}
+		li.Unlock()
+		result.Items++
+	}
+
+	for _, mob := range stagedMobs {
+		lm := Armeria.mobManager.MobByName(mob.UnsafeName)
+		if lm == nil {
+			lm = Armeria.mobManager.CreateMob(mob.UnsafeName)
+			Armeria.mobManager.AddMob(lm)
+		}
+		lm.Lock()
+		for attr, val := range mob.UnsafeAttributes {
+			lm.UnsafeAttributes[attr] = val
+		}
+		lm.Unlock()
+		result.Mobs++
+		if script := m.stagingScript(mob.UnsafeName); len(script) > 0 && script != lm.Script() {
+			WriteMobScript(lm, script)
+			result.Scripts++
+		}
+	}
+
+	if la == nil {
+		la = Armeria.worldManager.CreateArea(sa.UnsafeName)
+		// CreateArea places a default room at the origin that may not exist in staging.
+		for _, r := range la.Rooms() {
+			removed = append(removed, r)
+		}
+	}
+	for attr, val := range sa.UnsafeAttributes {
+		_ = la.SetAttribute(attr, val)
+	}
+
+	for _, r := range removed {
+		la.RemoveRoom(r)
+		result.RoomsRemoved++
+	}
+
+	for _, sr := range sa.UnsafeRooms {
+		lr := la.RoomAt(NewCoords(sr.Coords.X(), sr.Coords.Y(), sr.Coords.Z(), 0))
+		if o, rt := Armeria.registry.Get(sr.UUID); rt == RegistryTypeRoom {
+			lr = o.(*Room)
+		}
+
+		if lr == nil {
+			lr = &Room{
+				UUID:             sr.UUID,
+				Coords:           NewCoords(sr.Coords.X(), sr.Coords.Y(), sr.Coords.Z(), 0),
+				UnsafeAttributes: make(map[string]string),
+				UnsafeHere:       NewObjectContainer(0),
+			}
+			la.AddRoom(lr)
+			result.Objects += promoteRoomContents(lr, stagedContents[sr.UUID])
+			result.RoomsCreated++
+		} else {
+			lr.Coords.Set(sr.Coords.X(), sr.Coords.Y(), sr.Coords.Z(), 0)
+			result.RoomsUpdated++
+		}
+
+		lr.Lock()
+		lr.UnsafeAttributes = make(map[string]string)
+		for attr, val := range sr.UnsafeAttributes {
+			lr.UnsafeAttributes[attr] = val
+		}
+		lr.Unlock()
+	}
+
+	for key, hash := range baselines {
+		m.UnsafeBaselines[key] = hash
+	}
+	for _, r := range removed {
+		delete(m.UnsafeBaselines, "room:"+r.ID())
+	}
+
+	for _, c := range la.Characters() {
+		c.Player().client.SyncMap()
+		c.Player().client.SyncRoomTitle()
+	}
+
+	Armeria.log.Info("area promoted from staging",
+		zap.String("area", sa.UnsafeName),
+		zap.Int("conflicts", len(conflicts)),
+	)
+
+	return result, nil
+}
+
+// promoteRoomContents places new instances of the objects staged within a Room into the live Room, copying their
+// instance attributes, and returns how many were placed. Mobs start out with empty inventories, like spawned mobs.
+func promoteRoomContents(r *Room, contents []*stagedObject) int {
+	placed := 0
+	for _, so := range contents {
+		if so.item != nil {
+			li := Armeria.itemManager.ItemByName(so.item.UnsafeName)
+			if li == nil {
+				continue
+			}
+			ii := li.CreateInstance()
+			ii.Lock()
+			for attr, val := range so.attributes {
+				ii.UnsafeAttributes[attr] = val
+			}
+			ii.Unlock()
+			if err := r.Here().Add(ii.ID()); err != nil {
+				li.DeleteInstance(ii)
+				continue
+			}
+		} else {
+			lm := Armeria.mobManager.MobByName(so.mob.UnsafeName)
+			if lm == nil {
+				continue
+			}
+			mi := lm.CreateInstance()
+			mi.Lock()
+			for attr, val := range so.attributes {
+				mi.UnsafeAttributes[attr] = val
+			}
+			mi.Unlock()
+			if err := r.Here().Add(mi.ID()); err != nil {
+				lm.DeleteInstance(mi)
+				continue
+			}
+		}
+		placed++
+	}
+
+	return placed
+}
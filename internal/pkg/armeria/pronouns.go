@@ -0,0 +1,122 @@
+package armeria
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PronounSet is the set of pronouns used to refer to a character or mob.
+type PronounSet struct {
+	Subjective          string
+	Objective           string
+	PossessiveAdjective string
+	PossessiveAbsolute  string
+	Reflexive           string
+	// Plural is true when verbs must agree with a plural subject (ie: "they are" instead of "she is").
+	Plural bool
+}
+
+// Pronouned is an object that can be referred to using pronouns.
+type Pronouned interface {
+	PronounSet() *PronounSet
+}
+
+var (
+	pronounSets = map[string]*PronounSet{
+		"he/him":    {"he", "him", "his", "his", "himself", false},
+		"she/her":   {"she", "her", "her", "hers", "herself", false},
+		"they/them": {"they", "them", "their", "theirs", "themself", true},
+		"it/its":    {"it", "it", "its", "its", "itself", false},
+	}
+
+	pronounTokenRegex = regexp.MustCompile(`{([A-Za-z]+)(?:\|([A-Za-z]+))?}`)
+)
+
+// PronounSetNames returns the names of the available pronoun sets, ie: "they/them".
+func PronounSetNames() []string {
+	var names []string
+	for n := range pronounSets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// pronounSetFor returns the PronounSet for explicitly chosen pronouns, falling back to one based on gender.
+func pronounSetFor(pronouns, gender string) *PronounSet {
+	if ps, ok := pronounSets[pronouns]; ok {
+		return ps
+	}
+
+	switch gender {
+	case "male":
+		return pronounSets["he/him"]
+	case "female":
+		return pronounSets["she/her"]
+	case "thing":
+		return pronounSets["it/its"]
+	}
+
+	return pronounSets["they/them"]
+}
+
+// Pronoun returns a single pronoun from the PronounSet.
+func (ps *PronounSet) Pronoun(pt PronounType) string {
+	switch pt {
+	case PronounSubjective:
+		return ps.Subjective
+	case PronounObjective:
+		return ps.Objective
+	case PronounPossessiveAdjective:
+		return ps.PossessiveAdjective
+	case PronounPossessiveAbsolute:
+		return ps.PossessiveAbsolute
+	case PronounReflexive:
+		return ps.Reflexive
+	}
+
+	return ""
+}
+
+// SubstitutePronouns replaces the pronoun tokens within a message with the pronouns of an object. Tokens are
+// written using they/them: {they}, {them}, {their}, {theirs}, and {themself}. Verbs that must agree with the
+// pronoun are written as {singular|plural}, ie: "{they} {waves|wave}". Capitalized tokens are substituted with
+// capitalized pronouns, and unknown tokens are left as-is.
+func SubstitutePronouns(msg string, o Pronouned) string {
+	ps := o.PronounSet()
+
+	return pronounTokenRegex.ReplaceAllStringFunc(msg, func(token string) string {
+		m := pronounTokenRegex.FindStringSubmatch(token)
+
+		if len(m[2]) > 0 {
+			if ps.Plural {
+				return m[2]
+			}
+			return m[1]
+		}
+
+		var replacement string
+		switch strings.ToLower(m[1]) {
+		case "they":
+			replacement = ps.Subjective
+		case "them":
+			replacement = ps.Objective
+		case "their":
+			replacement = ps.PossessiveAdjective
+		case "theirs":
+			replacement = ps.PossessiveAbsolute
+		case "themself", "themselves":
+			replacement = ps.Reflexive
+		default:
+			return token
+		}
+
+		if m[1][0:1] != strings.ToLower(m[1][0:1]) {
+			replacement = strings.ToUpper(replacement[0:1]) + replacement[1:]
+		}
+
+		return replacement
+	})
+}
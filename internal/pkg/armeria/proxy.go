@@ -0,0 +1,79 @@
+package armeria
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// parseTrustedProxies parses the addresses of trusted reverse proxies, which may be single IPs or CIDR ranges.
+func parseTrustedProxies(addrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, a := range addrs {
+		if !strings.Contains(a, "/") {
+			ip := net.ParseIP(a)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %s", a)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(a)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %s", a)
+		}
+		nets = append(nets, n)
+	}
+
+	return nets, nil
+}
+
+// trustedProxy returns true if the address belongs to one of the configured reverse proxies.
+func trustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range Armeria.trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ClientIP returns the address a request originated from. When the request came through a trusted reverse proxy,
+// the X-Forwarded-For header is walked from the right, skipping any other trusted proxies, so that clients can't
+// spoof their address by sending the header themselves.
+func ClientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !trustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwarded[i])
+		if len(hop) == 0 {
+			continue
+		}
+		ip = hop
+		if !trustedProxy(hop) {
+			break
+		}
+	}
+
+	return ip
+}
@@ -0,0 +1,506 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// QuestObjectiveKill is completed by slaying a mob a number of times.
+	QuestObjectiveKill = "kill"
+	// QuestObjectiveFetch is completed by holding a number of an item, which are handed over when the quest is
+	// completed.
+	QuestObjectiveFetch = "fetch"
+	// QuestObjectiveTalk is completed by interacting with a mob.
+	QuestObjectiveTalk = "talk"
+	// QuestObjectiveScript is only progressed by mob scripts, using q_advance.
+	QuestObjectiveScript = "script"
+
+	// QuestRewardMoney is money given out when a quest is completed.
+	QuestRewardMoney = "money"
+	// QuestRewardTitle is a title from the catalog granted when a quest is completed.
+	QuestRewardTitle = "title"
+	// QuestRewardItem is an item given out when a quest is completed.
+	QuestRewardItem = "item"
+)
+
+// QuestObjectives returns the kinds of objectives a Quest can have.
+func QuestObjectives() []string {
+	return []string{QuestObjectiveKill, QuestObjectiveFetch, QuestObjectiveTalk, QuestObjectiveScript}
+}
+
+// QuestRewards returns the kinds of rewards a Quest can give out.
+func QuestRewards() []string {
+	return []string{QuestRewardMoney, QuestRewardTitle, QuestRewardItem}
+}
+
+// QuestManager holds the catalog of quests defined by builders. Quests that aren't in the catalog can still be
+// started by scripts, but they have no objectives or rewards, and are completed whenever the script decides.
+type QuestManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeQuests []*Quest `json:"quests"`
+}
+
+// Quest is a quest within the catalog.
+type Quest struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Objectives  []*QuestObjective `json:"objectives"`
+	Reward      QuestReward       `json:"reward"`
+}
+
+// QuestObjective is something a character must do, Count times, before a Quest can be completed. Target is the
+// name of the mob or item involved, or a description for script objectives.
+type QuestObjective struct {
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	Count  int    `json:"count"`
+}
+
+// QuestReward is what a character is given when they complete a Quest.
+type QuestReward struct {
+	Money float64 `json:"money,omitempty"`
+	Title string  `json:"title,omitempty"`
+	Item  string  `json:"item,omitempty"`
+}
+
+// QuestInstance is a character's progress through a Quest from the catalog. Progress is keyed by each objective's
+// Key, so it stays with the right objective when builders add or remove others.
+type QuestInstance struct {
+	Started  time.Time      `json:"started"`
+	Progress map[string]int `json:"objectives,omitempty"`
+}
+
+// NewQuestManager creates a new QuestManager.
+func NewQuestManager() *QuestManager {
+	m := &QuestManager{
+		dataFile: fmt.Sprintf("%s/quests.json", Armeria.dataPath),
+	}
+
+	m.LoadQuests()
+
+	return m
+}
+
+// LoadQuests loads the quest catalog from disk into memory.
+func (m *QuestManager) LoadQuests() {
+	m.Lock()
+	defer m.Unlock()
+
+	questsFile, err := os.Open(m.dataFile)
+	defer questsFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(questsFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("quests loaded",
+		zap.Int("count", len(m.UnsafeQuests)),
+	)
+}
+
+// SaveQuests writes the in-memory quest catalog to disk.
+func (m *QuestManager) SaveQuests() {
+	m.RLock()
+	defer m.RUnlock()
+
+	questsFile, err := os.Create(m.dataFile)
+	defer questsFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := questsFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = questsFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Quests returns every quest within the catalog.
+func (m *QuestManager) Quests() []*Quest {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeQuests
+}
+
+// QuestByName returns the matching Quest, by name, or nil if it isn't in the catalog.
+func (m *QuestManager) QuestByName(name string) *Quest {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, q := range m.UnsafeQuests {
+		if q.Name == strings.ToLower(name) {
+			return q
+		}
+	}
+
+	return nil
+}
+
+// AddQuest adds a new Quest to the catalog.
+func (m *QuestManager) AddQuest(q *Quest) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeQuests = append(m.UnsafeQuests, q)
+}
+
+// SetObjective adds an objective to a Quest, or changes the count of an existing objective with the same kind and
+// target. The objective is removed when count is zero.
+func (m *QuestManager) SetObjective(q *Quest, kind, target string, count int) error {
+	if !misc.Contains(QuestObjectives(), kind) {
+		return fmt.Errorf("the objective must be one of: %s", strings.Join(QuestObjectives(), ", "))
+	}
+	if len(target) == 0 {
+		return errors.New("the objective needs a target")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	for i, o := range q.Objectives {
+		if o.Kind != kind || strings.ToLower(o.Target) != strings.ToLower(target) {
+			continue
+		}
+		if count <= 0 {
+			q.Objectives = append(q.Objectives[:i], q.Objectives[i+1:]...)
+		} else {
+			o.Count = count
+		}
+		return nil
+	}
+
+	if count <= 0 {
+		return errors.New("the quest doesn't have that objective")
+	}
+	q.Objectives = append(q.Objectives, &QuestObjective{Kind: kind, Target: target, Count: count})
+	return nil
+}
+
+// SetReward sets one of the rewards of a Quest. An empty value removes the reward.
+func (m *QuestManager) SetReward(q *Quest, kind, value string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	switch kind {
+	case QuestRewardMoney:
+		if len(value) == 0 {
+			q.Reward.Money = 0
+			return nil
+		}
+		amount, err := strconv.ParseFloat(value, 64)
+		if err != nil || amount < 0 {
+			return errors.New("the money must be a positive amount")
+		}
+		q.Reward.Money = amount
+	case QuestRewardTitle:
+		if len(value) > 0 && Armeria.titleManager.TitleByName(value) == nil {
+			return errors.New("that title isn't in the catalog")
+		}
+		q.Reward.Title = value
+	case QuestRewardItem:
+		if len(value) > 0 && Armeria.itemManager.ItemByName(value) == nil {
+			return errors.New("that item doesn't exist")
+		}
+		q.Reward.Item = value
+	default:
+		return fmt.Errorf("the reward must be one of: %s", strings.Join(QuestRewards(), ", "))
+	}
+
+	return nil
+}
+
+// Key identifies the objective within its Quest by its kind and target (ie: "kill:cat").
+func (o *QuestObjective) Key() string {
+	return o.Kind + ":" + strings.ToLower(o.Target)
+}
+
+// objectives returns copies of the Quest's objectives, which builders can change while they are in use.
+func (q *Quest) objectives() []*QuestObjective {
+	Armeria.questManager.RLock()
+	defer Armeria.questManager.RUnlock()
+
+	objectives := make([]*QuestObjective, 0, len(q.Objectives))
+	for _, o := range q.Objectives {
+		objective := *o
+		objectives = append(objectives, &objective)
+	}
+	return objectives
+}
+
+// String describes the objective, such as "Slay Cat".
+func (o *QuestObjective) String() string {
+	switch o.Kind {
+	case QuestObjectiveKill:
+		return fmt.Sprintf("Slay %s", o.Target)
+	case QuestObjectiveFetch:
+		return fmt.Sprintf("Collect %s", o.Target)
+	case QuestObjectiveTalk:
+		return fmt.Sprintf("Speak with %s", o.Target)
+	}
+	return o.Target
+}
+
+// String describes the reward, or returns an empty string if there is none.
+func (r QuestReward) String() string {
+	var rewards []string
+	if r.Money > 0 {
+		rewards = append(rewards, misc.Money.FormatMoney(r.Money))
+	}
+	if len(r.Title) > 0 {
+		rewards = append(rewards, fmt.Sprintf("the title %s", r.Title))
+	}
+	if len(r.Item) > 0 {
+		rewards = append(rewards, r.Item)
+	}
+	return strings.Join(rewards, ", ")
+}
+
+// ValidQuestName returns true if a quest name only uses lowercase letters, numbers and dashes.
+func ValidQuestName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// QuestProgress returns the objectives of a Quest the Character is on, along with how far they have got with each.
+func (c *Character) QuestProgress(q *Quest) ([]*QuestObjective, []int) {
+	objectives := q.objectives()
+	progress := make([]int, len(objectives))
+
+	c.RLock()
+	if qi, ok := c.UnsafeQuestProgress[q.Name]; ok {
+		for i, o := range objectives {
+			progress[i] = qi.Progress[o.Key()]
+		}
+	}
+	c.RUnlock()
+
+	for i, o := range objectives {
+		if o.Kind == QuestObjectiveFetch {
+			progress[i] = len(c.itemsNamed(o.Target))
+		}
+		if progress[i] > o.Count {
+			progress[i] = o.Count
+		}
+	}
+	return objectives, progress
+}
+
+// QuestReady returns true if the Character has finished every objective of a quest they are on. Quests that
+// aren't in the catalog are always ready.
+func (c *Character) QuestReady(quest string) bool {
+	q := Armeria.questManager.QuestByName(quest)
+	if q == nil {
+		return true
+	}
+
+	objectives, progress := c.QuestProgress(q)
+	for i, o := range objectives {
+		if progress[i] < o.Count {
+			return false
+		}
+	}
+	return true
+}
+
+// AdvanceQuest progresses an objective of a quest the Character is on, by index, and lets them know. It returns
+// false if they aren't on the quest, the objective doesn't exist or is already finished, or it is a fetch
+// objective, which is progressed by holding the items.
+func (c *Character) AdvanceQuest(quest string, objective, amount int) bool {
+	q := Armeria.questManager.QuestByName(quest)
+	if q == nil {
+		return false
+	}
+
+	objectives := q.objectives()
+	if objective < 0 || objective >= len(objectives) {
+		return false
+	}
+
+	return c.advanceQuestObjective(q, objectives[objective], amount)
+}
+
+// advanceQuestObjective progresses an objective of a quest the Character is on, and lets them know.
+func (c *Character) advanceQuestObjective(q *Quest, o *QuestObjective, amount int) bool {
+	if !c.OnQuest(q.Name) || amount <= 0 || o.Kind == QuestObjectiveFetch {
+		return false
+	}
+
+	c.Lock()
+	if c.UnsafeQuestProgress == nil {
+		c.UnsafeQuestProgress = make(map[string]*QuestInstance)
+	}
+	qi, ok := c.UnsafeQuestProgress[q.Name]
+	if !ok {
+		qi = &QuestInstance{Started: time.Now()}
+		c.UnsafeQuestProgress[q.Name] = qi
+	}
+	if qi.Progress == nil {
+		qi.Progress = make(map[string]int)
+	}
+	key := o.Key()
+	if qi.Progress[key] >= o.Count {
+		c.Unlock()
+		return false
+	}
+	qi.Progress[key] += amount
+	if qi.Progress[key] > o.Count {
+		qi.Progress[key] = o.Count
+	}
+	progress := qi.Progress[key]
+	c.Unlock()
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf("%s: %s (%d/%d)", TextStyle(q.Name, WithBold()), o, progress, o.Count),
+			ColorSuccess,
+		)
+	}
+
+	return true
+}
+
+// advanceQuestObjectives progresses every objective of a kind, and for a target, across the quests the Character
+// is on.
+func (c *Character) advanceQuestObjectives(kind, target string) {
+	for _, name := range c.Quests() {
+		q := Armeria.questManager.QuestByName(name)
+		if q == nil {
+			continue
+		}
+		for _, o := range q.objectives() {
+			if o.Kind == kind && strings.ToLower(o.Target) == strings.ToLower(target) {
+				c.advanceQuestObjective(q, o, 1)
+			}
+		}
+	}
+}
+
+// itemsNamed returns the items in the Character's inventory with a name.
+func (c *Character) itemsNamed(name string) []*ItemInstance {
+	var items []*ItemInstance
+	for _, ii := range c.Inventory().Items() {
+		if strings.ToLower(ii.Name()) == strings.ToLower(name) {
+			items = append(items, ii)
+		}
+	}
+	return items
+}
+
+// takeFetchedItems removes the items collected for the fetch objectives of a Quest from the Character's inventory.
+func (c *Character) takeFetchedItems(q *Quest) {
+	for _, o := range q.objectives() {
+		if o.Kind != QuestObjectiveFetch {
+			continue
+		}
+		for i, ii := range c.itemsNamed(o.Target) {
+			if i >= o.Count {
+				break
+			}
+			c.Inventory().Remove(ii.ID())
+			ii.Delete()
+		}
+	}
+}
+
+// grantQuestReward gives the Character the reward for completing a Quest.
+func (c *Character) grantQuestReward(q *Quest) {
+	var received []string
+
+	if q.Reward.Money > 0 {
+		if err := c.AddMoney(q.Reward.Money, MoneySourceQuests); err != nil {
+			Armeria.log.Error("quest money reward could not be given",
+				zap.String("character", c.Name()),
+				zap.String("quest", q.Name),
+				zap.Error(err),
+			)
+			if c.Online() {
+				c.Player().client.ShowColorizedText(
+					fmt.Sprintf("You couldn't carry the %s you were rewarded with.", misc.Money.FormatMoney(q.Reward.Money)),
+					ColorError,
+				)
+			}
+		} else {
+			received = append(received, c.Colorize(misc.Money.FormatMoney(q.Reward.Money), ColorMoney))
+		}
+	}
+
+	if len(q.Reward.Title) > 0 {
+		if t := Armeria.titleManager.TitleByName(q.Reward.Title); t != nil && c.GrantTitle(t) {
+			received = append(received, fmt.Sprintf("the title %s", TextStyle(t.Name, WithBold())))
+		}
+	}
+
+	if len(q.Reward.Item) > 0 {
+		if item := Armeria.itemManager.ItemByName(q.Reward.Item); item != nil {
+			ii := item.CreateInstance()
+			if err := c.Inventory().Add(ii.ID()); err != nil {
+				item.DeleteInstance(ii)
+				if c.Online() {
+					c.Player().client.ShowColorizedText(
+						fmt.Sprintf("You had no room for the %s you were rewarded with.", item.Name()),
+						ColorError,
+					)
+				}
+			} else {
+				received = append(received, ii.FormattedName())
+			}
+		}
+	}
+
+	if c.Online() {
+		c.Player().client.SyncMoney()
+		c.Player().client.SyncInventory()
+		if len(received) > 0 {
+			c.Player().client.ShowColorizedText(
+				fmt.Sprintf("You were rewarded with %s.", strings.Join(received, ", ")),
+				ColorSuccess,
+			)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package armeria
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Quests returns the names of the quests the Character is currently on.
+func (c *Character) Quests() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	quests := make([]string, len(c.UnsafeQuests))
+	copy(quests, c.UnsafeQuests)
+	return quests
+}
+
+// OnQuest returns true if the Character is currently on a quest.
+func (c *Character) OnQuest(quest string) bool {
+	quest = strings.ToLower(quest)
+	for _, q := range c.Quests() {
+		if q == quest {
+			return true
+		}
+	}
+	return false
+}
+
+// StartQuest adds a quest to the Character's quest log. Quest names are case insensitive. It returns false
+// if they were already on the quest.
+func (c *Character) StartQuest(quest string) bool {
+	quest = strings.ToLower(quest)
+	if len(quest) == 0 || c.OnQuest(quest) {
+		return false
+	}
+
+	c.Lock()
+	c.UnsafeQuests = append(c.UnsafeQuests, quest)
+	if q := Armeria.questManager.QuestByName(quest); q != nil {
+		if c.UnsafeQuestProgress == nil {
+			c.UnsafeQuestProgress = make(map[string]*QuestInstance)
+		}
+		c.UnsafeQuestProgress[quest] = &QuestInstance{
+			Started:  time.Now(),
+			Progress: make(map[string]int),
+		}
+	}
+	c.Unlock()
+
+	// Phased quest items in the room may have just appeared.
+	if c.Online() {
+		c.Player().client.SyncRoomObjects()
+	}
+
+	return true
+}
+
+// CompleteQuest removes a finished quest from the Character's quest log, along with the quest items that were
+// given out for it, and remembers that they completed it. Quests from the catalog can only be completed once every
+// objective is finished: the items collected for them are handed over, and the quest's reward is given out. It
+// returns false if they weren't on the quest, or it wasn't finished.
+func (c *Character) CompleteQuest(quest string) bool {
+	if !c.OnQuest(quest) || !c.QuestReady(quest) {
+		return false
+	}
+
+	q := Armeria.questManager.QuestByName(quest)
+	if q != nil {
+		c.takeFetchedItems(q)
+	}
+
+	if !c.endQuest(quest) {
+		return false
+	}
+
+	if q != nil {
+		c.grantQuestReward(q)
+	}
+
+	if !c.CompletedQuest(quest) {
+		c.Lock()
+		c.UnsafeCompletedQuests = append(c.UnsafeCompletedQuests, strings.ToLower(quest))
+		c.Unlock()
+	}
+
+	return true
+}
+
+// CompletedQuest returns true if the Character has ever completed a quest.
+func (c *Character) CompletedQuest(quest string) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	quest = strings.ToLower(quest)
+	for _, q := range c.UnsafeCompletedQuests {
+		if q == quest {
+			return true
+		}
+	}
+	return false
+}
+
+// AbandonQuest removes a quest from the Character's quest log without finishing it, along with the quest items
+// that were given out for it. It returns false if they weren't on the quest.
+func (c *Character) AbandonQuest(quest string) bool {
+	return c.endQuest(quest)
+}
+
+// endQuest removes a quest from the Character's quest log and cleans up its quest items.
+func (c *Character) endQuest(quest string) bool {
+	quest = strings.ToLower(quest)
+
+	c.Lock()
+	found := false
+	for i, q := range c.UnsafeQuests {
+		if q == quest {
+			c.UnsafeQuests = append(c.UnsafeQuests[:i], c.UnsafeQuests[i+1:]...)
+			delete(c.UnsafeQuestPickups, quest)
+			delete(c.UnsafeQuestProgress, quest)
+			found = true
+			break
+		}
+	}
+	c.Unlock()
+
+	if found {
+		c.removeQuestItems(func(ii *ItemInstance) bool { return ii.Attribute(AttributeQuestItem) == quest })
+		c.clearDelivery(quest)
+		if c.Online() {
+			c.Player().client.SyncRoomObjects()
+		}
+	}
+	return found
+}
+
+// RemoveStaleQuestItems removes the quest items the Character is holding for quests they are no longer on, such as
+// items picked up from someone else's quest.
+func (c *Character) RemoveStaleQuestItems() {
+	c.removeQuestItems(func(ii *ItemInstance) bool { return !c.OnQuest(ii.Attribute(AttributeQuestItem)) })
+}
+
+// removeQuestItems deletes the quest items in the Character's inventory and equipment that match.
+func (c *Character) removeQuestItems(match func(ii *ItemInstance) bool) {
+	removed := 0
+	for _, container := range []*ObjectContainer{c.Inventory(), c.Equipment()} {
+		for _, ii := range container.Items() {
+			if !ii.IsQuestItem() || !match(ii) {
+				continue
+			}
+
+			container.Remove(ii.ID())
+			ii.Delete()
+			removed++
+
+			if c.Online() {
+				c.Player().client.ShowText(fmt.Sprintf("Your %s is no longer needed, and vanishes.", ii.FormattedName()))
+			}
+		}
+	}
+
+	if removed > 0 && c.Online() {
+		c.Player().client.SyncInventory()
+	}
+}
+
+// IsQuestItem returns true if the ItemInstance belongs to a quest, and so can't be sold or traded.
+func (ii *ItemInstance) IsQuestItem() bool {
+	return len(ii.Attribute(AttributeQuestItem)) > 0
+}
+
+// TookPhasedItem returns true if the Character has already taken their copy of a phased quest item in a room.
+func (c *Character) TookPhasedItem(ii *ItemInstance) bool {
+	c.RLock()
+	defer c.RUnlock()
+
+	for _, id := range c.UnsafeQuestPickups[ii.Attribute(AttributeQuestItem)] {
+		if id == ii.ID() {
+			return true
+		}
+	}
+	return false
+}
+
+// PhasedVisibleTo returns true if a Character can see an ItemInstance lying in a room. Quest items in rooms are
+// phased: each character on the quest sees their own copy until they take it, and nobody else sees it at all.
+// Builders always see them.
+func (ii *ItemInstance) PhasedVisibleTo(c *Character) bool {
+	if !ii.IsQuestItem() || c.HasPermission("CAN_BUILD") {
+		return true
+	}
+
+	return c.OnQuest(ii.Attribute(AttributeQuestItem)) && !c.TookPhasedItem(ii)
+}
+
+// TakePhasedItem gives the Character their own copy of a phased quest item in a room, leaving the item in place
+// for everyone else on the quest. It returns the Character's copy.
+func (c *Character) TakePhasedItem(ii *ItemInstance) (*ItemInstance, error) {
+	quest := ii.Attribute(AttributeQuestItem)
+	if !c.OnQuest(quest) {
+		return nil, errors.New("you aren't on the quest for that")
+	}
+	if c.TookPhasedItem(ii) {
+		return nil, errors.New("you already took yours")
+	}
+
+	copied := ii.Parent.CreateInstance()
+	if err := c.Inventory().Add(copied.ID()); err != nil {
+		ii.Parent.DeleteInstance(copied)
+		return nil, errors.New("you have no room in your inventory")
+	}
+
+	c.Lock()
+	if c.UnsafeQuestPickups == nil {
+		c.UnsafeQuestPickups = make(map[string][]string)
+	}
+	c.UnsafeQuestPickups[quest] = append(c.UnsafeQuestPickups[quest], ii.ID())
+	c.Unlock()
+
+	return copied, nil
+}
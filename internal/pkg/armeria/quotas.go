@@ -0,0 +1,225 @@
+package armeria
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// QuotaKind is a kind of object that builders create, and that quotas limit.
+type QuotaKind string
+
+const (
+	QuotaItems QuotaKind = "items"
+	QuotaMobs  QuotaKind = "mobs"
+	QuotaRooms QuotaKind = "rooms"
+)
+
+// QuotaKinds returns every kind of object that quotas limit.
+func QuotaKinds() []QuotaKind {
+	return []QuotaKind{QuotaItems, QuotaMobs, QuotaRooms}
+}
+
+// CreationQuotas limits how many objects builders can create, so that a runaway script or a trigger-happy builder
+// can't balloon the world data. A limit of zero (or one that isn't set) is unlimited.
+type CreationQuotas struct {
+	// Daily is how many objects of each kind a single builder can create each day (in UTC). Characters with the
+	// CAN_SYSOP permission are exempt.
+	Daily map[QuotaKind]int `yaml:"daily"`
+	// Max is the most objects of each kind that the world can hold. Items and mobs count both the definitions and
+	// their instances.
+	Max map[QuotaKind]int `yaml:"max"`
+}
+
+// QuotaManager tracks how many objects each builder created today, and enforces the CreationQuotas. Usage is kept
+// in memory, so it starts over when the server restarts.
+type QuotaManager struct {
+	sync.Mutex
+	quotas *CreationQuotas
+	day    string
+	usage  map[string]map[QuotaKind]int
+}
+
+// NewQuotaManager creates a new QuotaManager that enforces the quotas from the config file.
+func NewQuotaManager(quotas *CreationQuotas) *QuotaManager {
+	if quotas == nil {
+		quotas = &CreationQuotas{}
+	}
+
+	return &QuotaManager{
+		quotas: quotas,
+		usage:  make(map[string]map[QuotaKind]int),
+	}
+}
+
+// DailyLimit returns how many objects of a kind a builder can create each day, or zero if it is unlimited.
+func (m *QuotaManager) DailyLimit(kind QuotaKind) int {
+	return m.quotas.Daily[kind]
+}
+
+// MaxLimit returns how many objects of a kind the world can hold, or zero if it is unlimited.
+func (m *QuotaManager) MaxLimit(kind QuotaKind) int {
+	return m.quotas.Max[kind]
+}
+
+// rollover forgets the usage from previous days. The caller must hold the lock.
+func (m *QuotaManager) rollover() {
+	today := time.Now().UTC().Format("2006-01-02")
+	if m.day != today {
+		m.day = today
+		m.usage = make(map[string]map[QuotaKind]int)
+	}
+}
+
+// Use records that a Character is creating an object of a kind, or returns an error if it would go over their daily
+// quota or the world's limit.
+func (m *QuotaManager) Use(c *Character, kind QuotaKind) error {
+	if max := m.MaxLimit(kind); max > 0 && WorldCount(kind) >= max {
+		Armeria.log.Warn("world object limit reached",
+			zap.String("character", c.Name()),
+			zap.String("kind", string(kind)),
+			zap.Int("max", max),
+		)
+		return fmt.Errorf("the world has reached its limit of %d %s", max, kind)
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.rollover()
+	if m.usage[c.ID()] == nil {
+		m.usage[c.ID()] = make(map[QuotaKind]int)
+	}
+
+	daily := m.DailyLimit(kind)
+	if daily > 0 && m.usage[c.ID()][kind] >= daily && !c.HasGlobalPermission("CAN_SYSOP") {
+		Armeria.log.Warn("builder daily quota reached",
+			zap.String("character", c.Name()),
+			zap.String("kind", string(kind)),
+			zap.Int("daily", daily),
+		)
+		return fmt.Errorf("you've reached your daily quota of %d new %s, which resets at midnight UTC", daily, kind)
+	}
+
+	m.usage[c.ID()][kind]++
+	return nil
+}
+
+// Usage returns how many objects of each kind each builder created today, by character id.
+func (m *QuotaManager) Usage() map[string]map[QuotaKind]int {
+	m.Lock()
+	defer m.Unlock()
+
+	m.rollover()
+	usage := make(map[string]map[QuotaKind]int)
+	for id, kinds := range m.usage {
+		usage[id] = make(map[QuotaKind]int)
+		for k, n := range kinds {
+			usage[id][k] = n
+		}
+	}
+	return usage
+}
+
+// WorldCount returns how many objects of a kind the world holds, as counted against the world's limit.
+func WorldCount(kind QuotaKind) int {
+	count := 0
+	switch kind {
+	case QuotaItems:
+		for _, i := range Armeria.itemManager.Items() {
+			count += 1 + len(i.Instances())
+		}
+	case QuotaMobs:
+		for _, m := range Armeria.mobManager.Mobs() {
+			count += 1 + len(m.Instances())
+		}
+	case QuotaRooms:
+		for _, a := range Armeria.worldManager.Areas() {
+			count += len(a.Rooms())
+		}
+	}
+	return count
+}
+
+// quotaLimitString returns a quota limit for display.
+func quotaLimitString(limit int) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return strconv.Itoa(limit)
+}
+
+// quotaSummary describes the world's limits, and how much of their daily quotas builders have used today.
+func quotaSummary() string {
+	m := Armeria.quotaManager
+
+	limits := []string{TableRow(
+		TableCell{content: "Kind", header: true},
+		TableCell{content: "In World", header: true},
+		TableCell{content: "World Limit", header: true},
+		TableCell{content: "Daily Quota", header: true},
+	)}
+	for _, kind := range QuotaKinds() {
+		limits = append(limits, TableRow(
+			TableCell{content: TextCapitalization(string(kind))},
+			TableCell{content: strconv.Itoa(WorldCount(kind))},
+			TableCell{content: quotaLimitString(m.MaxLimit(kind))},
+			TableCell{content: quotaLimitString(m.DailyLimit(kind))},
+		))
+	}
+
+	sections := []string{TextTable(limits...)}
+
+	var builders []string
+	usage := m.Usage()
+	for id := range usage {
+		builders = append(builders, id)
+	}
+	if len(builders) == 0 {
+		return sections[0] + "\nNo builders have created anything today."
+	}
+
+	names := make(map[string]string)
+	for _, id := range builders {
+		names[id] = id
+		if c := Armeria.characterManager.CharacterById(id); c != nil {
+			names[id] = c.Name()
+		}
+	}
+	sort.Slice(builders, func(i, j int) bool {
+		return names[builders[i]] < names[builders[j]]
+	})
+
+	header := []TableCell{{content: "Builder", header: true}}
+	for _, kind := range QuotaKinds() {
+		header = append(header, TableCell{content: TextCapitalization(string(kind)) + " Today", header: true})
+	}
+	rows := []string{TableRow(header...)}
+	for _, id := range builders {
+		cells := []TableCell{{content: TextStyle(names[id], WithBold())}}
+		for _, kind := range QuotaKinds() {
+			used := strconv.Itoa(usage[id][kind])
+			if daily := m.DailyLimit(kind); daily > 0 {
+				used = fmt.Sprintf("%s / %d", used, daily)
+			}
+			cells = append(cells, TableCell{content: used})
+		}
+		rows = append(rows, TableRow(cells...))
+	}
+
+	return sections[0] + "\n" + TextTable(rows...)
+}
+
+// UseQuota returns true if the Character can create an object of a kind, and counts it against their daily quota.
+// Otherwise, the player is told which quota they reached.
+func (ctx *CommandContext) UseQuota(kind QuotaKind) bool {
+	if err := Armeria.quotaManager.Use(ctx.Character, kind); err != nil {
+		ctx.Player.client.ShowColorizedText(TextCapitalization(err.Error())+".", ColorError)
+		return false
+	}
+	return true
+}
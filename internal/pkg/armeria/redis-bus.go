@@ -0,0 +1,231 @@
+package armeria
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RedisBusTimeout is how long connecting or publishing to Redis can take before giving up.
+	RedisBusTimeout = 2 * time.Second
+	// RedisBusQueueSize is how many messages can wait to be published before new ones are dropped.
+	RedisBusQueueSize = 256
+)
+
+// RedisBus is a MessageBus backed by Redis pub/sub. It speaks just enough of the Redis protocol to publish and
+// subscribe, and reconnects subscriptions when the connection drops. Messages are published in the background, so
+// that a stalled Redis server can't hold up the game.
+type RedisBus struct {
+	addr   string
+	conn   net.Conn
+	reader *bufio.Reader
+	queue  chan redisMessage
+}
+
+// redisMessage is a message waiting to be published.
+type redisMessage struct {
+	subject string
+	data    []byte
+}
+
+// NewRedisBus connects to the Redis server at addr.
+func NewRedisBus(addr string) (*RedisBus, error) {
+	b := &RedisBus{
+		addr:  addr,
+		queue: make(chan redisMessage, RedisBusQueueSize),
+	}
+	if err := b.connect(); err != nil {
+		return nil, err
+	}
+
+	go b.publishQueued()
+
+	return b, nil
+}
+
+// connect opens the connection used for publishing. It is only called before the RedisBus is shared, or from the
+// publishing goroutine.
+func (b *RedisBus) connect() error {
+	conn, err := net.DialTimeout("tcp", b.addr, RedisBusTimeout)
+	if err != nil {
+		return err
+	}
+
+	b.conn = conn
+	b.reader = bufio.NewReader(conn)
+	return nil
+}
+
+// Publish queues data to be sent to every subscriber of the subject. An error is returned if the queue is full,
+// which happens when Redis has stopped responding.
+func (b *RedisBus) Publish(subject string, data []byte) error {
+	select {
+	case b.queue <- redisMessage{subject: subject, data: data}:
+		return nil
+	default:
+		return errors.New("the redis publish queue is full")
+	}
+}
+
+// publishQueued sends the queued messages to Redis, in order, for as long as the server runs.
+func (b *RedisBus) publishQueued() {
+	for msg := range b.queue {
+		if err := b.publish(msg); err != nil {
+			Armeria.log.Error("failed to publish to redis",
+				zap.String("subject", msg.subject),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// publish sends a message to Redis, reconnecting first if the last attempt failed.
+func (b *RedisBus) publish(msg redisMessage) error {
+	if b.conn == nil {
+		if err := b.connect(); err != nil {
+			return err
+		}
+	}
+
+	err := b.conn.SetDeadline(time.Now().Add(RedisBusTimeout))
+	if err == nil {
+		err = writeRedisCommand(b.conn, "PUBLISH", msg.subject, string(msg.data))
+	}
+	if err == nil {
+		_, err = readRedisValue(b.reader)
+	}
+	if err != nil {
+		b.conn.Close()
+		b.conn = nil
+	}
+
+	return err
+}
+
+// Subscribe calls fn with every message published to the subject, on its own connection.
+func (b *RedisBus) Subscribe(subject string, fn func(data []byte)) error {
+	// The subscription is made before returning, so that messages published right after aren't missed.
+	conn, err := b.subscribe(subject)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			err := b.listen(conn, fn)
+			Armeria.log.Error("lost redis subscription",
+				zap.String("subject", subject),
+				zap.Error(err),
+			)
+
+			for {
+				time.Sleep(5 * time.Second)
+				if conn, err = b.subscribe(subject); err == nil {
+					break
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// subscribe opens a new connection that is subscribed to the subject.
+func (b *RedisBus) subscribe(subject string) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", b.addr, RedisBusTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeRedisCommand(conn, "SUBSCRIBE", subject); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// listen calls fn with the data of every message received on a subscribed connection, until it fails.
+func (b *RedisBus) listen(conn net.Conn, fn func(data []byte)) error {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		v, err := readRedisValue(r)
+		if err != nil {
+			return err
+		}
+
+		parts, ok := v.([]interface{})
+		if !ok || len(parts) != 3 || parts[0] != "message" {
+			continue
+		}
+		if data, ok := parts[2].(string); ok {
+			fn([]byte(data))
+		}
+	}
+}
+
+// writeRedisCommand writes a command to a Redis connection as an array of bulk strings.
+func writeRedisCommand(w io.Writer, args ...string) error {
+	cmd := fmt.Sprintf("*%d\r\n", len(args))
+	for _, a := range args {
+		cmd += fmt.Sprintf("$%d\r\n%s\r\n", len(a), a)
+	}
+
+	_, err := io.WriteString(w, cmd)
+	return err
+}
+
+// readRedisValue reads a single reply from a Redis connection. Bulk and simple strings are returned as strings,
+// integers as int64, and arrays as []interface{}.
+func readRedisValue(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 {
+		return nil, errors.New("malformed redis reply")
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, errors.New(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, n)
+		for i := range values {
+			if values[i], err = readRedisValue(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("unknown redis reply type %q", line[0])
+}
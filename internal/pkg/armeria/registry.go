@@ -104,3 +104,24 @@ func (r *Registry) GetObjectContainer(ouuid string) *ObjectContainer {
 
 	return r.containerEntries[ouuid]
 }
+
+// GetArea returns the Area that an object is within. Objects held by a mob are within the mob's Area. Nil is
+// returned for objects that aren't within the game world (ie: in a character's inventory).
+func (r *Registry) GetArea(uuid string) *Area {
+	if o, rt := r.Get(uuid); rt == RegistryTypeRoom {
+		return o.(*Room).ParentArea
+	}
+
+	oc := r.GetObjectContainer(uuid)
+	if oc == nil {
+		return nil
+	}
+
+	if rm := oc.ParentRoom(); rm != nil {
+		return rm.ParentArea
+	} else if mi := oc.ParentMobInstance(); mi != nil {
+		return r.GetArea(mi.ID())
+	}
+
+	return nil
+}
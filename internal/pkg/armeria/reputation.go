@@ -0,0 +1,56 @@
+package armeria
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxReputation is the highest standing a Character can have with a faction. The lowest is its negative.
+	MaxReputation = 1000
+)
+
+// Reputation returns the Character's standing with a faction.
+func (c *Character) Reputation(faction string) int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeReputation[strings.ToLower(faction)]
+}
+
+// AdjustReputation raises or lowers the Character's standing with a faction, within MaxReputation either way. It
+// returns the new standing.
+func (c *Character) AdjustReputation(faction string, amount int) int {
+	faction = strings.ToLower(faction)
+
+	c.Lock()
+	defer c.Unlock()
+
+	rep := c.UnsafeReputation[faction] + amount
+	if rep > MaxReputation {
+		rep = MaxReputation
+	} else if rep < -MaxReputation {
+		rep = -MaxReputation
+	}
+
+	if c.UnsafeReputation == nil {
+		c.UnsafeReputation = make(map[string]int)
+	}
+	c.UnsafeReputation[faction] = rep
+
+	return rep
+}
+
+// Factions returns the names of the factions the Character has a standing with, in alphabetical order.
+func (c *Character) Factions() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	var factions []string
+	for f := range c.UnsafeReputation {
+		factions = append(factions, f)
+	}
+	sort.Strings(factions)
+
+	return factions
+}
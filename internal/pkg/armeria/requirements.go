@@ -0,0 +1,95 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	RequirementReputation = "reputation"
+	RequirementQuest      = "quest"
+	RequirementAttribute  = "attribute"
+)
+
+// Requirement is a condition a Character must meet to see a shop item or conversation option. It is written as
+// colon-separated sections:
+//
+//	reputation:<faction>:<minimum>  standing with a faction is at least the minimum
+//	quest:<name>                    the quest has been completed
+//	attribute:<name>:<value>        the character attribute has the value
+type Requirement struct {
+	Kind  string
+	Name  string
+	Value string
+}
+
+// ParseRequirements parses a comma-separated list of requirements, all of which must be met.
+func ParseRequirements(s string) ([]*Requirement, error) {
+	var reqs []*Requirement
+	if len(strings.TrimSpace(s)) == 0 {
+		return reqs, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		sections := strings.Split(strings.TrimSpace(part), ":")
+		for i := range sections {
+			sections[i] = strings.TrimSpace(sections[i])
+		}
+
+		switch sections[0] {
+		case RequirementReputation:
+			if len(sections) != 3 {
+				return nil, fmt.Errorf("%q must be formatted as reputation:faction:minimum", part)
+			}
+			if _, err := strconv.Atoi(sections[2]); err != nil {
+				return nil, fmt.Errorf("the minimum of %q must be a whole number", part)
+			}
+		case RequirementQuest:
+			if len(sections) != 2 {
+				return nil, fmt.Errorf("%q must be formatted as quest:name", part)
+			}
+			sections = append(sections, "")
+		case RequirementAttribute:
+			if len(sections) != 3 {
+				return nil, fmt.Errorf("%q must be formatted as attribute:name:value", part)
+			}
+		default:
+			return nil, fmt.Errorf("%q is not a reputation, quest or attribute requirement", part)
+		}
+
+		reqs = append(reqs, &Requirement{Kind: sections[0], Name: strings.ToLower(sections[1]), Value: sections[2]})
+	}
+
+	return reqs, nil
+}
+
+// Met returns true if the Character meets the requirement.
+func (r *Requirement) Met(c *Character) bool {
+	switch r.Kind {
+	case RequirementReputation:
+		min, _ := strconv.Atoi(r.Value)
+		return c.Reputation(r.Name) >= min
+	case RequirementQuest:
+		return c.CompletedQuest(r.Name)
+	case RequirementAttribute:
+		return strings.ToLower(c.Attribute(r.Name)) == strings.ToLower(r.Value)
+	}
+	return false
+}
+
+// MeetsRequirements returns true if the Character meets every requirement in a comma-separated list. Invalid
+// requirements are never met, so that a typo hides something rather than revealing it.
+func (c *Character) MeetsRequirements(s string) bool {
+	reqs, err := ParseRequirements(s)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range reqs {
+		if !r.Met(c) {
+			return false
+		}
+	}
+	return true
+}
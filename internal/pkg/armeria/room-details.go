@@ -0,0 +1,58 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RoomDetail is a piece of scenery within a room that can be looked at, without being an item.
+type RoomDetail struct {
+	Keywords    []string
+	Description string
+}
+
+// ParseRoomDetails parses room details formatted as keyword,alias: description; keyword: description.
+func ParseRoomDetails(s string) ([]*RoomDetail, error) {
+	var details []*RoomDetail
+	if len(strings.TrimSpace(s)) == 0 {
+		return details, nil
+	}
+
+	for _, entry := range strings.Split(s, ";") {
+		sections := strings.SplitN(entry, ":", 2)
+		if len(sections) != 2 || len(strings.TrimSpace(sections[1])) == 0 {
+			return nil, fmt.Errorf("%q must be formatted as keyword: description", strings.TrimSpace(entry))
+		}
+
+		d := &RoomDetail{Description: strings.TrimSpace(sections[1])}
+		for _, k := range strings.Split(sections[0], ",") {
+			if k = strings.ToLower(strings.TrimSpace(k)); len(k) > 0 {
+				d.Keywords = append(d.Keywords, k)
+			}
+		}
+		if len(d.Keywords) == 0 {
+			return nil, fmt.Errorf("%q needs at least one keyword", strings.TrimSpace(entry))
+		}
+
+		details = append(details, d)
+	}
+
+	return details, nil
+}
+
+// DetailFor returns the room detail matching a keyword, as seen by a specific Character, or nil if there isn't
+// one. Invalid details are ignored.
+func (r *Room) DetailFor(c *Character, keyword string) *RoomDetail {
+	details, _ := ParseRoomDetails(r.AttributeFor(c, AttributeDetails))
+
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	for _, d := range details {
+		for _, k := range d.Keywords {
+			if k == keyword {
+				return d
+			}
+		}
+	}
+
+	return nil
+}
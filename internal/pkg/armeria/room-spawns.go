@@ -0,0 +1,198 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RoomSpawnMaxCount is the most instances of a mob a single room spawn can keep alive.
+	RoomSpawnMaxCount = 20
+	// RoomSpawnMaxRespawn is the longest a room spawn can wait before replacing a mob, in seconds.
+	RoomSpawnMaxRespawn = 86400
+)
+
+// RoomSpawn is a mob that a room keeps populated. Whenever fewer than Count of its instances are alive, a new one
+// is spawned once Respawn has passed.
+type RoomSpawn struct {
+	Mob     string
+	Count   int
+	Respawn time.Duration
+}
+
+// ParseRoomSpawns parses room spawns formatted as comma-separated mob:count:respawn entries, where respawn is in
+// seconds (ie: "Cat:2:300,Brenda:1:60").
+func ParseRoomSpawns(s string) ([]*RoomSpawn, error) {
+	var spawns []*RoomSpawn
+	if len(strings.TrimSpace(s)) == 0 {
+		return spawns, nil
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(s, ",") {
+		sections := strings.Split(entry, ":")
+		if len(sections) != 3 {
+			return nil, fmt.Errorf("%q must be formatted as mob:count:respawn", strings.TrimSpace(entry))
+		}
+
+		mob := strings.TrimSpace(sections[0])
+		if len(mob) == 0 {
+			return nil, fmt.Errorf("%q needs a mob", strings.TrimSpace(entry))
+		}
+		if seen[strings.ToLower(mob)] {
+			return nil, fmt.Errorf("mob %q is spawned more than once", mob)
+		}
+		seen[strings.ToLower(mob)] = true
+
+		count, err := strconv.Atoi(strings.TrimSpace(sections[1]))
+		if err != nil || count < 1 || count > RoomSpawnMaxCount {
+			return nil, fmt.Errorf("the count of %q must be a whole number from 1 to %d", mob, RoomSpawnMaxCount)
+		}
+
+		respawn, err := strconv.Atoi(strings.TrimSpace(sections[2]))
+		if err != nil || respawn < 0 || respawn > RoomSpawnMaxRespawn {
+			return nil, fmt.Errorf(
+				"the respawn time of %q must be a whole number of seconds from 0 to %d",
+				mob,
+				RoomSpawnMaxRespawn,
+			)
+		}
+
+		spawns = append(spawns, &RoomSpawn{
+			Mob:     mob,
+			Count:   count,
+			Respawn: time.Duration(respawn) * time.Second,
+		})
+	}
+
+	return spawns, nil
+}
+
+// RoomSpawnManager tracks the mobs that room spawns are waiting to respawn.
+type RoomSpawnManager struct {
+	sync.RWMutex
+	unsafePending map[string][]time.Time
+}
+
+// NewRoomSpawnManager returns a new RoomSpawnManager.
+func NewRoomSpawnManager() *RoomSpawnManager {
+	return &RoomSpawnManager{
+		unsafePending: make(map[string][]time.Time),
+	}
+}
+
+// Due returns how many mobs a room spawn should spawn now, given how many of its mobs are missing. A respawn is
+// scheduled for each mob that has gone missing since the last check. Missing mobs are spawned right away the first
+// time a room spawn is checked, so rooms are populated when the server starts.
+func (m *RoomSpawnManager) Due(key string, missing int, respawn time.Duration) int {
+	m.Lock()
+	defer m.Unlock()
+
+	pending, ok := m.unsafePending[key]
+	if !ok {
+		m.unsafePending[key] = []time.Time{}
+		return missing
+	}
+
+	now := time.Now()
+	for len(pending) < missing {
+		pending = append(pending, now.Add(respawn))
+	}
+	// Forget respawns for mobs that came back some other way.
+	if len(pending) > missing {
+		pending = pending[:missing]
+	}
+
+	due := 0
+	for due < len(pending) && !now.Before(pending[due]) {
+		due++
+	}
+	m.unsafePending[key] = pending[due:]
+
+	return due
+}
+
+// InstancesFromRoom returns all MobInstance's spawned by a Room's spawns.
+func (m *Mob) InstancesFromRoom(r *Room) []*MobInstance {
+	m.RLock()
+	defer m.RUnlock()
+
+	matches := make([]*MobInstance, 0)
+	for _, mobInst := range m.UnsafeInstances {
+		if mobInst.MobSpawnerUUID() == r.ID() {
+			matches = append(matches, mobInst)
+		}
+	}
+
+	return matches
+}
+
+// RoomSpawner keeps rooms populated with the mobs from their spawns, replacing mobs that were killed, tamed or
+// removed once their respawn time has passed.
+func RoomSpawner() {
+	for _, a := range Armeria.worldManager.Areas() {
+		if !HostsArea(a) {
+			continue
+		}
+		for _, r := range a.Rooms() {
+			spawns, err := ParseRoomSpawns(r.Attribute(AttributeSpawns))
+			if err != nil {
+				continue
+			}
+
+			for _, s := range spawns {
+				mob := Armeria.mobManager.MobByName(s.Mob)
+				if mob == nil {
+					// Let builders know.
+					Armeria.channels[ChannelBuilders].Broadcast(
+						nil,
+						fmt.Sprintf(
+							"Room %s cannot spawn mob '%s' as it does not match any existing mobs.",
+							r.LocationString(),
+							s.Mob,
+						),
+					)
+					continue
+				}
+
+				key := r.ID() + ":" + strings.ToLower(mob.Name())
+				missing := s.Count - len(mob.InstancesFromRoom(r))
+				if missing < 0 {
+					missing = 0
+				}
+
+				for i := Armeria.roomSpawnManager.Due(key, missing, s.Respawn); i > 0; i-- {
+					mobInst := mob.CreateInstance()
+					mobInst.SetMobSpawnerUUID(r.ID())
+					_ = r.Here().Add(mobInst.ID())
+					announceMobSpawn(r, mobInst)
+				}
+			}
+		}
+	}
+}
+
+// checkRoomSpawnLinks finds room spawns for mobs that don't exist.
+func checkRoomSpawnLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	for _, a := range Armeria.worldManager.Areas() {
+		for _, r := range a.Rooms() {
+			spawns, _ := ParseRoomSpawns(r.Attribute(AttributeSpawns))
+			for _, s := range spawns {
+				if Armeria.mobManager.MobByName(s.Mob) == nil {
+					problems = append(problems, &LinkProblem{
+						Location: fmt.Sprintf("room %s", r.LocationString()),
+						Problem:  fmt.Sprintf("spawns the missing mob %s", s.Mob),
+						Fix:      fmt.Sprintf("/tp %s, then /room set . %s [spawns]", r.LocationString(), AttributeSpawns),
+					})
+				}
+			}
+		}
+	}
+
+	return problems
+}
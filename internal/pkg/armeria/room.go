@@ -4,7 +4,6 @@ import (
 	"armeria/internal/pkg/misc"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
 	"sync"
 
@@ -18,9 +17,11 @@ type Room struct {
 	sync.RWMutex
 	UUID             string            `json:"uuid"`
 	UnsafeAttributes map[string]string `json:"attributes"`
+	UnsafeDraft      map[string]string `json:"draft,omitempty"`
 	UnsafeHere       *ObjectContainer  `json:"here"`
 	Coords           *Coords           `json:"coords"`
 	ParentArea       *Area             `json:"-"`
+	transient        bool
 }
 
 // AdjacentRooms holds all of the Room objects that are adjacent to the current room.
@@ -102,24 +103,39 @@ func (r *Room) Deinit() {
 	Armeria.registry.Unregister(r.ID())
 }
 
-// SetAttribute sets a persistent attribute for the Room.
-func (r *Room) SetAttribute(name string, value string) {
+// SetAttribute sets a persistent attribute for the Room, and only valid attributes can be set.
+func (r *Room) SetAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeRoom, name, value, r.Attribute); err != nil {
+		return err
+	}
+
+	old := r.Attribute(name)
+
 	r.Lock()
-	defer r.Unlock()
 
 	if r.UnsafeAttributes == nil {
 		r.UnsafeAttributes = make(map[string]string)
 	}
 
-	if !misc.Contains(AttributeList(ObjectTypeRoom), name) {
-		log.Fatalf("[area] attempted set-attribute on a room using an invalid attribute: %s", name)
-	}
-
 	r.UnsafeAttributes[name] = value
+	r.Unlock()
+
+	NotifyAttributeObservers(ObjectTypeRoom, r, name, old, r.Attribute(name))
+	return nil
 }
 
 // Attribute retrieves a persistent attribute from the Room.
 func (r *Room) Attribute(name string) string {
+	if v, ok := ComputedAttributeValue(ObjectTypeRoom, r, name); ok {
+		return v
+	}
+
+	if Armeria.seasonManager != nil {
+		if v, ok := Armeria.seasonManager.RoomAttribute(r, name); ok {
+			return v
+		}
+	}
+
 	r.RLock()
 	defer r.RUnlock()
 
@@ -130,6 +146,112 @@ func (r *Room) Attribute(name string) string {
 	return r.UnsafeAttributes[name]
 }
 
+// SetDraftAttribute stages a change to a persistent attribute. The change is only visible to builders until
+// the Room is published.
+func (r *Room) SetDraftAttribute(name string, value string) error {
+	if err := ValidateAttribute(ObjectTypeRoom, name, value, r.DraftAttribute); err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if r.UnsafeDraft == nil {
+		r.UnsafeDraft = make(map[string]string)
+	}
+
+	r.UnsafeDraft[name] = value
+	return nil
+}
+
+// DraftAttribute retrieves a persistent attribute from the Room, including any unpublished changes.
+func (r *Room) DraftAttribute(name string) string {
+	r.RLock()
+	v, ok := r.UnsafeDraft[name]
+	r.RUnlock()
+
+	if !ok {
+		return r.Attribute(name)
+	}
+
+	if len(v) == 0 {
+		return AttributeDefault(ObjectTypeRoom, name)
+	}
+
+	return v
+}
+
+// AttributeFor retrieves a persistent attribute as seen by a specific Character. Builders see unpublished
+// changes, while everyone else sees the published value.
+func (r *Room) AttributeFor(c *Character, name string) string {
+	if c != nil && c.HasPermission("CAN_BUILD") {
+		return r.DraftAttribute(name)
+	}
+
+	return r.Attribute(name)
+}
+
+// Dirty returns true if the Room has unpublished changes.
+func (r *Room) Dirty() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return len(r.UnsafeDraft) > 0
+}
+
+// DraftAttributeNames returns the names of the attributes with unpublished changes.
+func (r *Room) DraftAttributeNames() []string {
+	r.RLock()
+	defer r.RUnlock()
+
+	var names []string
+	for name := range r.UnsafeDraft {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// Publish makes the unpublished changes to the Room visible to everyone. Every change is validated against the
+// draft before any are applied, and each one is only cleared from the draft once it has been applied, so a change
+// that fails leaves the rest unpublished rather than lost.
+func (r *Room) Publish() error {
+	r.RLock()
+	draft := make(map[string]string, len(r.UnsafeDraft))
+	for name, value := range r.UnsafeDraft {
+		draft[name] = value
+	}
+	r.RUnlock()
+
+	for name, value := range draft {
+		if err := ValidateAttribute(ObjectTypeRoom, name, value, r.DraftAttribute); err != nil {
+			return err
+		}
+	}
+
+	for name, value := range draft {
+		if err := r.SetAttribute(name, value); err != nil {
+			return err
+		}
+
+		r.Lock()
+		if v, ok := r.UnsafeDraft[name]; ok && v == value {
+			delete(r.UnsafeDraft, name)
+		}
+		r.Unlock()
+	}
+
+	return nil
+}
+
+// Discard throws away the unpublished changes to the Room.
+func (r *Room) Discard() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.UnsafeDraft = nil
+}
+
 // Here returns all the objects in the room via the ObjectContainer.
 func (r *Room) Here() *ObjectContainer {
 	r.RLock()
@@ -154,10 +276,19 @@ func (r *Room) RoomTargetJSON(char *Character) string {
 
 		rarityColor := ""
 		visible := true
+		if o.Type() == ContainerObjectTypeCharacter {
+			if !o.(*Character).VisibleTo(char) {
+				continue
+			}
+			visible = !o.(*Character).Invisible()
+		}
 		if o.Type() == ContainerObjectTypeItem {
 			if !o.(*ItemInstance).AttributeBool(AttributeVisible) && !char.HasPermission("CAN_BUILD") {
 				continue
 			}
+			if !o.(*ItemInstance).PhasedVisibleTo(char) {
+				continue
+			}
 			rarityColor = o.(*ItemInstance).RarityColor()
 			visible = o.(*ItemInstance).AttributeBool(AttributeVisible)
 		} else if o.Type() == ContainerObjectTypeMob {
@@ -195,7 +326,7 @@ func (r *Room) EditorData() *ObjectEditorData {
 			PropType: AttributeEditorType(ObjectTypeRoom, attrName),
 			Name:     attrName,
 			Group:    AttributeGroup(attrName),
-			Value:    r.Attribute(attrName),
+			Value:    r.DraftAttribute(attrName),
 		})
 	}
 
@@ -203,24 +334,37 @@ func (r *Room) EditorData() *ObjectEditorData {
 
 	return &ObjectEditorData{
 		UUID:       r.ID(),
-		Name:       r.Attribute(AttributeTitle),
+		Name:       r.DraftAttribute(AttributeTitle),
 		ObjectType: "room",
 		Properties: props,
 		TextCoords: tc,
+		Dirty:      r.Dirty(),
 	}
 }
 
 // CharacterEntered is called when the Character is moved to the room (or logged in).
 func (r *Room) CharacterEntered(c *Character, causedByLogin bool) {
 	ca := c.Player().client
+	if r.ParentArea.Wilderness() {
+		// The minimap only shows the wilderness around the character, so it needs to follow them.
+		ca.SyncMap()
+	}
+	c.Explore(r)
 	ca.SyncMapLocation()
 	ca.SyncRoomTitle()
 
+	if r.Transient() {
+		c.SetWildernessLocation(r.LocationString())
+	} else {
+		c.SetWildernessLocation("")
+	}
+
 	for _, char := range r.Here().Characters(true) {
 		char.Player().client.SyncRoomObjects()
 	}
 
 	for _, mi := range r.Here().Mobs() {
+		c.EncounterMob(mi.Parent)
 		go CallMobFunc(
 			c,
 			mi,
@@ -325,7 +469,11 @@ func (r *Room) ConnectedRoom(direction string) *Room {
 
 	loc := NewCoords(x, y, z, 0)
 
-	return r.ParentArea.RoomAt(loc)
+	if rm := r.ParentArea.RoomAt(loc); rm != nil {
+		return rm
+	}
+
+	return Armeria.wildernessManager.RoomAt(r.ParentArea, loc)
 }
 
 // LocationString returns the location of the room within the game world as a string.
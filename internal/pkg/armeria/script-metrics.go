@@ -0,0 +1,126 @@
+package armeria
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScriptFuncStats is the performance of one function within a mob script, since the server started or the script
+// was last saved.
+type ScriptFuncStats struct {
+	Calls     int
+	Errors    int
+	Time      time.Duration
+	LastError string
+}
+
+// Average returns the average execution time of each call.
+func (s ScriptFuncStats) Average() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Time / time.Duration(s.Calls)
+}
+
+// RecordScriptCall records a call to a function within the Mob's script, along with how long it took to run and
+// the error it returned, if any.
+func (m *Mob) RecordScriptCall(funcName string, d time.Duration, err error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.scriptStats == nil {
+		m.scriptStats = make(map[string]*ScriptFuncStats)
+	}
+
+	s, ok := m.scriptStats[funcName]
+	if !ok {
+		s = &ScriptFuncStats{}
+		m.scriptStats[funcName] = s
+	}
+
+	s.Calls++
+	s.Time += d
+	if err != nil {
+		s.Errors++
+		s.LastError = err.Error()
+	}
+}
+
+// ScriptStats returns a copy of the performance of each function that was called within the Mob's script.
+func (m *Mob) ScriptStats() map[string]ScriptFuncStats {
+	m.RLock()
+	defer m.RUnlock()
+
+	stats := make(map[string]ScriptFuncStats, len(m.scriptStats))
+	for f, s := range m.scriptStats {
+		stats[f] = *s
+	}
+	return stats
+}
+
+// ResetScriptStats clears the performance of the Mob's script, so that a changed script starts fresh.
+func (m *Mob) ResetScriptStats() {
+	m.Lock()
+	defer m.Unlock()
+	m.scriptStats = nil
+}
+
+// metricsLabel escapes a label value for the Prometheus text format.
+func metricsLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// HandleMetrics writes the server's performance counters in the Prometheus text format.
+func HandleMetrics(w http.ResponseWriter, r *http.Request) {
+	type sample struct {
+		labels string
+		stats  ScriptFuncStats
+	}
+
+	var samples []sample
+	for _, m := range Armeria.mobManager.Mobs() {
+		for f, s := range m.ScriptStats() {
+			samples = append(samples, sample{
+				labels: fmt.Sprintf(`mob="%s",function="%s"`, metricsLabel(m.Name()), metricsLabel(f)),
+				stats:  s,
+			})
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].labels < samples[j].labels
+	})
+
+	var b strings.Builder
+	for _, metric := range []struct {
+		name  string
+		help  string
+		value func(s ScriptFuncStats) string
+	}{
+		{
+			"armeria_script_calls_total",
+			"Number of times a mob script function was called.",
+			func(s ScriptFuncStats) string { return fmt.Sprintf("%d", s.Calls) },
+		},
+		{
+			"armeria_script_errors_total",
+			"Number of times a mob script function failed.",
+			func(s ScriptFuncStats) string { return fmt.Sprintf("%d", s.Errors) },
+		},
+		{
+			"armeria_script_seconds_total",
+			"Time spent running a mob script function, excluding sleeps.",
+			func(s ScriptFuncStats) string { return fmt.Sprintf("%f", s.Time.Seconds()) },
+		},
+	} {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", metric.name, metric.help, metric.name)
+		for _, s := range samples {
+			fmt.Fprintf(&b, "%s{%s} %s\n", metric.name, s.labels, metric.value(s.stats))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(b.String()))
+}
@@ -5,13 +5,27 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
 	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
 )
 
+// ScriptError is a line-numbered error found while validating a Lua script.
+type ScriptError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// Error returns the error message for a ScriptError, with the line number.
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
 // ReadMobScript returns the script contents for a mob from disk.
 func ReadMobScript(m *Mob) string {
 	return m.Script()
@@ -21,6 +35,31 @@ func ReadMobScript(m *Mob) string {
 func WriteMobScript(m *Mob, script string) {
 	_ = ioutil.WriteFile(m.ScriptFile(), []byte(script), 0644)
 	m.CacheScript()
+	m.ResetScriptStats()
+}
+
+// ValidateScript checks a Lua script for syntax and compilation errors without running it.
+func ValidateScript(script string) []*ScriptError {
+	chunk, err := parse.Parse(strings.NewReader(script), "<script>")
+	if err != nil {
+		if pe, ok := err.(*parse.Error); ok {
+			return []*ScriptError{{
+				Line:    pe.Pos.Line,
+				Column:  pe.Pos.Column,
+				Message: fmt.Sprintf("%s near '%s'", pe.Message, pe.Token),
+			}}
+		}
+		return []*ScriptError{{Message: err.Error()}}
+	}
+
+	if _, err = lua.Compile(chunk, "<script>"); err != nil {
+		if ce, ok := err.(*lua.CompileError); ok {
+			return []*ScriptError{{Line: ce.Line, Message: ce.Message}}
+		}
+		return []*ScriptError{{Message: err.Error()}}
+	}
+
+	return nil
 }
 
 // LuaInvoker returns the Character that invoked the lua function.
@@ -52,7 +91,7 @@ func LuaMobSay(L *lua.LState) int {
 	m := Armeria.mobManager.MobByName(mname)
 	mi := m.Instance(mid)
 
-	normalizedText, textType := TextPunctuation(text)
+	normalizedText, textType := TextPunctuation(TextTemplate(text, LuaInvoker(L)))
 
 	var verb string
 	switch textType {
@@ -65,9 +104,9 @@ func LuaMobSay(L *lua.LState) int {
 	}
 
 	for _, c := range mi.Room().Here().Characters(true) {
-		c.Player().client.ShowColorizedText(
-			fmt.Sprintf("%s %s, \"%s\"", mi.FormattedName(), verb, normalizedText),
-			ColorSay,
+		c.Player().client.ShowChatText(
+			"say",
+			c.Colorize(fmt.Sprintf("%s %s, \"%s\"", mi.FormattedName(), verb, normalizedText), ColorSay),
 		)
 	}
 
@@ -108,6 +147,97 @@ func LuaCharacterAttribute(L *lua.LState) int {
 	return 1
 }
 
+// LuaGiveExperience (c_give_xp) gives experience to a Character, and returns their level afterwards.
+func LuaGiveExperience(L *lua.LState) int {
+	uuid := L.ToString(1)
+	amount := L.ToInt(2)
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	L.Push(lua.LNumber(c.GiveExperience(amount, "")))
+	return 1
+}
+
+// LuaMoney (c_money) returns a Character's money.
+func LuaMoney(L *lua.LState) int {
+	c := Armeria.characterManager.CharacterById(L.ToString(1))
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	L.Push(lua.LNumber(c.Money()))
+	return 1
+}
+
+// LuaAddMoney (c_add_money) rewards a Character with money, and returns their money afterwards.
+func LuaAddMoney(L *lua.LState) int {
+	return luaChangeMoney(L, false)
+}
+
+// LuaDeductMoney (c_deduct_money) charges a Character money, and returns their money afterwards.
+func LuaDeductMoney(L *lua.LState) int {
+	return luaChangeMoney(L, true)
+}
+
+// luaChangeMoney adds or deducts money from the Character given to a Lua function.
+func luaChangeMoney(L *lua.LState, deduct bool) int {
+	uuid := L.ToString(1)
+	amount := float64(L.ToNumber(2))
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	var err error
+	if deduct {
+		err = c.DeductMoney(amount, MoneySourceScripts)
+	} else {
+		err = c.AddMoney(amount, MoneySourceScripts)
+	}
+	if err == ErrInsufficientFunds {
+		L.Push(lua.LNumber(-3))
+		return 1
+	} else if err != nil {
+		L.Push(lua.LNumber(-2))
+		return 1
+	}
+
+	if c.Online() {
+		c.Player().client.SyncMoney()
+	}
+
+	L.Push(lua.LNumber(c.Money()))
+	return 1
+}
+
+// LuaLootRoll (loot_roll) rolls a loot table and gives the loot to a Character, and returns how many items they got.
+func LuaLootRoll(L *lua.LState) int {
+	uuid := L.ToString(1)
+	table := L.ToString(2)
+
+	c := Armeria.characterManager.CharacterById(uuid)
+	if c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	t := Armeria.lootManager.TableByName(table)
+	if t == nil {
+		L.Push(lua.LNumber(-2))
+		return 1
+	}
+
+	L.Push(lua.LNumber(len(c.GiveLoot(t))))
+	return 1
+}
+
 // LuaSetCharacterAttribute (c_set_attr) sets a permanent or temporary Character attribute.
 func LuaSetCharacterAttribute(L *lua.LState) int {
 	uuid := L.ToString(1)
@@ -273,6 +403,7 @@ func LuaEndConvo(L *lua.LState) int {
 func LuaConvoSelect(L *lua.LState) int {
 	displayId := L.ToString(1)
 	displayText := L.ToString(2)
+	requires := L.OptString(3, "")
 
 	if len(displayId) == 0 || len(displayText) == 0 {
 		panic(fmt.Sprintf("must specify both an id and display text"))
@@ -281,10 +412,16 @@ func LuaConvoSelect(L *lua.LState) int {
 	char := LuaInvoker(L)
 	mi := LuaMobInstance(L)
 
-	mi.SetConvoText(displayId, displayText)
+	// Options the invoker doesn't qualify for are never sent to their client.
+	if !char.MeetsRequirements(requires) {
+		return 0
+	}
+
+	mi.SetConvoText(displayId, displayText, requires)
+	option, group := char.OfferConvoOption(mi, displayId, displayText)
 
 	char.Player().client.ShowText(
-		TextStyle(displayText, WithConvoSelection(displayId, mi.ID(), time.Now().Unix())),
+		TextStyle(displayText, WithConvoSelection(displayId, mi.ID(), group, option.Shortcut)),
 	)
 
 	return 0
@@ -323,12 +460,12 @@ func LuaShop(L *lua.LState) int {
 	)}
 	for _, ii := range mi.Inventory().Items() {
 		ledgerEntry := ledger.Contains(ii.Name())
-		if ledgerEntry != nil && ledgerEntry.BuyPrice > 0 {
+		if ledgerEntry != nil && ledgerEntry.BuyPrice > 0 && c.MeetsRequirements(ledgerEntry.Requires) {
 			buyTable = append(buyTable, TableRow(
 				TableCell{content: ii.FormattedName()},
 				TableCell{content: ii.Attribute(AttributeDescription)},
 				TableCell{content: TextStyle(
-					fmt.Sprintf("Buy %s <%s>", ii.Name(), misc.Money.FormatMoney(ledgerEntry.BuyPrice)),
+					fmt.Sprintf("Buy %s <%s>", ii.Name(), misc.Money.FormatMoney(ledger.BuyPrice(ledgerEntry))),
 					WithLinkCmd(fmt.Sprintf("/buy \"%s\" \"%s\"", mi.Name(), ii.Name())),
 				)},
 			))
@@ -347,7 +484,7 @@ func LuaShop(L *lua.LState) int {
 			sellTable = append(sellTable, TableRow(
 				TableCell{content: ii.FormattedName()},
 				TableCell{content: TextStyle(
-					fmt.Sprintf("Sell %s <%s>", ii.Name(), misc.Money.FormatMoney(ledgerEntry.SellPrice)),
+					fmt.Sprintf("Sell %s <%s>", ii.Name(), misc.Money.FormatMoney(ledger.SellPrice(ledgerEntry))),
 					WithLinkCmd(fmt.Sprintf("/sell \"%s\" \"%s\"", mi.Name(), ii.ID())),
 				)},
 			))
@@ -357,9 +494,297 @@ func LuaShop(L *lua.LState) int {
 		c.Player().client.ShowText(TextTable(sellTable...))
 	}
 
+	c.Player().client.SyncShop(mi)
+
+	return 0
+}
+
+// LuaRecordKill (record_kill) credits the invoker with slaying the current mob.
+func LuaRecordKill(L *lua.LState) int {
+	c := LuaInvoker(L)
+	mi := LuaMobInstance(L)
+	if c == nil || mi == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	L.Push(lua.LNumber(c.RecordKill(mi.Parent)))
+	return 1
+}
+
+// LuaSeasonActive (season_active) returns whether a seasonal event is active.
+func LuaSeasonActive(L *lua.LState) int {
+	L.Push(lua.LBool(Armeria.seasonManager.Active(L.ToString(1))))
+	return 1
+}
+
+// LuaGamble (gamble) plays a round of a minigame between the invoker and the mob, which acts as the house.
+func LuaGamble(L *lua.LState) int {
+	game := MinigameByName(L.ToString(1))
+	bet := float64(L.ToNumber(2))
+	c := LuaInvoker(L)
+	mi := LuaMobInstance(L)
+	if game == nil || c == nil || mi == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	payout, err := PlayHouse(c, game, bet, mi.Name())
+	if err != nil {
+		if c.Online() {
+			c.Player().client.ShowColorizedText(fmt.Sprintf("You can't play: %s.", err), ColorError)
+		}
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	L.Push(lua.LNumber(payout))
+	return 1
+}
+
+// LuaFollow (follow) makes the mob follow a character between rooms.
+func LuaFollow(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := Armeria.characterManager.CharacterById(L.ToString(1))
+	if mi == nil || c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	mi.Follow(c)
+
+	L.Push(lua.LNumber(0))
+	return 1
+}
+
+// LuaUnfollow (unfollow) stops the mob from following anyone.
+func LuaUnfollow(L *lua.LState) int {
+	if mi := LuaMobInstance(L); mi != nil {
+		mi.Unfollow()
+	}
+
+	return 0
+}
+
+// LuaReturnHome (return_home) sends the mob walking back to the room with its mob spawner.
+func LuaReturnHome(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	if mi == nil || mi.Home() == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	mi.ReturnHome()
+
+	L.Push(lua.LNumber(0))
+	return 1
+}
+
+// LuaPursue (pursue) makes the mob chase a character through exits when they leave the room.
+func LuaPursue(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := Armeria.characterManager.CharacterById(L.ToString(1))
+	if mi == nil || c == nil || c.Room() != mi.Room() {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	mi.Pursue(c)
+
+	L.Push(lua.LNumber(0))
+	return 1
+}
+
+// LuaAttack (attack) makes the mob attack a character in the same room.
+func LuaAttack(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := Armeria.characterManager.CharacterById(L.ToString(1))
+	if mi == nil || c == nil || !c.Online() || c.Room() != mi.Room() || mi.Owner() == c {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	Armeria.combatManager.Engage(mi, c)
+	announceMobAttack(mi, c)
+
+	L.Push(lua.LNumber(0))
+	return 1
+}
+
+// LuaCallForHelp (call_for_help) alerts nearby mobs of the same faction, which come to pursue the invoker.
+func LuaCallForHelp(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := LuaInvoker(L)
+	if mi == nil || c == nil {
+		L.Push(lua.LNumber(-1))
+		return 1
+	}
+
+	L.Push(lua.LNumber(mi.CallForHelp(c)))
+	return 1
+}
+
+// LuaDie (die) kills the current mob, crediting the invoker with the kill and leaving the mob's corpse behind.
+func LuaDie(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	if mi == nil {
+		return 0
+	}
+
+	mi.Die(LuaInvoker(L))
+	return 0
+}
+
+// LuaQuestStart (q_start) adds a quest to the invoker's quest log.
+func LuaQuestStart(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	L.Push(lua.LBool(c.StartQuest(L.ToString(1))))
+	return 1
+}
+
+// LuaQuestActive (q_active) returns whether the invoker is on a quest.
+func LuaQuestActive(L *lua.LState) int {
+	c := LuaInvoker(L)
+	L.Push(lua.LBool(c != nil && c.OnQuest(L.ToString(1))))
+	return 1
+}
+
+// LuaQuestAdvance (q_advance) progresses an objective of a quest the invoker is on.
+func LuaQuestAdvance(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	amount := 1
+	if L.GetTop() >= 3 {
+		amount = L.ToInt(3)
+	}
+
+	L.Push(lua.LBool(c.AdvanceQuest(L.ToString(1), L.ToInt(2)-1, amount)))
+	return 1
+}
+
+// LuaQuestComplete (q_complete) removes a finished quest from the invoker's quest log, along with its quest items,
+// and gives out its reward.
+func LuaQuestComplete(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	L.Push(lua.LBool(c.CompleteQuest(L.ToString(1))))
+	return 1
+}
+
+// LuaQuestDeliver (q_deliver) gives the invoker a timed objective to deliver an item to a mob.
+func LuaQuestDeliver(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	d := time.Duration(L.ToInt(4)) * time.Second
+	L.Push(lua.LBool(d > 0 && c.StartDelivery(L.ToString(1), L.ToString(2), L.ToString(3), d) == nil))
+	return 1
+}
+
+// LuaQuestDelivered (q_delivered) returns whether the invoker made the delivery for a quest in time.
+func LuaQuestDelivered(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LBool(false))
+		return 1
+	}
+
+	d := c.Delivery(L.ToString(1))
+	L.Push(lua.LBool(d != nil && d.Delivered))
+	return 1
+}
+
+// LuaQuestCompleted (q_completed) returns whether the invoker has ever completed a quest.
+func LuaQuestCompleted(L *lua.LState) int {
+	c := LuaInvoker(L)
+	L.Push(lua.LBool(c != nil && c.CompletedQuest(L.ToString(1))))
+	return 1
+}
+
+// LuaReputationGet (rep_get) returns the invoker's standing with a faction.
+func LuaReputationGet(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LNumber(0))
+		return 1
+	}
+
+	L.Push(lua.LNumber(c.Reputation(L.ToString(1))))
+	return 1
+}
+
+// LuaReputationAdd (rep_add) raises or lowers the invoker's standing with a faction, and returns the new standing.
+func LuaReputationAdd(L *lua.LState) int {
+	c := LuaInvoker(L)
+	if c == nil {
+		L.Push(lua.LNumber(0))
+		return 1
+	}
+
+	L.Push(lua.LNumber(c.AdjustReputation(L.ToString(1), L.ToInt(2))))
+	return 1
+}
+
+// LuaMeets (meets) returns whether the invoker meets a comma-separated list of requirements.
+func LuaMeets(L *lua.LState) int {
+	c := LuaInvoker(L)
+	L.Push(lua.LBool(c != nil && c.MeetsRequirements(L.ToString(1))))
+	return 1
+}
+
+// LuaWorldStateGet (ws_get) returns the value of a world state key.
+func LuaWorldStateGet(L *lua.LState) int {
+	L.Push(lua.LString(Armeria.worldStateManager.Get(L.ToString(1))))
+	return 1
+}
+
+// LuaWorldStateSet (ws_set) sets the value of a world state key.
+func LuaWorldStateSet(L *lua.LState) int {
+	Armeria.worldStateManager.Set(L.ToString(1), L.ToString(2))
+	return 0
+}
+
+// LuaRemember (m_remember) stores something for the mob to remember about the invoker.
+func LuaRemember(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := LuaInvoker(L)
+	if mi == nil || c == nil {
+		return 0
+	}
+
+	mi.Parent.Remember(c, L.ToString(1), L.ToString(2))
 	return 0
 }
 
+// LuaRecall (m_recall) returns something the mob remembers about the invoker.
+func LuaRecall(L *lua.LState) int {
+	mi := LuaMobInstance(L)
+	c := LuaInvoker(L)
+	if mi == nil || c == nil {
+		L.Push(lua.LString(""))
+		return 1
+	}
+
+	L.Push(lua.LString(mi.Parent.Recall(c, L.ToString(1))))
+	return 1
+}
+
 // LuaRoomText (room_text) sends arbitrary text to the room.
 func LuaRoomText(L *lua.LState) int {
 	text := L.ToString(1)
@@ -372,6 +797,7 @@ func LuaRoomText(L *lua.LState) int {
 		return 0
 	}
 
+	text = TextTemplate(text, LuaInvoker(L))
 	for _, c := range mi.Room().Here().Characters(true) {
 		c.Player().client.ShowText(text)
 	}
@@ -381,7 +807,12 @@ func LuaRoomText(L *lua.LState) int {
 
 // CallMobFunc handles executing mob scripts within the Lua environment.
 func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...lua.LValue) {
-	L := lua.NewState()
+	// Mobs don't react to anything while the world is frozen.
+	if Armeria.tickManager.Frozen() {
+		return
+	}
+
+	L := NewScriptState()
 	defer L.Close()
 
 	// Set a max timeout for script execution.
@@ -389,6 +820,8 @@ func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...l
 	defer cancel()
 	L.SetContext(ctx)
 
+	var slept time.Duration
+
 	// Set global variables.
 	L.SetGlobal("invoker_uuid", lua.LString(invoker.ID()))
 	L.SetGlobal("invoker_name", lua.LString(invoker.Name()))
@@ -397,16 +830,50 @@ func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...l
 
 	// Set global functions.
 	L.SetGlobal("say", L.NewFunction(LuaMobSay))
-	L.SetGlobal("sleep", L.NewFunction(LuaSleep))
+	L.SetGlobal("sleep", L.NewFunction(func(L *lua.LState) int {
+		// Time spent sleeping isn't counted towards the script's execution time.
+		start := time.Now()
+		defer func() { slept += time.Since(start) }()
+		return LuaSleep(L)
+	}))
 	L.SetGlobal("start_convo", L.NewFunction(LuaStartConvo))
 	L.SetGlobal("end_convo", L.NewFunction(LuaEndConvo))
 	L.SetGlobal("convo_select", L.NewFunction(LuaConvoSelect))
 	L.SetGlobal("c_attr", L.NewFunction(LuaCharacterAttribute))
 	L.SetGlobal("c_set_attr", L.NewFunction(LuaSetCharacterAttribute))
+	L.SetGlobal("c_give_xp", L.NewFunction(LuaGiveExperience))
+	L.SetGlobal("c_money", L.NewFunction(LuaMoney))
+	L.SetGlobal("c_add_money", L.NewFunction(LuaAddMoney))
+	L.SetGlobal("c_deduct_money", L.NewFunction(LuaDeductMoney))
+	L.SetGlobal("loot_roll", L.NewFunction(LuaLootRoll))
 	L.SetGlobal("i_name", L.NewFunction(LuaItemName))
 	L.SetGlobal("give", L.NewFunction(LuaInventoryGive))
 	L.SetGlobal("room_text", L.NewFunction(LuaRoomText))
 	L.SetGlobal("shop", L.NewFunction(LuaShop))
+	L.SetGlobal("record_kill", L.NewFunction(LuaRecordKill))
+	L.SetGlobal("season_active", L.NewFunction(LuaSeasonActive))
+	L.SetGlobal("gamble", L.NewFunction(LuaGamble))
+	L.SetGlobal("follow", L.NewFunction(LuaFollow))
+	L.SetGlobal("unfollow", L.NewFunction(LuaUnfollow))
+	L.SetGlobal("return_home", L.NewFunction(LuaReturnHome))
+	L.SetGlobal("pursue", L.NewFunction(LuaPursue))
+	L.SetGlobal("attack", L.NewFunction(LuaAttack))
+	L.SetGlobal("call_for_help", L.NewFunction(LuaCallForHelp))
+	L.SetGlobal("die", L.NewFunction(LuaDie))
+	L.SetGlobal("q_start", L.NewFunction(LuaQuestStart))
+	L.SetGlobal("q_active", L.NewFunction(LuaQuestActive))
+	L.SetGlobal("q_advance", L.NewFunction(LuaQuestAdvance))
+	L.SetGlobal("q_complete", L.NewFunction(LuaQuestComplete))
+	L.SetGlobal("q_deliver", L.NewFunction(LuaQuestDeliver))
+	L.SetGlobal("q_delivered", L.NewFunction(LuaQuestDelivered))
+	L.SetGlobal("q_completed", L.NewFunction(LuaQuestCompleted))
+	L.SetGlobal("rep_get", L.NewFunction(LuaReputationGet))
+	L.SetGlobal("rep_add", L.NewFunction(LuaReputationAdd))
+	L.SetGlobal("meets", L.NewFunction(LuaMeets))
+	L.SetGlobal("ws_get", L.NewFunction(LuaWorldStateGet))
+	L.SetGlobal("ws_set", L.NewFunction(LuaWorldStateSet))
+	L.SetGlobal("m_remember", L.NewFunction(LuaRemember))
+	L.SetGlobal("m_recall", L.NewFunction(LuaRecall))
 
 	// Set "room" module.
 	L.PreloadModule("room", func(state *lua.LState) int {
@@ -419,6 +886,7 @@ func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...l
 
 	err := L.DoString(mi.Parent.Script())
 	if err != nil {
+		mi.Parent.RecordScriptCall(funcName, 0, err)
 		Armeria.log.Error("error compiling lua script",
 			zap.String("script", mi.Parent.ScriptFile()),
 			zap.Error(err),
@@ -442,11 +910,13 @@ func CallMobFunc(invoker *Character, mi *MobInstance, funcName string, args ...l
 		return
 	}
 
+	start := time.Now()
 	err = L.CallByParam(lua.P{
 		Fn:      L.GetGlobal(funcName),
 		NRet:    0,
 		Protect: true,
 	}, args...)
+	mi.Parent.RecordScriptCall(funcName, time.Since(start)-slept, err)
 	if err != nil {
 		Armeria.log.Error("error executing function in lua script",
 			zap.String("script", mi.Parent.ScriptFile()),
@@ -0,0 +1,174 @@
+package armeria
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// seasonDateFormat is the format of the dates a Season starts and ends on. Seasons repeat every year.
+const seasonDateFormat = "01-02"
+
+// Season is time-bounded content that is only active between two dates each year (ie: holiday decorations, event
+// mobs, and limited items).
+type Season struct {
+	Name  string `yaml:"name"`
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// Announcement is broadcast to every online character when the season begins.
+	Announcement string `yaml:"announcement"`
+	// Rooms overlays attributes on rooms while the season is active, keyed by room location (ie: "Homeland,0,0,0").
+	Rooms map[string]map[string]string `yaml:"rooms"`
+}
+
+// SeasonManager tracks which seasons are active.
+type SeasonManager struct {
+	sync.RWMutex
+	seasons      []*Season
+	unsafeActive map[string]bool
+}
+
+// NewSeasonManager returns a new SeasonManager for the seasons defined in the config.
+func NewSeasonManager(seasons []*Season) *SeasonManager {
+	for _, s := range seasons {
+		_, startErr := time.Parse(seasonDateFormat, s.Start)
+		_, endErr := time.Parse(seasonDateFormat, s.End)
+		if len(s.Name) == 0 || startErr != nil || endErr != nil {
+			log.Fatalf("invalid season %q: seasons need a name, and start and end dates formatted as MM-DD", s.Name)
+		}
+	}
+
+	m := &SeasonManager{
+		seasons:      seasons,
+		unsafeActive: make(map[string]bool),
+	}
+
+	for _, s := range m.seasons {
+		m.unsafeActive[s.Name] = s.ActiveOn(time.Now())
+	}
+
+	return m
+}
+
+// ActiveOn returns true if the Season is active on a particular date. Seasons that end on an earlier date than they
+// start wrap around the new year.
+func (s *Season) ActiveOn(t time.Time) bool {
+	today := t.Format(seasonDateFormat)
+	if s.Start <= s.End {
+		return today >= s.Start && today <= s.End
+	}
+	return today >= s.Start || today <= s.End
+}
+
+// Seasons returns every Season defined in the config.
+func (m *SeasonManager) Seasons() []*Season {
+	return m.seasons
+}
+
+// Active returns true if the named season is active. Content without a season is always active.
+func (m *SeasonManager) Active(name string) bool {
+	if len(name) == 0 {
+		return true
+	}
+
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.unsafeActive[name]
+}
+
+// RoomAttribute returns the value an active season overlays on a Room's attribute, and whether there is one.
+func (m *SeasonManager) RoomAttribute(r *Room, name string) (string, bool) {
+	if len(m.seasons) == 0 {
+		return "", false
+	}
+
+	loc := r.LocationString()
+	for _, s := range m.seasons {
+		if !m.Active(s.Name) {
+			continue
+		}
+		if v, ok := s.Rooms[loc][name]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Update starts and ends seasons based on today's date. Mobs spawned for a season that ended are removed, and
+// rooms with overlays are refreshed for the characters in them.
+func (m *SeasonManager) Update() {
+	for _, s := range m.seasons {
+		active := s.ActiveOn(time.Now())
+
+		m.Lock()
+		changed := m.unsafeActive[s.Name] != active
+		m.unsafeActive[s.Name] = active
+		m.Unlock()
+
+		if !changed {
+			continue
+		}
+
+		Armeria.log.Info("season changed",
+			zap.String("season", s.Name),
+			zap.Bool("active", active),
+		)
+
+		if active && len(s.Announcement) > 0 {
+			Announce(s.Announcement)
+		}
+
+		if !active {
+			despawnSeasonalMobs(s)
+		}
+
+		for loc := range s.Rooms {
+			if r := Armeria.worldManager.RoomByLocation(loc); r != nil {
+				for _, c := range r.Here().Characters(true) {
+					c.Player().client.SyncRoomTitle()
+				}
+			}
+		}
+	}
+}
+
+// despawnSeasonalMobs removes the mobs that were spawned by the mob spawners of a Season.
+func despawnSeasonalMobs(s *Season) {
+	for _, spawner := range Armeria.itemManager.ItemsByAttribute(AttributeType, ItemTypeMobSpawner) {
+		for _, inst := range spawner.Instances() {
+			if inst.Attribute(AttributeSeason) != s.Name {
+				continue
+			}
+
+			mob := Armeria.mobManager.MobByName(inst.Attribute(AttributeSpawnMob))
+			if mob == nil {
+				continue
+			}
+
+			for _, mi := range mob.InstancesFromSpawner(inst) {
+				r := mi.Room()
+				if r == nil {
+					continue
+				}
+				r.Here().Remove(mi.ID())
+				mi.Delete()
+				for _, c := range r.Here().Characters(true) {
+					c.Player().client.ShowText(
+						fmt.Sprintf("%s vanishes as the %s season comes to an end.", mi.FormattedName(), s.Name),
+					)
+					c.Player().client.SyncRoomObjects()
+				}
+			}
+		}
+	}
+}
+
+// UpdateSeasons starts and ends seasons based on today's date.
+func UpdateSeasons() {
+	Armeria.seasonManager.Update()
+}
@@ -0,0 +1,289 @@
+package armeria
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SessionRecordingLimit is the maximum number of messages kept in a single session recording.
+	SessionRecordingLimit = 10000
+
+	SessionRecordingInbound  = "in"
+	SessionRecordingOutbound = "out"
+)
+
+var (
+	sessionRecordingIDRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+	accessKeyRegex          = regexp.MustCompile(`"accessKey":"[^"]*"`)
+)
+
+// SessionRecorder records every message sent between the server and the characters staff chose to record, so
+// that reported bugs can be replayed exactly as the player experienced them.
+type SessionRecorder struct {
+	sync.RWMutex
+	recordings map[string]*SessionRecording
+}
+
+// SessionRecording is a recording in progress, written to disk as one JSON entry per line.
+type SessionRecording struct {
+	sync.Mutex
+	ID      string
+	Started time.Time
+	file    *os.File
+	entries int
+}
+
+// SessionRecordingEntry is a single message within a session recording.
+type SessionRecordingEntry struct {
+	Time time.Time `json:"time"`
+	// Direction is SessionRecordingInbound for messages from the player, or SessionRecordingOutbound for messages to
+	// them.
+	Direction string `json:"direction"`
+	// Type is the type of an inbound message, or the client action of an outbound one.
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+// SessionRecordingInfo describes a session recording on disk.
+type SessionRecordingInfo struct {
+	ID       string
+	Size     int64
+	Modified time.Time
+}
+
+// NewSessionRecorder creates a new SessionRecorder that isn't recording anyone.
+func NewSessionRecorder() *SessionRecorder {
+	return &SessionRecorder{
+		recordings: make(map[string]*SessionRecording),
+	}
+}
+
+// sessionRecordingsPath returns the directory session recordings are stored in.
+func sessionRecordingsPath() string {
+	return fmt.Sprintf("%s/recordings", Armeria.dataPath)
+}
+
+// Start begins recording a character's session. The recording carries on across logins until it is stopped.
+func (r *SessionRecorder) Start(c *Character) (*SessionRecording, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, recording := r.recordings[c.ID()]; recording {
+		return nil, fmt.Errorf("%s is already being recorded", c.Name())
+	}
+
+	if err := os.MkdirAll(sessionRecordingsPath(), 0755); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	id := fmt.Sprintf("%s-%s", strings.ToLower(c.Name()), now.Format("20060102-150405"))
+	f, err := os.Create(fmt.Sprintf("%s/%s.jsonl", sessionRecordingsPath(), id))
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &SessionRecording{
+		ID:      id,
+		Started: now,
+		file:    f,
+	}
+	r.recordings[c.ID()] = rec
+
+	Armeria.log.Info("session recording started",
+		zap.String("character", c.Name()),
+		zap.String("recording", id),
+	)
+
+	return rec, nil
+}
+
+// Stop stops recording a character's session, and returns the finished recording.
+func (r *SessionRecorder) Stop(c *Character) (*SessionRecording, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	rec, recording := r.recordings[c.ID()]
+	if !recording {
+		return nil, fmt.Errorf("%s isn't being recorded", c.Name())
+	}
+	delete(r.recordings, c.ID())
+
+	rec.Lock()
+	_ = rec.file.Close()
+	rec.Unlock()
+
+	Armeria.log.Info("session recording stopped",
+		zap.String("character", c.Name()),
+		zap.String("recording", rec.ID),
+		zap.Int("entries", rec.entries),
+	)
+
+	return rec, nil
+}
+
+// Recording returns the recording in progress for a character, or nil if they aren't being recorded.
+func (r *SessionRecorder) Recording(c *Character) *SessionRecording {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.recordings[c.ID()]
+}
+
+// RecordInbound records a message the Player sent, if their character is being recorded. Commands are recorded
+// with the values of arguments marked NoLog hidden.
+func (r *SessionRecorder) RecordInbound(p *Player, msg *IncomingDataStructure) {
+	c := p.Character()
+	if c == nil || msg.Type == "ping" {
+		return
+	}
+
+	rec := r.Recording(c)
+	if rec == nil {
+		return
+	}
+
+	var data string
+	if msg.Type == "command" {
+		data = redactCommand(p, fmt.Sprintf("%v", msg.Payload))
+	} else {
+		data = sessionRecordingData(msg.Payload)
+	}
+
+	rec.Record(SessionRecordingInbound, msg.Type, data)
+}
+
+// RecordOutbound records a client action sent to the Player, if their character is being recorded.
+func (r *SessionRecorder) RecordOutbound(p *Player, action string, payload interface{}) {
+	c := p.Character()
+	if c == nil || action == "pong" {
+		return
+	}
+
+	if rec := r.Recording(c); rec != nil {
+		rec.Record(SessionRecordingOutbound, action, sessionRecordingData(payload))
+	}
+}
+
+// Record writes a message to the recording. Messages beyond SessionRecordingLimit are dropped.
+func (rec *SessionRecording) Record(direction, typ, data string) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.entries >= SessionRecordingLimit {
+		return
+	}
+	rec.entries++
+
+	raw, err := json.Marshal(&SessionRecordingEntry{
+		Time:      time.Now(),
+		Direction: direction,
+		Type:      typ,
+		Data:      accessKeyRegex.ReplaceAllString(data, `"accessKey":"***"`),
+	})
+	if err != nil {
+		return
+	}
+
+	if _, err := rec.file.Write(append(raw, '\n')); err != nil {
+		Armeria.log.Error("failed to write session recording",
+			zap.String("recording", rec.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// sessionRecordingData returns a message payload as it is stored within a session recording.
+func sessionRecordingData(payload interface{}) string {
+	switch v := payload.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Sprintf("%v", payload)
+	}
+	return string(raw)
+}
+
+// redactCommand returns a command the Player entered, with the values of arguments marked NoLog hidden.
+func redactCommand(p *Player, command string) string {
+	text := strings.TrimPrefix(command, "/")
+	if len(strings.Fields(text)) == 0 {
+		return command
+	}
+
+	cmd, args, _ := Armeria.commandManager.FindCommand(p, Armeria.commandManager.Commands(), text, []string{})
+	if cmd == nil {
+		return command
+	}
+	return cmd.Text(args)
+}
+
+// SessionRecordings returns the session recordings on disk, newest first.
+func SessionRecordings() []*SessionRecordingInfo {
+	files, err := ioutil.ReadDir(sessionRecordingsPath())
+	if err != nil {
+		return nil
+	}
+
+	var recordings []*SessionRecordingInfo
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".jsonl") {
+			continue
+		}
+		recordings = append(recordings, &SessionRecordingInfo{
+			ID:       strings.TrimSuffix(f.Name(), ".jsonl"),
+			Size:     f.Size(),
+			Modified: f.ModTime(),
+		})
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].Modified.After(recordings[j].Modified)
+	})
+
+	return recordings
+}
+
+// ReadSessionRecording reads the messages within a session recording from disk.
+func ReadSessionRecording(id string) ([]*SessionRecordingEntry, error) {
+	if !sessionRecordingIDRegex.MatchString(id) {
+		return nil, errors.New("there is no recording with that id")
+	}
+
+	b, err := ioutil.ReadFile(fmt.Sprintf("%s/%s.jsonl", sessionRecordingsPath(), id))
+	if os.IsNotExist(err) {
+		return nil, errors.New("there is no recording with that id")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var entries []*SessionRecordingEntry
+	for _, line := range strings.Split(string(b), "\n") {
+		if len(line) == 0 {
+			continue
+		}
+		e := &SessionRecordingEntry{}
+		if err := json.Unmarshal([]byte(line), e); err != nil {
+			return nil, fmt.Errorf("the recording is corrupt: %s", err)
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
@@ -1,10 +1,14 @@
 package armeria
 
 const (
-	SettingBrief       string = "brief"
-	SettingWrap               = "wrap"
-	SettingMaxLines           = "lines"
-	SettingScriptTheme        = "script_theme"
+	SettingBrief          string = "brief"
+	SettingWrap                  = "wrap"
+	SettingMaxLines              = "lines"
+	SettingScriptTheme           = "script_theme"
+	SettingPlainText             = "plain_text"
+	SettingNoFollow              = "no_follow"
+	SettingPublicProfile         = "public_profile"
+	SettingUnfilteredChat        = "unfiltered_chat"
 )
 
 // ValidSettings returns all valid settings for a Character.
@@ -14,6 +18,10 @@ func ValidSettings() []string {
 		SettingWrap,
 		SettingMaxLines,
 		SettingScriptTheme,
+		SettingPlainText,
+		SettingNoFollow,
+		SettingPublicProfile,
+		SettingUnfilteredChat,
 	}
 }
 
@@ -28,6 +36,14 @@ func SettingDesc(name string) string {
 		return "Truncate main display after this many lines."
 	case SettingScriptTheme:
 		return "Theme to use for the mob script editor."
+	case SettingPlainText:
+		return "Strip colors and styling from text, for use with screen readers."
+	case SettingNoFollow:
+		return "Prevent other characters from following you."
+	case SettingPublicProfile:
+		return "Share your character profile on the web at /c/<name>."
+	case SettingUnfilteredChat:
+		return "Show chat as it was written, without masking filtered words."
 	}
 
 	return ""
@@ -44,6 +60,14 @@ func SettingDefault(name string) string {
 		return "100"
 	case SettingScriptTheme:
 		return "one_dark"
+	case SettingPlainText:
+		return "false"
+	case SettingNoFollow:
+		return "false"
+	case SettingPublicProfile:
+		return "false"
+	case SettingUnfilteredChat:
+		return "false"
 	}
 
 	return ""
@@ -58,6 +82,14 @@ func SettingValidationString(name string) string {
 		return "num|min:50|max:500"
 	case SettingScriptTheme:
 		return "in:one_dark,gruvbox,nord_dark"
+	case SettingPlainText:
+		return "bool"
+	case SettingNoFollow:
+		return "bool"
+	case SettingPublicProfile:
+		return "bool"
+	case SettingUnfilteredChat:
+		return "bool"
 	}
 
 	return ""
@@ -0,0 +1,121 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DryRunFlag is the flag that runs a command as a Simulation when it is entered as the last argument.
+	DryRunFlag = "--dry-run"
+)
+
+// Simulation records the changes a command would make when it is run with DryRunFlag, instead of the command
+// making them. Commands that support it are marked with DryRun, and check CommandContext.DryRun before committing
+// anything.
+type Simulation struct {
+	changes []*SimulatedChange
+}
+
+// SimulatedChange is a single change recorded by a Simulation. A change with no Attribute removes the object.
+type SimulatedChange struct {
+	ObjectType ObjectType
+	Object     string
+	Attribute  string
+	OldValue   string
+	NewValue   string
+	Error      error
+}
+
+// NewSimulation creates a new Simulation that hasn't recorded any changes.
+func NewSimulation() *Simulation {
+	return &Simulation{}
+}
+
+// Modify records an attribute that would be modified on an object. A non-nil error means the change would be
+// rejected, such as by attribute validation.
+func (s *Simulation) Modify(ot ObjectType, object, attr, oldValue, newValue string, err error) {
+	s.changes = append(s.changes, &SimulatedChange{
+		ObjectType: ot,
+		Object:     object,
+		Attribute:  attr,
+		OldValue:   oldValue,
+		NewValue:   newValue,
+		Error:      err,
+	})
+}
+
+// Remove records an object that would be removed from the game.
+func (s *Simulation) Remove(ot ObjectType, object string) {
+	s.changes = append(s.changes, &SimulatedChange{
+		ObjectType: ot,
+		Object:     object,
+	})
+}
+
+// Changes returns the changes recorded by the Simulation, in the order they were recorded.
+func (s *Simulation) Changes() []*SimulatedChange {
+	return s.changes
+}
+
+// Summary describes the changes recorded by the Simulation: the objects affected, and how their attributes would
+// be modified.
+func (s *Simulation) Summary() string {
+	objects := make(map[string]bool)
+	modified := 0
+	rows := []string{TableRow(
+		TableCell{content: "Object", header: true},
+		TableCell{content: "Type", header: true},
+		TableCell{content: "Change", header: true},
+	)}
+
+	for _, ch := range s.changes {
+		var change string
+		switch {
+		case ch.Error != nil:
+			change = fmt.Sprintf("skipped: %s", ch.Error)
+		case len(ch.Attribute) == 0:
+			change = "removed"
+			objects[string(ch.ObjectType)+ch.Object] = true
+		default:
+			change = fmt.Sprintf(
+				"%s: %s &rarr; %s",
+				TextStyle(ch.Attribute, WithBold()),
+				TextAttributeValue(ch.OldValue),
+				TextAttributeValue(ch.NewValue),
+			)
+			objects[string(ch.ObjectType)+ch.Object] = true
+			modified++
+		}
+
+		rows = append(rows, TableRow(
+			TableCell{content: ch.Object},
+			TableCell{content: string(ch.ObjectType)},
+			TableCell{content: change},
+		))
+	}
+
+	if len(s.changes) == 0 {
+		return "This was a dry run. Nothing would have changed."
+	}
+
+	return fmt.Sprintf(
+		"This was a dry run, so nothing was changed. %d object(s) would be affected, with %d attribute(s) modified:\n%s",
+		len(objects),
+		modified,
+		TextTable(rows...),
+	)
+}
+
+// DryRun returns true if the command is being run as a Simulation, and must not make any changes.
+func (ctx *CommandContext) DryRun() bool {
+	return ctx.Simulation != nil
+}
+
+// stripDryRunFlag removes DryRunFlag from the end of the sections of a command, and returns true if it was there.
+func stripDryRunFlag(sections []string) ([]string, bool) {
+	if len(sections) > 1 && strings.ToLower(sections[len(sections)-1]) == DryRunFlag {
+		return sections[:len(sections)-1], true
+	}
+	return sections, false
+}
@@ -26,7 +26,7 @@ func ServeWs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	p := Armeria.playerManager.NewPlayer(conn)
+	p := Armeria.playerManager.NewPlayer(conn, ClientIP(r))
 	p.SetupPumps()
 	p.Connected()
 }
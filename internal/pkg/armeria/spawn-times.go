@@ -0,0 +1,65 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SpawnTimes returns the times of day that a mob spawner can be limited to spawning within.
+func SpawnTimes() []string {
+	return []string{"morning", "afternoon", "evening", "night"}
+}
+
+// SpawnTimeActive returns true if a mob spawner can spawn at the current time of day. Spawners without a spawn time
+// spawn at any time of day.
+func SpawnTimeActive(spawner *ItemInstance) bool {
+	times := spawner.Attribute(AttributeSpawnTime)
+	if len(times) == 0 {
+		return true
+	}
+
+	now := TimeOfDay(time.Now())
+	for _, t := range strings.Split(times, ",") {
+		if strings.TrimSpace(t) == now {
+			return true
+		}
+	}
+	return false
+}
+
+// despawnOutOfTimeMobs removes the mobs spawned by a mob spawner once its spawn time has passed, so that
+// night-only mobs don't linger into the morning.
+func despawnOutOfTimeMobs(spawner *ItemInstance, mob *Mob) {
+	now := TimeOfDay(time.Now())
+	for _, mi := range mob.InstancesFromSpawner(spawner) {
+		if mi.Owner() != nil {
+			continue
+		}
+
+		r := mi.Room()
+		if r == nil {
+			continue
+		}
+		r.Here().Remove(mi.ID())
+		mi.Delete()
+		for _, c := range r.Here().Characters(true) {
+			c.Player().client.ShowText(
+				fmt.Sprintf("%s fades away as the %s arrives.", mi.FormattedName(), now),
+			)
+			c.Player().client.SyncRoomObjects()
+		}
+	}
+}
+
+// validateSpawnTimes returns the reasons a spawn time attribute is invalid.
+func validateSpawnTimes(val string) []string {
+	var reasons []string
+	for _, t := range strings.Split(val, ",") {
+		if !misc.Contains(SpawnTimes(), strings.TrimSpace(t)) {
+			reasons = append(reasons, fmt.Sprintf("%q is not a time of day (%s)", t, strings.Join(SpawnTimes(), ", ")))
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,146 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// StartingKitDefault is the name of the starting kit every new character receives, whatever their class.
+const StartingKitDefault = "default"
+
+// StartingKit is what a new character starts the game with. Kits are configured by class, on top of the default
+// kit: a class's room and money replace the default ones, its items are given as well as the default items, and
+// its attributes take precedence over the default attributes.
+type StartingKit struct {
+	// Room is where new characters start, as "area,x,y,z". New characters start at 0,0,0 of the first area that
+	// isn't the tutorial when no room is configured.
+	Room string `yaml:"room"`
+	// Money is how much money new characters start with.
+	Money *float64 `yaml:"money"`
+	// Items are the names of the items placed in new characters' inventories.
+	Items []string `yaml:"items"`
+	// Attributes are the character attributes set on new characters (ie: species).
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// StartingKits are the configured starting kits, keyed by class or StartingKitDefault.
+type StartingKits map[string]*StartingKit
+
+// For returns the starting kit for a class, combined with the default kit.
+func (kits StartingKits) For(class string) *StartingKit {
+	kit := &StartingKit{Attributes: make(map[string]string)}
+	for _, k := range []*StartingKit{kits[StartingKitDefault], kits[strings.ToLower(class)]} {
+		if k == nil {
+			continue
+		}
+		if len(k.Room) > 0 {
+			kit.Room = k.Room
+		}
+		if k.Money != nil {
+			kit.Money = k.Money
+		}
+		kit.Items = append(kit.Items, k.Items...)
+		for attr, value := range k.Attributes {
+			kit.Attributes[attr] = value
+		}
+	}
+	return kit
+}
+
+// defaultStartRoom returns the room new characters start in when no starting room is configured.
+func defaultStartRoom() *Room {
+	for _, a := range Armeria.worldManager.Areas() {
+		if a.Name() != TutorialAreaName {
+			return a.RoomAt(NewCoords(0, 0, 0, 0))
+		}
+	}
+	return nil
+}
+
+// StartRoom returns the Room new characters with the kit start in. A configured room that doesn't exist falls back
+// to the default starting room.
+func (k *StartingKit) StartRoom() *Room {
+	if len(k.Room) > 0 {
+		if r := Armeria.worldManager.RoomByLocation(k.Room); r != nil {
+			return r
+		}
+		Armeria.log.Error("starting room doesn't exist",
+			zap.String("room", k.Room),
+		)
+	}
+	return defaultStartRoom()
+}
+
+// ApplyStartingKit gives a new Character the money, items and attributes of a starting kit.
+func (c *Character) ApplyStartingKit(k *StartingKit) {
+	for attr, value := range k.Attributes {
+		if err := c.SetAttribute(attr, value); err != nil {
+			Armeria.log.Error("invalid starting attribute",
+				zap.String("attribute", attr),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if k.Money != nil {
+		if err := c.AddMoney(*k.Money, MoneySourceStartingKit); err != nil {
+			Armeria.log.Error("invalid starting money",
+				zap.Float64("money", *k.Money),
+				zap.Error(err),
+			)
+		}
+	}
+
+	for _, name := range k.Items {
+		item := Armeria.itemManager.ItemByName(name)
+		if item == nil {
+			Armeria.log.Error("starting item doesn't exist",
+				zap.String("item", name),
+			)
+			continue
+		}
+		ii := item.CreateInstance()
+		if err := c.Inventory().Add(ii.ID()); err != nil {
+			item.DeleteInstance(ii)
+		}
+	}
+}
+
+// checkStartingKitLinks finds starting kits that use rooms, items or classes that don't exist.
+func checkStartingKitLinks() []*LinkProblem {
+	var problems []*LinkProblem
+
+	for name, k := range Armeria.startingKits {
+		location := fmt.Sprintf("starting kit %s", name)
+		fix := "edit startingKits in the config file"
+
+		if name != StartingKitDefault && !misc.Contains(CharacterClasses(), strings.ToLower(name)) {
+			problems = append(problems, &LinkProblem{
+				Location: location,
+				Problem:  "is for a class that doesn't exist",
+				Fix:      fix,
+			})
+		}
+		if len(k.Room) > 0 && Armeria.worldManager.RoomByLocation(k.Room) == nil {
+			problems = append(problems, &LinkProblem{
+				Location: location,
+				Problem:  fmt.Sprintf("starts in the missing room %s", k.Room),
+				Fix:      fix,
+			})
+		}
+		for _, item := range k.Items {
+			if Armeria.itemManager.ItemByName(item) == nil {
+				problems = append(problems, &LinkProblem{
+					Location: location,
+					Problem:  fmt.Sprintf("gives the missing item %s", item),
+					Fix:      fix,
+				})
+			}
+		}
+	}
+
+	return problems
+}
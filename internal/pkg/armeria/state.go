@@ -2,7 +2,9 @@ package armeria
 
 import (
 	"armeria/internal/pkg/github"
+	"armeria/internal/pkg/misc"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"strconv"
@@ -14,24 +16,62 @@ import (
 
 // GameState stores the manager singletons and any other global state.
 type GameState struct {
-	log              *zap.Logger
-	production       bool
-	playerManager    *PlayerManager
-	commandManager   *CommandManager
-	characterManager *CharacterManager
-	worldManager     *WorldManager
-	mobManager       *MobManager
-	itemManager      *ItemManager
-	convoManager     *ConversationManager
-	ledgerManager    *LedgerManager
-	tickManager      *TickManager
-	registry         *Registry
-	channels         map[string]*Channel
-	publicPath       string
-	dataPath         string
-	objectImagesPath string
-	startTime        time.Time
-	github           *github.ArmeriaRepo
+	log                 *zap.Logger
+	production          bool
+	playerManager       *PlayerManager
+	commandManager      *CommandManager
+	characterManager    *CharacterManager
+	worldManager        *WorldManager
+	mobManager          *MobManager
+	itemManager         *ItemManager
+	convoManager        *ConversationManager
+	ledgerManager       *LedgerManager
+	economyManager      *EconomyManager
+	tickManager         *TickManager
+	promotionManager    *PromotionManager
+	titleManager        *TitleManager
+	questManager        *QuestManager
+	contractManager     *ContractManager
+	lootManager         *LootManager
+	announcementManager *AnnouncementManager
+	creationManager     *CreationManager
+	nameManager         *NameManager
+	loginQueue          *LoginQueue
+	seasonManager       *SeasonManager
+	casinoManager       *CasinoManager
+	lotteryManager      *LotteryManager
+	gatheringManager    *GatheringManager
+	roomSpawnManager    *RoomSpawnManager
+	vendorManager       *VendorManager
+	corpseManager       *CorpseManager
+	combatManager       *CombatManager
+	petitionManager     *PetitionManager
+	chatFilterManager   *ChatFilterManager
+	noteManager         *NoteManager
+	languageManager     *LanguageManager
+	worldStateManager   *WorldStateManager
+	quarantineManager   *QuarantineManager
+	quotaManager        *QuotaManager
+	startingKits        StartingKits
+	clusterManager      *ClusterManager
+	wildernessManager   *WildernessManager
+	registry            *Registry
+	channels            map[string]*Channel
+	channelLog          *ChannelLog
+	sessionRecorder     *SessionRecorder
+	editHistory         *EditHistory
+	publicPath          string
+	dataPath            string
+	objectImagesPath    string
+	chatHistoryOnDisk   bool
+	idleWarning         time.Duration
+	idleTimeout         time.Duration
+	multiboxPolicy      string
+	apiOnly             bool
+	metrics             bool
+	trustedProxies      []*net.IPNet
+	startTime           time.Time
+	github              *github.ArmeriaRepo
 }
 
 var (
@@ -41,14 +81,78 @@ var (
 
 // Init loads the Armeria game server and starts serving requests.
 func Init(configFilePath string, serveTraffic bool) {
+	c := initState(configFilePath)
+	if !serveTraffic {
+		return
+	}
+
+	verifySchemaVersion()
+	loadManagers(c, true)
+
+	LogLinkProblems()
+
+	Armeria.github = github.New()
+
+	Armeria.setupGracefulExit()
+
+	Armeria.startTime = time.Now()
+
+	RegisterAttributeHooks()
+	RegisterGameCommands()
+
+	CallGlobalFunc(nil, "on_boot")
+
+	port := c.HTTPPort
+	// For Heroku, we must listen on a specific port.
+	if len(os.Getenv("PORT")) > 0 {
+		var err error
+		port, err = strconv.Atoi(os.Getenv("PORT"))
+		if err != nil {
+			log.Fatalf("error parsing PORT environment variable: %s", err)
+		}
+	}
+	InitWeb(port)
+}
+
+// InitOffline loads the game data without serving requests or running the game loop, for tools that maintain the
+// data directory while the server is stopped.
+func InitOffline(configFilePath string) {
+	c := initState(configFilePath)
+
+	verifySchemaVersion()
+	loadManagers(c, false)
+
+	RegisterAttributeHooks()
+}
+
+// initState creates the global state from the config file, along with the logger.
+func initState(configFilePath string) config {
 	c := parseConfigFile(configFilePath)
 
 	Armeria = &GameState{
-		production:       c.Production,
-		publicPath:       c.PublicPath,
-		dataPath:         c.DataPath,
-		objectImagesPath: c.DataPath + "/object-images",
+		production:        c.Production,
+		publicPath:        c.PublicPath,
+		dataPath:          c.DataPath,
+		objectImagesPath:  c.DataPath + "/object-images",
+		chatHistoryOnDisk: c.ChatHistoryOnDisk,
+		idleWarning:       c.IdleWarning,
+		idleTimeout:       c.IdleTimeout,
+		multiboxPolicy:    c.MultiboxPolicy,
+		apiOnly:           c.APIOnly,
+		metrics:           c.Metrics,
+	}
+
+	if len(Armeria.multiboxPolicy) == 0 {
+		Armeria.multiboxPolicy = MultiboxAllow
+	} else if !misc.Contains(MultiboxPolicies(), Armeria.multiboxPolicy) {
+		log.Fatalf("invalid multibox policy: %s", Armeria.multiboxPolicy)
+	}
+
+	proxies, err := parseTrustedProxies(c.TrustedProxies)
+	if err != nil {
+		log.Fatalf("%s", err)
 	}
+	Armeria.trustedProxies = proxies
 
 	logger, err := zap.NewDevelopment()
 	if err != nil {
@@ -56,41 +160,62 @@ func Init(configFilePath string, serveTraffic bool) {
 	}
 	Armeria.log = logger
 
-	if !serveTraffic {
-		return
-	}
-
-	verifySchemaVersion()
+	return c
+}
 
+// loadManagers creates the manager singletons, which loads the game data from disk. When live, the server also
+// joins its cluster and starts the game loop.
+func loadManagers(c config, live bool) {
 	Armeria.registry = NewRegistry()
 	Armeria.commandManager = NewCommandManager()
 	Armeria.playerManager = NewPlayerManager()
 	Armeria.characterManager = NewCharacterManager()
+	Armeria.nameManager = NewNameManager()
+	Armeria.languageManager = NewLanguageManager()
 	Armeria.worldManager = NewWorldManager()
 	Armeria.mobManager = NewMobManager()
 	Armeria.itemManager = NewItemManager()
 	Armeria.channels = NewChannels()
+	Armeria.channelLog = NewChannelLog()
+	Armeria.sessionRecorder = NewSessionRecorder()
+	Armeria.editHistory = NewEditHistory()
 	Armeria.convoManager = NewConversationManager()
+	Armeria.creationManager = NewCreationManager()
+	Armeria.loginQueue = NewLoginQueue(c.MaxPlayers)
 	Armeria.ledgerManager = NewLedgerManager()
-	Armeria.tickManager = NewTickManager()
-
-	Armeria.github = github.New()
-
-	Armeria.setupGracefulExit()
-
-	Armeria.startTime = time.Now()
-
-	RegisterGameCommands()
-
-	port := c.HTTPPort
-	// For Heroku, we must listen on a specific port.
-	if len(os.Getenv("PORT")) > 0 {
-		port, err = strconv.Atoi(os.Getenv("PORT"))
+	Armeria.economyManager = NewEconomyManager()
+	Armeria.announcementManager = NewAnnouncementManager()
+	Armeria.seasonManager = NewSeasonManager(c.Seasons)
+	Armeria.quotaManager = NewQuotaManager(c.Quotas)
+	Armeria.startingKits = c.StartingKits
+	Armeria.casinoManager = NewCasinoManager()
+	Armeria.lotteryManager = NewLotteryManager()
+	Armeria.gatheringManager = NewGatheringManager()
+	Armeria.roomSpawnManager = NewRoomSpawnManager()
+	Armeria.vendorManager = NewVendorManager()
+	Armeria.corpseManager = NewCorpseManager()
+	Armeria.combatManager = NewCombatManager()
+	Armeria.petitionManager = NewPetitionManager()
+	Armeria.chatFilterManager = NewChatFilterManager()
+	Armeria.noteManager = NewNoteManager()
+	Armeria.worldStateManager = NewWorldStateManager()
+	Armeria.quarantineManager = NewQuarantineManager()
+	Armeria.wildernessManager = NewWildernessManager()
+	if live && len(c.ClusterRedis) > 0 {
+		bus, err := NewRedisBus(c.ClusterRedis)
 		if err != nil {
-			log.Fatalf("error parsing PORT environment variable: %s", err)
+			log.Fatalf("error connecting to cluster message bus: %s", err)
 		}
+		Armeria.clusterManager = NewClusterManager(c.ClusterNode, c.ClusterAreas, c.ClusterAddress, bus)
 	}
-	InitWeb(port)
+	if live {
+		Armeria.tickManager = NewTickManager()
+	}
+	Armeria.promotionManager = NewPromotionManager(c.StagingPath)
+	Armeria.titleManager = NewTitleManager()
+	Armeria.questManager = NewQuestManager()
+	Armeria.contractManager = NewContractManager()
+	Armeria.lootManager = NewLootManager()
 }
 
 func (gs *GameState) setupGracefulExit() {
@@ -110,4 +235,22 @@ func (gs *GameState) Save() {
 	gs.mobManager.SaveMobs()
 	gs.itemManager.SaveItems()
 	gs.ledgerManager.SaveLedgers()
+	gs.promotionManager.SavePromotions()
+	gs.titleManager.SaveTitles()
+	gs.questManager.SaveQuests()
+	gs.contractManager.SaveContracts()
+	gs.lootManager.SaveLoot()
+	gs.announcementManager.SaveAnnouncements()
+	gs.nameManager.SaveNames()
+	gs.lotteryManager.SaveLottery()
+	gs.petitionManager.SavePetitions()
+	gs.chatFilterManager.SaveChatFilter()
+	gs.noteManager.SaveNotes()
+	gs.worldStateManager.SaveWorldState()
+	gs.quarantineManager.SaveQuarantine()
+	gs.economyManager.SaveEconomy()
+
+	for _, c := range gs.characterManager.OnlineCharacters() {
+		c.SaveChatHistory()
+	}
 }
@@ -0,0 +1,127 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxTamingSkill is the highest level the taming skill can reach.
+	MaxTamingSkill = 100
+	// TameWariness is how long a mob refuses to be tamed after a failed attempt.
+	TameWariness = 1 * time.Minute
+)
+
+// Owner returns the Character the MobInstance is a companion of, or nil if it is wild.
+func (mi *MobInstance) Owner() *Character {
+	mi.RLock()
+	id := mi.UnsafeOwner
+	mi.RUnlock()
+
+	if len(id) == 0 {
+		return nil
+	}
+
+	return Armeria.characterManager.CharacterById(id)
+}
+
+// TamingSkill returns the Character's level in the taming skill.
+func (c *Character) TamingSkill() int {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeTaming
+}
+
+// improveTamingSkill raises the Character's taming skill by one, up to MaxTamingSkill. Higher levels are harder to
+// improve. It returns true if the skill improved.
+func (c *Character) improveTamingSkill() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.UnsafeTaming >= MaxTamingSkill || misc.RandomInt(MaxTamingSkill) < c.UnsafeTaming {
+		return false
+	}
+
+	c.UnsafeTaming++
+	return true
+}
+
+// Companion returns the MobInstance the Character has tamed, or nil if they don't have one.
+func (c *Character) Companion() *MobInstance {
+	for _, m := range Armeria.mobManager.Mobs() {
+		for _, mi := range m.Instances() {
+			if mi.Owner() == c {
+				return mi
+			}
+		}
+	}
+
+	return nil
+}
+
+// tamingChance returns the percent chance of taming a mob of a given taming level.
+func tamingChance(skill, tameLevel int) int {
+	chance := 50 + (skill-tameLevel)*2
+	if chance < 5 {
+		return 5
+	} else if chance > 95 {
+		return 95
+	}
+	return chance
+}
+
+// Tame attempts to make a wild MobInstance the Character's companion. A tamed mob no longer belongs to its mob
+// spawner, and follows the Character around. When the attempt fails, the mob turns on the Character: it pursues
+// them, calls its faction for help, and won't let anyone tame it for a while. Taming a mob may improve the
+// Character's taming skill. It returns true if the mob was tamed.
+func (c *Character) Tame(mi *MobInstance) (bool, error) {
+	if mi.Attribute(AttributeTameable) != "true" {
+		return false, errors.New("that can't be tamed")
+	}
+	if mi.Owner() != nil {
+		return false, fmt.Errorf("%s is already someone's companion", mi.FormattedName())
+	}
+	if c.Companion() != nil {
+		return false, errors.New("you already have a companion, and must release it first")
+	}
+
+	mi.RLock()
+	wary := time.Until(mi.UnsafeWaryUntil)
+	mi.RUnlock()
+	if wary > 0 {
+		return false, fmt.Errorf("%s is too wary to approach right now", mi.FormattedName())
+	}
+
+	if misc.RandomInt(100) >= tamingChance(c.TamingSkill(), mi.AttributeInt(AttributeTameLevel)) {
+		mi.Lock()
+		mi.UnsafeWaryUntil = time.Now().Add(TameWariness)
+		mi.Unlock()
+
+		mi.Pursue(c)
+		mi.CallForHelp(c)
+		return false, nil
+	}
+
+	mi.Lock()
+	mi.UnsafeOwner = c.ID()
+	mi.UnsafeMobSpawnerUUID = ""
+	mi.UnsafeFollowing = ""
+	mi.UnsafePursuing = ""
+	mi.UnsafeReturning = false
+	mi.Unlock()
+
+	c.improveTamingSkill()
+
+	return true, nil
+}
+
+// Release turns the Character's companion loose, so that it is wild again.
+func (mi *MobInstance) Release() {
+	mi.Lock()
+	defer mi.Unlock()
+
+	mi.UnsafeOwner = ""
+}
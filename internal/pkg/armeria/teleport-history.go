@@ -0,0 +1,37 @@
+package armeria
+
+const (
+	// TeleportHistoryLimit is the maximum number of rooms kept in each character's teleport history.
+	TeleportHistoryLimit = 20
+)
+
+// PushTeleportHistory remembers the room the Character is teleporting away from, so they can return to it with
+// /tp back. The oldest rooms are forgotten once the history reaches TeleportHistoryLimit.
+func (c *Character) PushTeleportHistory(r *Room) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.unsafeTeleportHistory = append(c.unsafeTeleportHistory, r.ID())
+	if len(c.unsafeTeleportHistory) > TeleportHistoryLimit {
+		c.unsafeTeleportHistory = c.unsafeTeleportHistory[len(c.unsafeTeleportHistory)-TeleportHistoryLimit:]
+	}
+}
+
+// PopTeleportHistory forgets and returns the room the Character most recently teleported away from, skipping rooms
+// that no longer exist. It returns nil once the history is empty.
+func (c *Character) PopTeleportHistory() *Room {
+	c.Lock()
+	defer c.Unlock()
+
+	for len(c.unsafeTeleportHistory) > 0 {
+		last := len(c.unsafeTeleportHistory) - 1
+		id := c.unsafeTeleportHistory[last]
+		c.unsafeTeleportHistory = c.unsafeTeleportHistory[:last]
+
+		if o, rt := Armeria.registry.Get(id); rt == RegistryTypeRoom {
+			return o.(*Room)
+		}
+	}
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package armeria
 
 import (
-	"encoding/base64"
+	"armeria/internal/pkg/markup"
 	"fmt"
+	"html"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -13,6 +16,12 @@ const (
 	TextExclaim
 )
 
+var (
+	textPlainRows  = regexp.MustCompile(`</tr>|<br\s*/?>`)
+	textPlainCells = regexp.MustCompile(`</t[dh]>(<t[dh])`)
+	textPlainTags  = regexp.MustCompile(`<[^>]*(>|$)`)
+)
+
 type TableCell struct {
 	content string
 	styling string
@@ -34,106 +43,99 @@ func TextStyle(text interface{}, opts ...TextOperation) string {
 	return t
 }
 
-// WithBold formats the text as bold.
-func WithBold() TextOperation {
+// markupOperation returns a TextOperation that wraps the text in a markup element. Any percent signs within the
+// element's values are escaped, so they aren't mistaken for the placeholder the text is formatted into.
+func markupOperation(e *markup.Element) TextOperation {
+	const placeholder = "\x00"
+	e.Children = []markup.Node{markup.Raw(placeholder)}
+	rendered := strings.Replace(markup.HTML(e), "%", "%%", -1)
 	return TextOperation{
-		Text: "<span style='font-weight:600'>%v</span>",
+		Text: strings.Replace(rendered, placeholder, "%v", 1),
 	}
 }
 
+// WithBold formats the text as bold.
+func WithBold() TextOperation {
+	return markupOperation(markup.Bold())
+}
+
 // WithItalics formats the text using italics.
 func WithItalics() TextOperation {
-	return TextOperation{
-		Text: "<span style='font-style:italic'>%v</span>",
-	}
+	return markupOperation(markup.Italics())
 }
 
 // WithMonospace formats the text using a monospace font.
 func WithMonospace() TextOperation {
-	return TextOperation{
-		Text: "<span class='monospace'>%v</span>",
-	}
+	return markupOperation(markup.Monospace())
 }
 
 // WithButton formats the text creating a clickable button (with optional promptData).
 func WithButton(cmd, promptData string) TextOperation {
-	return TextOperation{
-		Text: "<span class='inline-button' data-cmd='" + cmd + "' data-prompt='" + promptData + "'>%v</span>",
-	}
+	return markupOperation(markup.Button(cmd, promptData))
 }
 
 // WithLinkCmd formats the text creating a hyperlink that executes a specific command when clicked on.
 func WithLinkCmd(cmd string) TextOperation {
-	enc := base64.StdEncoding.EncodeToString([]byte(cmd))
-	return TextOperation{
-		Text: "<a href='#' class='inline-command' " +
-			"data-command='" + enc + "' " +
-			"tooltip='Run: " + cmd + "'>" +
-			"%v</a>",
-	}
+	return markupOperation(markup.Link(cmd))
 }
 
 // WithColor formats the text using a specific color.
 func WithColor(color string) TextOperation {
-	return TextOperation{
-		Text: "<span style='color:#" + color + "'>%v</span>",
-	}
+	return markupOperation(markup.Color(color))
 }
 
 // WithColor formats the text using a specific color.
 func WithUserColor(c *Character, color int) TextOperation {
-	return TextOperation{
-		Text: "<span style='color:" + c.UserColor(color) + "'>%v</span>",
-	}
+	return markupOperation(markup.Color(c.UserColor(color)))
 }
 
 // WithLink formats the text creating a hyperlink.
 func WithLink(url string) TextOperation {
-	return TextOperation{
-		Text: "<a href='" + url + "' class='inline-link' target='_new'>%v</a>",
-	}
+	return markupOperation(markup.Link(url))
 }
 
 // WithSize formats the text using a specific size.
 func WithSize(size int) TextOperation {
-	return TextOperation{
-		Text: "<span style='font-size:" + strconv.Itoa(size) + "px'>%v</span>",
-	}
+	return markupOperation(markup.Size(size))
 }
 
 // WithItemTooltip formats the text allowing a player to mouse-over the item and view the item tooltip.
 func WithItemTooltip(uuid string) TextOperation {
-	return TextOperation{
-		Text: "<span class='hover-item-tooltip' data-uuid='" + uuid + "'>%v</span>",
-	}
+	return markupOperation(markup.Item(uuid))
 }
 
 // WithContextMenu formats the text to display a context menu when right-clicking.
 func WithContextMenu(name, objType, color string, content []string) TextOperation {
-	enc := base64.StdEncoding.EncodeToString([]byte(strings.Join(content, ";")))
-	return TextOperation{
-		Text: fmt.Sprintf(
-			"<span class='dynamic-context-menu' data-name='%s' data-type='%s' data-color='%s' data-content='%s'>%%v</span>",
-			name,
-			objType,
-			color,
-			enc,
-		),
-	}
+	return markupOperation(markup.Menu(name, objType, color, content))
 }
 
-// WithConvoSelection formats the text as a conversation answer.
-func WithConvoSelection(id, mobUUID string, groupId int64) TextOperation {
+// WithConvoSelection formats the text as a conversation answer, numbered with the shortcut that selects it.
+func WithConvoSelection(id, mobUUID string, groupId int64, shortcut int) TextOperation {
 	return TextOperation{
 		Text: fmt.Sprintf(
-			"<span class='convo-select' data-group='%d' data-convo-option-id='%s' data-mob-uuid='%s'>%%v</span>",
+			"<span class='convo-select' data-group='%d' data-convo-option-id='%s' data-mob-uuid='%s' data-shortcut='%d'>%d. %%v</span>",
 			groupId,
 			id,
 			mobUUID,
+			shortcut,
+			shortcut,
 		),
 	}
 }
 
+// WithChatMessage marks the text as a channel message, so that it can be found again on the client if a moderator
+// retracts it.
+func WithChatMessage(id string) TextOperation {
+	return TextOperation{
+		Text: fmt.Sprintf("<span class='chat-message' %s>%%v</span>", chatMessageMarker(id)),
+	}
+}
+
+// chatMessageMarker returns the attribute that identifies a channel message within formatted text.
+func chatMessageMarker(id string) string {
+	return fmt.Sprintf("data-chat-id='%s'", id)
+}
+
 // WithChannelLabel formats the text as a channel header label.
 func WithChannelLabel(color string) TextOperation {
 	return TextOperation{
@@ -144,6 +146,25 @@ func WithChannelLabel(color string) TextOperation {
 	}
 }
 
+// TextStylePlayer escapes player-provided text before styling it like TextStyle, so that any HTML or [b]-style
+// markup within it is shown as written. Text written by players must always be displayed through it.
+func TextStylePlayer(text string, opts ...TextOperation) string {
+	return TextStyle(textEscape(text), opts...)
+}
+
+// textEscape makes text safe to display by escaping HTML and the client's [b]-style markup.
+func textEscape(text string) string {
+	return strings.Replace(html.EscapeString(text), "[", "&#91;", -1)
+}
+
+// TextAttributeValue makes an attribute value safe to display, showing empty values as "(empty)".
+func TextAttributeValue(v string) string {
+	if len(strings.TrimSpace(v)) == 0 {
+		return TextStyle("(empty)", WithItalics())
+	}
+	return TextStylePlayer(v)
+}
+
 // TextPunctuation will automatically punctuate a string and return the punctuation type.
 func TextPunctuation(text string) (string, int) {
 	lastChar := text[len(text)-1:]
@@ -164,6 +185,72 @@ func TextCapitalization(text string) string {
 	return strings.ToUpper(text[0:1]) + text[1:]
 }
 
+// TextNumber formats an integer with thousand separators, such as "12,345".
+func TextNumber(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+
+	return sign + b.String()
+}
+
+// TextDuration humanizes a duration using its two largest units, such as "2h 5m" or "1d 3h". Durations under a
+// second are shown as "0s".
+func TextDuration(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+
+	var parts []string
+	for _, u := range units {
+		if d >= u.size {
+			parts = append(parts, fmt.Sprintf("%d%s", d/u.size, u.suffix))
+			d %= u.size
+		} else if len(parts) > 0 {
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0s"
+	}
+	return strings.Join(parts, " ")
+}
+
+// TextRelativeTime describes a time relative to now, such as "5m ago" or "in 2h 5m".
+func TextRelativeTime(t time.Time) string {
+	d := time.Until(t)
+	if d > -time.Second && d < time.Second {
+		return "just now"
+	} else if d > 0 {
+		return "in " + TextDuration(d)
+	}
+	return TextDuration(d) + " ago"
+}
+
 // TextTable returns a table in HTML with rows generated by TableRow.
 func TextTable(rows ...string) string {
 	rowString := ""
@@ -185,3 +272,11 @@ func TableRow(cells ...TableCell) string {
 	}
 	return "<tr>" + cellString + "</tr>"
 }
+
+// TextPlain strips the HTML styling from text so that it can be read by a screen reader. Table rows are placed
+// on separate lines, and the cells within them are separated by commas.
+func TextPlain(text string) string {
+	text = textPlainRows.ReplaceAllString(text, "\n")
+	text = textPlainCells.ReplaceAllString(text, ", $1")
+	return textPlainTags.ReplaceAllString(text, "")
+}
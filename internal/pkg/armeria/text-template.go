@@ -0,0 +1,75 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// textTemplateTag matches a template tag within builder-authored text, such as {{name}} or {{random:red|blue}}.
+var textTemplateTag = regexp.MustCompile(`{{\s*([a-z]+)(?::([^}]*))?\s*}}`)
+
+// TemplateAttributes returns the character attributes that can be used in templates with {{attr:name}}.
+func TemplateAttributes() []string {
+	return []string{
+		AttributeClass,
+		AttributeGender,
+		AttributePronouns,
+		AttributeSpecies,
+		AttributeTitle,
+	}
+}
+
+// TimeOfDay returns the part of the day (morning, afternoon, evening or night) for a particular time.
+func TimeOfDay(t time.Time) string {
+	switch h := t.Hour(); {
+	case h >= 5 && h < 12:
+		return "morning"
+	case h >= 12 && h < 17:
+		return "afternoon"
+	case h >= 17 && h < 21:
+		return "evening"
+	}
+
+	return "night"
+}
+
+// TextTemplate renders the template tags within builder-authored text, such as mob speech and descriptions. The
+// tags are filled in for a particular Character, which can be nil when there isn't one:
+//
+//	{{name}}             the character's name
+//	{{time}}             the time of day (morning, afternoon, evening or night)
+//	{{attr:species}}     one of the character's TemplateAttributes
+//	{{random:hi|hello}}  one of the choices, picked at random
+//
+// Values that come from characters are escaped, so they can't inject markup. Unknown tags are left as they are.
+func TextTemplate(text string, c *Character) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	return textTemplateTag.ReplaceAllStringFunc(text, func(tag string) string {
+		sections := textTemplateTag.FindStringSubmatch(tag)
+		switch sections[1] {
+		case "name":
+			if c != nil {
+				return TextStylePlayer(c.Name())
+			}
+			return "stranger"
+		case "time":
+			return TimeOfDay(time.Now())
+		case "attr":
+			name := strings.TrimSpace(sections[2])
+			if c == nil || !misc.Contains(TemplateAttributes(), name) {
+				return ""
+			}
+			return TextStylePlayer(c.Attribute(name))
+		case "random":
+			choices := strings.Split(sections[2], "|")
+			return choices[misc.RandomInt(len(choices))]
+		}
+
+		return tag
+	})
+}
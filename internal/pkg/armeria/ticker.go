@@ -1,7 +1,6 @@
 package armeria
 
 import (
-	"armeria/internal/pkg/misc"
 	"armeria/internal/pkg/sfx"
 	"fmt"
 	"strconv"
@@ -13,17 +12,22 @@ import (
 
 type Ticker struct {
 	sync.RWMutex
-	Name         string
-	Handler      func()
-	Interval     time.Duration
-	RunAtBoot    bool
-	LastStart    time.Time
-	LastDuration time.Duration
-	Iterations   int
+	Name           string
+	Handler        func()
+	Interval       time.Duration
+	RunAtBoot      bool
+	RunWhileFrozen bool
+	LastStart      time.Time
+	LastDuration   time.Duration
+	Iterations     int
 }
 
 type TickManager struct {
-	Tickers []*Ticker
+	sync.RWMutex
+	Tickers  []*Ticker
+	frozen   bool
+	frozenBy string
+	frozenAt time.Time
 }
 
 // NewTickManager creates a new TickManager.
@@ -37,20 +41,93 @@ func NewTickManager() *TickManager {
 				RunAtBoot: true,
 			},
 			{
-				Name:     "PeriodicGameSave",
-				Handler:  PeriodicGameSave,
-				Interval: 2 * time.Minute,
+				Name:           "PeriodicGameSave",
+				Handler:        PeriodicGameSave,
+				Interval:       2 * time.Minute,
+				RunWhileFrozen: true,
 			},
 			{
 				Name:     "MobSpawner",
 				Handler:  MobSpawner,
 				Interval: 1 * time.Minute,
 			},
+			{
+				Name:     "RoomSpawner",
+				Handler:  RoomSpawner,
+				Interval: 5 * time.Second,
+			},
 			{
 				Name:     "MobMovement",
 				Handler:  MobMovement,
 				Interval: 5 * time.Second,
 			},
+			{
+				Name:     "Announcements",
+				Handler:  BroadcastAnnouncements,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "Seasons",
+				Handler:  UpdateSeasons,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:      "Lottery",
+				Handler:   DrawLottery,
+				Interval:  1 * time.Minute,
+				RunAtBoot: true,
+			},
+			{
+				Name:     "MobFollowers",
+				Handler:  MoveFollowers,
+				Interval: 2 * time.Second,
+			},
+			{
+				Name:     "Vehicles",
+				Handler:  MoveVehicles,
+				Interval: 10 * time.Second,
+			},
+			{
+				Name:     "Wilderness",
+				Handler:  UnloadWildernessRooms,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:      "Corpses",
+				Handler:   DecayCorpses,
+				Interval:  15 * time.Second,
+				RunAtBoot: true,
+			},
+			{
+				Name:     "Combat",
+				Handler:  CombatRounds,
+				Interval: CombatRoundInterval,
+			},
+			{
+				Name:     "Crafting",
+				Handler:  ProgressCrafting,
+				Interval: CraftingTickInterval,
+			},
+			{
+				Name:     "QuestDeliveries",
+				Handler:  CheckQuestDeliveries,
+				Interval: 5 * time.Second,
+			},
+			{
+				Name:     "Contracts",
+				Handler:  SettleContracts,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "IdleCharacters",
+				Handler:  CampIdleCharacters,
+				Interval: 1 * time.Minute,
+			},
+			{
+				Name:     "ItemIntegrity",
+				Handler:  ItemIntegrity,
+				Interval: 15 * time.Minute,
+			},
 		},
 	}
 
@@ -69,6 +146,9 @@ func (m *TickManager) Start() {
 		c := time.Tick(ticker.Interval)
 		go func(t *Ticker) {
 			for range c {
+				if !t.RunWhileFrozen && m.Frozen() {
+					continue
+				}
 				t.Run()
 			}
 		}(ticker)
@@ -111,34 +191,24 @@ func (t *Ticker) IterationsString() string {
 	return strconv.Itoa(t.Iterations)
 }
 
-// WipeDanglingInstances searches for mob and item instances in the database that that have no valid container
-// parent. The instances are wiped from the registry and on the next database save, will be removed from the database.
+// WipeDanglingInstances searches for mob instances in the database that that have no valid container parent. The
+// instances are wiped from the registry and on the next database save, will be removed from the database. Item
+// instances without a container are left for the item integrity check, which quarantines them for staff to review.
 func WipeDanglingInstances() {
-	var mobsAndItems []interface{}
-
-	mi := Armeria.registry.GetAllFromType(RegistryTypeMobInstance)
-	mobsAndItems = append(mobsAndItems, mi...)
-	ii := Armeria.registry.GetAllFromType(RegistryTypeItemInstance)
-	mobsAndItems = append(mobsAndItems, ii...)
-
-	for _, o := range mobsAndItems {
-		obj := o.(ContainerObject)
-		container := Armeria.registry.GetObjectContainer(obj.ID())
+	for _, o := range Armeria.registry.GetAllFromType(RegistryTypeMobInstance) {
+		mi := o.(*MobInstance)
+		container := Armeria.registry.GetObjectContainer(mi.ID())
 		if container == nil {
 			Armeria.log.Info(
 				"found dangling object instance",
-				zap.String("uuid", obj.ID()),
+				zap.String("uuid", mi.ID()),
 			)
 
-			if obj.Type() == ContainerObjectTypeMob {
-				obj.(*MobInstance).Parent.DeleteInstance(obj.(*MobInstance))
-			} else if obj.Type() == ContainerObjectTypeItem {
-				obj.(*ItemInstance).Parent.DeleteInstance(obj.(*ItemInstance))
-			}
+			mi.Parent.DeleteInstance(mi)
 
 			Armeria.log.Info(
 				"dangling instance deleted",
-				zap.String("uuid", obj.ID()),
+				zap.String("uuid", mi.ID()),
 			)
 		}
 	}
@@ -154,6 +224,10 @@ func MobSpawner() {
 	mobSpawnerItems := Armeria.itemManager.ItemsByAttribute(AttributeType, ItemTypeMobSpawner)
 	for _, spawner := range mobSpawnerItems {
 		for _, inst := range spawner.Instances() {
+			// Areas hosted by other nodes of the cluster are spawned there.
+			if r := inst.Room(); r != nil && !HostsArea(r.ParentArea) {
+				continue
+			}
 			// Find the mob.
 			mobStr := inst.Attribute(AttributeSpawnMob)
 			mob := Armeria.mobManager.MobByName(mobStr)
@@ -169,6 +243,15 @@ func MobSpawner() {
 				)
 				continue
 			}
+			// Seasonal spawners only spawn while their season is active.
+			if !Armeria.seasonManager.Active(inst.Attribute(AttributeSeason)) {
+				continue
+			}
+			// Timed spawners only spawn during their times of day, and their mobs leave when it ends.
+			if !SpawnTimeActive(inst) {
+				despawnOutOfTimeMobs(inst, mob)
+				continue
+			}
 			// Check the limit. If we reached it, move on.
 			mobLimit := inst.AttributeInt(AttributeSpawnLimit)
 			existingSpawns := mob.InstancesFromSpawner(inst)
@@ -182,18 +265,23 @@ func MobSpawner() {
 			// Spawn the mob.
 			mobInst := mob.CreateInstance()
 			mobInst.SetMobSpawnerUUID(inst.ID())
+			mobInst.Scale(SpawnScale(inst))
 			_ = inst.Room().Here().Add(mobInst.ID())
-			// Refresh the room.
-			spawnSFX := mob.Attribute(AttributeSpawnSFX)
-			for _, c := range inst.Room().Here().Characters(true) {
-				c.Player().client.ShowText(
-					fmt.Sprintf("With a flash of light, a %s appeared out of nowhere!", mobInst.FormattedName()),
-				)
-				c.Player().client.SyncRoomObjects()
-				if len(spawnSFX) > 0 {
-					c.Player().client.PlaySFX(sfx.ClientSoundEffect(spawnSFX))
-				}
-			}
+			announceMobSpawn(inst.Room(), mobInst)
+		}
+	}
+}
+
+// announceMobSpawn shows the characters in a Room that a MobInstance has just spawned there.
+func announceMobSpawn(r *Room, mi *MobInstance) {
+	spawnSFX := mi.Parent.Attribute(AttributeSpawnSFX)
+	for _, c := range r.Here().Characters(true) {
+		c.Player().client.ShowText(
+			fmt.Sprintf("With a flash of light, a %s appeared out of nowhere!", mi.FormattedName()),
+		)
+		c.Player().client.SyncRoomObjects()
+		if len(spawnSFX) > 0 {
+			c.Player().client.PlaySFX(sfx.ClientSoundEffect(spawnSFX))
 		}
 	}
 }
@@ -202,7 +290,7 @@ func MobSpawner() {
 func MobMovement() {
 	for _, m := range Armeria.mobManager.Mobs() {
 		for _, mi := range m.Instances() {
-			if len(mi.Attribute(AttributeFollowCrumb)) == 0 {
+			if len(mi.Attribute(AttributeFollowCrumb)) == 0 || mi.Owner() != nil || !HostsArea(mi.Room().ParentArea) {
 				continue
 			}
 
@@ -216,6 +304,10 @@ func MobMovement() {
 			// Find a new random direction, following the crumb.
 			possibleRooms := mi.Room().AdjacentRoomsWithItem(mi.Attribute(AttributeFollowCrumb))
 			dirStr, newRoom := possibleRooms.Random()
+			if newRoom != nil && !HostsArea(newRoom.ParentArea) {
+				// Mobs don't wander into areas hosted by other nodes of the cluster.
+				continue
+			}
 			if newRoom == nil {
 				// Let builders know.
 				Armeria.channels[ChannelBuilders].Broadcast(
@@ -229,35 +321,49 @@ func MobMovement() {
 				continue
 			}
 			// Move the mob.
-			oldRoom := mi.Room()
-			oldRoom.Here().Remove(mi.ID())
-			newRoom.Here().Add(mi.ID())
-			mobNameString := fmt.Sprintf("A %s", mi.FormattedName())
-			if mi.Attribute(AttributeGender) != "thing" {
-				mobNameString = mi.FormattedName()
-			}
-			for _, c := range oldRoom.Here().Characters(true) {
-				c.Player().client.ShowText(
-					TextStyle(
-						fmt.Sprintf("%s travels %s.", mobNameString, misc.MoveToStringFromDir("to the", dirStr)),
-						WithUserColor(c, ColorMovement),
-					),
-				)
-				c.Player().client.SyncRoomObjects()
-			}
-			for _, c := range newRoom.Here().Characters(true) {
-				c.Player().client.ShowText(
-					TextStyle(
-						fmt.Sprintf(
-							"%s entered from %s.",
-							mobNameString,
-							misc.MoveToStringFromDir("the", misc.OppositeDirection(dirStr)),
-						),
-						WithUserColor(c, ColorMovement),
-					),
-				)
-				c.Player().client.SyncRoomObjects()
-			}
+			mi.Walk(newRoom, dirStr)
+		}
+	}
+}
+
+// CampIdleCharacters warns characters that haven't sent a command recently, and then safely logs them out once
+// they have been idle for too long. Characters with the CAN_IDLE permission are exempt.
+func CampIdleCharacters() {
+	if Armeria.idleTimeout <= 0 {
+		return
+	}
+
+	for _, p := range Armeria.playerManager.Players() {
+		c := p.Character()
+		if c == nil || c.HasGlobalPermission("CAN_IDLE") {
+			continue
+		}
+
+		idle := time.Since(p.LastCommand())
+		if idle >= Armeria.idleTimeout {
+			p.client.ShowColorizedText(
+				"You have been idle for too long, and your character sets up camp and logs out.",
+				ColorError,
+			)
+			Armeria.log.Info("idle character logged out",
+				zap.String("character", c.Name()),
+				zap.Duration("idle", idle),
+			)
+			Armeria.characterManager.SaveCharacters()
+			p.client.Disconnect()
+			// Clients that don't respond to the disconnect request are disconnected by the server.
+			time.AfterFunc(10*time.Second, func() {
+				Armeria.playerManager.DisconnectPlayer(p)
+			})
+		} else if Armeria.idleWarning > 0 && idle >= Armeria.idleWarning && !p.IdleWarned() {
+			p.SetIdleWarned(true)
+			p.client.ShowColorizedText(
+				fmt.Sprintf(
+					"You have been idle for a while. You will be logged out in %s unless you do something.",
+					TextStyle(TextDuration(Armeria.idleTimeout-idle), WithBold()),
+				),
+				ColorCmdHelp,
+			)
 		}
 	}
 }
@@ -0,0 +1,211 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// TitleManager holds the catalog of titles that characters can earn and display.
+type TitleManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeTitles []*Title `json:"titles"`
+}
+
+// Title is a title within the catalog. Titles are earned (ie: through achievements and quests) and can then be
+// displayed by the character.
+type Title struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// KillMob is the mob that must be slain Kills times for the title to be granted automatically.
+	KillMob string `json:"killMob,omitempty"`
+	Kills   int    `json:"kills,omitempty"`
+	// ExploreArea is the area that must be fully explored for the title to be granted automatically.
+	ExploreArea string `json:"exploreArea,omitempty"`
+}
+
+// NewTitleManager creates a new TitleManager.
+func NewTitleManager() *TitleManager {
+	m := &TitleManager{
+		dataFile: fmt.Sprintf("%s/titles.json", Armeria.dataPath),
+	}
+
+	m.LoadTitles()
+
+	return m
+}
+
+// LoadTitles loads the title catalog from disk into memory.
+func (m *TitleManager) LoadTitles() {
+	m.Lock()
+	defer m.Unlock()
+
+	titlesFile, err := os.Open(m.dataFile)
+	defer titlesFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(titlesFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	Armeria.log.Info("titles loaded",
+		zap.Int("count", len(m.UnsafeTitles)),
+	)
+}
+
+// SaveTitles writes the in-memory title catalog to disk.
+func (m *TitleManager) SaveTitles() {
+	m.RLock()
+	defer m.RUnlock()
+
+	titlesFile, err := os.Create(m.dataFile)
+	defer titlesFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := titlesFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = titlesFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Titles returns every title within the catalog.
+func (m *TitleManager) Titles() []*Title {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeTitles
+}
+
+// TitleByName returns the matching Title, by name.
+func (m *TitleManager) TitleByName(name string) *Title {
+	m.RLock()
+	defer m.RUnlock()
+
+	for _, t := range m.UnsafeTitles {
+		if strings.ToLower(t.Name) == strings.ToLower(name) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// AddTitle adds a new Title to the catalog.
+func (m *TitleManager) AddTitle(t *Title) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.UnsafeTitles = append(m.UnsafeTitles, t)
+}
+
+// SetKillReward sets the number of kills of a mob that earns a Title automatically. The reward is removed when
+// kills is zero.
+func (m *TitleManager) SetKillReward(t *Title, mob string, kills int) {
+	m.Lock()
+	defer m.Unlock()
+
+	if kills <= 0 {
+		t.KillMob = ""
+		t.Kills = 0
+		return
+	}
+
+	t.KillMob = mob
+	t.Kills = kills
+}
+
+// SetExploreReward sets the area that earns a Title automatically once every room within it has been explored. The
+// reward is removed when area is empty.
+func (m *TitleManager) SetExploreReward(t *Title, area string) {
+	m.Lock()
+	defer m.Unlock()
+
+	t.ExploreArea = area
+}
+
+// Titles returns the names of the titles the Character has earned.
+func (c *Character) Titles() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeTitles
+}
+
+// HasTitle returns true if the Character has earned a title.
+func (c *Character) HasTitle(name string) bool {
+	for _, t := range c.Titles() {
+		if strings.ToLower(t) == strings.ToLower(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GrantTitle adds a title from the catalog to the titles the Character has earned. It returns false if the
+// Character has already earned the title.
+func (c *Character) GrantTitle(t *Title) bool {
+	if c.HasTitle(t.Name) {
+		return false
+	}
+
+	c.Lock()
+	c.UnsafeTitles = append(c.UnsafeTitles, t.Name)
+	c.Unlock()
+
+	return true
+}
+
+// RevokeTitle removes a title from the titles the Character has earned. If the title is being displayed, the
+// Character's title is cleared. It returns false if the Character had not earned the title.
+func (c *Character) RevokeTitle(name string) bool {
+	c.Lock()
+	revoked := false
+	for i, t := range c.UnsafeTitles {
+		if strings.ToLower(t) == strings.ToLower(name) {
+			c.UnsafeTitles = append(c.UnsafeTitles[:i], c.UnsafeTitles[i+1:]...)
+			revoked = true
+			break
+		}
+	}
+	c.Unlock()
+
+	if revoked && strings.ToLower(c.Attribute(AttributeTitle)) == strings.ToLower(name) {
+		_ = c.SetAttribute(AttributeTitle, "")
+	}
+
+	return revoked
+}
@@ -0,0 +1,255 @@
+package armeria
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	TutorialAreaName = "Tutorial"
+	TutorialComplete = "complete"
+	TutorialSkipped  = "skipped"
+
+	tutorialItemName = "Cappuccino"
+	tutorialMobName  = "Training Dummy"
+)
+
+// TutorialStep is a single lesson within the new player tutorial.
+type TutorialStep struct {
+	Name         string
+	Instructions string
+	// Setup prepares the tutorial area when the step begins (ie: restocking an item to pick up).
+	Setup func(c *Character)
+	// Completed returns true when the command that was just processed satisfies the step.
+	Completed func(ctx *CommandContext) bool
+}
+
+var tutorialSteps = []*TutorialStep{
+	{
+		Name: "Movement",
+		Instructions: fmt.Sprintf(
+			"Walk into the storeroom by typing %s, or by clicking the room to the east on your minimap.",
+			TextStyle("/east", WithBold()),
+		),
+		Completed: func(ctx *CommandContext) bool {
+			return ctx.Command.Name == "move" && inTutorialRoom(ctx.Character, 1)
+		},
+	},
+	{
+		Name: "Inventory",
+		Instructions: fmt.Sprintf(
+			"Pick up the %s left on the shelf by typing %s. Items you pick up appear in your inventory.",
+			tutorialItemName,
+			TextStyle("/get cappuccino", WithBold()),
+		),
+		Setup: restockTutorialItem,
+		Completed: func(ctx *CommandContext) bool {
+			return ctx.Command.Name == "get" && ctx.Character.Inventory().Count() > 0
+		},
+	},
+	{
+		Name: "Chat",
+		Instructions: fmt.Sprintf(
+			"Head %s to the town square and greet everyone there by typing %s.",
+			TextStyle("/east", WithBold()),
+			TextStyle("/say Hello!", WithBold()),
+		),
+		Completed: func(ctx *CommandContext) bool {
+			return ctx.Command.Name == "say" && inTutorialRoom(ctx.Character, 2)
+		},
+	},
+	{
+		Name: "Combat",
+		Instructions: fmt.Sprintf(
+			"Continue %s to the training yard and practice your swing by typing %s. The fight goes on until one "+
+				"of you is defeated, or you leave the room.",
+			TextStyle("/east", WithBold()),
+			TextStyle("/attack training dummy", WithBold()),
+		),
+		Setup: restockTutorialMob,
+		Completed: func(ctx *CommandContext) bool {
+			return ctx.Command.Name == "attack" && inTutorialRoom(ctx.Character, 3) &&
+				Armeria.combatManager.InCombat(ctx.Character.ID())
+		},
+	},
+}
+
+// inTutorialRoom returns true if the Character is standing in the tutorial room at the x-coordinate.
+func inTutorialRoom(c *Character, x int) bool {
+	r := c.Room()
+	return r != nil && r.ParentArea.Name() == TutorialAreaName && r.Coords.X() == x
+}
+
+// restockTutorialItem places a new tutorial item in the Character's room if there are no items to pick up.
+func restockTutorialItem(c *Character) {
+	r := c.Room()
+	i := Armeria.itemManager.ItemByName(tutorialItemName)
+	if r == nil || i == nil || len(r.Here().Items()) > 0 {
+		return
+	}
+
+	ii := i.CreateInstance()
+	if err := r.Here().Add(ii.ID()); err != nil {
+		i.DeleteInstance(ii)
+		return
+	}
+
+	for _, char := range r.Here().Characters(true) {
+		char.Player().client.SyncRoomObjects()
+	}
+}
+
+// restockTutorialMob places a new tutorial mob in the training yard if the last one was defeated.
+func restockTutorialMob(c *Character) {
+	a := Armeria.worldManager.AreaByName(TutorialAreaName)
+	m := Armeria.mobManager.MobByName(tutorialMobName)
+	if a == nil || m == nil {
+		return
+	}
+	r := a.RoomAt(NewCoords(3, 0, 0, 0))
+	if r == nil || len(r.Here().Mobs()) > 0 {
+		return
+	}
+
+	mi := m.CreateInstance()
+	if err := r.Here().Add(mi.ID()); err != nil {
+		m.DeleteInstance(mi)
+		return
+	}
+
+	for _, char := range r.Here().Characters(true) {
+		char.Player().client.SyncRoomObjects()
+	}
+}
+
+// TutorialStep returns the index of the tutorial step the Character is on, or -1 if the Character is not taking
+// the tutorial.
+func (c *Character) TutorialStep() int {
+	step, err := strconv.Atoi(c.Attribute(AttributeTutorial))
+	if err != nil || step < 0 || step >= len(tutorialSteps) {
+		return -1
+	}
+
+	return step
+}
+
+// ShowTutorialStep shows the instructions for the tutorial step the Character is on.
+func (c *Character) ShowTutorialStep() {
+	step := c.TutorialStep()
+	if step < 0 || !c.Online() {
+		return
+	}
+
+	ts := tutorialSteps[step]
+	c.Player().client.ShowColorizedText(
+		fmt.Sprintf(
+			"%s %s",
+			TextStyle(fmt.Sprintf("[Tutorial %d/%d: %s]", step+1, len(tutorialSteps), ts.Name), WithBold()),
+			ts.Instructions,
+		),
+		ColorCmdHelp,
+	)
+}
+
+// StartTutorial moves the Character to the start of the tutorial area and shows the first step. The Character's
+// location is remembered so they can be returned to it when the tutorial ends. It returns false if the tutorial
+// area doesn't exist.
+func (c *Character) StartTutorial() bool {
+	a := Armeria.worldManager.AreaByName(TutorialAreaName)
+	if a == nil {
+		return false
+	}
+
+	start := a.RoomAt(NewCoords(0, 0, 0, 0))
+	if start == nil {
+		return false
+	}
+
+	if r := c.Room(); r != nil && r.ParentArea != a {
+		_ = c.SetAttribute(AttributeTutorialReturn, r.LocationString())
+	}
+	_ = c.SetAttribute(AttributeTutorial, "0")
+
+	c.Move(
+		start,
+		TextStyle("You find yourself in a bright, welcoming hall.", WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s left to begin the tutorial.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s arrived to begin the tutorial.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		"",
+	)
+
+	if c.Online() {
+		c.Player().client.ShowColorizedText(
+			fmt.Sprintf(
+				"Welcome to Armeria! This short tutorial will teach you the basics. Type %s to see your current "+
+					"lesson again, or %s if you've played before.",
+				TextStyle("/tutorial", WithBold()),
+				TextStyle("/tutorial skip", WithBold()),
+			),
+			ColorSuccess,
+		)
+		Armeria.commandManager.ProcessCommand(c.Player(), "look", false)
+	}
+
+	c.ShowTutorialStep()
+
+	return true
+}
+
+// AdvanceTutorial moves the Character on to the next tutorial step if the command that was just processed
+// completed the current one.
+func (c *Character) AdvanceTutorial(ctx *CommandContext) {
+	step := c.TutorialStep()
+	if step < 0 || !tutorialSteps[step].Completed(ctx) {
+		return
+	}
+
+	c.Player().client.ShowColorizedText(
+		fmt.Sprintf("You completed the %s lesson!", TextStyle(tutorialSteps[step].Name, WithBold())),
+		ColorSuccess,
+	)
+
+	if step+1 == len(tutorialSteps) {
+		c.EndTutorial(TutorialComplete)
+		return
+	}
+
+	_ = c.SetAttribute(AttributeTutorial, strconv.Itoa(step+1))
+	if setup := tutorialSteps[step+1].Setup; setup != nil {
+		setup(c)
+	}
+	c.ShowTutorialStep()
+}
+
+// EndTutorial marks the tutorial as complete or skipped, and returns the Character to where they were before the
+// tutorial began.
+func (c *Character) EndTutorial(state string) {
+	_ = c.SetAttribute(AttributeTutorial, state)
+
+	r := c.Room()
+	if r == nil || r.ParentArea.Name() != TutorialAreaName {
+		return
+	}
+
+	dest := Armeria.worldManager.RoomByLocation(c.Attribute(AttributeTutorialReturn))
+	if dest == nil {
+		dest = Armeria.startingKits.For(c.Attribute(AttributeClass)).StartRoom()
+	}
+	if dest == nil {
+		return
+	}
+
+	_ = c.SetAttribute(AttributeTutorialReturn, "")
+
+	c.Move(
+		dest,
+		TextStyle("You leave the tutorial behind and step out into the world.", WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s left the tutorial.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		TextStyle(fmt.Sprintf("%s arrived, fresh from the tutorial.", c.FormattedName()), WithUserColor(c, ColorMovement)),
+		"",
+	)
+
+	if c.Online() {
+		Armeria.commandManager.ProcessCommand(c.Player(), "look", false)
+	}
+}
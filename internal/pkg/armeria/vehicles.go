@@ -0,0 +1,239 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// RoomTypeDock is the room type where vehicles can stop when it has no terrain (ie: a harbor or a cart station).
+const RoomTypeDock = "dock"
+
+// VehicleRoute returns the directions a vehicle travels along, or nil if it is steered by its passengers.
+func VehicleRoute(v *ItemInstance) []string {
+	route := v.Attribute(AttributeVehicleRoute)
+	if len(route) == 0 {
+		return nil
+	}
+
+	var directions []string
+	for _, d := range strings.Split(route, ",") {
+		directions = append(directions, misc.NormalizeDirection(strings.TrimSpace(d)))
+	}
+	return directions
+}
+
+// NextVehicleStep returns the direction a vehicle will travel next along its route. Vehicles travel to the end of
+// their route, and then follow it back to where they started.
+func NextVehicleStep(v *ItemInstance) string {
+	route := VehicleRoute(v)
+	if len(route) == 0 {
+		return ""
+	}
+
+	v.RLock()
+	step := v.UnsafeVehicleStep % (len(route) * 2)
+	v.RUnlock()
+
+	if step < len(route) {
+		return route[step]
+	}
+	return misc.OppositeDirection(route[len(route)*2-step-1])
+}
+
+// AdvanceVehicle moves a vehicle to the next step along its route. The step is saved with the vehicle, so it picks
+// up where it left off after a restart.
+func AdvanceVehicle(v *ItemInstance) {
+	route := VehicleRoute(v)
+	if len(route) == 0 {
+		return
+	}
+
+	v.Lock()
+	defer v.Unlock()
+
+	v.UnsafeVehicleStep = (v.UnsafeVehicleStep + 1) % (len(route) * 2)
+}
+
+// VehicleCanEnter returns true if a vehicle can travel into a Room. Vehicles travel across the terrains they are
+// built for, and can stop at docks.
+func VehicleCanEnter(v *ItemInstance, r *Room) bool {
+	terrain := r.Attribute(AttributeTerrain)
+	if len(terrain) == 0 {
+		return r.Attribute(AttributeType) == RoomTypeDock
+	}
+
+	for _, t := range strings.Split(v.Attribute(AttributeVehicleTerrain), ",") {
+		if strings.TrimSpace(t) == terrain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Vehicle returns the vehicle the Character is aboard, or nil if they are on foot.
+func (c *Character) Vehicle() *ItemInstance {
+	id := c.TempAttribute(TempAttributeVehicle)
+	if len(id) == 0 {
+		return nil
+	}
+
+	o, rt := Armeria.registry.Get(id)
+	if rt != RegistryTypeItemInstance {
+		return nil
+	}
+
+	// Passengers are always in the same room as their vehicle.
+	v := o.(*ItemInstance)
+	if v.Room() == nil || v.Room() != c.Room() {
+		return nil
+	}
+
+	return v
+}
+
+// Board puts the Character aboard a vehicle.
+func (c *Character) Board(v *ItemInstance) error {
+	if v.Attribute(AttributeType) != ItemTypeVehicle {
+		return errors.New("you can't board that")
+	}
+	if v.Room() != c.Room() {
+		return errors.New("that isn't here")
+	}
+	if c.Vehicle() != nil {
+		return errors.New("you're already aboard a vehicle")
+	}
+
+	c.SetTempAttribute(TempAttributeVehicle, v.ID())
+	return nil
+}
+
+// Disembark takes the Character off of the vehicle they are aboard.
+func (c *Character) Disembark() error {
+	if c.Vehicle() == nil {
+		return errors.New("you aren't aboard a vehicle")
+	}
+
+	if t := TerrainByName(c.Room().Attribute(AttributeTerrain)); t != nil && !t.Passable {
+		return fmt.Errorf("you can't disembark into the %s", strings.ToLower(t.Title))
+	}
+
+	c.SetTempAttribute(TempAttributeVehicle, "")
+	return nil
+}
+
+// Passengers returns the online characters aboard a vehicle.
+func Passengers(v *ItemInstance) []*Character {
+	r := v.Room()
+	if r == nil {
+		return nil
+	}
+
+	var passengers []*Character
+	for _, c := range r.Here().Characters(true) {
+		if c.TempAttribute(TempAttributeVehicle) == v.ID() {
+			passengers = append(passengers, c)
+		}
+	}
+
+	return passengers
+}
+
+// DriveVehicle moves a vehicle in a direction, carrying its passengers along with it.
+func DriveVehicle(v *ItemInstance, direction string) error {
+	from := v.Room()
+	if from == nil {
+		return errors.New("the vehicle isn't anywhere")
+	}
+
+	to := from.ConnectedRoom(direction)
+	if to == nil {
+		return errors.New("there's no way to go in that direction")
+	}
+	if !VehicleCanEnter(v, to) {
+		return fmt.Errorf("the %s can't travel there", v.Name())
+	}
+
+	passengers := Passengers(v)
+
+	from.Here().Remove(v.ID())
+	if err := to.Here().Add(v.ID()); err != nil {
+		_ = from.Here().Add(v.ID())
+		return errors.New("there's no room for it there")
+	}
+
+	for _, c := range from.Here().Characters(true, passengers...) {
+		c.Player().client.ShowText(
+			TextStyle(
+				fmt.Sprintf("The %s travels %s.", v.FormattedName(), misc.MoveToStringFromDir("to the", direction)),
+				WithUserColor(c, ColorMovement),
+			),
+		)
+		c.Player().client.SyncRoomObjects()
+	}
+	for _, c := range to.Here().Characters(true) {
+		c.Player().client.ShowText(
+			TextStyle(
+				fmt.Sprintf(
+					"The %s arrives from %s.",
+					v.FormattedName(),
+					misc.MoveFromStringFromDir("the", misc.OppositeDirection(direction)),
+				),
+				WithUserColor(c, ColorMovement),
+			),
+		)
+		c.Player().client.SyncRoomObjects()
+	}
+
+	for _, c := range passengers {
+		c.Move(
+			to,
+			TextStyle(
+				fmt.Sprintf("The %s carries you %s.", v.FormattedName(), misc.MoveToStringFromDir("to the", direction)),
+				WithUserColor(c, ColorMovement),
+			),
+			"",
+			"",
+			"",
+		)
+		Armeria.commandManager.ProcessCommand(c.Player(), "glance", false)
+	}
+
+	return nil
+}
+
+// MoveVehicles moves the vehicles that travel along routes to the next room on their route.
+func MoveVehicles() {
+	for _, item := range Armeria.itemManager.ItemsByAttribute(AttributeType, ItemTypeVehicle) {
+		for _, v := range item.Instances() {
+			if v.Room() == nil || !HostsArea(v.Room().ParentArea) {
+				continue
+			}
+
+			direction := NextVehicleStep(v)
+			if len(direction) == 0 {
+				continue
+			}
+
+			if err := DriveVehicle(v, direction); err != nil {
+				// Let builders know.
+				Armeria.channels[ChannelBuilders].Broadcast(
+					nil,
+					fmt.Sprintf("Vehicle %s is stuck on its route at %s: %s.", v.FormattedName(), v.Room().LocationString(), err),
+				)
+				Armeria.log.Debug("vehicle is stuck on its route",
+					zap.String("vehicle", v.ID()),
+					zap.String("direction", direction),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			AdvanceVehicle(v)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package armeria
+
+import (
+	"armeria/internal/pkg/misc"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// VendorMaxRestock is the longest an item on a ledger can take to restock, in seconds.
+const VendorMaxRestock = 86400
+
+// VendorManager tracks the stock that vendors have sold and are waiting to restock. Stock isn't kept across
+// restarts, so vendors start the day fully stocked.
+type VendorManager struct {
+	sync.RWMutex
+	unsafeRestocks map[string][]time.Time
+}
+
+// ShopItem is an item shown in the shop window on the client.
+type ShopItem struct {
+	Name        string `json:"name"`
+	Picture     string `json:"picture"`
+	Description string `json:"description"`
+	Price       string `json:"price"`
+	Stock       int    `json:"stock"`
+}
+
+// Shop is the data shown in the shop window on the client: what a vendor sells, and what it will buy from the
+// character.
+type Shop struct {
+	Vendor     string      `json:"vendor"`
+	VendorUUID string      `json:"vendorUUID"`
+	Buy        []*ShopItem `json:"buy"`
+	Sell       []*ShopItem `json:"sell"`
+}
+
+// NewVendorManager returns a new VendorManager.
+func NewVendorManager() *VendorManager {
+	return &VendorManager{
+		unsafeRestocks: make(map[string][]time.Time),
+	}
+}
+
+// vendorStockKey returns the key that a vendor's stock of an item is tracked under.
+func vendorStockKey(mi *MobInstance, entry *LedgerEntry) string {
+	return mi.ID() + ":" + strings.ToLower(entry.ItemName)
+}
+
+// Stock returns how many of an item on a ledger a vendor has left to sell, or -1 if its stock is unlimited.
+func (m *VendorManager) Stock(mi *MobInstance, entry *LedgerEntry) int {
+	if entry.Stock <= 0 {
+		return -1
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	return entry.Stock - len(m.unsafePendingRestocks(vendorStockKey(mi, entry)))
+}
+
+// TryTake reserves one of an item on a ledger for a sale, which restocks after the entry's restock time. It returns
+// the restock time as a token for Release, or false, reserving nothing, if the vendor has sold out. The stock is
+// checked and taken together, so two characters can't both buy the last one.
+func (m *VendorManager) TryTake(mi *MobInstance, entry *LedgerEntry) (time.Time, bool) {
+	if entry.Stock <= 0 {
+		return time.Time{}, true
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := vendorStockKey(mi, entry)
+	restocks := m.unsafePendingRestocks(key)
+	if len(restocks) >= entry.Stock {
+		return time.Time{}, false
+	}
+
+	restock := time.Now().Add(time.Duration(entry.Restock) * time.Second)
+	m.unsafeRestocks[key] = append(restocks, restock)
+	return restock, true
+}
+
+// Release puts back an item reserved with TryTake, for when the sale falls through. Only the reservation the token
+// was returned for is removed, so that other sales made in the meantime keep theirs.
+func (m *VendorManager) Release(mi *MobInstance, entry *LedgerEntry, token time.Time) {
+	if entry.Stock <= 0 {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	key := vendorStockKey(mi, entry)
+	restocks := m.unsafeRestocks[key]
+	for i, restock := range restocks {
+		if restock.Equal(token) {
+			m.unsafeRestocks[key] = append(restocks[:i], restocks[i+1:]...)
+			return
+		}
+	}
+}
+
+// unsafePendingRestocks returns the restock times of an item that haven't passed yet, forgetting the rest. The
+// caller must hold the lock.
+func (m *VendorManager) unsafePendingRestocks(key string) []time.Time {
+	restocks := m.unsafeRestocks[key]
+	now := time.Now()
+	for len(restocks) > 0 && !now.Before(restocks[0]) {
+		restocks = restocks[1:]
+	}
+	m.unsafeRestocks[key] = restocks
+
+	return restocks
+}
+
+// VendorLedgers returns the ledgers the MobInstance buys and sells items from: the ledger in its ledger attribute,
+// along with any ledgers its script has opened with shop.
+func (mi *MobInstance) VendorLedgers() []*Ledger {
+	var ledgers []*Ledger
+	if name := mi.Attribute(AttributeLedger); len(name) > 0 {
+		if l := Armeria.ledgerManager.LedgerByName(name); l != nil {
+			ledgers = append(ledgers, l)
+		}
+	}
+	for _, l := range mi.ItemLedgers() {
+		known := false
+		for _, existing := range ledgers {
+			if existing == l {
+				known = true
+				break
+			}
+		}
+		if !known {
+			ledgers = append(ledgers, l)
+		}
+	}
+	return ledgers
+}
+
+// Vendor returns true if the MobInstance buys or sells items.
+func (mi *MobInstance) Vendor() bool {
+	return len(mi.VendorLedgers()) > 0
+}
+
+// ShopFor returns what the MobInstance sells to a Character, and what it will buy from them.
+func (mi *MobInstance) ShopFor(c *Character) *Shop {
+	shop := &Shop{
+		Vendor:     mi.Name(),
+		VendorUUID: mi.ID(),
+		Buy:        []*ShopItem{},
+		Sell:       []*ShopItem{},
+	}
+
+	for _, ledger := range mi.VendorLedgers() {
+		for _, entry := range ledger.Entries() {
+			if entry.BuyPrice <= 0 || !c.MeetsRequirements(entry.Requires) {
+				continue
+			}
+			item := Armeria.itemManager.ItemByName(entry.ItemName)
+			if item == nil {
+				continue
+			}
+			shop.Buy = append(shop.Buy, &ShopItem{
+				Name:        item.Name(),
+				Picture:     item.Attribute(AttributePicture),
+				Description: item.Attribute(AttributeDescription),
+				Price:       misc.Money.FormatMoney(ledger.BuyPrice(entry)),
+				Stock:       Armeria.vendorManager.Stock(mi, entry),
+			})
+		}
+	}
+
+	for _, ii := range c.Inventory().Items() {
+		if ii.IsQuestItem() {
+			continue
+		}
+		if ledger, entry := mi.ledgerEntry(ii.Name()); entry != nil && entry.SellPrice > 0 {
+			shop.Sell = append(shop.Sell, &ShopItem{
+				Name:        ii.Name(),
+				Picture:     ii.Attribute(AttributePicture),
+				Description: ii.Attribute(AttributeDescription),
+				Price:       misc.Money.FormatMoney(ledger.SellPrice(entry)),
+				Stock:       -1,
+			})
+		}
+	}
+
+	return shop
+}
+
+// ledgerEntry returns the first of the MobInstance's ledgers with an item on it, along with the item's entry.
+func (mi *MobInstance) ledgerEntry(itemName string) (*Ledger, *LedgerEntry) {
+	for _, ledger := range mi.VendorLedgers() {
+		if entry := ledger.Contains(itemName); entry != nil {
+			return ledger, entry
+		}
+	}
+	return nil, nil
+}
+
+// ShopJSON returns the JSON-encoded shop of a vendor, as seen by the Character.
+func (c *Character) ShopJSON(mi *MobInstance) string {
+	shopJSON, err := json.Marshal(mi.ShopFor(c))
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal shop data",
+			zap.String("character", c.UUID),
+			zap.String("vendor", mi.ID()),
+			zap.Error(err),
+		)
+	}
+
+	return string(shopJSON)
+}
@@ -1,9 +1,11 @@
 package armeria
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"go.uber.org/zap"
 
@@ -29,18 +31,42 @@ func HandleScriptRead(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ot != "mob" {
-		w.WriteHeader(http.StatusBadRequest)
+	if ot == GlobalScriptName {
+		if !c.HasGlobalPermission("CAN_SYSOP") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		_, _ = w.Write([]byte(ReadGlobalScript()))
 		return
 	}
 
-	m := Armeria.mobManager.MobByName(on)
-	if m == nil {
-		w.WriteHeader(http.StatusBadRequest)
+	// Mob and item scripts apply world-wide, so area-scoped builders cannot edit them.
+	if !c.HasGlobalPermission("CAN_BUILD") {
+		w.WriteHeader(http.StatusForbidden)
 		return
 	}
 
-	s := ReadMobScript(m)
+	var s string
+	switch ot {
+	case "mob":
+		m := Armeria.mobManager.MobByName(on)
+		if m == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s = ReadMobScript(m)
+	case "item":
+		i := Armeria.itemManager.ItemByName(on)
+		if i == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s = ReadItemScript(i)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
 
 	_, _ = w.Write([]byte(s))
 }
@@ -63,29 +89,84 @@ func HandleScriptWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ot != "mob" {
-		w.WriteHeader(http.StatusBadRequest)
-		return
+	var m *Mob
+	var i *Item
+	label := TextStyle(GlobalScriptName, WithBold())
+	if ot == GlobalScriptName {
+		if !c.HasGlobalPermission("CAN_SYSOP") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	} else {
+		// Mob and item scripts apply world-wide, so area-scoped builders cannot edit them.
+		if !c.HasGlobalPermission("CAN_BUILD") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch ot {
+		case "mob":
+			m = Armeria.mobManager.MobByName(on)
+			if m == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			label = TextStyle(m.UnsafeName, WithBold())
+		case "item":
+			i = Armeria.itemManager.ItemByName(on)
+			if i == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			label = TextStyle(i.Name(), WithBold())
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
 	}
 
-	m := Armeria.mobManager.MobByName(on)
-	if m == nil {
+	script, err := ioutil.ReadAll(r.Body)
+	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	script, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	cp := c.Player()
+
+	if errs := ValidateScript(string(script)); len(errs) > 0 {
+		if cp != nil {
+			var lines []string
+			for _, e := range errs {
+				lines = append(lines, e.Error())
+			}
+			cp.client.ShowColorizedText(
+				fmt.Sprintf(
+					"The script for %s was not saved because it has errors:\n%s",
+					label,
+					strings.Join(lines, "\n"),
+				),
+				ColorError,
+			)
+		}
+
+		b, _ := json.Marshal(map[string]interface{}{"errors": errs})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write(b)
 		return
 	}
 
-	WriteMobScript(m, string(script))
+	if m != nil {
+		WriteMobScript(m, string(script))
+	} else if i != nil {
+		WriteItemScript(i, string(script))
+	} else {
+		WriteGlobalScript(string(script))
+	}
 
-	cp := c.Player()
 	if cp != nil {
 		cp.client.ShowColorizedText(
-			fmt.Sprintf("The script has been saved to %s.", TextStyle(m.UnsafeName, WithBold())),
+			fmt.Sprintf("The script has been saved to %s.", label),
 			ColorSuccess,
 		)
 	}
@@ -93,15 +174,50 @@ func HandleScriptWrite(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-// InitWeb will initialize the HTTP web server, for serving the web client
+// InitWeb will initialize the HTTP web server, for serving the web client. When the server is API-only, the web
+// client's static files are expected to be served by something else.
 func InitWeb(port int) {
 	Armeria.log.Info("serving http requests",
 		zap.String("path", Armeria.publicPath),
 		zap.Int("port", port),
+		zap.Bool("apiOnly", Armeria.apiOnly),
 	)
 
-	// Set up routes
 	r := mux.NewRouter()
+	registerAPIRoutes(r)
+	if !Armeria.apiOnly {
+		registerStaticRoutes(r)
+	}
+
+	err := http.ListenAndServe(fmt.Sprintf(":%d", port),
+		handlers.CORS(handlers.AllowedOrigins([]string{"*"}))(r),
+	)
+
+	if err != nil {
+		Armeria.log.Fatal("error listening to http",
+			zap.Error(err),
+		)
+	}
+}
+
+// registerAPIRoutes sets up the routes served by the game engine itself: the websocket, and the APIs backed by
+// live game data.
+func registerAPIRoutes(r *mux.Router) {
+	r.PathPrefix("/oi/").Handler(http.StripPrefix("/oi/", http.FileServer(http.Dir(Armeria.objectImagesPath))))
+	r.HandleFunc("/script/{objectType}/{objectName}/{accessName}/{accessKey}", HandleScriptRead).Methods("GET")
+	r.HandleFunc("/script/{objectType}/{objectName}/{accessName}/{accessKey}", HandleScriptWrite).Methods("POST")
+	r.HandleFunc("/c/{name}.json", HandleProfileJSON).Methods("GET")
+	r.HandleFunc("/c/{name}", HandleProfile).Methods("GET")
+	if Armeria.metrics {
+		r.HandleFunc("/metrics", HandleMetrics).Methods("GET")
+	}
+	r.PathPrefix("/ws").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ServeWs(w, r)
+	})
+}
+
+// registerStaticRoutes sets up the routes that serve the web client's built files from the public path.
+func registerStaticRoutes(r *mux.Router) {
 	publicRoutes := []string{
 		"/js/",
 		"/css/",
@@ -117,23 +233,7 @@ func InitWeb(port int) {
 	for _, route := range publicRoutes {
 		r.PathPrefix(route).Handler(http.FileServer(http.Dir(Armeria.publicPath)))
 	}
-	r.PathPrefix("/oi/").Handler(http.StripPrefix("/oi/", http.FileServer(http.Dir(Armeria.objectImagesPath))))
-	r.HandleFunc("/script/{objectType}/{objectName}/{accessName}/{accessKey}", HandleScriptRead).Methods("GET")
-	r.HandleFunc("/script/{objectType}/{objectName}/{accessName}/{accessKey}", HandleScriptWrite).Methods("POST")
-	r.PathPrefix("/ws").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ServeWs(w, r)
-	})
 	r.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		http.ServeFile(w, req, fmt.Sprintf("%s/index.html", Armeria.publicPath))
 	})
-
-	err := http.ListenAndServe(fmt.Sprintf(":%d", port),
-		handlers.CORS(handlers.AllowedOrigins([]string{"*"}))(r),
-	)
-
-	if err != nil {
-		Armeria.log.Fatal("error listening to http",
-			zap.Error(err),
-		)
-	}
 }
@@ -0,0 +1,410 @@
+package armeria
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go.uber.org/zap"
+)
+
+const (
+	TerrainPlains    = "plains"
+	TerrainForest    = "forest"
+	TerrainHills     = "hills"
+	TerrainMountains = "mountains"
+	TerrainDesert    = "desert"
+	TerrainWater     = "water"
+
+	// WildernessMinimapRadius is how many wilderness rooms around a character are shown on their minimap.
+	WildernessMinimapRadius = 6
+)
+
+// Terrain describes the land of a wilderness room.
+type Terrain struct {
+	Name        string
+	Title       string
+	Description string
+	Color       string
+	// TravelTime is how long it takes to travel into a room with this terrain.
+	TravelTime time.Duration
+	// Passable is false when characters cannot travel into a room with this terrain on foot.
+	Passable bool
+}
+
+var terrains = []*Terrain{
+	{
+		Name:        TerrainPlains,
+		Title:       "Open Plains",
+		Description: "Tall grass sways in the wind across the rolling plains, stretching as far as you can see.",
+		Color:       "150,190,90",
+		TravelTime:  1 * time.Second,
+		Passable:    true,
+	},
+	{
+		Name:        TerrainForest,
+		Title:       "Dense Forest",
+		Description: "Old trees crowd together overhead, and the undergrowth tugs at you with every step.",
+		Color:       "40,120,60",
+		TravelTime:  3 * time.Second,
+		Passable:    true,
+	},
+	{
+		Name:        TerrainHills,
+		Title:       "Rolling Hills",
+		Description: "Grassy hills rise and fall around you, with rocky outcrops dotting their slopes.",
+		Color:       "130,150,80",
+		TravelTime:  2 * time.Second,
+		Passable:    true,
+	},
+	{
+		Name:        TerrainMountains,
+		Title:       "Rocky Mountains",
+		Description: "A narrow trail winds between jagged peaks, and loose stones skitter away beneath your feet.",
+		Color:       "140,130,120",
+		TravelTime:  5 * time.Second,
+		Passable:    true,
+	},
+	{
+		Name:        TerrainDesert,
+		Title:       "Sandy Desert",
+		Description: "Dunes of hot sand shift in the wind, and the sun beats down without mercy.",
+		Color:       "220,190,120",
+		TravelTime:  3 * time.Second,
+		Passable:    true,
+	},
+	{
+		Name:        TerrainWater,
+		Title:       "Deep Water",
+		Description: "Dark water stretches out in every direction.",
+		Color:       "50,90,170",
+		Passable:    false,
+	},
+}
+
+// TerrainNames returns the names of every Terrain.
+func TerrainNames() []string {
+	var names []string
+	for _, t := range terrains {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// TerrainByName returns the matching Terrain, by name.
+func TerrainByName(name string) *Terrain {
+	for _, t := range terrains {
+		if t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// WildernessManager generates the rooms of wilderness areas on demand. Wilderness rooms are transient: they are
+// never written to disk, and are unloaded once no one is in them.
+type WildernessManager struct {
+	sync.RWMutex
+	unsafeRooms map[string]*Room
+}
+
+// Rooms returns the wilderness rooms that are currently loaded.
+func (m *WildernessManager) Rooms() []*Room {
+	m.RLock()
+	defer m.RUnlock()
+
+	var rooms []*Room
+	for _, r := range m.unsafeRooms {
+		rooms = append(rooms, r)
+	}
+	return rooms
+}
+
+// NewWildernessManager returns a new WildernessManager.
+func NewWildernessManager() *WildernessManager {
+	return &WildernessManager{
+		unsafeRooms: make(map[string]*Room),
+	}
+}
+
+// Wilderness returns true if rooms in the Area are generated from the terrain when they haven't been built.
+func (a *Area) Wilderness() bool {
+	return a.Attribute(AttributeWilderness) == "true"
+}
+
+// InWildernessBounds returns true if the coordinates are within the Area's wilderness. The wilderness is a flat
+// grid centered on 0,0,0.
+func (a *Area) InWildernessBounds(x, y, z int) bool {
+	size := a.AttributeInt(AttributeWildernessSize)
+	return a.Wilderness() && z == 0 && x >= -size && x <= size && y >= -size && y <= size
+}
+
+// TerrainAt returns the Terrain of a wilderness Area at a particular x and y coordinate. Terrain is generated from
+// noise seeded by the Area, so the same coordinates always have the same Terrain.
+func (a *Area) TerrainAt(x, y int) *Terrain {
+	seed := wildernessSeed(a.ID())
+	elevation := wildernessNoise(seed, x, y, 8)
+	moisture := wildernessNoise(seed^0x9e3779b9, x, y, 12)
+
+	switch {
+	case elevation < 0.3:
+		return TerrainByName(TerrainWater)
+	case elevation > 0.78:
+		return TerrainByName(TerrainMountains)
+	case elevation > 0.68:
+		return TerrainByName(TerrainHills)
+	case moisture < 0.35:
+		return TerrainByName(TerrainDesert)
+	case moisture > 0.55:
+		return TerrainByName(TerrainForest)
+	}
+
+	return TerrainByName(TerrainPlains)
+}
+
+// wildernessSeed returns the noise seed for an Area.
+func wildernessSeed(areaID string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(areaID))
+	return h.Sum32()
+}
+
+// wildernessLattice returns a random value between 0 and 1 for a point on the noise lattice.
+func wildernessLattice(seed uint32, x, y int) float64 {
+	// Mix the bits thoroughly, so neighboring points aren't similar.
+	h := seed ^ uint32(x)*0x27d4eb2d ^ uint32(y)*0x165667b1
+	h ^= h >> 15
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return float64(h) / math.MaxUint32
+}
+
+// wildernessNoise returns smoothed value noise between 0 and 1, where scale is the distance between lattice points.
+func wildernessNoise(seed uint32, x, y, scale int) float64 {
+	x0 := int(math.Floor(float64(x) / float64(scale)))
+	y0 := int(math.Floor(float64(y) / float64(scale)))
+	fx := float64(x-x0*scale) / float64(scale)
+	fy := float64(y-y0*scale) / float64(scale)
+
+	// Smooth the interpolation so the lattice isn't visible as straight lines.
+	fx = fx * fx * (3 - 2*fx)
+	fy = fy * fy * (3 - 2*fy)
+
+	top := wildernessLattice(seed, x0, y0)*(1-fx) + wildernessLattice(seed, x0+1, y0)*fx
+	bottom := wildernessLattice(seed, x0, y0+1)*(1-fx) + wildernessLattice(seed, x0+1, y0+1)*fx
+
+	return top*(1-fy) + bottom*fy
+}
+
+// RoomAt returns the wilderness Room at a particular Coords within an Area, generating it if it isn't loaded. It
+// returns nil if the Area isn't a wilderness, or the coordinates are outside of it.
+func (m *WildernessManager) RoomAt(a *Area, c *Coords) *Room {
+	if !a.InWildernessBounds(c.X(), c.Y(), c.Z()) {
+		return nil
+	}
+
+	loc := fmt.Sprintf("%s,%d,%d,%d", a.Name(), c.X(), c.Y(), c.Z())
+
+	m.Lock()
+	defer m.Unlock()
+
+	if r, ok := m.unsafeRooms[loc]; ok {
+		return r
+	}
+
+	t := a.TerrainAt(c.X(), c.Y())
+	r := &Room{
+		UUID:   uuid.New().String(),
+		Coords: NewCoords(c.X(), c.Y(), 0, 0),
+		UnsafeAttributes: map[string]string{
+			AttributeTitle:       t.Title,
+			AttributeDescription: t.Description,
+			AttributeColor:       t.Color,
+			AttributeTerrain:     t.Name,
+		},
+		UnsafeHere: NewObjectContainer(0),
+		transient:  true,
+	}
+	r.Init(a)
+
+	m.unsafeRooms[loc] = r
+
+	return r
+}
+
+// Unload removes the wilderness rooms that don't have any online characters in them. Offline characters are
+// returned to their wilderness room when they log in, while the mob and item instances left behind are deleted, since
+// the rooms holding them are never saved.
+func (m *WildernessManager) Unload() {
+	m.Lock()
+	defer m.Unlock()
+
+	for loc, r := range m.unsafeRooms {
+		if len(r.Here().Characters(true)) > 0 || roomHasVehicle(r) {
+			continue
+		}
+
+		for _, o := range r.Here().All() {
+			obj, ok := o.(ContainerObject)
+			if !ok {
+				continue
+			}
+			r.Here().Remove(obj.ID())
+			switch inst := obj.(type) {
+			case *MobInstance:
+				inst.Delete()
+			case *ItemInstance:
+				inst.Delete()
+			}
+		}
+
+		r.Deinit()
+		delete(m.unsafeRooms, loc)
+	}
+}
+
+// roomHasVehicle returns true if there is a vehicle in the Room, which keeps wilderness rooms loaded so vehicles can
+// travel through them.
+func roomHasVehicle(r *Room) bool {
+	for _, ii := range r.Here().Items() {
+		if ii.Attribute(AttributeType) == ItemTypeVehicle {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnloadWildernessRooms removes the wilderness rooms that no one is in.
+func UnloadWildernessRooms() {
+	Armeria.wildernessManager.Unload()
+}
+
+// Transient returns true if the Room was generated for a wilderness, and isn't written to disk.
+func (r *Room) Transient() bool {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.transient
+}
+
+// TravelTime returns how long it takes to travel into the Room, based on its terrain.
+func (r *Room) TravelTime() time.Duration {
+	if t := TerrainByName(r.Attribute(AttributeTerrain)); t != nil {
+		return t.TravelTime
+	}
+
+	return 0
+}
+
+// WildernessLocation returns the location of the wilderness room the Character is in, if any.
+func (c *Character) WildernessLocation() string {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.UnsafeWilderness
+}
+
+// SetWildernessLocation sets the location of the wilderness room the Character is in.
+func (c *Character) SetWildernessLocation(loc string) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.UnsafeWilderness = loc
+}
+
+// RestoreWildernessRoom returns the Character to the wilderness room they were in, if it was unloaded (or the
+// server restarted) while they were offline.
+func (c *Character) RestoreWildernessRoom() {
+	loc := c.WildernessLocation()
+	if c.Room() != nil || len(loc) == 0 {
+		return
+	}
+
+	sections := strings.SplitN(loc, ",", 2)
+	if len(sections) != 2 {
+		return
+	}
+
+	a := Armeria.worldManager.AreaByName(sections[0])
+	co := NewCoordsFromString(sections[1])
+	if a == nil || co == nil {
+		return
+	}
+
+	r := Armeria.wildernessManager.RoomAt(a, co)
+	if r == nil {
+		return
+	}
+
+	if err := r.Here().Add(c.ID()); err != nil {
+		Armeria.log.Error("failed to restore character to the wilderness",
+			zap.String("character", c.Name()),
+			zap.String("location", loc),
+			zap.Error(err),
+		)
+	}
+}
+
+// wildernessMinimapVisible returns true if a wilderness room is shown on the minimap of a Character at the center
+// coordinates.
+func (a *Area) wildernessMinimapVisible(cx, cy, x, y int) bool {
+	dx, dy := x-cx, y-cy
+	return a.InWildernessBounds(x, y, 0) &&
+		dx >= -WildernessMinimapRadius && dx <= WildernessMinimapRadius &&
+		dy >= -WildernessMinimapRadius && dy <= WildernessMinimapRadius
+}
+
+// wildernessMinimapRooms returns the minimap data for the wilderness rooms around a Character that haven't been
+// built, without generating them.
+func (a *Area) wildernessMinimapRooms(cx, cy int) []map[string]interface{} {
+	var rooms []map[string]interface{}
+	for x := cx - WildernessMinimapRadius; x <= cx+WildernessMinimapRadius; x++ {
+		for y := cy - WildernessMinimapRadius; y <= cy+WildernessMinimapRadius; y++ {
+			if !a.InWildernessBounds(x, y, 0) || a.RoomAt(NewCoords(x, y, 0, 0)) != nil {
+				continue
+			}
+
+			t := a.TerrainAt(x, y)
+			room := map[string]interface{}{
+				"title": t.Title,
+				"color": t.Color,
+				"type":  "generic",
+				"x":     x,
+				"y":     y,
+				"z":     0,
+				"up":    "",
+				"down":  "",
+			}
+
+			neighbors := map[string][]int{
+				NorthDirection: {x, y + 1},
+				SouthDirection: {x, y - 1},
+				EastDirection:  {x + 1, y},
+				WestDirection:  {x - 1, y},
+			}
+			for dir, n := range neighbors {
+				room[dir] = ""
+				if !t.Passable || !a.wildernessMinimapVisible(cx, cy, n[0], n[1]) {
+					continue
+				}
+				if a.RoomAt(NewCoords(n[0], n[1], 0, 0)) == nil && !a.TerrainAt(n[0], n[1]).Passable {
+					continue
+				}
+				room[dir] = fmt.Sprintf("%s,%d,%d,0", a.UnsafeName, n[0], n[1])
+			}
+
+			rooms = append(rooms, room)
+		}
+	}
+
+	return rooms
+}
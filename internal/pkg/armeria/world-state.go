@@ -0,0 +1,115 @@
+package armeria
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// WorldStateManager holds global key/value pairs that scripts use to coordinate story arcs across mobs and areas
+// (ie: "bridge_destroyed" set to "true"). The values survive restarts.
+type WorldStateManager struct {
+	sync.RWMutex
+	dataFile     string
+	UnsafeValues map[string]string `json:"values"`
+}
+
+// NewWorldStateManager creates a new WorldStateManager.
+func NewWorldStateManager() *WorldStateManager {
+	m := &WorldStateManager{
+		dataFile: fmt.Sprintf("%s/world-state.json", Armeria.dataPath),
+	}
+
+	m.LoadWorldState()
+
+	return m
+}
+
+// LoadWorldState loads the world state from disk into memory.
+func (m *WorldStateManager) LoadWorldState() {
+	m.Lock()
+	defer m.Unlock()
+
+	stateFile, err := os.Open(m.dataFile)
+	defer stateFile.Close()
+
+	if err != nil {
+		Armeria.log.Fatal("failed to load data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	jsonParser := json.NewDecoder(stateFile)
+
+	err = jsonParser.Decode(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to decode data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	if m.UnsafeValues == nil {
+		m.UnsafeValues = make(map[string]string)
+	}
+
+	Armeria.log.Info("world state loaded",
+		zap.Int("count", len(m.UnsafeValues)),
+	)
+}
+
+// SaveWorldState writes the in-memory world state to disk.
+func (m *WorldStateManager) SaveWorldState() {
+	m.RLock()
+	defer m.RUnlock()
+
+	stateFile, err := os.Create(m.dataFile)
+	defer stateFile.Close()
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		Armeria.log.Fatal("failed to marshal data",
+			zap.Error(err),
+		)
+	}
+
+	bytes, err := stateFile.Write(raw)
+	if err != nil {
+		Armeria.log.Fatal("failed to write data file",
+			zap.String("file", m.dataFile),
+			zap.Error(err),
+		)
+	}
+
+	_ = stateFile.Sync()
+
+	Armeria.log.Info("wrote data to file",
+		zap.String("file", m.dataFile),
+		zap.Int("bytes", bytes),
+	)
+}
+
+// Get returns the value of a world state key, or an empty string if it isn't set.
+func (m *WorldStateManager) Get(key string) string {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.UnsafeValues[key]
+}
+
+// Set sets the value of a world state key. Setting an empty value removes the key.
+func (m *WorldStateManager) Set(key, value string) {
+	m.Lock()
+	defer m.Unlock()
+
+	if len(value) == 0 {
+		delete(m.UnsafeValues, key)
+		return
+	}
+
+	m.UnsafeValues[key] = value
+}
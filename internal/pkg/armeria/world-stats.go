@@ -0,0 +1,60 @@
+package armeria
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorldStats is a summary of the content within the game world.
+type WorldStats struct {
+	Areas             int
+	Rooms             int
+	Items             int
+	ItemInstances     int
+	Mobs              int
+	MobInstances      int
+	OrphanedObjects   []string
+	RoomsMissingDesc  []*Room
+	MobsMissingScript []*Mob
+}
+
+// CollectWorldStats walks the game world and returns a summary of its content. Instances that aren't within
+// any container (room, inventory, etc) are considered orphaned.
+func CollectWorldStats() *WorldStats {
+	ws := &WorldStats{}
+
+	for _, a := range Armeria.worldManager.Areas() {
+		ws.Areas++
+		for _, r := range a.Rooms() {
+			ws.Rooms++
+			if len(strings.TrimSpace(r.Attribute(AttributeDescription))) == 0 {
+				ws.RoomsMissingDesc = append(ws.RoomsMissingDesc, r)
+			}
+		}
+	}
+
+	for _, i := range Armeria.itemManager.Items() {
+		ws.Items++
+		for _, ii := range i.Instances() {
+			ws.ItemInstances++
+			if Armeria.registry.GetObjectContainer(ii.ID()) == nil {
+				ws.OrphanedObjects = append(ws.OrphanedObjects, fmt.Sprintf("%s (%s)", ii.Name(), ii.ID()))
+			}
+		}
+	}
+
+	for _, m := range Armeria.mobManager.Mobs() {
+		ws.Mobs++
+		if len(strings.TrimSpace(m.Script())) == 0 {
+			ws.MobsMissingScript = append(ws.MobsMissingScript, m)
+		}
+		for _, mi := range m.Instances() {
+			ws.MobInstances++
+			if Armeria.registry.GetObjectContainer(mi.ID()) == nil {
+				ws.OrphanedObjects = append(ws.OrphanedObjects, fmt.Sprintf("%s (%s)", mi.Name(), mi.ID()))
+			}
+		}
+	}
+
+	return ws
+}
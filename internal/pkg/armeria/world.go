@@ -145,3 +145,23 @@ func (m *WorldManager) Areas() []*Area {
 
 	return m.UnsafeWorld
 }
+
+// RoomByLocation returns the Room at a location string in the form of "area,x,y,z".
+func (m *WorldManager) RoomByLocation(loc string) *Room {
+	sections := strings.SplitN(loc, ",", 2)
+	if len(sections) != 2 {
+		return nil
+	}
+
+	a := m.AreaByName(sections[0])
+	if a == nil {
+		return nil
+	}
+
+	c := NewCoordsFromString(sections[1])
+	if c == nil {
+		return nil
+	}
+
+	return a.RoomAt(c)
+}
@@ -0,0 +1,313 @@
+package markup
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TagBold makes text bold (ie: [b]text[/b]).
+	TagBold string = "b"
+	// TagItalics makes text italic (ie: [i]text[/i]).
+	TagItalics = "i"
+	// TagColor colors text with a hex color (ie: [color=ff0000]text[/color]).
+	TagColor = "color"
+	// TagLink links text to a url, or to a command when the value starts with a slash (ie: [link=/look]text[/link]).
+	TagLink = "link"
+	// TagItem shows an item's tooltip when hovering over text (ie: [item=uuid]text[/item]).
+	TagItem = "item"
+	// TagMonospace shows text in a monospace font (ie: [mono]text[/mono]).
+	TagMonospace = "mono"
+	// TagSize shows text at a font size, in pixels (ie: [size=18]text[/size]).
+	TagSize = "size"
+	// TagButton shows text as a button that runs a command, with optional prompt data. Buttons can't be parsed
+	// from text, and are only built by the server.
+	TagButton = "button"
+	// TagMenu shows a context menu when right-clicking text. Menus can't be parsed from text, and are only built by
+	// the server.
+	TagMenu = "menu"
+)
+
+var (
+	markupTag  = regexp.MustCompile(`\[(/?)([a-z]+)(?:=([^\]]*))?\]`)
+	markupHTML = regexp.MustCompile(`<[^>]*(>|$)`)
+)
+
+// Node is a piece of marked-up text.
+type Node interface {
+	node()
+}
+
+// Text is plain text, which is escaped when rendered.
+type Text string
+
+// Raw is text that is rendered as it is, such as HTML that has already been rendered. Any HTML tags are removed
+// when rendering to ANSI.
+type Raw string
+
+// Element is a markup tag wrapping other nodes. Data holds any extra values the tag needs beyond its Value (ie: the
+// prompt of a button).
+type Element struct {
+	Tag      string
+	Value    string
+	Data     map[string]string
+	Children []Node
+}
+
+func (Text) node()     {}
+func (Raw) node()      {}
+func (*Element) node() {}
+
+// Bold returns an Element that makes its children bold.
+func Bold(children ...Node) *Element {
+	return &Element{Tag: TagBold, Children: children}
+}
+
+// Italics returns an Element that makes its children italic.
+func Italics(children ...Node) *Element {
+	return &Element{Tag: TagItalics, Children: children}
+}
+
+// Color returns an Element that colors its children with a hex color, with or without a leading #.
+func Color(color string, children ...Node) *Element {
+	return &Element{Tag: TagColor, Value: strings.TrimPrefix(color, "#"), Children: children}
+}
+
+// Link returns an Element that links its children to a url, or to a command when the target starts with a slash.
+func Link(target string, children ...Node) *Element {
+	return &Element{Tag: TagLink, Value: target, Children: children}
+}
+
+// Item returns an Element that shows an item's tooltip when hovering over its children.
+func Item(uuid string, children ...Node) *Element {
+	return &Element{Tag: TagItem, Value: uuid, Children: children}
+}
+
+// Monospace returns an Element that shows its children in a monospace font.
+func Monospace(children ...Node) *Element {
+	return &Element{Tag: TagMonospace, Children: children}
+}
+
+// Size returns an Element that shows its children at a font size, in pixels.
+func Size(px int, children ...Node) *Element {
+	return &Element{Tag: TagSize, Value: strconv.Itoa(px), Children: children}
+}
+
+// Button returns an Element that shows its children as a button that runs a command, with optional prompt data.
+func Button(cmd, prompt string, children ...Node) *Element {
+	return &Element{Tag: TagButton, Value: cmd, Data: map[string]string{"prompt": prompt}, Children: children}
+}
+
+// Menu returns an Element that shows a context menu of commands when right-clicking its children. The menu is
+// titled with the object's name and colored with its color.
+func Menu(name, objType, color string, commands []string, children ...Node) *Element {
+	return &Element{
+		Tag: TagMenu,
+		Data: map[string]string{
+			"name":    name,
+			"type":    objType,
+			"color":   color,
+			"content": base64.StdEncoding.EncodeToString([]byte(strings.Join(commands, ";"))),
+		},
+		Children: children,
+	}
+}
+
+// validTag returns true if a tag name can be parsed from text.
+func validTag(tag string) bool {
+	switch tag {
+	case TagBold, TagItalics, TagColor, TagLink, TagItem, TagMonospace, TagSize:
+		return true
+	}
+	return false
+}
+
+// Parse parses marked-up text into nodes. Unknown tags and closing tags that don't match an open tag are kept as
+// text, and tags left open are closed at the end of the text.
+func Parse(s string) []Node {
+	root := &Element{}
+	stack := []*Element{root}
+	last := 0
+
+	for _, m := range markupTag.FindAllStringSubmatchIndex(s, -1) {
+		closing := m[3] > m[2]
+		tag := s[m[4]:m[5]]
+		if !validTag(tag) {
+			continue
+		}
+
+		top := stack[len(stack)-1]
+		if closing && top.Tag != tag {
+			continue
+		}
+
+		if m[0] > last {
+			top.Children = append(top.Children, Text(s[last:m[0]]))
+		}
+		last = m[1]
+
+		if closing {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		e := &Element{Tag: tag}
+		if m[6] >= 0 {
+			e.Value = s[m[6]:m[7]]
+		}
+		top.Children = append(top.Children, e)
+		stack = append(stack, e)
+	}
+
+	if last < len(s) {
+		top := stack[len(stack)-1]
+		top.Children = append(top.Children, Text(s[last:]))
+	}
+
+	return root.Children
+}
+
+// HTML renders nodes as HTML for the web client.
+func HTML(nodes ...Node) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case Text:
+			sb.WriteString(html.EscapeString(string(n)))
+		case Raw:
+			sb.WriteString(string(n))
+		case *Element:
+			sb.WriteString(htmlElement(n))
+		}
+	}
+	return sb.String()
+}
+
+// htmlElement renders an Element as HTML.
+func htmlElement(e *Element) string {
+	inner := HTML(e.Children...)
+	v := html.EscapeString(e.Value)
+
+	switch e.Tag {
+	case TagBold:
+		return "<span style='font-weight:600'>" + inner + "</span>"
+	case TagItalics:
+		return "<span style='font-style:italic'>" + inner + "</span>"
+	case TagColor:
+		return "<span style='color:#" + v + "'>" + inner + "</span>"
+	case TagLink:
+		if strings.HasPrefix(e.Value, "/") {
+			enc := base64.StdEncoding.EncodeToString([]byte(e.Value))
+			return "<a href='#' class='inline-command' data-command='" + enc + "' tooltip='Run: " + v + "'>" +
+				inner + "</a>"
+		}
+		return "<a href='" + v + "' class='inline-link' target='_new'>" + inner + "</a>"
+	case TagItem:
+		return "<span class='hover-item-tooltip' data-uuid='" + v + "'>" + inner + "</span>"
+	case TagMonospace:
+		return "<span class='monospace'>" + inner + "</span>"
+	case TagSize:
+		if _, err := strconv.Atoi(e.Value); err != nil {
+			return inner
+		}
+		return "<span style='font-size:" + v + "px'>" + inner + "</span>"
+	case TagButton:
+		return "<span class='inline-button' data-cmd='" + v + "' data-prompt='" + htmlData(e, "prompt") + "'>" +
+			inner + "</span>"
+	case TagMenu:
+		return "<span class='dynamic-context-menu' data-name='" + htmlData(e, "name") +
+			"' data-type='" + htmlData(e, "type") +
+			"' data-color='" + htmlData(e, "color") +
+			"' data-content='" + htmlData(e, "content") + "'>" + inner + "</span>"
+	}
+
+	return inner
+}
+
+// htmlData returns one of an Element's data values, escaped for use within an HTML attribute.
+func htmlData(e *Element, key string) string {
+	return html.EscapeString(e.Data[key])
+}
+
+// ansiState is the styling in effect while rendering ANSI, so it can be restored when an element ends.
+type ansiState struct {
+	bold      bool
+	italics   bool
+	underline bool
+	color     string
+}
+
+// codes returns the ANSI escape codes that apply the state.
+func (s ansiState) codes() string {
+	var codes []string
+	if s.bold {
+		codes = append(codes, "1")
+	}
+	if s.italics {
+		codes = append(codes, "3")
+	}
+	if s.underline {
+		codes = append(codes, "4")
+	}
+	if rgb := ansiColor(s.color); len(rgb) > 0 {
+		codes = append(codes, rgb)
+	}
+
+	if len(codes) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// ansiColor returns the ANSI truecolor code for a hex color, or an empty string if it isn't a valid color.
+func ansiColor(hex string) string {
+	if len(hex) != 6 {
+		return ""
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("38;2;%d;%d;%d", v>>16, (v>>8)&0xff, v&0xff)
+}
+
+// ANSI renders nodes as text styled with ANSI escape codes, for terminal clients.
+func ANSI(nodes ...Node) string {
+	return renderANSI(nodes, ansiState{})
+}
+
+// renderANSI renders nodes as ANSI within the styling of their parent elements.
+func renderANSI(nodes []Node, state ansiState) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case Text:
+			sb.WriteString(string(n))
+		case Raw:
+			sb.WriteString(html.UnescapeString(markupHTML.ReplaceAllString(string(n), "")))
+		case *Element:
+			inner := state
+			switch n.Tag {
+			case TagBold:
+				inner.bold = true
+			case TagItalics:
+				inner.italics = true
+			case TagColor:
+				inner.color = n.Value
+			case TagLink, TagButton:
+				inner.underline = true
+			}
+
+			sb.WriteString(inner.codes())
+			sb.WriteString(renderANSI(n.Children, inner))
+			sb.WriteString("\x1b[0m" + state.codes())
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,71 @@
+package markup
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		"plain text":                    "plain text",
+		"[b]bold[/b] text":              "<span style='font-weight:600'>bold</span> text",
+		"[color=ff0000]red[/color]":     "<span style='color:#ff0000'>red</span>",
+		"[b][i]both[/i][/b]":            "<span style='font-weight:600'><span style='font-style:italic'>both</span></span>",
+		"[b]unclosed":                   "<span style='font-weight:600'>unclosed</span>",
+		"[x]unknown[/x]":                "[x]unknown[/x]",
+		"[b]stray[/i][/b]":              "<span style='font-weight:600'>stray[/i]</span>",
+		"<script>[b]escaped[/b]":        "&lt;script&gt;<span style='font-weight:600'>escaped</span>",
+		"[link=https://a.b]site[/link]": "<a href='https://a.b' class='inline-link' target='_new'>site</a>",
+		"[item=abc]sword[/item]":        "<span class='hover-item-tooltip' data-uuid='abc'>sword</span>",
+	}
+
+	for in, want := range tests {
+		if got := HTML(Parse(in)...); got != want {
+			t.Errorf("HTML(Parse(%q)) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLinkCommand(t *testing.T) {
+	want := "<a href='#' class='inline-command' data-command='L2xvb2s=' tooltip='Run: /look'>look</a>"
+	if got := HTML(Link("/look", Text("look"))); got != want {
+		t.Errorf("unexpected command link: %q", got)
+	}
+}
+
+func TestANSI(t *testing.T) {
+	tests := map[string]string{
+		"plain":                               "plain",
+		"[b]bold[/b]":                         "\x1b[1mbold\x1b[0m",
+		"[color=ff8000]orange[/color]":        "\x1b[38;2;255;128;0morange\x1b[0m",
+		"[b]a [color=00ff00]b[/color] c[/b]":  "\x1b[1ma \x1b[1;38;2;0;255;0mb\x1b[0m\x1b[1m c\x1b[0m",
+		"[color=nothex]text[/color] after it": "text\x1b[0m after it",
+	}
+
+	for in, want := range tests {
+		if got := ANSI(Parse(in)...); got != want {
+			t.Errorf("ANSI(Parse(%q)) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := ANSI(Bold(Raw("<span style='color:#fff'>&lt;hi&gt;</span>"))); got != "\x1b[1m<hi>\x1b[0m" {
+		t.Errorf("unexpected raw rendering: %q", got)
+	}
+}
+
+func TestElements(t *testing.T) {
+	tests := map[string]*Element{
+		"<span class='monospace'>a</span>":                                            Monospace(Text("a")),
+		"<span style='font-size:18px'>a</span>":                                       Size(18, Text("a")),
+		"<span class='inline-button' data-cmd='/look' data-prompt='p&#39;s'>a</span>": Button("/look", "p's", Text("a")),
+		"<span class='dynamic-context-menu' data-name='Bob' data-type='character' data-color='ff0000' " +
+			"data-content='TG9vaw=='>a</span>": Menu("Bob", "character", "ff0000", []string{"Look"}, Text("a")),
+	}
+
+	for want, e := range tests {
+		if got := HTML(e); got != want {
+			t.Errorf("HTML(%s) = %q, want %q", e.Tag, got, want)
+		}
+	}
+
+	if got := HTML(Parse("[button=/look]a[/button]")...); got != "[button=/look]a[/button]" {
+		t.Errorf("buttons shouldn't be parsed from text: %q", got)
+	}
+}
@@ -3,6 +3,7 @@ package misc
 import (
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +12,12 @@ import (
 
 var Money = accounting.Accounting{Symbol: "$", Precision: 2}
 
+var (
+	// rng is the random number generator shared by the whole game, seeded once at startup.
+	rng      = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rngMutex sync.Mutex
+)
+
 // Contains tells whether a contains x. Case insensitive.
 func Contains(a []string, x string) bool {
 	for _, n := range a {
@@ -110,10 +117,20 @@ func MoveFromStringFromDir(prefix, dir string) string {
 	}
 }
 
-// RandomInt returns an int between [0,max].
+// RandomInt returns an int between [0,max).
 func RandomInt(max int) int {
-	rand.Seed(time.Now().Unix())
-	return rand.Intn(max)
+	rngMutex.Lock()
+	defer rngMutex.Unlock()
+
+	return rng.Intn(max)
+}
+
+// Shuffle randomly reorders n elements using the swap function.
+func Shuffle(n int, swap func(i, j int)) {
+	rngMutex.Lock()
+	defer rngMutex.Unlock()
+
+	rng.Shuffle(n, swap)
 }
 
 // ParseArguments parses a string and returns an array of arguments.
@@ -179,4 +196,36 @@ func IsUUID(in string) bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
+
+// Levenshtein returns the number of single-character edits needed to turn a into b. Case insensitive.
+func Levenshtein(a, b string) int {
+	ra := []rune(strings.ToLower(a))
+	rb := []rune(strings.ToLower(b))
+
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j] + 1
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1]+cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+		}
+		prev = cur
+	}
+
+	return prev[len(rb)]
+}
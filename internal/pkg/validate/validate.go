@@ -2,6 +2,8 @@ package validate
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -25,6 +27,11 @@ const (
 	Num = "num"
 	// Empty checks if the input string is empty.
 	Empty = "empty"
+	// Decimal checks if the input string is a number, which may include a fractional part.
+	Decimal = "decimal"
+	// Regex checks if the input string matches the provided regular expression. The expression cannot contain
+	// a pipe, since pipes separate validators.
+	Regex = "regex"
 )
 
 func Check(str, validatorString string) ValidationResult {
@@ -34,7 +41,7 @@ func Check(str, validatorString string) ValidationResult {
 
 	validations := strings.Split(validatorString, "|")
 	for _, validation := range validations {
-		sections := strings.Split(validation, ":")
+		sections := strings.SplitN(validation, ":", 2)
 		switch sections[0] {
 		case Bool:
 			validate(&result, Bool, checkBool(str))
@@ -48,6 +55,10 @@ func Check(str, validatorString string) ValidationResult {
 			validate(&result, Num, checkNum(str))
 		case Empty:
 			validate(&result, Empty, checkEmpty(str))
+		case Decimal:
+			validate(&result, Decimal, checkDecimal(str))
+		case Regex:
+			validate(&result, Regex, checkRegex(str, sections[1]))
 		}
 	}
 
@@ -78,36 +89,36 @@ func checkBool(str string) string {
 }
 
 func checkMin(str, min string) string {
-	i, err := strconv.Atoi(str)
+	i, err := parseFinite(str)
 	if err != nil {
 		return fmt.Sprintf("less than %s", min)
 	}
 
-	m, err := strconv.Atoi(min)
+	m, err := strconv.ParseFloat(min, 64)
 	if err != nil {
-		return "min value not an int"
+		return "min value not a number"
 	}
 
 	if i < m {
-		return fmt.Sprintf("less than %d", m)
+		return fmt.Sprintf("less than %s", min)
 	}
 
 	return ""
 }
 
 func checkMax(str, max string) string {
-	i, err := strconv.Atoi(str)
+	i, err := parseFinite(str)
 	if err != nil {
 		return fmt.Sprintf("greater than %s", max)
 	}
 
-	m, err := strconv.Atoi(max)
+	m, err := strconv.ParseFloat(max, 64)
 	if err != nil {
-		return "max value not an int"
+		return "max value not a number"
 	}
 
 	if i > m {
-		return fmt.Sprintf("greater than %d", m)
+		return fmt.Sprintf("greater than %s", max)
 	}
 
 	return ""
@@ -140,6 +151,42 @@ func checkNum(str string) string {
 	return ""
 }
 
+func checkDecimal(str string) string {
+	_, err := parseFinite(str)
+	if err != nil {
+		return "not a number"
+	}
+
+	return ""
+}
+
+// parseFinite parses a float, treating NaN and infinity as invalid since they can't be compared against bounds.
+func parseFinite(str string) (float64, error) {
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%s is not a finite number", str)
+	}
+
+	return f, nil
+}
+
+func checkRegex(str, expr string) string {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return "invalid regular expression"
+	}
+
+	if !re.MatchString(str) {
+		return fmt.Sprintf("does not match the format %s", expr)
+	}
+
+	return ""
+}
+
 func checkEmpty(str string) string {
 	if len(str) > 0 {
 		return "not empty"
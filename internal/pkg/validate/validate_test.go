@@ -45,6 +45,8 @@ func TestMin(t *testing.T) {
 	shouldFail := []ValidationResult{
 		Check("1", "min:2"),
 		Check("abc", "min:2"),
+		Check("NaN", "min:2"),
+		Check("-Inf", "min:2"),
 	}
 
 	check(shouldPass, true, t)
@@ -59,6 +61,8 @@ func TestMax(t *testing.T) {
 	shouldFail := []ValidationResult{
 		Check("6", "max:4"),
 		Check("abc", "max:4"),
+		Check("NaN", "max:4"),
+		Check("Inf", "max:4"),
 	}
 
 	check(shouldPass, true, t)
@@ -101,3 +105,32 @@ func TestEmpty(t *testing.T) {
 	check(shouldPass, true, t)
 	check(shouldFail, false, t)
 }
+
+func TestDecimal(t *testing.T) {
+	shouldPass := []ValidationResult{
+		Check("5", "decimal"),
+		Check("5.25", "decimal|min:0"),
+	}
+	shouldFail := []ValidationResult{
+		Check("five", "decimal"),
+		Check("-0.5", "decimal|min:0"),
+		Check("NaN", "decimal"),
+		Check("+Inf", "decimal"),
+		Check("-Infinity", "decimal"),
+	}
+
+	check(shouldPass, true, t)
+	check(shouldFail, false, t)
+}
+
+func TestRegex(t *testing.T) {
+	shouldPass := []ValidationResult{
+		Check("190,190,190", `regex:^\d{1,3},\d{1,3},\d{1,3}$`),
+	}
+	shouldFail := []ValidationResult{
+		Check("red", `regex:^\d{1,3},\d{1,3},\d{1,3}$`),
+	}
+
+	check(shouldPass, true, t)
+	check(shouldFail, false, t)
+}
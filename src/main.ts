@@ -18,7 +18,9 @@ if (process.env.NODE_ENV === "production") {
   connectionString = `ws://${window.location.hostname}:8081/ws`;
 }
 
-Vue.use(VueNativeSock, connectionString, { store: store, format: 'json' })
+// The socket is connected manually, so that it can be reconnected to another node of a cluster.
+Vue.use(VueNativeSock, connectionString, { store: store, format: 'json', connectManually: true })
+Vue.prototype.$connect()
 Vue.use(SFX)
 Vue.use(VueAnimXYZ)
 
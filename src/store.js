@@ -17,11 +17,19 @@ export default new Vuex.Store({
     characterLocation: { x: 0, y: 0, z: 0 },
     roomObjects: [],
     roomTitle: 'Unknown',
+    questTimers: [],
+    expiredConvoGroup: '',
     objectTargetUUID: '',
     objectEditorOpen: false,
     objectEditorData: {},
     autoLoginToken: window.localStorage.getItem('auto_login_token') || '',
+    creationToken: window.localStorage.getItem('creation_token') || '',
+    handoffToken: '',
+    creationState: { active: false, step: '', options: [], secret: false },
     inventory: [],
+    equipment: [],
+    craftQueue: [],
+    shop: { vendor: '', vendorUUID: '', buy: [], sell: [] },
     itemBeingDragged: false,
     permissions: [],
     playerInfo: { uuid: '', name: '' },
@@ -54,7 +62,8 @@ export default new Vuex.Store({
     },
 
     hasPermission: (state) => (permission) => {
-      return state.permissions.indexOf(permission) >= 0;
+      // Permissions may be scoped to an area (ie: CAN_BUILD:emberfall).
+      return state.permissions.some(p => p === permission || p.startsWith(`${permission}:`));
     },
 
     normalizedDeployVersion: (state) => {
@@ -75,6 +84,12 @@ export default new Vuex.Store({
     },
 
     SOCKET_ONCLOSE: (state) => {
+      if (state.isConnected && state.handoffToken.length > 0) {
+        // The connection was closed to move to another node.
+        state.isConnected = false;
+        return;
+      }
+
       if (state.isConnected) {
         state.isConnected = false;
         state.gameText.push({ id: state.gameText.length, html: '<br>Connection to the game server has been closed.' });
@@ -99,6 +114,15 @@ export default new Vuex.Store({
       });
     },
 
+    REDACT_CHAT_MESSAGE: (state, { id, text }) => {
+      const marker = `data-chat-id='${id}'`;
+      state.gameText
+          .filter(line => line.html.includes(marker))
+          .forEach(line => {
+            line.html = text.replace(/\n/g, "<br>");
+          });
+    },
+
     SET_ALLOW_GLOBAL_HOTKEYS: (state, allow) => {
       state.allowGlobalHotkeys = allow;
     },
@@ -125,6 +149,21 @@ export default new Vuex.Store({
       state.roomTitle = title;
     },
 
+    SET_EXPIRED_CONVO_GROUP: (state, group) => {
+      state.expiredConvoGroup = group;
+    },
+
+    SET_QUEST_TIMER: (state, timer) => {
+      state.questTimers = state.questTimers.filter(t => t.quest !== timer.quest);
+      if (timer.seconds > 0) {
+        state.questTimers.push({
+          quest: timer.quest,
+          label: timer.label,
+          endsAt: Date.now() + timer.seconds * 1000,
+        });
+      }
+    },
+
     SET_OBJECT_TARGET: (state, targetUUID) => {
       state.objectTargetUUID = targetUUID;
     },
@@ -137,6 +176,10 @@ export default new Vuex.Store({
       state.objectEditorData = data;
     },
 
+    REMOVE_OBJECT_EDITOR_NOTE: (state, id) => {
+      state.objectEditorData.notes = state.objectEditorData.notes.filter(n => n.id !== id);
+    },
+
     SET_FORCE_INPUT_FOCUS: (state, data) => {
       state.forceInputFocus = data;
     },
@@ -151,10 +194,37 @@ export default new Vuex.Store({
       }
     },
 
+    SET_HANDOFF_TOKEN: (state, token) => {
+      state.handoffToken = token;
+    },
+
+    SET_CREATION_STATE: (state, creation) => {
+      state.creationToken = creation.complete ? '' : creation.token;
+      window.localStorage.setItem('creation_token', state.creationToken);
+      state.creationState = {
+        active: !creation.complete,
+        step: creation.step,
+        options: creation.options || [],
+        secret: creation.secret,
+      };
+    },
+
     SET_INVENTORY: (state, inventory) => {
       state.inventory = inventory;
     },
 
+    SET_EQUIPMENT: (state, equipment) => {
+      state.equipment = equipment;
+    },
+
+    SET_CRAFT_QUEUE: (state, craftQueue) => {
+      state.craftQueue = craftQueue;
+    },
+
+    SET_SHOP: (state, shop) => {
+      state.shop = shop;
+    },
+
     SET_ITEM_BEING_DRAGGED: (state, being_dragged) => {
       state.itemBeingDragged = being_dragged;
     },
@@ -258,6 +328,27 @@ export default new Vuex.Store({
       });
     },
 
+    sendCreationInput: ({ state, commit }, payload) => {
+      if (!state.isConnected) {
+        return;
+      }
+
+      const echo = state.creationState.secret ? '*'.repeat(payload.length) : payload;
+      commit('ADD_GAME_TEXT', `<div class="inline-loopback">${echo}</div>`);
+
+      Vue.prototype.$socket.sendObj({
+        type: "creationSubmit",
+        payload: payload
+      });
+    },
+
+    resumeCreation: ({ state }) => {
+      Vue.prototype.$socket.sendObj({
+        type: "creationResume",
+        payload: state.creationToken
+      });
+    },
+
     sendKeepAlive: ({ state }) => {
       state.sentKeepAlive = Date.now();
       Vue.prototype.$socket.sendObj({
@@ -329,6 +420,10 @@ export default new Vuex.Store({
       commit('ADD_GAME_TEXT', payload.data);
     },
 
+    redactChatMessage: ({ commit }, payload) => {
+      commit('REDACT_CHAT_MESSAGE', JSON.parse(payload.data));
+    },
+
     setMapData: ({ commit }, payload) => {
       commit('SET_MINIMAP_DATA', JSON.parse(payload.data));
     },
@@ -345,15 +440,45 @@ export default new Vuex.Store({
       commit('SET_ROOM_TITLE', payload.data);
     },
 
+    setQuestTimer: ({ commit }, payload) => {
+      commit('SET_QUEST_TIMER', JSON.parse(payload.data));
+    },
+
+    expireConvoOptions: ({ commit }, payload) => {
+      commit('SET_EXPIRED_CONVO_GROUP', payload.data);
+    },
+
     setObjectEditorData: ({ commit }, payload) => {
       commit('SET_OBJECT_EDITOR_DATA', JSON.parse(payload.data));
       commit('SET_OBJECT_EDITOR_OPEN', true);
     },
 
+    openScriptEditor: ({ state }, payload) => {
+      const data = JSON.parse(payload.data);
+      let baseUrl = '/scripteditor.html';
+
+      if (!state.isProduction) {
+        baseUrl = `http://${window.location.hostname}:${window.location.port}/scripteditor.html`;
+      }
+
+      window.open(
+        `${baseUrl}?name=${data.name}&type=${data.type}&accessKey=${data.accessKey}&dev=${!state.isProduction}&theme=${state.settings['script_theme']}`,
+        'scripteditor',
+        'width=800,height=600'
+      );
+    },
+
     disconnect: () => {
       Vue.prototype.$socket.close();
     },
 
+    clusterHandoff: ({ commit }, payload) => {
+      const handoff = JSON.parse(payload.data);
+      commit('SET_HANDOFF_TOKEN', handoff.token);
+      Vue.prototype.$disconnect();
+      Vue.prototype.$connect(handoff.address);
+    },
+
     pong: ({ commit }) => {
       commit('KEEP_ALIVE_RESPONSE');
     },
@@ -366,10 +491,30 @@ export default new Vuex.Store({
       }
     },
 
+    setCreationState: ({ commit }, payload) => {
+      commit('SET_CREATION_STATE', JSON.parse(payload.data));
+    },
+
     setInventory: ({ commit }, payload) => {
       commit('SET_INVENTORY', JSON.parse(payload.data) || []);
     },
 
+    setEquipment: ({ commit }, payload) => {
+      commit('SET_EQUIPMENT', JSON.parse(payload.data) || []);
+    },
+
+    setCraftQueue: ({ commit }, payload) => {
+      commit('SET_CRAFT_QUEUE', JSON.parse(payload.data) || []);
+    },
+
+    setShop: ({ commit }, payload) => {
+      commit('SET_SHOP', JSON.parse(payload.data));
+    },
+
+    closeShop: ({ commit }) => {
+      commit('SET_SHOP', { vendor: '', vendorUUID: '', buy: [], sell: [] });
+    },
+
     setPermissions: ({ commit }, payload) => {
       commit('SET_PERMISSIONS', payload.data);
     },